@@ -56,9 +56,124 @@ const docTemplate = `{
                     {
                         "type": "string",
                         "description": "Email to subscribe",
-                        "name": "body",
+                        "name": "email",
                         "in": "formData",
                         "required": true
+                    },
+                    {
+                        "enum": [
+                            "daily",
+                            "weekly",
+                            "monthly"
+                        ],
+                        "type": "string",
+                        "default": "daily",
+                        "description": "How often to send mails",
+                        "name": "frequency",
+                        "in": "formData"
+                    },
+                    {
+                        "maximum": 6,
+                        "minimum": 0,
+                        "type": "integer",
+                        "description": "Day of the week for the weekly digest, 0 is Sunday",
+                        "name": "weekday",
+                        "in": "formData"
+                    },
+                    {
+                        "maximum": 31,
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "Day of the month for the monthly digest",
+                        "name": "day",
+                        "in": "formData"
+                    },
+                    {
+                        "enum": [
+                            "en",
+                            "uk"
+                        ],
+                        "type": "string",
+                        "default": "en",
+                        "description": "Language of the mails",
+                        "name": "locale",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "CAPTCHA token, required when verification is enabled",
+                        "name": "captcha_token",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_hrvadl_converter_gw_internal_transport_http_handlers.EmptyResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Email or preferences are rejected, reason is one of INVALID_SYNTAX, NO_MAIL_SERVER, DISPOSABLE_DOMAIN, ROLE_ACCOUNT, INVALID_PREFERENCES, VERIFICATION_FAILED",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_hrvadl_converter_gw_internal_transport_http_handlers.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_hrvadl_converter_gw_internal_transport_http_handlers.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too many requests from the IP or email domain, see Retry-After header",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_hrvadl_converter_gw_internal_transport_http_handlers.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "CAPTCHA provider is unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_hrvadl_converter_gw_internal_transport_http_handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/webhooks/resend": {
+            "post": {
+                "description": "Payload should be signed by Svix. Bounced and complaining emails are suppressed, other events are ignored.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhook"
+                ],
+                "summary": "Receive Resend webhook with bounces and complaints",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Svix message ID",
+                        "name": "svix-id",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Svix timestamp in Unix seconds",
+                        "name": "svix-timestamp",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Svix signature",
+                        "name": "svix-signature",
+                        "in": "header",
+                        "required": true
                     }
                 ],
                 "responses": {
@@ -73,6 +188,18 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/github_com_hrvadl_converter_gw_internal_transport_http_handlers.ErrorResponse"
                         }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_hrvadl_converter_gw_internal_transport_http_handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_hrvadl_converter_gw_internal_transport_http_handlers.ErrorResponse"
+                        }
                     }
                 }
             }
@@ -96,6 +223,9 @@ const docTemplate = `{
                 "error": {
                     "type": "string"
                 },
+                "reason": {
+                    "type": "string"
+                },
                 "success": {
                     "type": "boolean"
                 }
@@ -2,27 +2,42 @@ package app
 
 //nolint:revive
 import (
+	"context"
+	"errors"
 	"fmt"
+	"io"
 	"log/slog"
+	"net/http"
 	"os"
 	"os/signal"
+	"sync"
 	"syscall"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
 	httpSwagger "github.com/swaggo/http-swagger"
 
 	_ "github.com/hrvadl/converter/gw/docs"
+	"github.com/hrvadl/converter/gw/internal/captcha"
 	"github.com/hrvadl/converter/gw/internal/cfg"
 	"github.com/hrvadl/converter/gw/internal/transport/grpc/clients/ratewatcher"
 	ssvc "github.com/hrvadl/converter/gw/internal/transport/grpc/clients/sub"
+	"github.com/hrvadl/converter/gw/internal/transport/http/guard"
 	"github.com/hrvadl/converter/gw/internal/transport/http/handlers/rate"
 	"github.com/hrvadl/converter/gw/internal/transport/http/handlers/sub"
+	"github.com/hrvadl/converter/gw/internal/transport/http/handlers/webhook"
 	"github.com/hrvadl/converter/gw/pkg/logger"
+	"github.com/hrvadl/converter/gw/pkg/ratelimit"
 )
 
 const operation = "app init"
 
+// shutdownTimeout is a period, during which in-flight requests
+// are waited for on stop. Connections, which aren't finished
+// by then, are closed.
+const shutdownTimeout = time.Second * 5
+
 // New constructs new App with provided arguments.
 // NOTE: than neither cfg or log can't be nil or App will panic.
 func New(cfg cfg.Config, log *slog.Logger) *App {
@@ -38,6 +53,13 @@ func New(cfg cfg.Config, log *slog.Logger) *App {
 type App struct {
 	cfg cfg.Config
 	log *slog.Logger
+
+	// mu guards fields below, which are set in Run and
+	// read in GracefulStop from the other goroutine.
+	mu       sync.Mutex
+	stopping bool
+	srv      *http.Server
+	closers  []io.Closer
 }
 
 // MustRun is a wrapper around App.Run() function which could be handly
@@ -61,15 +83,40 @@ func (a *App) Run() error {
 	if err != nil {
 		return fmt.Errorf("%s: failed to initialize ratewatcher client: %w", operation, err)
 	}
+	a.closeOnStop(rw)
 
 	subsvc, err := ssvc.NewClient(a.cfg.SubAddr, a.log.With("source", "subClient"))
 	if err != nil {
 		return fmt.Errorf("%s: failed to init sub service: %w", operation, err)
 	}
+	a.closeOnStop(subsvc)
 
 	sh := sub.NewHandler(subsvc, a.log.With("source", "subHandler"))
 	rh := rate.NewHandler(rw, a.log.With("source", "rateHandler"))
 
+	var verifier guard.Verifier
+	if a.cfg.CaptchaSecret != "" {
+		verifier = captcha.NewSiteVerify(a.cfg.CaptchaVerifyURL, a.cfg.CaptchaSecret)
+	}
+
+	limits := ratelimit.NewMemoryStore()
+	sg := guard.NewGuard(
+		ratelimit.NewLimiter("subscribe:ip", limits, a.cfg.SubscribeIPLimit),
+		ratelimit.NewLimiter("subscribe:domain", limits, a.cfg.SubscribeDomainLimit),
+		verifier,
+		a.cfg.TrustedProxies,
+		a.log.With("source", "subscribeGuard"),
+	)
+
+	var wh *webhook.Handler
+	if a.cfg.ResendWebhookSecret != "" {
+		key, err := webhook.ParseSecret(a.cfg.ResendWebhookSecret)
+		if err != nil {
+			return fmt.Errorf("%s: failed to init resend webhook: %w", operation, err)
+		}
+		wh = webhook.NewHandler(subsvc, key, a.log.With("source", "webhookHandler"))
+	}
+
 	r := chi.NewRouter()
 	r.Use(
 		middleware.Heartbeat("/health"),
@@ -83,7 +130,13 @@ func (a *App) Run() error {
 		r.Get("/rate", rh.GetRate)
 		r.With(
 			middleware.AllowContentType("application/x-www-form-urlencoded"),
+			sg.Protect,
 		).Post("/subscribe", sh.Subscribe)
+		if wh != nil {
+			r.With(
+				middleware.AllowContentType("application/json"),
+			).Post("/webhooks/resend", wh.Resend)
+		}
 	})
 
 	if a.cfg.LogLevel == "DEBUG" {
@@ -97,16 +150,58 @@ func (a *App) Run() error {
 		slog.NewLogLogger(a.log.Handler(), logger.MapLevels(a.cfg.LogLevel)),
 	)
 
-	return srv.ListenAndServe()
+	a.mu.Lock()
+	if a.stopping {
+		a.mu.Unlock()
+		return nil
+	}
+	a.srv = srv
+	a.mu.Unlock()
+
+	if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("%s: failed to serve: %w", operation, err)
+	}
+
+	return nil
+}
+
+// closeOnStop registers GRPC client, which is closed in GracefulStop
+// after the server is stopped. Clients are closed in reverse order.
+func (a *App) closeOnStop(c io.Closer) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.closers = append(a.closers, c)
 }
 
 // GracefulStop method gracefully stop the server. It listens to the OS sigals.
-// After it recieves signal it terminates all currently active servers,
-// client, connections (if any) and gracefully exits.
+// After it recieves signal it stops accepting new requests and waits up to
+// shutdownTimeout for in-flight ones to finish. Then it closes GRPC client
+// connections and gracefully exits.
 func (a *App) GracefulStop() {
 	ch := make(chan os.Signal, 1)
 	signal.Notify(ch, syscall.SIGTERM, syscall.SIGINT)
 	signal := <-ch
 	a.log.Info("Recieved stop signal. Terminating...", "signal", signal)
+
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+
+	a.mu.Lock()
+	a.stopping = true
+	srv, closers := a.srv, a.closers
+	a.mu.Unlock()
+
+	if srv != nil {
+		if err := srv.Shutdown(ctx); err != nil {
+			a.log.Warn("In-flight requests weren't finished on time, closed them", "err", err)
+			srv.Close()
+		}
+	}
+
+	for i := len(closers) - 1; i >= 0; i-- {
+		if err := closers[i].Close(); err != nil {
+			a.log.Error("Failed to close connection", "err", err)
+		}
+	}
 	a.log.Info("Successfully terminated server. Bye!")
 }
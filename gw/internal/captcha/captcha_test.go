@@ -0,0 +1,114 @@
+package captcha
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestSiteVerifyVerify(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name         string
+		token        string
+		status       int
+		body         string
+		wantErr      bool
+		wantRejected bool
+	}{
+		{
+			name:   "Should accept token when endpoint succeeded",
+			token:  "token",
+			status: http.StatusOK,
+			body:   `{"success":true}`,
+		},
+		{
+			name:         "Should reject token when endpoint rejected it",
+			token:        "token",
+			status:       http.StatusOK,
+			body:         `{"success":false,"error-codes":["invalid-input-response"]}`,
+			wantErr:      true,
+			wantRejected: true,
+		},
+		{
+			name:         "Should reject missing token without calling endpoint",
+			token:        "",
+			wantErr:      true,
+			wantRejected: true,
+		},
+		{
+			name:    "Should return error when endpoint failed",
+			token:   "token",
+			status:  http.StatusInternalServerError,
+			wantErr: true,
+		},
+		{
+			name:    "Should return error when response is malformed",
+			token:   "token",
+			status:  http.StatusOK,
+			body:    `{`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if tt.token == "" {
+					t.Error("Endpoint was called for the missing token")
+				}
+				if r.FormValue("secret") != "secret" || r.FormValue("response") != tt.token ||
+					r.FormValue("remoteip") != "127.0.0.1" {
+					t.Errorf("Endpoint got unexpected form %v", r.Form)
+				}
+				w.WriteHeader(tt.status)
+				_, _ = w.Write([]byte(tt.body))
+			}))
+			t.Cleanup(srv.Close)
+
+			err := NewSiteVerify(srv.URL, "secret").Verify(context.Background(), tt.token, "127.0.0.1")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("SiteVerify.Verify() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if errors.Is(err, ErrRejected) != tt.wantRejected {
+				t.Errorf("SiteVerify.Verify() error = %v, wantRejected %v", err, tt.wantRejected)
+			}
+		})
+	}
+}
+
+func TestFakeVerify(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		token   string
+		wantErr error
+	}{
+		{
+			name:  "Should accept expected token",
+			token: "pass",
+		},
+		{
+			name:    "Should reject unexpected token",
+			token:   "fail",
+			wantErr: ErrRejected,
+		},
+		{
+			name:    "Should reject missing token",
+			token:   "",
+			wantErr: ErrRejected,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if err := (Fake{Token: "pass"}).Verify(context.Background(), tt.token, ""); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Fake.Verify() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -2,7 +2,12 @@ package cfg
 
 import (
 	"fmt"
+	"net/netip"
 	"os"
+	"strings"
+
+	"github.com/hrvadl/converter/gw/internal/captcha"
+	"github.com/hrvadl/converter/gw/pkg/ratelimit"
 )
 
 const operation = "config parsing"
@@ -12,6 +17,21 @@ const (
 	rateWatchAddrEnvKey  = "RATE_WATCH_ADDR"
 	logLevelEnvKey       = "GATEWAY_LOG_LEVEL"
 	addrEnvKey           = "GATEWAY_ADDR"
+	resendSecretEnvKey   = "GATEWAY_RESEND_WEBHOOK_SECRET"
+	ipLimitEnvKey        = "GATEWAY_SUBSCRIBE_IP_LIMIT"
+	domainLimitEnvKey    = "GATEWAY_SUBSCRIBE_DOMAIN_LIMIT"
+	captchaSecretEnvKey  = "GATEWAY_CAPTCHA_SECRET"
+	captchaURLEnvKey     = "GATEWAY_CAPTCHA_VERIFY_URL"
+	trustedProxiesEnvKey = "GATEWAY_TRUSTED_PROXIES"
+)
+
+// defaultIPLimit and defaultDomainLimit are limits of the
+// subscribe requests, which are used when they're not provided.
+// Domain limit is looser, since popular mail providers are
+// shared by lots of subscribers.
+const (
+	defaultIPLimit     = "10/1h"
+	defaultDomainLimit = "60/1m"
 )
 
 // Config struct represents application config,
@@ -21,6 +41,22 @@ type Config struct {
 	RateWatcherAddr string
 	Addr            string
 	LogLevel        string
+	// ResendWebhookSecret is a Svix signing secret of the Resend
+	// webhook. Webhook is disabled, when secret is empty.
+	ResendWebhookSecret string
+	// SubscribeIPLimit is a limit of subscribe requests from the single IP.
+	SubscribeIPLimit ratelimit.Limit
+	// SubscribeDomainLimit is a limit of subscribe
+	// requests with emails from the single domain.
+	SubscribeDomainLimit ratelimit.Limit
+	// CaptchaSecret is a secret of the CAPTCHA provider.
+	// Tokens aren't verified, when secret is empty.
+	CaptchaSecret    string
+	CaptchaVerifyURL string
+	// TrustedProxies are networks of the proxies, which are allowed
+	// to pass client IP in the X-Forwarded-For header. Header is
+	// ignored, when there are no trusted proxies.
+	TrustedProxies []netip.Prefix
 }
 
 // Must is a handly wrapper around return results from
@@ -58,10 +94,82 @@ func NewFromEnv() (*Config, error) {
 		return nil, fmt.Errorf("%s: port can't be empty", operation)
 	}
 
+	ipLimit, err := parseLimit(ipLimitEnvKey, defaultIPLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	domainLimit, err := parseLimit(domainLimitEnvKey, defaultDomainLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	proxies, err := parseProxies(trustedProxiesEnvKey)
+	if err != nil {
+		return nil, err
+	}
+
+	captchaURL := os.Getenv(captchaURLEnvKey)
+	if captchaURL == "" {
+		captchaURL = captcha.TurnstileURL
+	}
+
 	return &Config{
-		LogLevel:        logLevel,
-		Addr:            port,
-		RateWatcherAddr: rwAddr,
-		SubAddr:         subAddr,
+		LogLevel:             logLevel,
+		Addr:                 port,
+		RateWatcherAddr:      rwAddr,
+		SubAddr:              subAddr,
+		ResendWebhookSecret:  os.Getenv(resendSecretEnvKey),
+		SubscribeIPLimit:     ipLimit,
+		SubscribeDomainLimit: domainLimit,
+		CaptchaSecret:        os.Getenv(captchaSecretEnvKey),
+		CaptchaVerifyURL:     captchaURL,
+		TrustedProxies:       proxies,
 	}, nil
 }
+
+// parseLimit parses rate limit from the env variable
+// or falls back to the default one, when it's not provided.
+func parseLimit(key, fallback string) (ratelimit.Limit, error) {
+	v := os.Getenv(key)
+	if v == "" {
+		v = fallback
+	}
+
+	l, err := ratelimit.ParseLimit(v)
+	if err != nil {
+		return ratelimit.Limit{}, fmt.Errorf("%s: invalid %s: %w", operation, key, err)
+	}
+
+	return l, nil
+}
+
+// parseProxies parses comma-separated list of networks in the CIDR
+// notation from the env variable. Single IP is treated as the network
+// of the one address.
+func parseProxies(key string) ([]netip.Prefix, error) {
+	var proxies []netip.Prefix
+	for _, v := range strings.Split(os.Getenv(key), ",") {
+		v = strings.TrimSpace(v)
+		if v == "" {
+			continue
+		}
+
+		if !strings.Contains(v, "/") {
+			addr, err := netip.ParseAddr(v)
+			if err != nil {
+				return nil, fmt.Errorf("%s: invalid %s: %w", operation, key, err)
+			}
+			proxies = append(proxies, netip.PrefixFrom(addr, addr.BitLen()))
+			continue
+		}
+
+		p, err := netip.ParsePrefix(v)
+		if err != nil {
+			return nil, fmt.Errorf("%s: invalid %s: %w", operation, key, err)
+		}
+		proxies = append(proxies, p.Masked())
+	}
+
+	return proxies, nil
+}
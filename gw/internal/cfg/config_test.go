@@ -2,9 +2,14 @@ package cfg
 
 import (
 	"errors"
+	"net/netip"
 	"os"
 	"reflect"
 	"testing"
+	"time"
+
+	"github.com/hrvadl/converter/gw/internal/captcha"
+	"github.com/hrvadl/converter/gw/pkg/ratelimit"
 )
 
 func TestMust(t *testing.T) {
@@ -69,15 +74,86 @@ func TestNewFromEnv(t *testing.T) {
 				os.Setenv(addrEnvKey, "0.0.0.0:80")
 				os.Setenv(rateWatchAddrEnvKey, "rw:3333")
 				os.Setenv(subServiceAddrEnvKey, "ss:6666")
+				os.Setenv(resendSecretEnvKey, "whsec_c2VjcmV0")
+				os.Setenv(ipLimitEnvKey, "5/30m")
+				os.Setenv(domainLimitEnvKey, "100/1m")
+				os.Setenv(captchaSecretEnvKey, "secret")
+				os.Setenv(captchaURLEnvKey, "https://hcaptcha.com/siteverify")
+				os.Setenv(trustedProxiesEnvKey, "10.0.0.0/8, 192.0.2.1,2001:db8::/32")
 			},
 			want: &Config{
-				LogLevel:        "debug",
-				Addr:            "0.0.0.0:80",
-				RateWatcherAddr: "rw:3333",
-				SubAddr:         "ss:6666",
+				LogLevel:             "debug",
+				Addr:                 "0.0.0.0:80",
+				RateWatcherAddr:      "rw:3333",
+				SubAddr:              "ss:6666",
+				ResendWebhookSecret:  "whsec_c2VjcmV0",
+				SubscribeIPLimit:     ratelimit.Per(5, time.Minute*30),
+				SubscribeDomainLimit: ratelimit.Per(100, time.Minute),
+				CaptchaSecret:        "secret",
+				CaptchaVerifyURL:     "https://hcaptcha.com/siteverify",
+				TrustedProxies: []netip.Prefix{
+					netip.MustParsePrefix("10.0.0.0/8"),
+					netip.MustParsePrefix("192.0.2.1/32"),
+					netip.MustParsePrefix("2001:db8::/32"),
+				},
 			},
 			wantErr: false,
 		},
+		{
+			name: "Should fall back to defaults when optional env vars are missing",
+			setup: func() {
+				os.Setenv(logLevelEnvKey, "debug")
+				os.Setenv(addrEnvKey, "0.0.0.0:80")
+				os.Setenv(rateWatchAddrEnvKey, "rw:3333")
+				os.Setenv(subServiceAddrEnvKey, "ss:6666")
+			},
+			want: &Config{
+				LogLevel:             "debug",
+				Addr:                 "0.0.0.0:80",
+				RateWatcherAddr:      "rw:3333",
+				SubAddr:              "ss:6666",
+				SubscribeIPLimit:     ratelimit.Per(10, time.Hour),
+				SubscribeDomainLimit: ratelimit.Per(60, time.Minute),
+				CaptchaVerifyURL:     captcha.TurnstileURL,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Should not parse config when ip limit is invalid",
+			setup: func() {
+				os.Setenv(logLevelEnvKey, "debug")
+				os.Setenv(addrEnvKey, "0.0.0.0:80")
+				os.Setenv(rateWatchAddrEnvKey, "rw:3333")
+				os.Setenv(subServiceAddrEnvKey, "ss:6666")
+				os.Setenv(ipLimitEnvKey, "ten per hour")
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "Should not parse config when domain limit is invalid",
+			setup: func() {
+				os.Setenv(logLevelEnvKey, "debug")
+				os.Setenv(addrEnvKey, "0.0.0.0:80")
+				os.Setenv(rateWatchAddrEnvKey, "rw:3333")
+				os.Setenv(subServiceAddrEnvKey, "ss:6666")
+				os.Setenv(domainLimitEnvKey, "0/1m")
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "Should not parse config when trusted proxy is invalid",
+			setup: func() {
+				os.Setenv(logLevelEnvKey, "debug")
+				os.Setenv(addrEnvKey, "0.0.0.0:80")
+				os.Setenv(rateWatchAddrEnvKey, "rw:3333")
+				os.Setenv(subServiceAddrEnvKey, "ss:6666")
+				os.Setenv(trustedProxiesEnvKey, "10.0.0.0/33")
+			},
+			want:    nil,
+			wantErr: true,
+		},
 		{
 			name: "Should not parse config when log level is missing",
 			setup: func() {
@@ -131,6 +207,12 @@ func TestNewFromEnv(t *testing.T) {
 				os.Unsetenv(addrEnvKey)
 				os.Unsetenv(rateWatchAddrEnvKey)
 				os.Unsetenv(subServiceAddrEnvKey)
+				os.Unsetenv(resendSecretEnvKey)
+				os.Unsetenv(ipLimitEnvKey)
+				os.Unsetenv(domainLimitEnvKey)
+				os.Unsetenv(captchaSecretEnvKey)
+				os.Unsetenv(captchaURLEnvKey)
+				os.Unsetenv(trustedProxiesEnvKey)
 			})
 
 			tt.setup()
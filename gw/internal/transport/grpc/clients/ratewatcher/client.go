@@ -48,6 +48,7 @@ func NewClient(addr string, log *slog.Logger) (*Client, error) {
 	}
 
 	return &Client{
+		cc:  cc,
 		api: pb.NewRateWatcherServiceClient(cc),
 	}, nil
 }
@@ -56,6 +57,7 @@ func NewClient(addr string, log *slog.Logger) (*Client, error) {
 // is responsible for getting latest exchange rates and
 // returning it in response.
 type Client struct {
+	cc  *grpc.ClientConn
 	api pb.RateWatcherServiceClient
 }
 
@@ -67,3 +69,9 @@ func (c *Client) GetRate(ctx context.Context) (float32, error) {
 
 	return resp.Rate, nil
 }
+
+// Close method closes connection to the rate watcher service.
+// Client can't be used after it's closed.
+func (c *Client) Close() error {
+	return c.cc.Close()
+}
@@ -1,4 +1,4 @@
-//go:generate mockgen -destination=./mocks/mock_sub.go -package=mocks github.com/hrvadl/converter/protos/gen/go/v1/sub SubServiceClient
+//go:generate mockgen -destination=./mocks/mock_sub.go -package=mocks github.com/hrvadl/converter/protos/gen/go/v1/sub SubServiceClient,SuppressionServiceClient
 package sub
 
 import (
@@ -47,18 +47,35 @@ func NewClient(addr string, log *slog.Logger) (*Client, error) {
 	}
 
 	return &Client{
-		api: pb.NewSubServiceClient(cc),
+		cc:           cc,
+		api:          pb.NewSubServiceClient(cc),
+		suppressions: pb.NewSuppressionServiceClient(cc),
 	}, nil
 }
 
 // Client represents GRPC subscriber client which
-// is responsible for subscribing new users and triggering
-// email notification to the subscribers.
+// is responsible for subscribing new users and reporting
+// bounces and complaints of the sent mails.
 type Client struct {
-	api pb.SubServiceClient
+	cc           *grpc.ClientConn
+	api          pb.SubServiceClient
+	suppressions pb.SuppressionServiceClient
 }
 
 func (c *Client) Subscribe(ctx context.Context, req *pb.SubscribeRequest) error {
 	_, err := c.api.Subscribe(ctx, req)
 	return err
 }
+
+// RecordMailEvent reports bounce or complaint of the sent mail,
+// so sub could stop mailing the recipient.
+func (c *Client) RecordMailEvent(ctx context.Context, req *pb.RecordMailEventRequest) error {
+	_, err := c.suppressions.RecordMailEvent(ctx, req)
+	return err
+}
+
+// Close method closes connection to the sub service.
+// Client can't be used after it's closed.
+func (c *Client) Close() error {
+	return c.cc.Close()
+}
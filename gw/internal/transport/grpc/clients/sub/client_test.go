@@ -1,4 +1,4 @@
-//go:generate mockgen -destination=./mocks/mock_sub.go -package=mocks github.com/hrvadl/converter/protos/gen/go/v1/sub SubServiceClient
+//go:generate mockgen -destination=./mocks/mock_sub.go -package=mocks github.com/hrvadl/converter/protos/gen/go/v1/sub SubServiceClient,SuppressionServiceClient
 package sub
 
 import (
@@ -89,3 +89,41 @@ func TestClientSubscribe(t *testing.T) {
 		})
 	}
 }
+
+func TestClientRecordMailEvent(t *testing.T) {
+	t.Parallel()
+	req := &pb.RecordMailEventRequest{
+		EventId: "msg_1",
+		Email:   "sub@me.com",
+		Type:    pb.MailEventType_MAIL_EVENT_TYPE_HARD_BOUNCE,
+	}
+	tests := []struct {
+		name    string
+		apiErr  error
+		wantErr bool
+	}{
+		{
+			name:    "Should not return error when suppression svc succeeded",
+			wantErr: false,
+		},
+		{
+			name:    "Should return error when suppression svc failed",
+			apiErr:  errors.New("failed to record event"),
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			api := mocks.NewMockSuppressionServiceClient(gomock.NewController(t))
+			api.EXPECT().RecordMailEvent(gomock.Any(), req).Times(1).Return(nil, tt.apiErr)
+			c := &Client{
+				suppressions: api,
+			}
+			if err := c.RecordMailEvent(context.Background(), req); (err != nil) != tt.wantErr {
+				t.Errorf("Client.RecordMailEvent() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
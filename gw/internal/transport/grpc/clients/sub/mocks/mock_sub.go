@@ -1,9 +1,9 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: github.com/hrvadl/converter/protos/gen/go/v1/sub (interfaces: SubServiceClient)
+// Source: github.com/hrvadl/converter/protos/gen/go/v1/sub (interfaces: SubServiceClient,SuppressionServiceClient)
 //
 // Generated by this command:
 //
-//	mockgen -destination=./mocks/mock_sub.go -package=mocks github.com/hrvadl/converter/protos/gen/go/v1/sub SubServiceClient
+//	mockgen -destination=./mocks/mock_sub.go -package=mocks github.com/hrvadl/converter/protos/gen/go/v1/sub SubServiceClient,SuppressionServiceClient
 //
 
 // Package mocks is a generated GoMock package.
@@ -61,3 +61,46 @@ func (mr *MockSubServiceClientMockRecorder) Subscribe(arg0, arg1 any, arg2 ...an
 	varargs := append([]any{arg0, arg1}, arg2...)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Subscribe", reflect.TypeOf((*MockSubServiceClient)(nil).Subscribe), varargs...)
 }
+
+// MockSuppressionServiceClient is a mock of SuppressionServiceClient interface.
+type MockSuppressionServiceClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockSuppressionServiceClientMockRecorder
+}
+
+// MockSuppressionServiceClientMockRecorder is the mock recorder for MockSuppressionServiceClient.
+type MockSuppressionServiceClientMockRecorder struct {
+	mock *MockSuppressionServiceClient
+}
+
+// NewMockSuppressionServiceClient creates a new mock instance.
+func NewMockSuppressionServiceClient(ctrl *gomock.Controller) *MockSuppressionServiceClient {
+	mock := &MockSuppressionServiceClient{ctrl: ctrl}
+	mock.recorder = &MockSuppressionServiceClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSuppressionServiceClient) EXPECT() *MockSuppressionServiceClientMockRecorder {
+	return m.recorder
+}
+
+// RecordMailEvent mocks base method.
+func (m *MockSuppressionServiceClient) RecordMailEvent(arg0 context.Context, arg1 *sub.RecordMailEventRequest, arg2 ...grpc.CallOption) (*emptypb.Empty, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "RecordMailEvent", varargs...)
+	ret0, _ := ret[0].(*emptypb.Empty)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RecordMailEvent indicates an expected call of RecordMailEvent.
+func (mr *MockSuppressionServiceClientMockRecorder) RecordMailEvent(arg0, arg1 any, arg2 ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordMailEvent", reflect.TypeOf((*MockSuppressionServiceClient)(nil).RecordMailEvent), varargs...)
+}
@@ -0,0 +1,218 @@
+// Package guard protects public forms from the abuse: it limits
+// rate of the requests per client IP and per email domain, drops
+// requests with filled honeypot field and verifies CAPTCHA tokens.
+package guard
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+	"net"
+	"net/http"
+	"net/netip"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hrvadl/converter/gw/internal/captcha"
+	"github.com/hrvadl/converter/gw/internal/transport/http/handlers"
+	"github.com/hrvadl/converter/gw/pkg/ratelimit"
+)
+
+const (
+	// HoneypotField is a form field, which is hidden from humans,
+	// so only bots fill it.
+	HoneypotField = "website"
+	// TokenField is a form field with the CAPTCHA token.
+	TokenField = "captcha_token"
+)
+
+const verifyTimeout = time.Second * 5
+
+// NewGuard constructs guard with the per IP and per email domain limiters.
+// Verifier is optional: tokens aren't checked when it's nil. Client IP is
+// taken from the X-Forwarded-For header only when request comes from one
+// of the trusted proxies, so clients can't spoof it.
+// NOTE: neither of ip, domain or log arguments can't be nil,
+// or guard will panic.
+func NewGuard(
+	ip, domain Limiter,
+	v Verifier,
+	proxies []netip.Prefix,
+	log *slog.Logger,
+) *Guard {
+	return &Guard{
+		ip:      ip,
+		domain:  domain,
+		v:       v,
+		proxies: proxies,
+		log:     log,
+	}
+}
+
+//go:generate mockgen -destination=./mocks/mock_limiter.go -package=mocks . Limiter
+type Limiter interface {
+	Allow(ctx context.Context, key string) (ratelimit.Result, error)
+}
+
+//go:generate mockgen -destination=./mocks/mock_verifier.go -package=mocks . Verifier
+type Verifier interface {
+	Verify(ctx context.Context, token, remoteIP string) error
+}
+
+// Guard is a middleware, which protects form
+// submissions with email field from the abuse.
+type Guard struct {
+	ip      Limiter
+	domain  Limiter
+	v       Verifier
+	proxies []netip.Prefix
+	log     *slog.Logger
+}
+
+// Protect method wraps the handler. Bots, which filled the honeypot field,
+// receive fake success response. Clients, which exceeded limits, receive
+// 429 with Retry-After header. Requests with rejected tokens receive 400.
+// Limiter failures are logged and request is let through, so broken store
+// doesn't take the form down.
+func (g *Guard) Protect(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.FormValue(HoneypotField) != "" {
+			g.log.Info("Dropped request with filled honeypot", "addr", r.RemoteAddr)
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write(handlers.NewEmptyResponse("added email"))
+			return
+		}
+
+		ip := g.remoteIP(r)
+		if !g.allow(w, r, g.ip, ip) {
+			return
+		}
+
+		if domain := emailDomain(r.FormValue("email")); domain != "" && !g.allow(w, r, g.domain, domain) {
+			return
+		}
+
+		if g.v != nil && !g.verify(w, r, ip) {
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// allow takes token from the limiter and writes
+// 429 response, if request isn't allowed.
+func (g *Guard) allow(w http.ResponseWriter, r *http.Request, l Limiter, key string) bool {
+	res, err := l.Allow(r.Context(), key)
+	if err != nil {
+		g.log.Error("Failed to check rate limit", "key", key, "err", err)
+		return true
+	}
+
+	if res.Allowed {
+		return true
+	}
+
+	w.Header().Set("Retry-After", strconv.Itoa(retryAfter(res.RetryAfter)))
+	w.WriteHeader(http.StatusTooManyRequests)
+	_, _ = w.Write(handlers.NewErrResponse(errors.New("too many requests")))
+	return false
+}
+
+// verify checks token from the form and writes
+// error response, if token is rejected.
+func (g *Guard) verify(w http.ResponseWriter, r *http.Request, ip string) bool {
+	ctx, cancel := context.WithTimeout(r.Context(), verifyTimeout)
+	defer cancel()
+
+	err := g.v.Verify(ctx, r.FormValue(TokenField), ip)
+	if err == nil {
+		return true
+	}
+
+	if errors.Is(err, captcha.ErrRejected) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write(handlers.NewRejectedResponse(err.Error(), "VERIFICATION_FAILED"))
+		return false
+	}
+
+	g.log.Error("Failed to verify token", "err", err)
+	w.WriteHeader(http.StatusServiceUnavailable)
+	_, _ = w.Write(handlers.NewErrResponse(fmt.Errorf("failed to verify token: %w", err)))
+	return false
+}
+
+// retryAfter returns number of seconds for the Retry-After
+// header. It's rounded up, so client doesn't retry too early.
+func retryAfter(d time.Duration) int {
+	return max(int(math.Ceil(d.Seconds())), 1)
+}
+
+// remoteIP returns IP of the client without the port. When request
+// comes from the trusted proxy, X-Forwarded-For header is walked from
+// the right, and the first address, which isn't trusted proxy, is
+// returned. Addresses left of it could be forged by the client.
+func (g *Guard) remoteIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+
+	addr, err := netip.ParseAddr(host)
+	if err != nil || !g.trusted(addr) {
+		return host
+	}
+
+	hops := forwardedFor(r)
+	for i := len(hops) - 1; i >= 0; i-- {
+		hop, err := netip.ParseAddr(hops[i])
+		if err != nil {
+			break
+		}
+
+		host = hop.Unmap().String()
+		if !g.trusted(hop) {
+			break
+		}
+	}
+
+	return host
+}
+
+// trusted reports whether the address belongs to one of the trusted proxies.
+func (g *Guard) trusted(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	for _, p := range g.proxies {
+		if p.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// forwardedFor returns addresses from all X-Forwarded-For
+// headers of the request in the order they were added.
+func forwardedFor(r *http.Request) []string {
+	var hops []string
+	for _, h := range r.Header.Values("X-Forwarded-For") {
+		for _, hop := range strings.Split(h, ",") {
+			if hop = strings.TrimSpace(hop); hop != "" {
+				hops = append(hops, hop)
+			}
+		}
+	}
+	return hops
+}
+
+// emailDomain returns lowercased domain of the email
+// or empty string, if email is malformed.
+func emailDomain(email string) string {
+	i := strings.LastIndexByte(email, '@')
+	if i < 0 {
+		return ""
+	}
+	return strings.ToLower(strings.TrimSpace(email[i+1:]))
+}
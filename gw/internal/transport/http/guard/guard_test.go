@@ -0,0 +1,295 @@
+package guard
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"go.uber.org/mock/gomock"
+
+	"github.com/hrvadl/converter/gw/internal/captcha"
+	"github.com/hrvadl/converter/gw/internal/transport/http/guard/mocks"
+	"github.com/hrvadl/converter/gw/pkg/ratelimit"
+)
+
+func TestNewGuard(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	ip := mocks.NewMockLimiter(ctrl)
+	domain := mocks.NewMockLimiter(ctrl)
+	v := captcha.Fake{Token: "pass"}
+
+	proxies := []netip.Prefix{netip.MustParsePrefix("10.0.0.0/8")}
+
+	want := &Guard{ip: ip, domain: domain, v: v, proxies: proxies, log: slog.Default()}
+	if got := NewGuard(ip, domain, v, proxies, slog.Default()); !reflect.DeepEqual(got, want) {
+		t.Errorf("NewGuard() = %v, want %v", got, want)
+	}
+}
+
+func TestGuardProtect(t *testing.T) {
+	t.Parallel()
+	allowed := ratelimit.Result{Allowed: true, Remaining: 1}
+	limited := ratelimit.Result{RetryAfter: time.Millisecond * 1500}
+
+	tests := []struct {
+		name           string
+		form           url.Values
+		verifier       func(ctrl *gomock.Controller) Verifier
+		setup          func(ip, domain *mocks.MockLimiter)
+		want           int
+		wantNext       bool
+		wantRetryAfter string
+		wantReason     string
+	}{
+		{
+			name: "Should pass request when limits aren't exceeded",
+			form: url.Values{"email": {"user@Example.com"}},
+			setup: func(ip, domain *mocks.MockLimiter) {
+				ip.EXPECT().Allow(gomock.Any(), "192.0.2.1").Return(allowed, nil)
+				domain.EXPECT().Allow(gomock.Any(), "example.com").Return(allowed, nil)
+			},
+			want:     http.StatusOK,
+			wantNext: true,
+		},
+		{
+			name: "Should fake success when honeypot is filled",
+			form: url.Values{"email": {"user@example.com"}, HoneypotField: {"https://spam.com"}},
+			setup: func(_, _ *mocks.MockLimiter) {
+			},
+			want: http.StatusOK,
+		},
+		{
+			name: "Should return 429 when IP limit is exceeded",
+			form: url.Values{"email": {"user@example.com"}},
+			setup: func(ip, _ *mocks.MockLimiter) {
+				ip.EXPECT().Allow(gomock.Any(), "192.0.2.1").Return(limited, nil)
+			},
+			want:           http.StatusTooManyRequests,
+			wantRetryAfter: "2",
+		},
+		{
+			name: "Should return 429 when domain limit is exceeded",
+			form: url.Values{"email": {"user@example.com"}},
+			setup: func(ip, domain *mocks.MockLimiter) {
+				ip.EXPECT().Allow(gomock.Any(), "192.0.2.1").Return(allowed, nil)
+				domain.EXPECT().Allow(gomock.Any(), "example.com").Return(limited, nil)
+			},
+			want:           http.StatusTooManyRequests,
+			wantRetryAfter: "2",
+		},
+		{
+			name: "Should skip domain limit when email is malformed",
+			form: url.Values{"email": {"user"}},
+			setup: func(ip, _ *mocks.MockLimiter) {
+				ip.EXPECT().Allow(gomock.Any(), "192.0.2.1").Return(allowed, nil)
+			},
+			want:     http.StatusOK,
+			wantNext: true,
+		},
+		{
+			name: "Should pass request when limiter failed",
+			form: url.Values{"email": {"user@example.com"}},
+			setup: func(ip, domain *mocks.MockLimiter) {
+				ip.EXPECT().Allow(gomock.Any(), "192.0.2.1").Return(ratelimit.Result{}, errors.New("store is down"))
+				domain.EXPECT().Allow(gomock.Any(), "example.com").Return(allowed, nil)
+			},
+			want:     http.StatusOK,
+			wantNext: true,
+		},
+		{
+			name: "Should pass request when token is accepted",
+			form: url.Values{"email": {"user@example.com"}, TokenField: {"pass"}},
+			verifier: func(_ *gomock.Controller) Verifier {
+				return captcha.Fake{Token: "pass"}
+			},
+			setup: func(ip, domain *mocks.MockLimiter) {
+				ip.EXPECT().Allow(gomock.Any(), "192.0.2.1").Return(allowed, nil)
+				domain.EXPECT().Allow(gomock.Any(), "example.com").Return(allowed, nil)
+			},
+			want:     http.StatusOK,
+			wantNext: true,
+		},
+		{
+			name: "Should return 400 when token is rejected",
+			form: url.Values{"email": {"user@example.com"}, TokenField: {"fail"}},
+			verifier: func(_ *gomock.Controller) Verifier {
+				return captcha.Fake{Token: "pass"}
+			},
+			setup: func(ip, domain *mocks.MockLimiter) {
+				ip.EXPECT().Allow(gomock.Any(), "192.0.2.1").Return(allowed, nil)
+				domain.EXPECT().Allow(gomock.Any(), "example.com").Return(allowed, nil)
+			},
+			want:       http.StatusBadRequest,
+			wantReason: "VERIFICATION_FAILED",
+		},
+		{
+			name: "Should return 503 when verifier failed",
+			form: url.Values{"email": {"user@example.com"}, TokenField: {"pass"}},
+			verifier: func(ctrl *gomock.Controller) Verifier {
+				v := mocks.NewMockVerifier(ctrl)
+				v.EXPECT().Verify(gomock.Any(), "pass", "192.0.2.1").Return(errors.New("timeout"))
+				return v
+			},
+			setup: func(ip, domain *mocks.MockLimiter) {
+				ip.EXPECT().Allow(gomock.Any(), "192.0.2.1").Return(allowed, nil)
+				domain.EXPECT().Allow(gomock.Any(), "example.com").Return(allowed, nil)
+			},
+			want: http.StatusServiceUnavailable,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			ip := mocks.NewMockLimiter(ctrl)
+			domain := mocks.NewMockLimiter(ctrl)
+			tt.setup(ip, domain)
+
+			var v Verifier
+			if tt.verifier != nil {
+				v = tt.verifier(ctrl)
+			}
+
+			var called bool
+			next := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				called = true
+				w.WriteHeader(http.StatusOK)
+			})
+
+			r := httptest.NewRequest(http.MethodPost, "/api/subscribe", strings.NewReader(tt.form.Encode()))
+			r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			r.RemoteAddr = "192.0.2.1:1234"
+			w := httptest.NewRecorder()
+
+			NewGuard(ip, domain, v, nil, slog.Default()).Protect(next).ServeHTTP(w, r)
+
+			if w.Code != tt.want {
+				t.Errorf("Protect() status = %d, want %d", w.Code, tt.want)
+			}
+			if called != tt.wantNext {
+				t.Errorf("Protect() called next = %v, want %v", called, tt.wantNext)
+			}
+			if got := w.Header().Get("Retry-After"); got != tt.wantRetryAfter {
+				t.Errorf("Protect() Retry-After = %q, want %q", got, tt.wantRetryAfter)
+			}
+			if tt.wantReason != "" && !strings.Contains(w.Body.String(), tt.wantReason) {
+				t.Errorf("Protect() body = %s, want reason %s", w.Body.String(), tt.wantReason)
+			}
+		})
+	}
+}
+
+func TestGuardRemoteIP(t *testing.T) {
+	t.Parallel()
+	proxies := []netip.Prefix{
+		netip.MustParsePrefix("10.0.0.0/8"),
+		netip.MustParsePrefix("2001:db8::/32"),
+	}
+
+	tests := []struct {
+		name         string
+		remoteAddr   string
+		forwardedFor []string
+		want         string
+	}{
+		{
+			name:       "Should return connection IP when there is no proxy",
+			remoteAddr: "192.0.2.1:1234",
+			want:       "192.0.2.1",
+		},
+		{
+			name:         "Should ignore forwarded IP when connection isn't from trusted proxy",
+			remoteAddr:   "192.0.2.1:1234",
+			forwardedFor: []string{"198.51.100.7"},
+			want:         "192.0.2.1",
+		},
+		{
+			name:         "Should return forwarded IP when connection is from trusted proxy",
+			remoteAddr:   "10.0.0.1:1234",
+			forwardedFor: []string{"198.51.100.7"},
+			want:         "198.51.100.7",
+		},
+		{
+			name:         "Should skip trusted proxies in forwarded chain",
+			remoteAddr:   "10.0.0.1:1234",
+			forwardedFor: []string{"198.51.100.7, 10.0.0.2", "10.0.0.3"},
+			want:         "198.51.100.7",
+		},
+		{
+			name:         "Should not trust IP forged by client left of the real one",
+			remoteAddr:   "10.0.0.1:1234",
+			forwardedFor: []string{"203.0.113.9, 198.51.100.7"},
+			want:         "198.51.100.7",
+		},
+		{
+			name:         "Should return leftmost IP when whole chain is trusted",
+			remoteAddr:   "10.0.0.1:1234",
+			forwardedFor: []string{"10.0.0.3, 10.0.0.2"},
+			want:         "10.0.0.3",
+		},
+		{
+			name:         "Should stop at malformed forwarded IP",
+			remoteAddr:   "10.0.0.1:1234",
+			forwardedFor: []string{"198.51.100.7, unknown"},
+			want:         "10.0.0.1",
+		},
+		{
+			name:       "Should return connection IP when forwarded header is missing",
+			remoteAddr: "10.0.0.1:1234",
+			want:       "10.0.0.1",
+		},
+		{
+			name:         "Should support IPv6 proxies",
+			remoteAddr:   "[2001:db8::1]:1234",
+			forwardedFor: []string{"2001:db9::7"},
+			want:         "2001:db9::7",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			r := httptest.NewRequest(http.MethodPost, "/api/subscribe", nil)
+			r.RemoteAddr = tt.remoteAddr
+			for _, h := range tt.forwardedFor {
+				r.Header.Add("X-Forwarded-For", h)
+			}
+
+			g := NewGuard(nil, nil, nil, proxies, slog.Default())
+			if got := g.remoteIP(r); got != tt.want {
+				t.Errorf("remoteIP() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name string
+		d    time.Duration
+		want int
+	}{
+		{name: "Should round up fractional seconds", d: time.Millisecond * 1200, want: 2},
+		{name: "Should keep whole seconds", d: time.Second * 30, want: 30},
+		{name: "Should return at least one second", d: time.Millisecond, want: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := retryAfter(tt.d); got != tt.want {
+				t.Errorf("retryAfter() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -13,12 +13,25 @@ func NewErrResponse(err error) []byte {
 	return bytes
 }
 
+// NewRejectedResponse constructs the JSON encoded response,
+// which represents request rejected by API with the machine-readable
+// reason, i.e. DISPOSABLE_DOMAIN.
+func NewRejectedResponse(msg, reason string) []byte {
+	bytes, _ := json.Marshal(ErrorResponse{
+		Err:     msg,
+		Reason:  reason,
+		Success: false,
+	})
+	return bytes
+}
+
 // ErrorResponse struct is a JSON encoded response,
 // which represents request failure from API.
 // NOTE: it expects error to be not nil value.
 type ErrorResponse struct {
 	Success bool   `json:"success"`
 	Err     string `json:"error"`
+	Reason  string `json:"reason,omitempty"`
 }
 
 // NewEmptyResponse constructs the JSON encoded response,
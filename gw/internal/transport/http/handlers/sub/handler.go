@@ -2,11 +2,16 @@ package sub
 
 import (
 	"context"
+	"errors"
 	"log/slog"
 	"net/http"
+	"strconv"
 	"time"
 
 	pb "github.com/hrvadl/converter/protos/gen/go/v1/sub"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	"github.com/hrvadl/converter/gw/internal/transport/http/handlers"
 )
@@ -33,16 +38,36 @@ type Handler struct {
 // @Tags         Rate
 // @Accept       application/x-www-form-urlencoded
 // @Produce      json
-// @Param        body formData string true "Email to subscribe"
+// @Param        email formData string true "Email to subscribe"
+// @Param        frequency formData string false "How often to send mails" Enums(daily, weekly, monthly) default(daily)
+// @Param        weekday formData int false "Day of the week for the weekly digest, 0 is Sunday" minimum(0) maximum(6)
+// @Param        day formData int false "Day of the month for the monthly digest" minimum(1) maximum(31)
+// @Param        locale formData string false "Language of the mails" Enums(en, uk) default(en)
+// @Param        captcha_token formData string false "CAPTCHA token, required when verification is enabled"
 // @Success      200  {object}  handlers.EmptyResponse
-// @Failure      400  {object}  handlers.ErrorResponse
+// @Failure      400  {object}  handlers.ErrorResponse "Email or preferences are rejected, reason is one of INVALID_SYNTAX, NO_MAIL_SERVER, DISPOSABLE_DOMAIN, ROLE_ACCOUNT, INVALID_PREFERENCES, VERIFICATION_FAILED"
+// @Failure      409  {object}  handlers.ErrorResponse
+// @Failure      429  {object}  handlers.ErrorResponse "Too many requests from the IP or email domain, see Retry-After header"
+// @Failure      503  {object}  handlers.ErrorResponse "CAPTCHA provider is unavailable"
 // @Router       /api/subscribe [post]
 func (h *Handler) Subscribe(w http.ResponseWriter, r *http.Request) {
-	mail := r.FormValue("email")
+	req, err := parseSubscribeRequest(r)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write(handlers.NewErrResponse(err))
+		return
+	}
+
 	ctx, cancel := context.WithTimeout(r.Context(), time.Second*5)
 	defer cancel()
 
-	if err := h.svc.Subscribe(ctx, &pb.SubscribeRequest{Email: mail}); err != nil {
+	if err := h.svc.Subscribe(ctx, req); err != nil {
+		if st, reason, ok := rejection(err); ok {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write(handlers.NewRejectedResponse(st.Message(), reason))
+			return
+		}
+
 		h.log.Error("Failed to subscribe user", "err", err)
 		w.WriteHeader(http.StatusConflict)
 		_, _ = w.Write(handlers.NewErrResponse(err))
@@ -52,3 +77,65 @@ func (h *Handler) Subscribe(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusOK)
 	_, _ = w.Write(handlers.NewEmptyResponse("added email"))
 }
+
+// rejection returns status and the reason, if subscriber was
+// rejected by the sub service with InvalidArgument code.
+func rejection(err error) (*status.Status, string, bool) {
+	st, ok := status.FromError(err)
+	if !ok || st.Code() != codes.InvalidArgument {
+		return nil, "", false
+	}
+
+	for _, d := range st.Details() {
+		if info, ok := d.(*errdetails.ErrorInfo); ok {
+			return st, info.GetReason(), true
+		}
+	}
+
+	return st, "", true
+}
+
+// parseSubscribeRequest maps form values to the GRPC request.
+// Frequency, weekday, day and locale are optional, so subscriber without
+// them will receive daily mails in English.
+func parseSubscribeRequest(r *http.Request) (*pb.SubscribeRequest, error) {
+	req := &pb.SubscribeRequest{Email: r.FormValue("email")}
+
+	switch r.FormValue("frequency") {
+	case "":
+		req.Frequency = pb.Frequency_FREQUENCY_UNSPECIFIED
+	case "daily":
+		req.Frequency = pb.Frequency_FREQUENCY_DAILY
+	case "weekly":
+		req.Frequency = pb.Frequency_FREQUENCY_WEEKLY
+	case "monthly":
+		req.Frequency = pb.Frequency_FREQUENCY_MONTHLY
+	default:
+		return nil, errors.New("invalid frequency")
+	}
+
+	if weekday := r.FormValue("weekday"); weekday != "" {
+		v, err := strconv.ParseInt(weekday, 10, 32)
+		if err != nil {
+			return nil, errors.New("invalid weekday")
+		}
+		req.Weekday = int32(v)
+	}
+
+	if day := r.FormValue("day"); day != "" {
+		v, err := strconv.ParseInt(day, 10, 32)
+		if err != nil {
+			return nil, errors.New("invalid day")
+		}
+		req.MonthDay = int32(v)
+	}
+
+	switch locale := r.FormValue("locale"); locale {
+	case "", "en", "uk":
+		req.Locale = locale
+	default:
+		return nil, errors.New("invalid locale")
+	}
+
+	return req, nil
+}
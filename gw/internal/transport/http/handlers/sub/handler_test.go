@@ -2,6 +2,7 @@ package sub
 
 import (
 	"bytes"
+	"encoding/json"
 	"errors"
 	"log/slog"
 	"net/http"
@@ -12,7 +13,11 @@ import (
 
 	pb "github.com/hrvadl/converter/protos/gen/go/v1/sub"
 	"go.uber.org/mock/gomock"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
+	"github.com/hrvadl/converter/gw/internal/transport/http/handlers"
 	"github.com/hrvadl/converter/gw/internal/transport/http/handlers/sub/mocks"
 )
 
@@ -61,11 +66,12 @@ func TestHandlerSubscribe(t *testing.T) {
 		r *http.Request
 	}
 	tests := []struct {
-		name   string
-		fields fields
-		args   args
-		setup  func(t *testing.T, service Service)
-		want   int
+		name       string
+		fields     fields
+		args       args
+		setup      func(t *testing.T, service Service)
+		want       int
+		wantReason string
 	}{
 		{
 			name: "Should return 200 when service succeeded",
@@ -123,6 +129,164 @@ func TestHandlerSubscribe(t *testing.T) {
 			},
 			want: http.StatusConflict,
 		},
+		{
+			name: "Should return 400 with the reason when subscriber is rejected",
+			fields: fields{
+				svc: mocks.NewMockService(gomock.NewController(t)),
+				log: slog.Default(),
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: withFormDataContentType(httptest.NewRequest(
+					http.MethodPost,
+					"/",
+					bytes.NewBufferString(url.Values{"email": {"test@mailinator.com"}}.Encode()),
+				)),
+			},
+			setup: func(t *testing.T, service Service) {
+				t.Helper()
+				svc, ok := service.(*mocks.MockService)
+				if !ok {
+					t.Fatal("Failed to cast service to mock")
+				}
+
+				st, err := status.New(codes.InvalidArgument, "mailinator.com is a disposable mail provider").
+					WithDetails(&errdetails.ErrorInfo{Reason: "DISPOSABLE_DOMAIN", Domain: "sub.converter"})
+				if err != nil {
+					t.Fatalf("Failed to add details: %v", err)
+				}
+
+				svc.EXPECT().
+					Subscribe(gomock.Any(), &pb.SubscribeRequest{Email: "test@mailinator.com"}).
+					Times(1).
+					Return(st.Err())
+			},
+			want:       http.StatusBadRequest,
+			wantReason: "DISPOSABLE_DOMAIN",
+		},
+		{
+			name: "Should pass digest preferences and locale to the service",
+			fields: fields{
+				svc: mocks.NewMockService(gomock.NewController(t)),
+				log: slog.Default(),
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: withFormDataContentType(httptest.NewRequest(
+					http.MethodPost,
+					"/",
+					bytes.NewBufferString(url.Values{
+						"email":     {"test@test.com"},
+						"frequency": {"weekly"},
+						"weekday":   {"5"},
+						"locale":    {"uk"},
+					}.Encode()),
+				)),
+			},
+			setup: func(t *testing.T, service Service) {
+				t.Helper()
+				svc, ok := service.(*mocks.MockService)
+				if !ok {
+					t.Fatal("Failed to cast service to mock")
+				}
+
+				svc.EXPECT().
+					Subscribe(gomock.Any(), &pb.SubscribeRequest{
+						Email:     "test@test.com",
+						Frequency: pb.Frequency_FREQUENCY_WEEKLY,
+						Weekday:   5,
+						Locale:    "uk",
+					}).
+					Times(1).
+					Return(nil)
+			},
+			want: http.StatusOK,
+		},
+		{
+			name: "Should return 400 when frequency is unknown",
+			fields: fields{
+				svc: mocks.NewMockService(gomock.NewController(t)),
+				log: slog.Default(),
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: withFormDataContentType(httptest.NewRequest(
+					http.MethodPost,
+					"/",
+					bytes.NewBufferString(url.Values{
+						"email":     {"test@test.com"},
+						"frequency": {"hourly"},
+					}.Encode()),
+				)),
+			},
+			setup: func(t *testing.T, service Service) {
+				t.Helper()
+				svc, ok := service.(*mocks.MockService)
+				if !ok {
+					t.Fatal("Failed to cast service to mock")
+				}
+
+				svc.EXPECT().Subscribe(gomock.Any(), gomock.Any()).Times(0)
+			},
+			want: http.StatusBadRequest,
+		},
+		{
+			name: "Should return 400 when locale is unknown",
+			fields: fields{
+				svc: mocks.NewMockService(gomock.NewController(t)),
+				log: slog.Default(),
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: withFormDataContentType(httptest.NewRequest(
+					http.MethodPost,
+					"/",
+					bytes.NewBufferString(url.Values{
+						"email":  {"test@test.com"},
+						"locale": {"fr"},
+					}.Encode()),
+				)),
+			},
+			setup: func(t *testing.T, service Service) {
+				t.Helper()
+				svc, ok := service.(*mocks.MockService)
+				if !ok {
+					t.Fatal("Failed to cast service to mock")
+				}
+
+				svc.EXPECT().Subscribe(gomock.Any(), gomock.Any()).Times(0)
+			},
+			want: http.StatusBadRequest,
+		},
+		{
+			name: "Should return 400 when day is not a number",
+			fields: fields{
+				svc: mocks.NewMockService(gomock.NewController(t)),
+				log: slog.Default(),
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: withFormDataContentType(httptest.NewRequest(
+					http.MethodPost,
+					"/",
+					bytes.NewBufferString(url.Values{
+						"email":     {"test@test.com"},
+						"frequency": {"monthly"},
+						"day":       {"first"},
+					}.Encode()),
+				)),
+			},
+			setup: func(t *testing.T, service Service) {
+				t.Helper()
+				svc, ok := service.(*mocks.MockService)
+				if !ok {
+					t.Fatal("Failed to cast service to mock")
+				}
+
+				svc.EXPECT().Subscribe(gomock.Any(), gomock.Any()).Times(0)
+			},
+			want: http.StatusBadRequest,
+		},
 	}
 
 	for _, tt := range tests {
@@ -137,6 +301,18 @@ func TestHandlerSubscribe(t *testing.T) {
 			if got := tt.args.w.Result().StatusCode; got != tt.want {
 				t.Errorf("Subscribe() = %v, want %v", got, tt.want)
 			}
+
+			if tt.wantReason == "" {
+				return
+			}
+
+			var res handlers.ErrorResponse
+			if err := json.NewDecoder(tt.args.w.Body).Decode(&res); err != nil {
+				t.Fatalf("Failed to decode response: %v", err)
+			}
+			if res.Reason != tt.wantReason {
+				t.Errorf("Subscribe() reason = %v, want %v", res.Reason, tt.wantReason)
+			}
 		})
 	}
 }
@@ -0,0 +1,154 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"time"
+
+	pb "github.com/hrvadl/converter/protos/gen/go/v1/sub"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/hrvadl/converter/gw/internal/transport/http/handlers"
+)
+
+// maxBodySize is a maximum size of the webhook payload.
+const maxBodySize = 1 << 20
+
+const (
+	eventBounced    = "email.bounced"
+	eventComplained = "email.complained"
+	// permanentBounce is a type of the bounce, which won't go away
+	// on retry, i.e. mailbox doesn't exist. Transient and undetermined
+	// bounces are treated as soft ones.
+	permanentBounce = "Permanent"
+)
+
+// NewHandler constructs webhook handler, which verifies
+// payloads with the key returned from the ParseSecret.
+// NOTE: neither of arguments can't be nil, or handler will panic.
+func NewHandler(svc Service, key []byte, log *slog.Logger) *Handler {
+	return &Handler{
+		svc: svc,
+		key: key,
+		log: log,
+	}
+}
+
+//go:generate mockgen -destination=./mocks/mock_svc.go -package=mocks . Service
+type Service interface {
+	RecordMailEvent(ctx context.Context, req *pb.RecordMailEventRequest) error
+}
+
+// Handler is a handler of the mail provider webhooks,
+// which records delivery events with the sub service.
+type Handler struct {
+	svc Service
+	key []byte
+	log *slog.Logger
+}
+
+// resendEvent is a payload of the Resend webhook.
+// Only fields used by the handler are decoded.
+type resendEvent struct {
+	Type string `json:"type"`
+	Data struct {
+		EmailID string   `json:"email_id"`
+		To      []string `json:"to"`
+		Bounce  struct {
+			Message string `json:"message"`
+			Type    string `json:"type"`
+		} `json:"bounce"`
+	} `json:"data"`
+}
+
+// Resend godoc
+// @Summary      Receive Resend webhook with bounces and complaints
+// @Description  Payload should be signed by Svix. Bounced and complaining emails are suppressed, other events are ignored.
+// @Tags         Webhook
+// @Accept       json
+// @Produce      json
+// @Param        svix-id header string true "Svix message ID"
+// @Param        svix-timestamp header string true "Svix timestamp in Unix seconds"
+// @Param        svix-signature header string true "Svix signature"
+// @Success      200  {object}  handlers.EmptyResponse
+// @Failure      400  {object}  handlers.ErrorResponse
+// @Failure      401  {object}  handlers.ErrorResponse
+// @Failure      500  {object}  handlers.ErrorResponse
+// @Router       /api/webhooks/resend [post]
+func (h *Handler) Resend(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxBodySize))
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write(handlers.NewErrResponse(err))
+		return
+	}
+
+	if err := verify(h.key, r.Header, body, time.Now()); err != nil {
+		h.log.Warn("Rejected webhook", "err", err)
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write(handlers.NewErrResponse(err))
+		return
+	}
+
+	var e resendEvent
+	if err := json.Unmarshal(body, &e); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write(handlers.NewErrResponse(errors.New("invalid payload")))
+		return
+	}
+
+	t, ok := mapEventType(e)
+	if !ok {
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(handlers.NewEmptyResponse("event is ignored"))
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), time.Second*5)
+	defer cancel()
+
+	for _, to := range e.Data.To {
+		err := h.svc.RecordMailEvent(ctx, &pb.RecordMailEventRequest{
+			EventId:     r.Header.Get(idHeader),
+			Email:       to,
+			Type:        t,
+			MessageId:   e.Data.EmailID,
+			Description: e.Data.Bounce.Message,
+		})
+		if status.Code(err) == codes.InvalidArgument {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write(handlers.NewErrResponse(err))
+			return
+		}
+		if err != nil {
+			h.log.Error("Failed to record mail event", "err", err, "type", e.Type)
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write(handlers.NewErrResponse(errors.New("failed to record event")))
+			return
+		}
+	}
+
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(handlers.NewEmptyResponse("event recorded"))
+}
+
+// mapEventType maps Resend event to the GRPC mail event type.
+// Returns false if event isn't a bounce or complaint.
+func mapEventType(e resendEvent) (pb.MailEventType, bool) {
+	switch e.Type {
+	case eventBounced:
+		if e.Data.Bounce.Type == permanentBounce {
+			return pb.MailEventType_MAIL_EVENT_TYPE_HARD_BOUNCE, true
+		}
+		return pb.MailEventType_MAIL_EVENT_TYPE_SOFT_BOUNCE, true
+	case eventComplained:
+		return pb.MailEventType_MAIL_EVENT_TYPE_COMPLAINT, true
+	default:
+		return pb.MailEventType_MAIL_EVENT_TYPE_UNSPECIFIED, false
+	}
+}
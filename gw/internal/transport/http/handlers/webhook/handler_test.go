@@ -0,0 +1,346 @@
+package webhook
+
+import (
+	"bytes"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+
+	pb "github.com/hrvadl/converter/protos/gen/go/v1/sub"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/hrvadl/converter/gw/internal/transport/http/handlers/webhook/mocks"
+)
+
+var testKey = []byte("secret")
+
+func TestNewHandler(t *testing.T) {
+	t.Parallel()
+	type args struct {
+		svc Service
+		key []byte
+		log *slog.Logger
+	}
+	tests := []struct {
+		name string
+		args args
+		want *Handler
+	}{
+		{
+			name: "Should create handler correctly",
+			args: args{
+				svc: mocks.NewMockService(gomock.NewController(t)),
+				key: testKey,
+				log: slog.Default(),
+			},
+			want: &Handler{
+				svc: mocks.NewMockService(gomock.NewController(t)),
+				key: testKey,
+				log: slog.Default(),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := NewHandler(tt.args.svc, tt.args.key, tt.args.log); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewHandler() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHandlerResend(t *testing.T) {
+	t.Parallel()
+	const (
+		hardBounce = `{"type":"email.bounced","data":{"email_id":"id","to":["test@test.com"],` +
+			`"bounce":{"message":"mailbox doesn't exist","type":"Permanent"}}}`
+		softBounce = `{"type":"email.bounced","data":{"email_id":"id","to":["test@test.com"],` +
+			`"bounce":{"message":"mailbox is full","type":"Transient"}}}`
+		complaint = `{"type":"email.complained","data":{"email_id":"id","to":["test@test.com","test2@test.com"]}}`
+		delivered = `{"type":"email.delivered","data":{"email_id":"id","to":["test@test.com"]}}`
+	)
+	type fields struct {
+		svc Service
+		log *slog.Logger
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		r      *http.Request
+		setup  func(t *testing.T, service Service)
+		want   int
+	}{
+		{
+			name: "Should record hard bounce when payload is signed",
+			fields: fields{
+				svc: mocks.NewMockService(gomock.NewController(t)),
+				log: slog.Default(),
+			},
+			r: newSignedRequest(t, hardBounce),
+			setup: func(t *testing.T, service Service) {
+				t.Helper()
+				svc, ok := service.(*mocks.MockService)
+				if !ok {
+					t.Fatal("Failed to cast service to mock")
+				}
+
+				svc.EXPECT().
+					RecordMailEvent(gomock.Any(), &pb.RecordMailEventRequest{
+						EventId:     "msg_1",
+						Email:       "test@test.com",
+						Type:        pb.MailEventType_MAIL_EVENT_TYPE_HARD_BOUNCE,
+						MessageId:   "id",
+						Description: "mailbox doesn't exist",
+					}).
+					Times(1).
+					Return(nil)
+			},
+			want: http.StatusOK,
+		},
+		{
+			name: "Should record transient bounce as soft one",
+			fields: fields{
+				svc: mocks.NewMockService(gomock.NewController(t)),
+				log: slog.Default(),
+			},
+			r: newSignedRequest(t, softBounce),
+			setup: func(t *testing.T, service Service) {
+				t.Helper()
+				svc, ok := service.(*mocks.MockService)
+				if !ok {
+					t.Fatal("Failed to cast service to mock")
+				}
+
+				svc.EXPECT().
+					RecordMailEvent(gomock.Any(), &pb.RecordMailEventRequest{
+						EventId:     "msg_1",
+						Email:       "test@test.com",
+						Type:        pb.MailEventType_MAIL_EVENT_TYPE_SOFT_BOUNCE,
+						MessageId:   "id",
+						Description: "mailbox is full",
+					}).
+					Times(1).
+					Return(nil)
+			},
+			want: http.StatusOK,
+		},
+		{
+			name: "Should record complaint for every recipient",
+			fields: fields{
+				svc: mocks.NewMockService(gomock.NewController(t)),
+				log: slog.Default(),
+			},
+			r: newSignedRequest(t, complaint),
+			setup: func(t *testing.T, service Service) {
+				t.Helper()
+				svc, ok := service.(*mocks.MockService)
+				if !ok {
+					t.Fatal("Failed to cast service to mock")
+				}
+
+				for _, email := range []string{"test@test.com", "test2@test.com"} {
+					svc.EXPECT().
+						RecordMailEvent(gomock.Any(), &pb.RecordMailEventRequest{
+							EventId:   "msg_1",
+							Email:     email,
+							Type:      pb.MailEventType_MAIL_EVENT_TYPE_COMPLAINT,
+							MessageId: "id",
+						}).
+						Times(1).
+						Return(nil)
+				}
+			},
+			want: http.StatusOK,
+		},
+		{
+			name: "Should ignore events other than bounces and complaints",
+			fields: fields{
+				svc: mocks.NewMockService(gomock.NewController(t)),
+				log: slog.Default(),
+			},
+			r: newSignedRequest(t, delivered),
+			setup: func(t *testing.T, service Service) {
+				t.Helper()
+				svc, ok := service.(*mocks.MockService)
+				if !ok {
+					t.Fatal("Failed to cast service to mock")
+				}
+
+				svc.EXPECT().RecordMailEvent(gomock.Any(), gomock.Any()).Times(0)
+			},
+			want: http.StatusOK,
+		},
+		{
+			name: "Should return 401 when signature is invalid",
+			fields: fields{
+				svc: mocks.NewMockService(gomock.NewController(t)),
+				log: slog.Default(),
+			},
+			r: func() *http.Request {
+				r := newSignedRequest(t, hardBounce)
+				r.Header.Set(signatureHeader, "v1,"+sign([]byte("other"), "msg_1", r.Header.Get(timestampHeader), nil))
+				return r
+			}(),
+			setup: func(t *testing.T, service Service) {
+				t.Helper()
+				svc, ok := service.(*mocks.MockService)
+				if !ok {
+					t.Fatal("Failed to cast service to mock")
+				}
+
+				svc.EXPECT().RecordMailEvent(gomock.Any(), gomock.Any()).Times(0)
+			},
+			want: http.StatusUnauthorized,
+		},
+		{
+			name: "Should return 400 when payload is malformed",
+			fields: fields{
+				svc: mocks.NewMockService(gomock.NewController(t)),
+				log: slog.Default(),
+			},
+			r: newSignedRequest(t, "{"),
+			setup: func(t *testing.T, service Service) {
+				t.Helper()
+				svc, ok := service.(*mocks.MockService)
+				if !ok {
+					t.Fatal("Failed to cast service to mock")
+				}
+
+				svc.EXPECT().RecordMailEvent(gomock.Any(), gomock.Any()).Times(0)
+			},
+			want: http.StatusBadRequest,
+		},
+		{
+			name: "Should return 400 when event is rejected",
+			fields: fields{
+				svc: mocks.NewMockService(gomock.NewController(t)),
+				log: slog.Default(),
+			},
+			r: newSignedRequest(t, hardBounce),
+			setup: func(t *testing.T, service Service) {
+				t.Helper()
+				svc, ok := service.(*mocks.MockService)
+				if !ok {
+					t.Fatal("Failed to cast service to mock")
+				}
+
+				svc.EXPECT().
+					RecordMailEvent(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(status.Error(codes.InvalidArgument, "email is required"))
+			},
+			want: http.StatusBadRequest,
+		},
+		{
+			name: "Should return 500 when service failed, so webhook is retried",
+			fields: fields{
+				svc: mocks.NewMockService(gomock.NewController(t)),
+				log: slog.Default(),
+			},
+			r: newSignedRequest(t, hardBounce),
+			setup: func(t *testing.T, service Service) {
+				t.Helper()
+				svc, ok := service.(*mocks.MockService)
+				if !ok {
+					t.Fatal("Failed to cast service to mock")
+				}
+
+				svc.EXPECT().
+					RecordMailEvent(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(errors.New("failed to record"))
+			},
+			want: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			tt.setup(t, tt.fields.svc)
+			h := &Handler{
+				svc: tt.fields.svc,
+				key: testKey,
+				log: tt.fields.log,
+			}
+			w := httptest.NewRecorder()
+			h.Resend(w, tt.r)
+			if w.Code != tt.want {
+				t.Errorf("Handler.Resend() code = %v, want %v", w.Code, tt.want)
+			}
+		})
+	}
+}
+
+func TestMapEventType(t *testing.T) {
+	t.Parallel()
+	bounce := func(bounceType string) resendEvent {
+		var e resendEvent
+		e.Type = eventBounced
+		e.Data.Bounce.Type = bounceType
+		return e
+	}
+	tests := []struct {
+		name   string
+		e      resendEvent
+		want   pb.MailEventType
+		wantOk bool
+	}{
+		{
+			name:   "Should map permanent bounce to the hard one",
+			e:      bounce("Permanent"),
+			want:   pb.MailEventType_MAIL_EVENT_TYPE_HARD_BOUNCE,
+			wantOk: true,
+		},
+		{
+			name:   "Should map undetermined bounce to the soft one",
+			e:      bounce("Undetermined"),
+			want:   pb.MailEventType_MAIL_EVENT_TYPE_SOFT_BOUNCE,
+			wantOk: true,
+		},
+		{
+			name:   "Should map complaint correctly",
+			e:      resendEvent{Type: eventComplained},
+			want:   pb.MailEventType_MAIL_EVENT_TYPE_COMPLAINT,
+			wantOk: true,
+		},
+		{
+			name:   "Should not map other events",
+			e:      resendEvent{Type: "email.opened"},
+			want:   pb.MailEventType_MAIL_EVENT_TYPE_UNSPECIFIED,
+			wantOk: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, ok := mapEventType(tt.e)
+			if got != tt.want || ok != tt.wantOk {
+				t.Errorf("mapEventType() = %v, %v, want %v, %v", got, ok, tt.want, tt.wantOk)
+			}
+		})
+	}
+}
+
+// newSignedRequest constructs webhook request with
+// the body signed by testKey at the current time.
+func newSignedRequest(t *testing.T, body string) *http.Request {
+	t.Helper()
+	ts := strconv.FormatInt(time.Now().Unix(), 10)
+	r := httptest.NewRequest(http.MethodPost, "/", bytes.NewBufferString(body))
+	r.Header.Set("Content-Type", "application/json")
+	r.Header.Set(idHeader, "msg_1")
+	r.Header.Set(timestampHeader, ts)
+	r.Header.Set(signatureHeader, "v1,"+sign(testKey, "msg_1", ts, []byte(body)))
+	return r
+}
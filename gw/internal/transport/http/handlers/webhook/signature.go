@@ -0,0 +1,92 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	idHeader        = "svix-id"
+	timestampHeader = "svix-timestamp"
+	signatureHeader = "svix-signature"
+)
+
+const (
+	secretPrefix     = "whsec_"
+	signatureVersion = "v1,"
+)
+
+// tolerance is a maximum difference between the time webhook was
+// signed and the current time, so captured webhooks can't be replayed.
+const tolerance = time.Minute * 5
+
+var (
+	// ErrMissingHeaders is returned when the webhook isn't signed.
+	ErrMissingHeaders = errors.New("missing signature headers")
+	// ErrInvalidTimestamp is returned when webhook was signed
+	// too long ago or its timestamp is malformed.
+	ErrInvalidTimestamp = errors.New("invalid signature timestamp")
+	// ErrInvalidSignature is returned when none of the
+	// signatures match the payload.
+	ErrInvalidSignature = errors.New("invalid signature")
+)
+
+// ParseSecret decodes Svix signing secret, i.e. "whsec_<base64>",
+// which is shown in the Resend dashboard.
+func ParseSecret(secret string) ([]byte, error) {
+	key, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(secret, secretPrefix))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode webhook secret: %w", err)
+	}
+
+	if len(key) == 0 {
+		return nil, errors.New("webhook secret can't be empty")
+	}
+
+	return key, nil
+}
+
+// verify checks Svix signature of the webhook payload. Payload is
+// signed with HMAC-SHA256 over "{id}.{timestamp}.{body}". Signature
+// header could contain several space-delimited signatures, i.e. during
+// secret rotation, webhook is accepted if any of them matches.
+func verify(key []byte, h http.Header, body []byte, now time.Time) error {
+	id, ts, sigs := h.Get(idHeader), h.Get(timestampHeader), h.Get(signatureHeader)
+	if id == "" || ts == "" || sigs == "" {
+		return ErrMissingHeaders
+	}
+
+	sec, err := strconv.ParseInt(ts, 10, 64)
+	if err != nil {
+		return ErrInvalidTimestamp
+	}
+
+	if d := now.Sub(time.Unix(sec, 0)); d > tolerance || d < -tolerance {
+		return ErrInvalidTimestamp
+	}
+
+	want := sign(key, id, ts, body)
+	for _, sig := range strings.Fields(sigs) {
+		got, ok := strings.CutPrefix(sig, signatureVersion)
+		if ok && hmac.Equal([]byte(got), []byte(want)) {
+			return nil
+		}
+	}
+
+	return ErrInvalidSignature
+}
+
+// sign returns base64 encoded signature of the payload.
+func sign(key []byte, id, ts string, body []byte) string {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(id + "." + ts + "."))
+	mac.Write(body)
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
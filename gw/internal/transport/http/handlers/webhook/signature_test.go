@@ -0,0 +1,134 @@
+package webhook
+
+import (
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestParseSecret(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		secret  string
+		want    string
+		wantErr bool
+	}{
+		{
+			name:   "Should decode secret with the prefix",
+			secret: "whsec_" + base64.StdEncoding.EncodeToString([]byte("secret")),
+			want:   "secret",
+		},
+		{
+			name:   "Should decode secret without the prefix",
+			secret: base64.StdEncoding.EncodeToString([]byte("secret")),
+			want:   "secret",
+		},
+		{
+			name:    "Should return error when secret is not base64",
+			secret:  "whsec_not base64",
+			wantErr: true,
+		},
+		{
+			name:    "Should return error when secret is empty",
+			secret:  "whsec_",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := ParseSecret(tt.secret)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseSecret() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if string(got) != tt.want {
+				t.Errorf("ParseSecret() = %v, want %v", string(got), tt.want)
+			}
+		})
+	}
+}
+
+func TestVerify(t *testing.T) {
+	t.Parallel()
+	key := []byte("secret")
+	body := []byte(`{"type":"email.bounced"}`)
+	now := time.Unix(1700000000, 0)
+	ts := strconv.FormatInt(now.Unix(), 10)
+	valid := "v1," + sign(key, "msg_1", ts, body)
+	headers := func(id, ts, sig string) http.Header {
+		h := http.Header{}
+		h.Set(idHeader, id)
+		h.Set(timestampHeader, ts)
+		h.Set(signatureHeader, sig)
+		return h
+	}
+	tests := []struct {
+		name    string
+		h       http.Header
+		body    []byte
+		now     time.Time
+		wantErr error
+	}{
+		{
+			name: "Should accept valid signature",
+			h:    headers("msg_1", ts, valid),
+			body: body,
+			now:  now,
+		},
+		{
+			name: "Should accept payload when any of signatures is valid",
+			h:    headers("msg_1", ts, "v1,cm90YXRlZA== "+valid),
+			body: body,
+			now:  now.Add(tolerance),
+		},
+		{
+			name:    "Should reject payload when headers are missing",
+			h:       headers("msg_1", "", valid),
+			body:    body,
+			now:     now,
+			wantErr: ErrMissingHeaders,
+		},
+		{
+			name:    "Should reject payload when timestamp is too old",
+			h:       headers("msg_1", ts, valid),
+			body:    body,
+			now:     now.Add(tolerance + time.Second),
+			wantErr: ErrInvalidTimestamp,
+		},
+		{
+			name:    "Should reject payload when timestamp is malformed",
+			h:       headers("msg_1", "yesterday", valid),
+			body:    body,
+			now:     now,
+			wantErr: ErrInvalidTimestamp,
+		},
+		{
+			name:    "Should reject payload when body was tampered",
+			h:       headers("msg_1", ts, valid),
+			body:    []byte(`{"type":"email.complained"}`),
+			now:     now,
+			wantErr: ErrInvalidSignature,
+		},
+		{
+			name:    "Should reject payload when signature version is unknown",
+			h:       headers("msg_1", ts, "v2,"+sign(key, "msg_1", ts, body)),
+			body:    body,
+			now:     now,
+			wantErr: ErrInvalidSignature,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if err := verify(key, tt.h, tt.body, tt.now); !errors.Is(err, tt.wantErr) {
+				t.Errorf("verify() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
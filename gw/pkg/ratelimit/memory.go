@@ -0,0 +1,56 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// sweepInterval is an interval between evictions of the full buckets.
+const sweepInterval = time.Minute
+
+// NewMemoryStore constructs store, which keeps buckets in memory
+// of the process. It's suitable for a single replica only.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		buckets: make(map[string]entry),
+	}
+}
+
+type entry struct {
+	bucket Bucket
+	fullAt time.Time
+}
+
+// MemoryStore is an in-memory Store. Buckets, which are
+// refilled completely, are evicted, so memory doesn't grow
+// with the number of distinct keys over time.
+type MemoryStore struct {
+	mu        sync.Mutex
+	buckets   map[string]entry
+	lastSweep time.Time
+}
+
+// Take method takes a token from the bucket stored under the key.
+func (s *MemoryStore) Take(_ context.Context, key string, l Limit, now time.Time) (Result, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if now.Sub(s.lastSweep) >= sweepInterval {
+		s.sweep(now)
+	}
+
+	b, res := s.buckets[key].bucket.Take(l, now)
+	s.buckets[key] = entry{bucket: b, fullAt: b.FullAt(l)}
+	return res, nil
+}
+
+// sweep evicts buckets, which are full at the given point of time.
+func (s *MemoryStore) sweep(now time.Time) {
+	for k, e := range s.buckets {
+		if !e.fullAt.After(now) {
+			delete(s.buckets, k)
+		}
+	}
+	s.lastSweep = now
+}
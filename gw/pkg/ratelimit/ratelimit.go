@@ -0,0 +1,133 @@
+// Package ratelimit implements token bucket rate limiting
+// on top of the pluggable bucket store.
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Limit describes the token bucket. Bucket holds at most Burst
+// tokens and is refilled with Burst tokens every Period, so Burst
+// requests could be made at once and Burst per Period on average.
+type Limit struct {
+	Burst  int
+	Period time.Duration
+}
+
+// Per returns limit of n requests per period.
+func Per(n int, period time.Duration) Limit {
+	return Limit{Burst: n, Period: period}
+}
+
+// ParseLimit parses limit in the "N/period" format, i.e. "20/1h".
+// Both N and period must be positive.
+func ParseLimit(s string) (Limit, error) {
+	n, period, ok := strings.Cut(s, "/")
+	if !ok {
+		return Limit{}, errors.New("limit must be in the N/period format")
+	}
+
+	burst, err := strconv.Atoi(strings.TrimSpace(n))
+	if err != nil || burst <= 0 {
+		return Limit{}, errors.New("number of requests must be positive integer")
+	}
+
+	d, err := time.ParseDuration(strings.TrimSpace(period))
+	if err != nil || d <= 0 {
+		return Limit{}, errors.New("period must be positive duration")
+	}
+
+	return Per(burst, d), nil
+}
+
+// rate returns number of tokens added every second.
+func (l Limit) rate() float64 {
+	return float64(l.Burst) / l.Period.Seconds()
+}
+
+// Result is an outcome of the single request.
+// RetryAfter is set only when request isn't allowed.
+type Result struct {
+	Allowed    bool
+	Remaining  int
+	RetryAfter time.Duration
+}
+
+// Bucket is a state of the token bucket, which is kept by the Store.
+type Bucket struct {
+	Tokens    float64
+	UpdatedAt time.Time
+}
+
+// Take refills the bucket with tokens accrued since it was updated and
+// takes a single token from it, if there's one. Zero bucket is full.
+// Returns updated bucket, which should be saved by the Store.
+func (b Bucket) Take(l Limit, now time.Time) (Bucket, Result) {
+	tokens := float64(l.Burst)
+	if !b.UpdatedAt.IsZero() {
+		elapsed := max(now.Sub(b.UpdatedAt).Seconds(), 0)
+		tokens = min(tokens, b.Tokens+elapsed*l.rate())
+	}
+
+	if tokens < 1 {
+		wait := time.Duration((1 - tokens) / l.rate() * float64(time.Second))
+		return Bucket{Tokens: tokens, UpdatedAt: now}, Result{RetryAfter: wait}
+	}
+
+	tokens--
+	return Bucket{Tokens: tokens, UpdatedAt: now}, Result{
+		Allowed:   true,
+		Remaining: int(math.Floor(tokens)),
+	}
+}
+
+// FullAt returns point of time, when bucket is refilled completely.
+// Full bucket doesn't differ from the missing one, so it could be evicted.
+func (b Bucket) FullAt(l Limit) time.Time {
+	missing := max(float64(l.Burst)-b.Tokens, 0)
+	return b.UpdatedAt.Add(time.Duration(missing / l.rate() * float64(time.Second)))
+}
+
+// Store keeps buckets, so they could be shared between replicas,
+// i.e. in Redis. Take should apply Bucket.Take to the bucket stored
+// under the key and save result atomically.
+type Store interface {
+	Take(ctx context.Context, key string, l Limit, now time.Time) (Result, error)
+}
+
+// NewLimiter constructs limiter, which limits requests with the
+// same key to the given limit. Name is prepended to the keys, so
+// limiters could share the store.
+// NOTE: neither of arguments can't be nil, or limiter will panic.
+func NewLimiter(name string, s Store, l Limit) *Limiter {
+	return &Limiter{
+		name:  name,
+		store: s,
+		limit: l,
+	}
+}
+
+// Limiter is a main structure, responsible for
+// limiting rate of the requests by the key.
+type Limiter struct {
+	name  string
+	store Store
+	limit Limit
+}
+
+// Allow method takes a token from the bucket of the key.
+// Could return an error if store has failed.
+func (l *Limiter) Allow(ctx context.Context, key string) (Result, error) {
+	res, err := l.store.Take(ctx, l.name+":"+key, l.limit, time.Now())
+	if err != nil {
+		return Result{}, fmt.Errorf("failed to take token: %w", err)
+	}
+
+	return res, nil
+}
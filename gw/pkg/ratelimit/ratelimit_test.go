@@ -0,0 +1,182 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestBucketTake(t *testing.T) {
+	t.Parallel()
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	l := Per(2, time.Minute)
+	tests := []struct {
+		name       string
+		b          Bucket
+		now        time.Time
+		want       Result
+		wantTokens float64
+	}{
+		{
+			name:       "Should treat zero bucket as full one",
+			b:          Bucket{},
+			now:        now,
+			want:       Result{Allowed: true, Remaining: 1},
+			wantTokens: 1,
+		},
+		{
+			name:       "Should take the last token",
+			b:          Bucket{Tokens: 1, UpdatedAt: now},
+			now:        now,
+			want:       Result{Allowed: true, Remaining: 0},
+			wantTokens: 0,
+		},
+		{
+			name:       "Should not allow request when bucket is empty",
+			b:          Bucket{Tokens: 0, UpdatedAt: now},
+			now:        now,
+			want:       Result{RetryAfter: time.Second * 30},
+			wantTokens: 0,
+		},
+		{
+			name:       "Should refill bucket with accrued tokens",
+			b:          Bucket{Tokens: 0, UpdatedAt: now},
+			now:        now.Add(time.Second * 45),
+			want:       Result{Allowed: true, Remaining: 0},
+			wantTokens: 0.5,
+		},
+		{
+			name:       "Should not refill bucket above the burst",
+			b:          Bucket{Tokens: 0, UpdatedAt: now},
+			now:        now.Add(time.Hour),
+			want:       Result{Allowed: true, Remaining: 1},
+			wantTokens: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			b, got := tt.b.Take(l, tt.now)
+			if got != tt.want {
+				t.Errorf("Bucket.Take() = %+v, want %+v", got, tt.want)
+			}
+			if b.Tokens != tt.wantTokens || !b.UpdatedAt.Equal(tt.now) {
+				t.Errorf("Bucket.Take() bucket = %+v, want %v tokens at %v", b, tt.wantTokens, tt.now)
+			}
+		})
+	}
+}
+
+func TestBucketFullAt(t *testing.T) {
+	t.Parallel()
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	l := Per(2, time.Minute)
+	if got := (Bucket{Tokens: 0.5, UpdatedAt: now}).FullAt(l); !got.Equal(now.Add(time.Second * 45)) {
+		t.Errorf("Bucket.FullAt() = %v, want %v", got, now.Add(time.Second*45))
+	}
+	if got := (Bucket{Tokens: 2, UpdatedAt: now}).FullAt(l); !got.Equal(now) {
+		t.Errorf("Bucket.FullAt() of full bucket = %v, want %v", got, now)
+	}
+}
+
+func TestMemoryStoreTake(t *testing.T) {
+	t.Parallel()
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	l := Per(1, time.Minute)
+	s := NewMemoryStore()
+
+	if res, _ := s.Take(context.Background(), "a", l, now); !res.Allowed {
+		t.Fatalf("Take() = %+v, want first request to be allowed", res)
+	}
+	if res, _ := s.Take(context.Background(), "a", l, now); res.Allowed || res.RetryAfter != time.Minute {
+		t.Errorf("Take() = %+v, want second request to wait a minute", res)
+	}
+	if res, _ := s.Take(context.Background(), "b", l, now); !res.Allowed {
+		t.Errorf("Take() = %+v, want request with another key to be allowed", res)
+	}
+
+	if _, err := s.Take(context.Background(), "c", l, now.Add(sweepInterval*2)); err != nil {
+		t.Fatalf("Take() error = %v", err)
+	}
+	if len(s.buckets) != 1 {
+		t.Errorf("MemoryStore has %d buckets after sweep, want only the latest one", len(s.buckets))
+	}
+}
+
+func TestLimiterAllow(t *testing.T) {
+	t.Parallel()
+	l := NewLimiter("ip", NewMemoryStore(), Per(1, time.Hour))
+	if res, err := l.Allow(context.Background(), "127.0.0.1"); err != nil || !res.Allowed {
+		t.Fatalf("Allow() = %+v, %v, want first request to be allowed", res, err)
+	}
+	if res, err := l.Allow(context.Background(), "127.0.0.1"); err != nil || res.Allowed {
+		t.Errorf("Allow() = %+v, %v, want second request to be limited", res, err)
+	}
+
+	failing := NewLimiter("ip", failingStore{}, Per(1, time.Hour))
+	if _, err := failing.Allow(context.Background(), "127.0.0.1"); err == nil {
+		t.Error("Allow() error = nil, want store error")
+	}
+}
+
+type failingStore struct{}
+
+func (failingStore) Take(context.Context, string, Limit, time.Time) (Result, error) {
+	return Result{}, errors.New("store is down")
+}
+
+func TestParseLimit(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		s       string
+		want    Limit
+		wantErr bool
+	}{
+		{
+			name: "Should parse limit correctly",
+			s:    "20/1h",
+			want: Per(20, time.Hour),
+		},
+		{
+			name: "Should tolerate spaces",
+			s:    " 5 / 30s ",
+			want: Per(5, time.Second*30),
+		},
+		{
+			name:    "Should return error when separator is missing",
+			s:       "20",
+			wantErr: true,
+		},
+		{
+			name:    "Should return error when number isn't positive",
+			s:       "0/1h",
+			wantErr: true,
+		},
+		{
+			name:    "Should return error when period is invalid",
+			s:       "20/hour",
+			wantErr: true,
+		},
+		{
+			name:    "Should return error when period isn't positive",
+			s:       "20/0s",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := ParseLimit(tt.s)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseLimit() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseLimit() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
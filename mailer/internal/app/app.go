@@ -1,12 +1,15 @@
 package app
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 	"net"
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
 	"google.golang.org/grpc"
 
@@ -18,12 +21,20 @@ import (
 
 const operation = "app init"
 
+// shutdownTimeout is a period, during which in-flight calls
+// are waited for on stop. Those, which aren't finished by then,
+// are cancelled.
+const shutdownTimeout = time.Second * 5
+
 // New constructs new App with provided arguments.
 // NOTE: than neither cfg or log can't be nil or App will panic.
 func New(cfg cfg.Config, log *slog.Logger) *App {
 	return &App{
 		cfg: cfg,
 		log: log,
+		srv: grpc.NewServer(grpc.ChainUnaryInterceptor(
+			logger.NewServerGRPCMiddleware(log),
+		)),
 	}
 }
 
@@ -45,32 +56,49 @@ func (a *App) MustRun() {
 	}
 }
 
-// Run method creates new GRPC server then initializes MySQL DB connection,
-// after that initializes all neccessary domain related services and finally
-// starts listening on the provided ports. Could return an error if any of
-// described above steps failed
+// Run method initializes all neccessary domain related services, registers
+// them to the GRPC server created in New and finally starts listening on
+// the provided ports. Could return an error if any of described above
+// steps failed
 func (a *App) Run() error {
-	a.srv = grpc.NewServer(grpc.ChainUnaryInterceptor(
-		logger.NewServerGRPCMiddleware(a.log),
-	))
-
 	mailer.Register(a.srv, resend.NewClient(a.cfg.MailerToken), a.log.With("source", "mailerSrv"))
 	listener, err := net.Listen("tcp", net.JoinHostPort("", a.cfg.Port))
 	if err != nil {
 		return fmt.Errorf("%s: failed to listen on port %s: %w", operation, a.cfg.Port, err)
 	}
 
-	return a.srv.Serve(listener)
+	if err := a.srv.Serve(listener); err != nil && !errors.Is(err, grpc.ErrServerStopped) {
+		return fmt.Errorf("%s: failed to serve: %w", operation, err)
+	}
+
+	return nil
 }
 
 // GracefulStop method gracefully stop the server. It listens to the OS sigals.
-// After it recieves signal it terminates all currently active servers,
-// client, connections (if any) and gracefully exits.
+// After it recieves signal it stops accepting new calls and waits up to
+// shutdownTimeout for in-flight ones to finish. Those, which aren't finished
+// by then, are cancelled. Then it gracefully exits.
 func (a *App) GracefulStop() {
 	ch := make(chan os.Signal, 1)
 	signal.Notify(ch, syscall.SIGTERM, syscall.SIGINT)
 	signal := <-ch
 	a.log.Info("Recieved stop signal. Terminating...", "signal", signal)
-	a.srv.Stop()
+
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+
+	done := make(chan struct{})
+	go func() {
+		a.srv.GracefulStop()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-ctx.Done():
+		a.log.Warn("In-flight calls weren't finished on time, cancelled them")
+		a.srv.Stop()
+		<-done
+	}
 	a.log.Info("Successfully terminated server. Bye!")
 }
@@ -2,6 +2,7 @@ package resend
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	pb "github.com/hrvadl/converter/protos/gen/go/v1/mailer"
@@ -10,6 +11,10 @@ import (
 
 const operation = "resend mail client"
 
+// maxRecipients is a maximum number of recipients
+// Resend accepts in the single email.
+const maxRecipients = 50
+
 // NewClient constructs new Resend client
 // with provided token.
 func NewClient(token string) *Client {
@@ -28,21 +33,58 @@ type Client struct {
 // Send method initiates a call to the resend API using
 // bult-in resend's SDK. Blocks untill call is finished, or
 // error is raised, or context is done.
-func (c *Client) Send(ctx context.Context, m *pb.Mail) error {
+// Mail with a single recipient is sent as is. Mail with multiple
+// recipients is split into chunks of maxRecipients, and each chunk
+// is sent in BCC, so recipients never see each other's addresses.
+// Returns IDs of the sent mails assigned by Resend.
+func (c *Client) Send(ctx context.Context, m *pb.Mail) ([]string, error) {
 	if len(m.To) == 0 {
-		return fmt.Errorf("%s: recipients cannot be empty", operation)
+		return nil, fmt.Errorf("%s: recipients cannot be empty", operation)
 	}
 
-	resCh := make(chan *rs.SendEmailResponse)
-	errCh := make(chan error)
-
-	go func() {
-		res, err := c.c.Emails.Send(&rs.SendEmailRequest{
+	if len(m.To) == 1 {
+		id, err := c.send(ctx, &rs.SendEmailRequest{
 			From:    m.From,
 			To:      m.To,
 			Subject: m.Subject,
 			Html:    m.Html,
+			Text:    m.Text,
+		})
+		if err != nil {
+			return nil, err
+		}
+		return []string{id}, nil
+	}
+
+	var (
+		ids  []string
+		errs []error
+	)
+	for _, bcc := range chunk(m.To, maxRecipients) {
+		id, err := c.send(ctx, &rs.SendEmailRequest{
+			From:    m.From,
+			To:      []string{m.From},
+			Bcc:     bcc,
+			Subject: m.Subject,
+			Html:    m.Html,
+			Text:    m.Text,
 		})
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		ids = append(ids, id)
+	}
+
+	return ids, errors.Join(errs...)
+}
+
+func (c *Client) send(ctx context.Context, req *rs.SendEmailRequest) (string, error) {
+	resCh := make(chan *rs.SendEmailResponse, 1)
+	errCh := make(chan error, 1)
+
+	go func() {
+		res, err := c.c.Emails.Send(req)
 		if err != nil {
 			errCh <- err
 			return
@@ -52,10 +94,19 @@ func (c *Client) Send(ctx context.Context, m *pb.Mail) error {
 
 	select {
 	case err := <-errCh:
-		return fmt.Errorf("%s: failed to send message: %w", operation, err)
-	case <-resCh:
-		return nil
+		return "", fmt.Errorf("%s: failed to send message: %w", operation, err)
+	case res := <-resCh:
+		return res.Id, nil
 	case <-ctx.Done():
-		return ctx.Err()
+		return "", ctx.Err()
+	}
+}
+
+// chunk splits recipients into the slices of at most size elements.
+func chunk(recipients []string, size int) [][]string {
+	chunks := make([][]string, 0, (len(recipients)+size-1)/size)
+	for size < len(recipients) {
+		recipients, chunks = recipients[size:], append(chunks, recipients[:size:size])
 	}
+	return append(chunks, recipients)
 }
@@ -0,0 +1,61 @@
+package resend
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestChunk(t *testing.T) {
+	t.Parallel()
+	type args struct {
+		recipients []string
+		size       int
+	}
+	tests := []struct {
+		name string
+		args args
+		want [][]string
+	}{
+		{
+			name: "Should split recipients into equal chunks",
+			args: args{
+				recipients: []string{"a@test.com", "b@test.com", "c@test.com", "d@test.com"},
+				size:       2,
+			},
+			want: [][]string{
+				{"a@test.com", "b@test.com"},
+				{"c@test.com", "d@test.com"},
+			},
+		},
+		{
+			name: "Should put the remainder to the last chunk",
+			args: args{
+				recipients: []string{"a@test.com", "b@test.com", "c@test.com"},
+				size:       2,
+			},
+			want: [][]string{
+				{"a@test.com", "b@test.com"},
+				{"c@test.com"},
+			},
+		},
+		{
+			name: "Should return single chunk when recipients fit",
+			args: args{
+				recipients: []string{"a@test.com"},
+				size:       maxRecipients,
+			},
+			want: [][]string{
+				{"a@test.com"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := chunk(tt.args.recipients, tt.args.size); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("chunk() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
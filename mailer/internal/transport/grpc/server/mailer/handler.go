@@ -7,7 +7,6 @@ import (
 
 	pb "github.com/hrvadl/converter/protos/gen/go/v1/mailer"
 	"google.golang.org/grpc"
-	"google.golang.org/protobuf/types/known/emptypb"
 )
 
 const operation = "mailer server"
@@ -24,7 +23,7 @@ func Register(srv *grpc.Server, client Client, log *slog.Logger) {
 
 //go:generate mockgen -destination=./mocks/mock_client.go -package=mocks . Client
 type Client interface {
-	Send(ctx context.Context, m *pb.Mail) error
+	Send(ctx context.Context, m *pb.Mail) ([]string, error)
 }
 
 // Server represents mailer GRPC server
@@ -36,11 +35,12 @@ type Server struct {
 	client Client
 }
 
-// Send method calls underlying client method and returns an error, in case there was a
-// failure.
-func (s *Server) Send(ctx context.Context, m *pb.Mail) (*emptypb.Empty, error) {
-	if err := s.client.Send(ctx, m); err != nil {
+// Send method calls underlying client method and returns IDs of the sent
+// mails or an error, in case there was a failure.
+func (s *Server) Send(ctx context.Context, m *pb.Mail) (*pb.SendResponse, error) {
+	ids, err := s.client.Send(ctx, m)
+	if err != nil {
 		return nil, fmt.Errorf("%s: failed to send mail: %w", operation, err)
 	}
-	return nil, nil
+	return &pb.SendResponse{MessageIds: ids}, nil
 }
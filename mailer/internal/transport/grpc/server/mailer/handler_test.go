@@ -4,12 +4,11 @@ import (
 	"context"
 	"errors"
 	"log/slog"
-	"reflect"
 	"testing"
 
 	pb "github.com/hrvadl/converter/protos/gen/go/v1/mailer"
 	"go.uber.org/mock/gomock"
-	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/proto"
 
 	"github.com/hrvadl/converter/mailer/internal/transport/grpc/server/mailer/mocks"
 )
@@ -29,7 +28,7 @@ func TestServerSend(t *testing.T) {
 		fields  fields
 		args    args
 		setup   func(t *testing.T, client Client)
-		want    *emptypb.Empty
+		want    *pb.SendResponse
 		wantErr bool
 	}{
 		{
@@ -58,13 +57,13 @@ func TestServerSend(t *testing.T) {
 					To:      []string{"to@to.com", "to1@to.com"},
 					Html:    "test html",
 					Subject: "test subject",
-				}).Times(1).Return(nil)
+				}).Times(1).Return([]string{"id"}, nil)
 			},
-			want:    nil,
+			want:    &pb.SendResponse{MessageIds: []string{"id"}},
 			wantErr: false,
 		},
 		{
-			name: "Should not return error when mail sender succeeded",
+			name: "Should return error when mail sender failed",
 			fields: fields{
 				log:    slog.Default(),
 				client: mocks.NewMockClient(gomock.NewController(t)),
@@ -89,7 +88,7 @@ func TestServerSend(t *testing.T) {
 					To:      []string{"to@to.com", "to1@to.com"},
 					Html:    "test html",
 					Subject: "test subject",
-				}).Times(1).Return(errors.New("failed to send mail"))
+				}).Times(1).Return(nil, errors.New("failed to send mail"))
 			},
 			want:    nil,
 			wantErr: true,
@@ -111,7 +110,7 @@ func TestServerSend(t *testing.T) {
 				return
 			}
 
-			if !reflect.DeepEqual(got, tt.want) {
+			if !proto.Equal(got, tt.want) {
 				t.Errorf("Server.Send() = %v, want %v", got, tt.want)
 			}
 		})
@@ -41,11 +41,12 @@ func (m *MockClient) EXPECT() *MockClientMockRecorder {
 }
 
 // Send mocks base method.
-func (m *MockClient) Send(arg0 context.Context, arg1 *mailer.Mail) error {
+func (m *MockClient) Send(arg0 context.Context, arg1 *mailer.Mail) ([]string, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "Send", arg0, arg1)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // Send indicates an expected call of Send.
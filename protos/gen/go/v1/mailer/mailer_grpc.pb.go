@@ -11,7 +11,6 @@ import (
 	grpc "google.golang.org/grpc"
 	codes "google.golang.org/grpc/codes"
 	status "google.golang.org/grpc/status"
-	emptypb "google.golang.org/protobuf/types/known/emptypb"
 )
 
 // This is a compile-time assertion to ensure that this generated file
@@ -23,7 +22,7 @@ const _ = grpc.SupportPackageIsVersion7
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type MailerServiceClient interface {
-	Send(ctx context.Context, in *Mail, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	Send(ctx context.Context, in *Mail, opts ...grpc.CallOption) (*SendResponse, error)
 }
 
 type mailerServiceClient struct {
@@ -34,8 +33,8 @@ func NewMailerServiceClient(cc grpc.ClientConnInterface) MailerServiceClient {
 	return &mailerServiceClient{cc}
 }
 
-func (c *mailerServiceClient) Send(ctx context.Context, in *Mail, opts ...grpc.CallOption) (*emptypb.Empty, error) {
-	out := new(emptypb.Empty)
+func (c *mailerServiceClient) Send(ctx context.Context, in *Mail, opts ...grpc.CallOption) (*SendResponse, error) {
+	out := new(SendResponse)
 	err := c.cc.Invoke(ctx, "/mailer.v1.MailerService/Send", in, out, opts...)
 	if err != nil {
 		return nil, err
@@ -47,7 +46,7 @@ func (c *mailerServiceClient) Send(ctx context.Context, in *Mail, opts ...grpc.C
 // All implementations must embed UnimplementedMailerServiceServer
 // for forward compatibility
 type MailerServiceServer interface {
-	Send(context.Context, *Mail) (*emptypb.Empty, error)
+	Send(context.Context, *Mail) (*SendResponse, error)
 	mustEmbedUnimplementedMailerServiceServer()
 }
 
@@ -55,7 +54,7 @@ type MailerServiceServer interface {
 type UnimplementedMailerServiceServer struct {
 }
 
-func (UnimplementedMailerServiceServer) Send(context.Context, *Mail) (*emptypb.Empty, error) {
+func (UnimplementedMailerServiceServer) Send(context.Context, *Mail) (*SendResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Send not implemented")
 }
 func (UnimplementedMailerServiceServer) mustEmbedUnimplementedMailerServiceServer() {}
@@ -104,3 +104,989 @@ var SubService_ServiceDesc = grpc.ServiceDesc{
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "v1/sub/sub.proto",
 }
+
+// OutboxServiceClient is the client API for OutboxService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type OutboxServiceClient interface {
+	ListDeadLetters(ctx context.Context, in *ListDeadLettersRequest, opts ...grpc.CallOption) (*ListDeadLettersResponse, error)
+	RequeueDeadLetters(ctx context.Context, in *RequeueDeadLettersRequest, opts ...grpc.CallOption) (*RequeueDeadLettersResponse, error)
+}
+
+type outboxServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewOutboxServiceClient(cc grpc.ClientConnInterface) OutboxServiceClient {
+	return &outboxServiceClient{cc}
+}
+
+func (c *outboxServiceClient) ListDeadLetters(ctx context.Context, in *ListDeadLettersRequest, opts ...grpc.CallOption) (*ListDeadLettersResponse, error) {
+	out := new(ListDeadLettersResponse)
+	err := c.cc.Invoke(ctx, "/sub.v1.OutboxService/ListDeadLetters", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *outboxServiceClient) RequeueDeadLetters(ctx context.Context, in *RequeueDeadLettersRequest, opts ...grpc.CallOption) (*RequeueDeadLettersResponse, error) {
+	out := new(RequeueDeadLettersResponse)
+	err := c.cc.Invoke(ctx, "/sub.v1.OutboxService/RequeueDeadLetters", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// OutboxServiceServer is the server API for OutboxService service.
+// All implementations must embed UnimplementedOutboxServiceServer
+// for forward compatibility
+type OutboxServiceServer interface {
+	ListDeadLetters(context.Context, *ListDeadLettersRequest) (*ListDeadLettersResponse, error)
+	RequeueDeadLetters(context.Context, *RequeueDeadLettersRequest) (*RequeueDeadLettersResponse, error)
+	mustEmbedUnimplementedOutboxServiceServer()
+}
+
+// UnimplementedOutboxServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedOutboxServiceServer struct {
+}
+
+func (UnimplementedOutboxServiceServer) ListDeadLetters(context.Context, *ListDeadLettersRequest) (*ListDeadLettersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListDeadLetters not implemented")
+}
+func (UnimplementedOutboxServiceServer) RequeueDeadLetters(context.Context, *RequeueDeadLettersRequest) (*RequeueDeadLettersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RequeueDeadLetters not implemented")
+}
+func (UnimplementedOutboxServiceServer) mustEmbedUnimplementedOutboxServiceServer() {}
+
+// UnsafeOutboxServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to OutboxServiceServer will
+// result in compilation errors.
+type UnsafeOutboxServiceServer interface {
+	mustEmbedUnimplementedOutboxServiceServer()
+}
+
+func RegisterOutboxServiceServer(s grpc.ServiceRegistrar, srv OutboxServiceServer) {
+	s.RegisterService(&OutboxService_ServiceDesc, srv)
+}
+
+func _OutboxService_ListDeadLetters_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDeadLettersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OutboxServiceServer).ListDeadLetters(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/sub.v1.OutboxService/ListDeadLetters",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OutboxServiceServer).ListDeadLetters(ctx, req.(*ListDeadLettersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OutboxService_RequeueDeadLetters_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequeueDeadLettersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OutboxServiceServer).RequeueDeadLetters(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/sub.v1.OutboxService/RequeueDeadLetters",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OutboxServiceServer).RequeueDeadLetters(ctx, req.(*RequeueDeadLettersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// OutboxService_ServiceDesc is the grpc.ServiceDesc for OutboxService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var OutboxService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "sub.v1.OutboxService",
+	HandlerType: (*OutboxServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListDeadLetters",
+			Handler:    _OutboxService_ListDeadLetters_Handler,
+		},
+		{
+			MethodName: "RequeueDeadLetters",
+			Handler:    _OutboxService_RequeueDeadLetters_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "v1/sub/sub.proto",
+}
+
+// DeliveryServiceClient is the client API for DeliveryService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type DeliveryServiceClient interface {
+	GetDeliveryHistory(ctx context.Context, in *GetDeliveryHistoryRequest, opts ...grpc.CallOption) (*GetDeliveryHistoryResponse, error)
+}
+
+type deliveryServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewDeliveryServiceClient(cc grpc.ClientConnInterface) DeliveryServiceClient {
+	return &deliveryServiceClient{cc}
+}
+
+func (c *deliveryServiceClient) GetDeliveryHistory(ctx context.Context, in *GetDeliveryHistoryRequest, opts ...grpc.CallOption) (*GetDeliveryHistoryResponse, error) {
+	out := new(GetDeliveryHistoryResponse)
+	err := c.cc.Invoke(ctx, "/sub.v1.DeliveryService/GetDeliveryHistory", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// DeliveryServiceServer is the server API for DeliveryService service.
+// All implementations must embed UnimplementedDeliveryServiceServer
+// for forward compatibility
+type DeliveryServiceServer interface {
+	GetDeliveryHistory(context.Context, *GetDeliveryHistoryRequest) (*GetDeliveryHistoryResponse, error)
+	mustEmbedUnimplementedDeliveryServiceServer()
+}
+
+// UnimplementedDeliveryServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedDeliveryServiceServer struct {
+}
+
+func (UnimplementedDeliveryServiceServer) GetDeliveryHistory(context.Context, *GetDeliveryHistoryRequest) (*GetDeliveryHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDeliveryHistory not implemented")
+}
+func (UnimplementedDeliveryServiceServer) mustEmbedUnimplementedDeliveryServiceServer() {}
+
+// UnsafeDeliveryServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to DeliveryServiceServer will
+// result in compilation errors.
+type UnsafeDeliveryServiceServer interface {
+	mustEmbedUnimplementedDeliveryServiceServer()
+}
+
+func RegisterDeliveryServiceServer(s grpc.ServiceRegistrar, srv DeliveryServiceServer) {
+	s.RegisterService(&DeliveryService_ServiceDesc, srv)
+}
+
+func _DeliveryService_GetDeliveryHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDeliveryHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeliveryServiceServer).GetDeliveryHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/sub.v1.DeliveryService/GetDeliveryHistory",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeliveryServiceServer).GetDeliveryHistory(ctx, req.(*GetDeliveryHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// DeliveryService_ServiceDesc is the grpc.ServiceDesc for DeliveryService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var DeliveryService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "sub.v1.DeliveryService",
+	HandlerType: (*DeliveryServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetDeliveryHistory",
+			Handler:    _DeliveryService_GetDeliveryHistory_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "v1/sub/sub.proto",
+}
+
+// AdminServiceClient is the client API for AdminService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type AdminServiceClient interface {
+	ListSubscribers(ctx context.Context, in *ListSubscribersRequest, opts ...grpc.CallOption) (*ListSubscribersResponse, error)
+	GetSubscriber(ctx context.Context, in *GetSubscriberRequest, opts ...grpc.CallOption) (*Subscriber, error)
+	DeleteSubscriber(ctx context.Context, in *DeleteSubscriberRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	CountSubscribers(ctx context.Context, in *CountSubscribersRequest, opts ...grpc.CallOption) (*CountSubscribersResponse, error)
+	// ImportSubscribers accepts CSV file in chunks and subscribes
+	// every row, reporting rows, which weren't imported.
+	ImportSubscribers(ctx context.Context, opts ...grpc.CallOption) (AdminService_ImportSubscribersClient, error)
+	// ExportSubscribers streams subscribers matching the filter as CSV file.
+	ExportSubscribers(ctx context.Context, in *ExportSubscribersRequest, opts ...grpc.CallOption) (AdminService_ExportSubscribersClient, error)
+	// LiftSuppression removes email from the suppression list,
+	// so it's mailed again.
+	LiftSuppression(ctx context.Context, in *LiftSuppressionRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// RegisterWebhook subscribes URL to the daily rate. Rate is posted
+	// as JSON payload signed with HMAC-SHA256 using the secret, or as
+	// the message of the Slack or Discord incoming webhook.
+	RegisterWebhook(ctx context.Context, in *RegisterWebhookRequest, opts ...grpc.CallOption) (*RegisterWebhookResponse, error)
+	// UnregisterWebhook stops posting the rate to the URL.
+	UnregisterWebhook(ctx context.Context, in *UnregisterWebhookRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// AddSubscriberAddress adds channel address to the subscriber, so
+	// the same notifications are sent through that channel as well.
+	AddSubscriberAddress(ctx context.Context, in *AddSubscriberAddressRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// RemoveSubscriberAddress stops sending notifications through
+	// the additional channel of the subscriber.
+	RemoveSubscriberAddress(ctx context.Context, in *RemoveSubscriberAddressRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+}
+
+type adminServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewAdminServiceClient(cc grpc.ClientConnInterface) AdminServiceClient {
+	return &adminServiceClient{cc}
+}
+
+func (c *adminServiceClient) ListSubscribers(ctx context.Context, in *ListSubscribersRequest, opts ...grpc.CallOption) (*ListSubscribersResponse, error) {
+	out := new(ListSubscribersResponse)
+	err := c.cc.Invoke(ctx, "/sub.v1.AdminService/ListSubscribers", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) GetSubscriber(ctx context.Context, in *GetSubscriberRequest, opts ...grpc.CallOption) (*Subscriber, error) {
+	out := new(Subscriber)
+	err := c.cc.Invoke(ctx, "/sub.v1.AdminService/GetSubscriber", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) DeleteSubscriber(ctx context.Context, in *DeleteSubscriberRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/sub.v1.AdminService/DeleteSubscriber", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) CountSubscribers(ctx context.Context, in *CountSubscribersRequest, opts ...grpc.CallOption) (*CountSubscribersResponse, error) {
+	out := new(CountSubscribersResponse)
+	err := c.cc.Invoke(ctx, "/sub.v1.AdminService/CountSubscribers", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) ImportSubscribers(ctx context.Context, opts ...grpc.CallOption) (AdminService_ImportSubscribersClient, error) {
+	stream, err := c.cc.NewStream(ctx, &AdminService_ServiceDesc.Streams[0], "/sub.v1.AdminService/ImportSubscribers", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &adminServiceImportSubscribersClient{stream}
+	return x, nil
+}
+
+type AdminService_ImportSubscribersClient interface {
+	Send(*ImportSubscribersRequest) error
+	CloseAndRecv() (*ImportSubscribersResponse, error)
+	grpc.ClientStream
+}
+
+type adminServiceImportSubscribersClient struct {
+	grpc.ClientStream
+}
+
+func (x *adminServiceImportSubscribersClient) Send(m *ImportSubscribersRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *adminServiceImportSubscribersClient) CloseAndRecv() (*ImportSubscribersResponse, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(ImportSubscribersResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *adminServiceClient) ExportSubscribers(ctx context.Context, in *ExportSubscribersRequest, opts ...grpc.CallOption) (AdminService_ExportSubscribersClient, error) {
+	stream, err := c.cc.NewStream(ctx, &AdminService_ServiceDesc.Streams[1], "/sub.v1.AdminService/ExportSubscribers", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &adminServiceExportSubscribersClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type AdminService_ExportSubscribersClient interface {
+	Recv() (*ExportSubscribersResponse, error)
+	grpc.ClientStream
+}
+
+type adminServiceExportSubscribersClient struct {
+	grpc.ClientStream
+}
+
+func (x *adminServiceExportSubscribersClient) Recv() (*ExportSubscribersResponse, error) {
+	m := new(ExportSubscribersResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *adminServiceClient) LiftSuppression(ctx context.Context, in *LiftSuppressionRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/sub.v1.AdminService/LiftSuppression", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) RegisterWebhook(ctx context.Context, in *RegisterWebhookRequest, opts ...grpc.CallOption) (*RegisterWebhookResponse, error) {
+	out := new(RegisterWebhookResponse)
+	err := c.cc.Invoke(ctx, "/sub.v1.AdminService/RegisterWebhook", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) UnregisterWebhook(ctx context.Context, in *UnregisterWebhookRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/sub.v1.AdminService/UnregisterWebhook", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) AddSubscriberAddress(ctx context.Context, in *AddSubscriberAddressRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/sub.v1.AdminService/AddSubscriberAddress", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) RemoveSubscriberAddress(ctx context.Context, in *RemoveSubscriberAddressRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/sub.v1.AdminService/RemoveSubscriberAddress", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AdminServiceServer is the server API for AdminService service.
+// All implementations must embed UnimplementedAdminServiceServer
+// for forward compatibility
+type AdminServiceServer interface {
+	ListSubscribers(context.Context, *ListSubscribersRequest) (*ListSubscribersResponse, error)
+	GetSubscriber(context.Context, *GetSubscriberRequest) (*Subscriber, error)
+	DeleteSubscriber(context.Context, *DeleteSubscriberRequest) (*emptypb.Empty, error)
+	CountSubscribers(context.Context, *CountSubscribersRequest) (*CountSubscribersResponse, error)
+	// ImportSubscribers accepts CSV file in chunks and subscribes
+	// every row, reporting rows, which weren't imported.
+	ImportSubscribers(AdminService_ImportSubscribersServer) error
+	// ExportSubscribers streams subscribers matching the filter as CSV file.
+	ExportSubscribers(*ExportSubscribersRequest, AdminService_ExportSubscribersServer) error
+	// LiftSuppression removes email from the suppression list,
+	// so it's mailed again.
+	LiftSuppression(context.Context, *LiftSuppressionRequest) (*emptypb.Empty, error)
+	// RegisterWebhook subscribes URL to the daily rate. Rate is posted
+	// as JSON payload signed with HMAC-SHA256 using the secret, or as
+	// the message of the Slack or Discord incoming webhook.
+	RegisterWebhook(context.Context, *RegisterWebhookRequest) (*RegisterWebhookResponse, error)
+	// UnregisterWebhook stops posting the rate to the URL.
+	UnregisterWebhook(context.Context, *UnregisterWebhookRequest) (*emptypb.Empty, error)
+	// AddSubscriberAddress adds channel address to the subscriber, so
+	// the same notifications are sent through that channel as well.
+	AddSubscriberAddress(context.Context, *AddSubscriberAddressRequest) (*emptypb.Empty, error)
+	// RemoveSubscriberAddress stops sending notifications through
+	// the additional channel of the subscriber.
+	RemoveSubscriberAddress(context.Context, *RemoveSubscriberAddressRequest) (*emptypb.Empty, error)
+	mustEmbedUnimplementedAdminServiceServer()
+}
+
+// UnimplementedAdminServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedAdminServiceServer struct {
+}
+
+func (UnimplementedAdminServiceServer) ListSubscribers(context.Context, *ListSubscribersRequest) (*ListSubscribersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSubscribers not implemented")
+}
+func (UnimplementedAdminServiceServer) GetSubscriber(context.Context, *GetSubscriberRequest) (*Subscriber, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSubscriber not implemented")
+}
+func (UnimplementedAdminServiceServer) DeleteSubscriber(context.Context, *DeleteSubscriberRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteSubscriber not implemented")
+}
+func (UnimplementedAdminServiceServer) CountSubscribers(context.Context, *CountSubscribersRequest) (*CountSubscribersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CountSubscribers not implemented")
+}
+func (UnimplementedAdminServiceServer) ImportSubscribers(AdminService_ImportSubscribersServer) error {
+	return status.Errorf(codes.Unimplemented, "method ImportSubscribers not implemented")
+}
+func (UnimplementedAdminServiceServer) ExportSubscribers(*ExportSubscribersRequest, AdminService_ExportSubscribersServer) error {
+	return status.Errorf(codes.Unimplemented, "method ExportSubscribers not implemented")
+}
+func (UnimplementedAdminServiceServer) LiftSuppression(context.Context, *LiftSuppressionRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LiftSuppression not implemented")
+}
+func (UnimplementedAdminServiceServer) RegisterWebhook(context.Context, *RegisterWebhookRequest) (*RegisterWebhookResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RegisterWebhook not implemented")
+}
+func (UnimplementedAdminServiceServer) UnregisterWebhook(context.Context, *UnregisterWebhookRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UnregisterWebhook not implemented")
+}
+func (UnimplementedAdminServiceServer) AddSubscriberAddress(context.Context, *AddSubscriberAddressRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddSubscriberAddress not implemented")
+}
+func (UnimplementedAdminServiceServer) RemoveSubscriberAddress(context.Context, *RemoveSubscriberAddressRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveSubscriberAddress not implemented")
+}
+func (UnimplementedAdminServiceServer) mustEmbedUnimplementedAdminServiceServer() {}
+
+// UnsafeAdminServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to AdminServiceServer will
+// result in compilation errors.
+type UnsafeAdminServiceServer interface {
+	mustEmbedUnimplementedAdminServiceServer()
+}
+
+func RegisterAdminServiceServer(s grpc.ServiceRegistrar, srv AdminServiceServer) {
+	s.RegisterService(&AdminService_ServiceDesc, srv)
+}
+
+func _AdminService_ListSubscribers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSubscribersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).ListSubscribers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/sub.v1.AdminService/ListSubscribers",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).ListSubscribers(ctx, req.(*ListSubscribersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_GetSubscriber_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSubscriberRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).GetSubscriber(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/sub.v1.AdminService/GetSubscriber",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).GetSubscriber(ctx, req.(*GetSubscriberRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_DeleteSubscriber_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteSubscriberRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).DeleteSubscriber(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/sub.v1.AdminService/DeleteSubscriber",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).DeleteSubscriber(ctx, req.(*DeleteSubscriberRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_CountSubscribers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CountSubscribersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).CountSubscribers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/sub.v1.AdminService/CountSubscribers",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).CountSubscribers(ctx, req.(*CountSubscribersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_ImportSubscribers_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(AdminServiceServer).ImportSubscribers(&adminServiceImportSubscribersServer{stream})
+}
+
+type AdminService_ImportSubscribersServer interface {
+	SendAndClose(*ImportSubscribersResponse) error
+	Recv() (*ImportSubscribersRequest, error)
+	grpc.ServerStream
+}
+
+type adminServiceImportSubscribersServer struct {
+	grpc.ServerStream
+}
+
+func (x *adminServiceImportSubscribersServer) SendAndClose(m *ImportSubscribersResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *adminServiceImportSubscribersServer) Recv() (*ImportSubscribersRequest, error) {
+	m := new(ImportSubscribersRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _AdminService_ExportSubscribers_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportSubscribersRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(AdminServiceServer).ExportSubscribers(m, &adminServiceExportSubscribersServer{stream})
+}
+
+type AdminService_ExportSubscribersServer interface {
+	Send(*ExportSubscribersResponse) error
+	grpc.ServerStream
+}
+
+type adminServiceExportSubscribersServer struct {
+	grpc.ServerStream
+}
+
+func (x *adminServiceExportSubscribersServer) Send(m *ExportSubscribersResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _AdminService_LiftSuppression_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LiftSuppressionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).LiftSuppression(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/sub.v1.AdminService/LiftSuppression",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).LiftSuppression(ctx, req.(*LiftSuppressionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_RegisterWebhook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RegisterWebhookRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).RegisterWebhook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/sub.v1.AdminService/RegisterWebhook",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).RegisterWebhook(ctx, req.(*RegisterWebhookRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_UnregisterWebhook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnregisterWebhookRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).UnregisterWebhook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/sub.v1.AdminService/UnregisterWebhook",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).UnregisterWebhook(ctx, req.(*UnregisterWebhookRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_AddSubscriberAddress_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddSubscriberAddressRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).AddSubscriberAddress(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/sub.v1.AdminService/AddSubscriberAddress",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).AddSubscriberAddress(ctx, req.(*AddSubscriberAddressRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_RemoveSubscriberAddress_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveSubscriberAddressRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).RemoveSubscriberAddress(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/sub.v1.AdminService/RemoveSubscriberAddress",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).RemoveSubscriberAddress(ctx, req.(*RemoveSubscriberAddressRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// AdminService_ServiceDesc is the grpc.ServiceDesc for AdminService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var AdminService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "sub.v1.AdminService",
+	HandlerType: (*AdminServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListSubscribers",
+			Handler:    _AdminService_ListSubscribers_Handler,
+		},
+		{
+			MethodName: "GetSubscriber",
+			Handler:    _AdminService_GetSubscriber_Handler,
+		},
+		{
+			MethodName: "DeleteSubscriber",
+			Handler:    _AdminService_DeleteSubscriber_Handler,
+		},
+		{
+			MethodName: "CountSubscribers",
+			Handler:    _AdminService_CountSubscribers_Handler,
+		},
+		{
+			MethodName: "LiftSuppression",
+			Handler:    _AdminService_LiftSuppression_Handler,
+		},
+		{
+			MethodName: "RegisterWebhook",
+			Handler:    _AdminService_RegisterWebhook_Handler,
+		},
+		{
+			MethodName: "UnregisterWebhook",
+			Handler:    _AdminService_UnregisterWebhook_Handler,
+		},
+		{
+			MethodName: "AddSubscriberAddress",
+			Handler:    _AdminService_AddSubscriberAddress_Handler,
+		},
+		{
+			MethodName: "RemoveSubscriberAddress",
+			Handler:    _AdminService_RemoveSubscriberAddress_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ImportSubscribers",
+			Handler:       _AdminService_ImportSubscribers_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "ExportSubscribers",
+			Handler:       _AdminService_ExportSubscribers_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "v1/sub/sub.proto",
+}
+
+// PrivacyServiceClient is the client API for PrivacyService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type PrivacyServiceClient interface {
+	RequestPrivacyToken(ctx context.Context, in *RequestPrivacyTokenRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	ExportPersonalData(ctx context.Context, in *ExportPersonalDataRequest, opts ...grpc.CallOption) (*ExportPersonalDataResponse, error)
+	ErasePersonalData(ctx context.Context, in *ErasePersonalDataRequest, opts ...grpc.CallOption) (*ErasePersonalDataResponse, error)
+}
+
+type privacyServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewPrivacyServiceClient(cc grpc.ClientConnInterface) PrivacyServiceClient {
+	return &privacyServiceClient{cc}
+}
+
+func (c *privacyServiceClient) RequestPrivacyToken(ctx context.Context, in *RequestPrivacyTokenRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/sub.v1.PrivacyService/RequestPrivacyToken", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *privacyServiceClient) ExportPersonalData(ctx context.Context, in *ExportPersonalDataRequest, opts ...grpc.CallOption) (*ExportPersonalDataResponse, error) {
+	out := new(ExportPersonalDataResponse)
+	err := c.cc.Invoke(ctx, "/sub.v1.PrivacyService/ExportPersonalData", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *privacyServiceClient) ErasePersonalData(ctx context.Context, in *ErasePersonalDataRequest, opts ...grpc.CallOption) (*ErasePersonalDataResponse, error) {
+	out := new(ErasePersonalDataResponse)
+	err := c.cc.Invoke(ctx, "/sub.v1.PrivacyService/ErasePersonalData", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// PrivacyServiceServer is the server API for PrivacyService service.
+// All implementations must embed UnimplementedPrivacyServiceServer
+// for forward compatibility
+type PrivacyServiceServer interface {
+	RequestPrivacyToken(context.Context, *RequestPrivacyTokenRequest) (*emptypb.Empty, error)
+	ExportPersonalData(context.Context, *ExportPersonalDataRequest) (*ExportPersonalDataResponse, error)
+	ErasePersonalData(context.Context, *ErasePersonalDataRequest) (*ErasePersonalDataResponse, error)
+	mustEmbedUnimplementedPrivacyServiceServer()
+}
+
+// UnimplementedPrivacyServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedPrivacyServiceServer struct {
+}
+
+func (UnimplementedPrivacyServiceServer) RequestPrivacyToken(context.Context, *RequestPrivacyTokenRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RequestPrivacyToken not implemented")
+}
+func (UnimplementedPrivacyServiceServer) ExportPersonalData(context.Context, *ExportPersonalDataRequest) (*ExportPersonalDataResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExportPersonalData not implemented")
+}
+func (UnimplementedPrivacyServiceServer) ErasePersonalData(context.Context, *ErasePersonalDataRequest) (*ErasePersonalDataResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ErasePersonalData not implemented")
+}
+func (UnimplementedPrivacyServiceServer) mustEmbedUnimplementedPrivacyServiceServer() {}
+
+// UnsafePrivacyServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to PrivacyServiceServer will
+// result in compilation errors.
+type UnsafePrivacyServiceServer interface {
+	mustEmbedUnimplementedPrivacyServiceServer()
+}
+
+func RegisterPrivacyServiceServer(s grpc.ServiceRegistrar, srv PrivacyServiceServer) {
+	s.RegisterService(&PrivacyService_ServiceDesc, srv)
+}
+
+func _PrivacyService_RequestPrivacyToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestPrivacyTokenRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PrivacyServiceServer).RequestPrivacyToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/sub.v1.PrivacyService/RequestPrivacyToken",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PrivacyServiceServer).RequestPrivacyToken(ctx, req.(*RequestPrivacyTokenRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PrivacyService_ExportPersonalData_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExportPersonalDataRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PrivacyServiceServer).ExportPersonalData(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/sub.v1.PrivacyService/ExportPersonalData",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PrivacyServiceServer).ExportPersonalData(ctx, req.(*ExportPersonalDataRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PrivacyService_ErasePersonalData_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ErasePersonalDataRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PrivacyServiceServer).ErasePersonalData(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/sub.v1.PrivacyService/ErasePersonalData",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PrivacyServiceServer).ErasePersonalData(ctx, req.(*ErasePersonalDataRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// PrivacyService_ServiceDesc is the grpc.ServiceDesc for PrivacyService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var PrivacyService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "sub.v1.PrivacyService",
+	HandlerType: (*PrivacyServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "RequestPrivacyToken",
+			Handler:    _PrivacyService_RequestPrivacyToken_Handler,
+		},
+		{
+			MethodName: "ExportPersonalData",
+			Handler:    _PrivacyService_ExportPersonalData_Handler,
+		},
+		{
+			MethodName: "ErasePersonalData",
+			Handler:    _PrivacyService_ErasePersonalData_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "v1/sub/sub.proto",
+}
+
+// SuppressionServiceClient is the client API for SuppressionService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type SuppressionServiceClient interface {
+	RecordMailEvent(ctx context.Context, in *RecordMailEventRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+}
+
+type suppressionServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewSuppressionServiceClient(cc grpc.ClientConnInterface) SuppressionServiceClient {
+	return &suppressionServiceClient{cc}
+}
+
+func (c *suppressionServiceClient) RecordMailEvent(ctx context.Context, in *RecordMailEventRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/sub.v1.SuppressionService/RecordMailEvent", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// SuppressionServiceServer is the server API for SuppressionService service.
+// All implementations must embed UnimplementedSuppressionServiceServer
+// for forward compatibility
+type SuppressionServiceServer interface {
+	RecordMailEvent(context.Context, *RecordMailEventRequest) (*emptypb.Empty, error)
+	mustEmbedUnimplementedSuppressionServiceServer()
+}
+
+// UnimplementedSuppressionServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedSuppressionServiceServer struct {
+}
+
+func (UnimplementedSuppressionServiceServer) RecordMailEvent(context.Context, *RecordMailEventRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RecordMailEvent not implemented")
+}
+func (UnimplementedSuppressionServiceServer) mustEmbedUnimplementedSuppressionServiceServer() {}
+
+// UnsafeSuppressionServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to SuppressionServiceServer will
+// result in compilation errors.
+type UnsafeSuppressionServiceServer interface {
+	mustEmbedUnimplementedSuppressionServiceServer()
+}
+
+func RegisterSuppressionServiceServer(s grpc.ServiceRegistrar, srv SuppressionServiceServer) {
+	s.RegisterService(&SuppressionService_ServiceDesc, srv)
+}
+
+func _SuppressionService_RecordMailEvent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RecordMailEventRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SuppressionServiceServer).RecordMailEvent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/sub.v1.SuppressionService/RecordMailEvent",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SuppressionServiceServer).RecordMailEvent(ctx, req.(*RecordMailEventRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// SuppressionService_ServiceDesc is the grpc.ServiceDesc for SuppressionService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var SuppressionService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "sub.v1.SuppressionService",
+	HandlerType: (*SuppressionServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "RecordMailEvent",
+			Handler:    _SuppressionService_RecordMailEvent_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "v1/sub/sub.proto",
+}
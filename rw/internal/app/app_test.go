@@ -35,7 +35,11 @@ func TestNew(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
-			if got := New(tt.args.cfg, tt.args.log); !reflect.DeepEqual(got, tt.want) {
+			got := New(tt.args.cfg, tt.args.log)
+			if got.srv == nil {
+				t.Fatal("New() server is not initialized")
+			}
+			if !reflect.DeepEqual(got.cfg, tt.want.cfg) || got.log != tt.want.log {
 				t.Errorf("New() = %v, want %v", got, tt.want)
 			}
 		})
@@ -13,6 +13,10 @@ const (
 	operation = "usd to uah rate"
 )
 
+// Provider is a name of the exchange rate API,
+// which is reported to the rate watcher clients.
+const Provider = "exchangerate-api"
+
 const (
 	usd = "USD"
 	uah = "UAH"
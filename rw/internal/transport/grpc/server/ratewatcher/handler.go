@@ -13,12 +13,15 @@ import (
 const operation = "converted server"
 
 // Registers rate watcher handler to the given GRPC server.
+// Provider is a name of the converter's upstream, which is
+// returned alongside the rate.
 // NOTE: all parameters are required, the service will panic if
 // either of them is missing.
-func Register(srv *grpc.Server, cnv Converter, log *slog.Logger) {
+func Register(srv *grpc.Server, cnv Converter, provider string, log *slog.Logger) {
 	pb.RegisterRateWatcherServiceServer(srv, &Server{
 		log:       log,
 		converter: cnv,
+		provider:  provider,
 	})
 }
 
@@ -34,6 +37,7 @@ type Server struct {
 	pb.UnimplementedRateWatcherServiceServer
 	log       *slog.Logger
 	converter Converter
+	provider  string
 }
 
 // GetRate method calls underlying converter method and returns an error, in case there was a
@@ -43,5 +47,5 @@ func (s *Server) GetRate(ctx context.Context, _ *emptypb.Empty) (*pb.RateRespons
 	if err != nil {
 		return nil, fmt.Errorf("%s: failed to convert: %w", operation, err)
 	}
-	return &pb.RateResponse{Rate: rate}, nil
+	return &pb.RateResponse{Rate: rate, Provider: s.provider}, nil
 }
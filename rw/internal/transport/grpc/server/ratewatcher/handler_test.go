@@ -50,7 +50,7 @@ func TestServerGetRate(t *testing.T) {
 
 				c.EXPECT().Convert(gomock.Any()).Times(1).Return(float32(3.3), nil)
 			},
-			want:    &pb.RateResponse{Rate: 3.3},
+			want:    &pb.RateResponse{Rate: 3.3, Provider: "exchangerate-api"},
 			wantErr: false,
 		},
 		{
@@ -87,6 +87,7 @@ func TestServerGetRate(t *testing.T) {
 				UnimplementedRateWatcherServiceServer: pb.UnimplementedRateWatcherServiceServer{},
 				log:                                   tt.fields.log,
 				converter:                             tt.fields.converter,
+				provider:                              "exchangerate-api",
 			}
 			got, err := s.GetRate(tt.args.ctx, tt.args.in1)
 			if (err != nil) != tt.wantErr {
@@ -0,0 +1,93 @@
+// Command preview renders mails with the sample rates, so templates
+// could be checked in the browser without sending them.
+//
+// Usage:
+//
+//	preview [-locale en] [-frequency daily] > preview.html
+//	preview [-locale uk] [-frequency weekly] -text
+package main
+
+import (
+	"flag"
+	"fmt"
+	"math"
+	"os"
+	"time"
+	_ "time/tzdata"
+
+	"github.com/hrvadl/converter/sub/internal/service/sender/formatter"
+	"github.com/hrvadl/converter/sub/internal/storage/rate"
+	"github.com/hrvadl/converter/sub/internal/storage/subscriber"
+)
+
+func main() {
+	if err := run(os.Args[1:]); err != nil {
+		fmt.Fprintln(os.Stderr, "preview:", err)
+		os.Exit(1)
+	}
+}
+
+func run(args []string) error {
+	fs := flag.NewFlagSet("preview", flag.ExitOnError)
+	locale := fs.String("locale", string(subscriber.LocaleEnglish), "locale of the mail: en or uk")
+	frequency := fs.String("frequency", string(subscriber.FrequencyDaily), "mail to render: daily, weekly or monthly")
+	text := fs.Bool("text", false, "print plain-text alternative instead of html")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	f, err := formatter.NewTemplate()
+	if err != nil {
+		return err
+	}
+
+	var (
+		at      = time.Now().UTC()
+		history = sampleHistory(at)
+		latest  = history[len(history)-1].Rate
+		m       formatter.Message
+	)
+	switch freq := subscriber.Frequency(*frequency); freq {
+	case subscriber.FrequencyDaily:
+		m, err = f.Format(subscriber.Locale(*locale), formatter.Trend{Rate: latest, History: history}, at)
+	case subscriber.FrequencyWeekly, subscriber.FrequencyMonthly:
+		m, err = f.FormatDigest(subscriber.Locale(*locale), freq, sampleStats(history), at)
+	default:
+		return fmt.Errorf("unknown frequency %q", *frequency)
+	}
+	if err != nil {
+		return err
+	}
+
+	out := m.HTML
+	if *text {
+		out = "Subject: " + m.Subject + "\n\n" + m.Text
+	}
+	_, err = fmt.Fprint(os.Stdout, out)
+	return err
+}
+
+// sampleHistory returns daily rates of the last formatter.HistoryDays
+// days, which slowly grow with small fluctuations.
+func sampleHistory(at time.Time) []rate.Daily {
+	day := time.Date(at.Year(), at.Month(), at.Day(), 0, 0, 0, 0, time.UTC)
+	h := make([]rate.Daily, formatter.HistoryDays)
+	for i := range h {
+		h[i] = rate.Daily{
+			Day:  day.AddDate(0, 0, i-len(h)+1),
+			Rate: float32(40.5 + 0.03*float64(i) + 0.25*math.Sin(float64(i)/3)),
+		}
+	}
+	return h
+}
+
+func sampleStats(history []rate.Daily) rate.Stats {
+	s := rate.Stats{Min: history[0].Rate, Max: history[0].Rate}
+	var sum float32
+	for _, d := range history {
+		s.Min, s.Max = min(s.Min, d.Rate), max(s.Max, d.Rate)
+		sum += d.Rate
+	}
+	s.Avg, s.Count = sum/float32(len(history)), len(history)
+	return s
+}
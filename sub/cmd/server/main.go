@@ -1,7 +1,18 @@
+// Command server runs the sub service. It also migrates the schema:
+//
+//	server migrate up [N]       apply N or all pending migrations
+//	server migrate down [N]     revert N (1 by default) applied migrations, or all with -all
+//	server migrate status       list migrations and whether they're applied
+//	server migrate version      print current version of the schema
+//	server migrate force V      set version V after dirty schema was fixed manually
 package main
 
 import (
+	"fmt"
 	"os"
+	// Service runs in the scratch image without zoneinfo, so it's
+	// embedded to support timezones in the send schedule.
+	_ "time/tzdata"
 
 	"github.com/hrvadl/converter/sub/internal/app"
 	"github.com/hrvadl/converter/sub/internal/cfg"
@@ -13,6 +24,14 @@ const (
 )
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		if err := runMigrate(os.Args[2:]); err != nil {
+			fmt.Fprintln(os.Stderr, "migrate:", err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	cfg := cfg.Must(cfg.NewFromEnv())
 	l := logger.New(os.Stdout, cfg.LogLevel).With(
 		"source", source,
@@ -0,0 +1,142 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"strconv"
+
+	"github.com/hrvadl/converter/sub/internal/cfg"
+	"github.com/hrvadl/converter/sub/internal/storage/platform/db"
+	"github.com/hrvadl/converter/sub/internal/storage/platform/migrate"
+	"github.com/hrvadl/converter/sub/migrations"
+	"github.com/hrvadl/converter/sub/pkg/logger"
+)
+
+func runMigrate(args []string) error {
+	if len(args) == 0 {
+		return errors.New("command is required: up, down, status, version or force")
+	}
+
+	cfg, err := cfg.NewMigrationFromEnv()
+	if err != nil {
+		return err
+	}
+
+	conn, err := db.NewMigrationConn(cfg.Dsn)
+	if err != nil {
+		return fmt.Errorf("failed to connect to db: %w", err)
+	}
+	defer conn.Close()
+
+	fsys, err := migrations.For(string(db.DialectOf(conn)))
+	if err != nil {
+		return err
+	}
+
+	mm, err := migrate.Load(fsys)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer cancel()
+
+	l := logger.New(os.Stderr, cfg.LogLevel).With("source", "migrate")
+	m := migrate.NewMigrator(conn, mm, l)
+
+	switch cmd, rest := args[0], args[1:]; cmd {
+	case "up":
+		n, err := parseCount(rest, 0)
+		if err != nil {
+			return err
+		}
+		applied, err := m.Up(ctx, n)
+		fmt.Printf("applied %d migration(s)\n", applied)
+		return err
+	case "down":
+		n, err := parseDownCount(rest)
+		if err != nil {
+			return err
+		}
+		reverted, err := m.Down(ctx, n)
+		fmt.Printf("reverted %d migration(s)\n", reverted)
+		return err
+	case "status":
+		statuses, err := m.Status(ctx)
+		if err != nil {
+			return err
+		}
+		for _, s := range statuses {
+			state := "pending"
+			if s.Applied {
+				state = "applied"
+			}
+			fmt.Printf("%06d %-30s %s\n", s.Version, s.Name, state)
+		}
+		return nil
+	case "version":
+		v, err := m.Version(ctx)
+		if err != nil {
+			return err
+		}
+		switch {
+		case v.Dirty:
+			fmt.Printf("%d (dirty)\n", v.Version)
+		case v.Version == migrate.NilVersion:
+			fmt.Println("none")
+		default:
+			fmt.Println(v.Version)
+		}
+		return nil
+	case "force":
+		if len(rest) != 1 {
+			return errors.New("force expects a single version")
+		}
+		v, err := strconv.ParseInt(rest[0], 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid version %q", rest[0])
+		}
+		return m.Force(ctx, v)
+	default:
+		return fmt.Errorf("unknown command %q", cmd)
+	}
+}
+
+// parseDownCount parses number of migrations to revert. Only the last
+// migration is reverted by default, all of them are reverted with -all.
+func parseDownCount(args []string) (int, error) {
+	fs := flag.NewFlagSet("down", flag.ContinueOnError)
+	all := fs.Bool("all", false, "revert all applied migrations")
+	if err := fs.Parse(args); err != nil {
+		return 0, err
+	}
+
+	if *all {
+		if fs.NArg() != 0 {
+			return 0, errors.New("down -all doesn't accept a number")
+		}
+		return 0, nil
+	}
+
+	return parseCount(fs.Args(), 1)
+}
+
+// parseCount parses optional positive number of migrations.
+func parseCount(args []string, def int) (int, error) {
+	switch len(args) {
+	case 0:
+		return def, nil
+	case 1:
+		n, err := strconv.Atoi(args[0])
+		if err != nil || n <= 0 {
+			return 0, fmt.Errorf("number of migrations should be positive: %s", args[0])
+		}
+		return n, nil
+	default:
+		return 0, errors.New("too many arguments")
+	}
+}
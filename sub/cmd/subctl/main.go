@@ -0,0 +1,166 @@
+// Command subctl moves subscribers between environments with CSV files,
+// using the admin service of sub.
+//
+// Usage:
+//
+//	subctl [-addr localhost:8083] [-token $SUB_ADMIN_TOKEN] import subscribers.csv
+//	subctl [-addr localhost:8083] [-token $SUB_ADMIN_TOKEN] export [-status active] [-email gmail] > subscribers.csv
+//
+// Pass "-" instead of the file name to import from the stdin.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+
+	pb "github.com/hrvadl/converter/protos/gen/go/v1/sub"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+)
+
+// chunkSize is a size of the CSV chunk sent in a single message.
+const chunkSize = 32 * 1024
+
+func main() {
+	if err := run(os.Args[1:]); err != nil {
+		fmt.Fprintln(os.Stderr, "subctl:", err)
+		os.Exit(1)
+	}
+}
+
+func run(args []string) error {
+	fs := flag.NewFlagSet("subctl", flag.ExitOnError)
+	addr := fs.String("addr", "localhost:8083", "address of the sub service")
+	token := fs.String("token", os.Getenv("SUB_ADMIN_TOKEN"), "admin token, defaults to $SUB_ADMIN_TOKEN")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if fs.NArg() == 0 {
+		return errors.New("command is required: import or export")
+	}
+
+	cc, err := grpc.NewClient(*addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return fmt.Errorf("failed to connect to sub: %w", err)
+	}
+	defer cc.Close()
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer cancel()
+	ctx = metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+*token)
+	api := pb.NewAdminServiceClient(cc)
+
+	switch cmd, rest := fs.Arg(0), fs.Args()[1:]; cmd {
+	case "import":
+		return runImport(ctx, api, rest)
+	case "export":
+		return runExport(ctx, api, rest)
+	default:
+		return fmt.Errorf("unknown command %q", cmd)
+	}
+}
+
+func runImport(ctx context.Context, api pb.AdminServiceClient, args []string) error {
+	if len(args) != 1 {
+		return errors.New("import expects a single file name")
+	}
+
+	in := io.Reader(os.Stdin)
+	if args[0] != "-" {
+		f, err := os.Open(args[0])
+		if err != nil {
+			return fmt.Errorf("failed to open file: %w", err)
+		}
+		defer f.Close()
+		in = f
+	}
+
+	stream, err := api.ImportSubscribers(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to start import: %w", err)
+	}
+
+	buf := make([]byte, chunkSize)
+	for {
+		n, err := in.Read(buf)
+		if n > 0 {
+			if err := stream.Send(&pb.ImportSubscribersRequest{Data: buf[:n]}); err != nil {
+				return fmt.Errorf("failed to send chunk: %w", err)
+			}
+		}
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read file: %w", err)
+		}
+	}
+
+	res, err := stream.CloseAndRecv()
+	if err != nil {
+		return fmt.Errorf("failed to import: %w", err)
+	}
+
+	for _, r := range res.GetFailed() {
+		fmt.Fprintf(os.Stderr, "line %d: %s: %s\n", r.GetLine(), r.GetEmail(), r.GetReason())
+	}
+	for _, r := range res.GetDuplicates() {
+		fmt.Fprintf(os.Stderr, "line %d: %s: duplicate\n", r.GetLine(), r.GetEmail())
+	}
+
+	fmt.Fprintf(
+		os.Stderr,
+		"imported: %d, skipped: %d, failed: %d, duplicates: %d\n",
+		res.GetImported(),
+		res.GetSkipped(),
+		res.GetFailedCount(),
+		res.GetDuplicateCount(),
+	)
+	return nil
+}
+
+func runExport(ctx context.Context, api pb.AdminServiceClient, args []string) error {
+	fs := flag.NewFlagSet("export", flag.ExitOnError)
+	status := fs.String("status", "", "export only subscribers with status: active or unsubscribed")
+	email := fs.String("email", "", "export only subscribers, whose email contains it")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	filter := &pb.SubscriberFilter{Email: *email}
+	switch *status {
+	case "":
+	case "active":
+		filter.Status = pb.SubscriberStatus_SUBSCRIBER_STATUS_ACTIVE
+	case "unsubscribed":
+		filter.Status = pb.SubscriberStatus_SUBSCRIBER_STATUS_UNSUBSCRIBED
+	default:
+		return fmt.Errorf("unknown status %q", *status)
+	}
+
+	stream, err := api.ExportSubscribers(ctx, &pb.ExportSubscribersRequest{Filter: filter})
+	if err != nil {
+		return fmt.Errorf("failed to start export: %w", err)
+	}
+
+	for {
+		chunk, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to export: %w", err)
+		}
+
+		if _, err := os.Stdout.Write(chunk.GetData()); err != nil {
+			return fmt.Errorf("failed to write file: %w", err)
+		}
+	}
+}
@@ -1,42 +1,104 @@
 package app
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"io"
 	"log/slog"
 	"net"
+	"net/http"
 	"os"
 	"os/signal"
+	"sync"
 	"syscall"
+	"time"
 
+	"github.com/jmoiron/sqlx"
 	"google.golang.org/grpc"
 
 	"github.com/hrvadl/converter/sub/internal/cfg"
+	adminsvc "github.com/hrvadl/converter/sub/internal/service/admin"
+	"github.com/hrvadl/converter/sub/internal/service/canonical"
+	"github.com/hrvadl/converter/sub/internal/service/coordinator"
 	"github.com/hrvadl/converter/sub/internal/service/cron"
+	deliverysvc "github.com/hrvadl/converter/sub/internal/service/delivery"
+	outboxsvc "github.com/hrvadl/converter/sub/internal/service/outbox"
+	"github.com/hrvadl/converter/sub/internal/service/privacy"
 	"github.com/hrvadl/converter/sub/internal/service/sender"
+	"github.com/hrvadl/converter/sub/internal/service/sender/channel"
 	"github.com/hrvadl/converter/sub/internal/service/sender/formatter"
 	subs "github.com/hrvadl/converter/sub/internal/service/sub"
+	suppressionsvc "github.com/hrvadl/converter/sub/internal/service/suppression"
+	telegramsvc "github.com/hrvadl/converter/sub/internal/service/telegram"
+	"github.com/hrvadl/converter/sub/internal/service/transfer"
 	"github.com/hrvadl/converter/sub/internal/service/validator"
+	webhooksvc "github.com/hrvadl/converter/sub/internal/service/webhook"
+	"github.com/hrvadl/converter/sub/internal/storage/dedup"
+	"github.com/hrvadl/converter/sub/internal/storage/delivery"
+	"github.com/hrvadl/converter/sub/internal/storage/erasure"
+	"github.com/hrvadl/converter/sub/internal/storage/jobrun"
+	"github.com/hrvadl/converter/sub/internal/storage/outbox"
 	"github.com/hrvadl/converter/sub/internal/storage/platform/db"
+	"github.com/hrvadl/converter/sub/internal/storage/platform/migrate"
+	"github.com/hrvadl/converter/sub/internal/storage/rate"
 	"github.com/hrvadl/converter/sub/internal/storage/subscriber"
+	"github.com/hrvadl/converter/sub/internal/storage/suppression"
+	"github.com/hrvadl/converter/sub/internal/storage/webhook"
+	"github.com/hrvadl/converter/sub/internal/transport/chat"
 	"github.com/hrvadl/converter/sub/internal/transport/grpc/clients/mailer"
 	"github.com/hrvadl/converter/sub/internal/transport/grpc/clients/ratewatcher"
+	adminsrv "github.com/hrvadl/converter/sub/internal/transport/grpc/server/admin"
+	deliverysrv "github.com/hrvadl/converter/sub/internal/transport/grpc/server/delivery"
+	outboxsrv "github.com/hrvadl/converter/sub/internal/transport/grpc/server/outbox"
+	privacysrv "github.com/hrvadl/converter/sub/internal/transport/grpc/server/privacy"
 	"github.com/hrvadl/converter/sub/internal/transport/grpc/server/sub"
+	suppressionsrv "github.com/hrvadl/converter/sub/internal/transport/grpc/server/suppression"
+	"github.com/hrvadl/converter/sub/internal/transport/telegram"
+	webhookclient "github.com/hrvadl/converter/sub/internal/transport/webhook"
+	"github.com/hrvadl/converter/sub/migrations"
 	"github.com/hrvadl/converter/sub/pkg/logger"
 )
 
 const operation = "app init"
 
-const (
-	cronJobHour   = 12
-	cronJobMinute = 0
-)
+// deliveryInterval is an interval between the delivery
+// attempts of the pending notifications from the outbox.
+const deliveryInterval = time.Minute
+
+// enqueueJobName is a name of the daily run, which
+// enqueues notifications to the outbox.
+const enqueueJobName = "enqueue"
+
+// privacyTokenTTL is a period, during which token
+// mailed to the subscriber could be used.
+const privacyTokenTTL = time.Hour * 24
+
+// shutdownTimeout is a period, during which in-flight calls, jobs
+// and background tasks are waited for on stop. Those, which aren't
+// finished by then, are cancelled. It should be shorter than the
+// stop grace period of the container.
+const shutdownTimeout = time.Second * 20
+
+// cancelTimeout is a period, during which cancelled background tasks
+// are waited for to save their progress, before connections are closed.
+const cancelTimeout = time.Second * 5
 
 // New constructs new App with provided arguments.
 // NOTE: than neither cfg or log can't be nil or App will panic.
 func New(cfg cfg.Config, log *slog.Logger) *App {
+	ctx, cancel := context.WithCancel(context.Background())
 	return &App{
 		cfg: cfg,
 		log: log,
+		srv: grpc.NewServer(grpc.ChainUnaryInterceptor(
+			logger.NewServerGRPCMiddleware(log),
+			adminsrv.NewAuthInterceptor(cfg.AdminToken),
+		), grpc.ChainStreamInterceptor(
+			adminsrv.NewStreamAuthInterceptor(cfg.AdminToken),
+		)),
+		ctx:    ctx,
+		cancel: cancel,
 	}
 }
 
@@ -47,6 +109,22 @@ type App struct {
 	cfg cfg.Config
 	log *slog.Logger
 	srv *grpc.Server
+
+	// ctx is a context of the jobs and background tasks,
+	// which is cancelled, when they aren't finished on time.
+	ctx    context.Context
+	cancel context.CancelFunc
+	tasks  sync.WaitGroup
+
+	// mu guards fields below, which are set in Run and
+	// read in GracefulStop from the other goroutine.
+	mu       sync.Mutex
+	stopping bool
+	jobs     []*cron.Job
+	closers  []io.Closer
+	// stops are called, when stopping begins, so tasks, which are
+	// idle or waiting, return without waiting for cancellation.
+	stops []func()
 }
 
 // MustRun is a wrapper around App.Run() function which could be handly
@@ -58,65 +136,407 @@ func (a *App) MustRun() {
 	}
 }
 
-// Run method creates new GRPC server then initializes MySQL DB connection,
-// after that initializes all neccessary domain related services and finally
-// starts listening on the provided ports. Could return an error if any of
-// described above steps failed
+// Run method initializes DB connection, after that initializes all
+// neccessary domain related services and finally starts listening on
+// the provided ports. Connections and jobs are registered, so they're
+// torn down in GracefulStop. Could return an error if any of described
+// above steps failed
 func (a *App) Run() error {
-	a.srv = grpc.NewServer(grpc.ChainUnaryInterceptor(
-		logger.NewServerGRPCMiddleware(a.log),
-	))
+	if a.cfg.AutoMigrate {
+		if err := a.migrate(); err != nil {
+			return fmt.Errorf("%s: failed to migrate db: %w", operation, err)
+		}
+	}
 
 	db, err := db.NewConn(a.cfg.Dsn)
 	if err != nil {
 		return fmt.Errorf("%s: failed to init db: %w", operation, err)
 	}
+	a.closeOnStop(db)
+
+	normalizer := newNormalizer(a.cfg.ProviderRules)
+	if err := a.canonicalize(db, normalizer); err != nil {
+		return fmt.Errorf("%s: failed to canonicalize subscribers: %w", operation, err)
+	}
 
 	sr := subscriber.NewRepo(db)
-	v := validator.NewStdlib()
-	svc := subs.NewService(sr, v)
+	v, err := newValidator(a.cfg)
+	if err != nil {
+		return fmt.Errorf("%s: failed to init validator: %w", operation, err)
+	}
+
+	svc := subs.NewService(sr, v, normalizer)
 	sub.Register(a.srv, svc, a.log.With("source", "sub"))
 
+	sl := suppression.NewRepo(db)
+	suppressionSvc := suppressionsvc.NewService(sl, a.cfg.SoftBounceLimit)
+	suppressionsrv.Register(a.srv, suppressionSvc, a.log.With("source", "suppression"))
+
+	webhookSvc := webhooksvc.NewService(webhook.NewRepo(db), webhookclient.NewClient(&http.Client{}))
+	adminsrv.Register(
+		a.srv,
+		adminsvc.NewService(sr),
+		transfer.NewService(svc, sr),
+		suppressionSvc,
+		webhookSvc,
+		a.log.With("source", "admin"),
+	)
+
+	ob := outbox.NewRepo(db)
+	outboxsrv.Register(a.srv, outboxsvc.NewService(ob), a.log.With("source", "outbox"))
+
+	dl := delivery.NewRepo(db)
+	deliverysrv.Register(a.srv, deliverysvc.NewService(dl), a.log.With("source", "delivery"))
+
 	m, err := mailer.NewClient(a.cfg.MailerAddr, a.cfg.MailerFromAddr, a.log)
 	if err != nil {
 		return fmt.Errorf("%s: failed to connect to mailer service: %w", operation, err)
 	}
+	a.closeOnStop(m)
+
+	if a.cfg.PrivacySecret != "" {
+		privacySvc := privacy.NewService(
+			sr,
+			ob,
+			dl,
+			erasure.NewRepo(db),
+			m,
+			privacy.NewSigner(a.cfg.PrivacySecret, privacyTokenTTL),
+			normalizer,
+		)
+		privacysrv.Register(a.srv, privacySvc, a.log.With("source", "privacy"))
+	}
 
 	sg := subscriber.NewRepo(db)
-	fmter := formatter.NewWithDate()
+	fmter, err := formatter.NewTemplate()
+	if err != nil {
+		return fmt.Errorf("%s: failed to init mail formatter: %w", operation, err)
+	}
+
 	rw, err := ratewatcher.NewClient(a.cfg.RateWatcherAddr, a.log.With("source", "rateWatcher"))
 	if err != nil {
 		return fmt.Errorf("%s: failed to connect to rate watcher: %w", operation, err)
 	}
+	a.closeOnStop(rw)
+
+	channels := map[subscriber.Channel]sender.Channel{
+		subscriber.ChannelEmail:   channel.NewEmail(m, fmter),
+		subscriber.ChannelWebhook: channel.NewWebhook(webhookSvc),
+		subscriber.ChannelSlack: channel.NewChat(
+			subscriber.ChannelSlack,
+			chat.NewSlack(&http.Client{}),
+			fmter,
+		),
+		subscriber.ChannelDiscord: channel.NewChat(
+			subscriber.ChannelDiscord,
+			chat.NewDiscord(&http.Client{}),
+			fmter,
+		),
+	}
+
+	if a.cfg.TelegramToken != "" {
+		client := telegram.NewClient(a.cfg.TelegramAPIURL, a.cfg.TelegramToken)
+		if a.cfg.TelegramPolling {
+			bot := telegram.NewBot(client, telegramsvc.NewService(sr), rw, a.log.With("source", "telegram bot"))
+			ctx, cancel := context.WithCancel(a.ctx)
+			a.onStop(cancel)
+			a.goTask(func() { bot.Run(ctx) })
+		}
+		channels[subscriber.ChannelTelegram] = channel.NewTelegram(client, fmter)
+	}
+
+	for c, ch := range channels {
+		limit, ok := a.cfg.ChannelRateLimits[string(c)]
+		switch {
+		case !ok:
+		case c == subscriber.ChannelSlack || c == subscriber.ChannelDiscord:
+			// Chats limit each incoming webhook on its own.
+			channels[c] = channel.NewLimitedPerAddress(ch, limit)
+		default:
+			channels[c] = channel.NewLimited(ch, limit)
+		}
+	}
 
 	mailSender := sender.New(
-		m,
 		sg,
-		fmter,
 		rw,
+		rate.NewRepo(db),
+		ob,
+		dl,
+		sl,
+		channels,
 		a.log.With("source", "cron sender"),
 	)
 
 	cronAdapter := sender.NewCronJobAdapter(mailSender, a.log.With("source", "adapter"))
-	job := cron.NewDailyJob(cronJobHour, cronJobMinute, a.log.With("source", "cron"))
-	job.Do(cronAdapter)
+	schedule, err := cron.Parse(a.cfg.SendSchedule, time.UTC)
+	if err != nil {
+		return fmt.Errorf("%s: failed to parse send schedule: %w", operation, err)
+	}
+	dailyRun := coordinator.NewDailyRun(
+		enqueueJobName,
+		replicaID(),
+		schedule.Location(),
+		jobrun.NewRepo(db),
+		cronAdapter,
+		a.log.With("source", "coordinator"),
+	)
+
+	a.onStop(dailyRun.Stop)
+	a.goTask(func() {
+		if err := dailyRun.CatchUp(a.ctx, schedule, a.cfg.CatchUpGrace); err != nil {
+			a.log.Error("Failed to catch up missed run", "err", err)
+		}
+	})
+
+	clock := cron.NewRealClock()
+	job := cron.NewJob(schedule, clock, a.log.With("source", "cron"))
+	a.startJob(job, dailyRun)
+
+	deliveryAdapter := sender.NewDeliveryJobAdapter(mailSender, a.log.With("source", "delivery adapter"))
+	deliveryJob := cron.NewJob(cron.Every(deliveryInterval), clock, a.log.With("source", "delivery cron"))
+	a.startJob(deliveryJob, deliveryAdapter)
 
 	l, err := net.Listen("tcp", net.JoinHostPort("", a.cfg.Port))
 	if err != nil {
 		return fmt.Errorf("%s: failed to start listener on port %s: %w", operation, a.cfg.Port, err)
 	}
 
-	return a.srv.Serve(l)
+	if err := a.srv.Serve(l); err != nil && !errors.Is(err, grpc.ErrServerStopped) {
+		return fmt.Errorf("%s: failed to serve: %w", operation, err)
+	}
+
+	return nil
+}
+
+// closeOnStop registers connection, which is closed in GracefulStop
+// after jobs are stopped. Connections are closed in reverse order.
+func (a *App) closeOnStop(c io.Closer) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.closers = append(a.closers, c)
+}
+
+// startJob starts the job, which is stopped in GracefulStop.
+// Job isn't started, when the app is already stopping.
+func (a *App) startJob(j *cron.Job, fn cron.Doer) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.stopping {
+		return
+	}
+	j.Do(a.ctx, fn)
+	a.jobs = append(a.jobs, j)
+}
+
+// onStop registers fn, which is called, when stopping begins.
+func (a *App) onStop(fn func()) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.stops = append(a.stops, fn)
+}
+
+// goTask runs fn in the background. It's waited for in GracefulStop, so
+// fn should return, when a.ctx is done. fn isn't run, when the app is
+// already stopping.
+func (a *App) goTask(fn func()) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.stopping {
+		return
+	}
+	a.tasks.Add(1)
+	go func() {
+		defer a.tasks.Done()
+		fn()
+	}()
+}
+
+// migrate applies all pending embedded migrations. Replicas, which
+// start simultaneously, wait for each other on the migration lock.
+func (a *App) migrate() error {
+	conn, err := db.NewMigrationConn(a.cfg.Dsn)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	fsys, err := migrations.For(string(db.DialectOf(conn)))
+	if err != nil {
+		return err
+	}
+
+	mm, err := migrate.Load(fsys)
+	if err != nil {
+		return err
+	}
+
+	m := migrate.NewMigrator(conn, mm, a.log.With("source", "migrator"))
+	n, err := m.Up(context.Background(), 0)
+	if err != nil {
+		return err
+	}
+
+	a.log.Info("Migrated db", "applied", n)
+	return nil
+}
+
+// canonicalize recomputes canonical emails of the subscribers and merges
+// duplicates, when they were computed with other rules, i.e. provider
+// rules were enabled since the last start. Replicas, which start
+// simultaneously, wait for each other, and the rest find nothing to do.
+func (a *App) canonicalize(conn *sqlx.DB, n *canonical.Normalizer) error {
+	res, err := dedup.NewRepo(conn).Canonicalize(context.Background(), n.Rules(), n.Normalize)
+	if err != nil {
+		return err
+	}
+
+	if !res.UpToDate {
+		a.log.Info(
+			"Canonicalized subscribers",
+			"rules", n.Rules(),
+			"updated", res.Updated,
+			"merged", res.Merged,
+			"invalid", res.Invalid,
+		)
+	}
+	return nil
+}
+
+// newValidator constructs email validation pipeline. Cheap checks
+// go first, so DNS is queried only for emails, which passed them.
+func newValidator(cfg cfg.Config) (*validator.Pipeline, error) {
+	disposable := validator.DefaultDisposable()
+	if cfg.DisposableDomainsFile != "" {
+		var err error
+		disposable, err = validator.LoadDisposable(cfg.DisposableDomainsFile)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	checks := []validator.Check{validator.NewRoleAccount(validator.DefaultRoles...), disposable}
+	if cfg.CheckMailServer {
+		checks = append(checks, validator.NewMailServer(net.DefaultResolver))
+	}
+
+	return validator.NewPipeline(checks...), nil
+}
+
+// newNormalizer constructs email normalizer, which
+// applies provider rules only when they're enabled.
+func newNormalizer(providerRules bool) *canonical.Normalizer {
+	if !providerRules {
+		return canonical.New()
+	}
+	return canonical.New(canonical.Gmail)
+}
+
+// replicaID returns identifier of the current replica,
+// which is used to coordinate daily runs between replicas.
+func replicaID() string {
+	host, err := os.Hostname()
+	if err != nil {
+		host = "unknown"
+	}
+	return fmt.Sprintf("%s-%d", host, os.Getpid())
 }
 
 // GracefulStop method gracefully stop the server. It listens to the OS sigals.
-// After it recieves signal it terminates all currently active servers,
-// client, connections (if any) and gracefully exits.
+// After it recieves signal it stops accepting new calls, starting new runs of
+// the jobs and waiting for the runs held by other replicas, and waits up to
+// shutdownTimeout for in-flight calls, runs and background tasks to finish.
+// Those, which aren't finished by then, are cancelled, so jobs save their
+// progress. Finally, it closes all connections and gracefully exits.
 func (a *App) GracefulStop() {
 	ch := make(chan os.Signal, 1)
 	signal.Notify(ch, syscall.SIGTERM, syscall.SIGINT)
 	signal := <-ch
 	a.log.Info("Recieved stop signal. Terminating...", "signal", signal)
-	a.srv.Stop()
+
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+
+	a.mu.Lock()
+	a.stopping = true
+	jobs, closers, stops := a.jobs, a.closers, a.stops
+	a.mu.Unlock()
+
+	for _, stop := range stops {
+		stop()
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(len(jobs) + 2)
+	go func() {
+		defer wg.Done()
+		stopServer(ctx, a.srv)
+	}()
+	for _, j := range jobs {
+		go func(j *cron.Job) {
+			defer wg.Done()
+			if err := j.Stop(ctx); err != nil {
+				a.log.Warn("Job wasn't finished on time, cancelled it", "err", err)
+			}
+		}(j)
+	}
+	go func() {
+		defer wg.Done()
+		if err := a.waitTasks(ctx); err != nil {
+			a.log.Warn("Background tasks weren't finished on time, cancelled them", "err", err)
+		}
+	}()
+	wg.Wait()
+
+	for i := len(closers) - 1; i >= 0; i-- {
+		if err := closers[i].Close(); err != nil {
+			a.log.Error("Failed to close connection", "err", err)
+		}
+	}
 	a.log.Info("Successfully terminated server. Bye!")
 }
+
+// waitTasks waits until background tasks are finished. If ctx is done
+// before that, tasks are cancelled and waited for up to cancelTimeout
+// more, so they don't use connections, which are about to be closed.
+// Returns ctx.Err() if tasks were cancelled.
+func (a *App) waitTasks(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		a.tasks.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		a.cancel()
+		return nil
+	case <-ctx.Done():
+		a.cancel()
+	}
+
+	select {
+	case <-done:
+	case <-time.After(cancelTimeout):
+		a.log.Error("Background tasks weren't finished after cancellation")
+	}
+
+	return ctx.Err()
+}
+
+// stopServer stops the server gracefully, so in-flight calls are
+// finished. Calls, which aren't finished, when ctx is done, are cancelled.
+func stopServer(ctx context.Context, srv *grpc.Server) {
+	done := make(chan struct{})
+	go func() {
+		srv.GracefulStop()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-ctx.Done():
+		srv.Stop()
+		<-done
+	}
+}
@@ -2,7 +2,11 @@ package cfg
 
 import (
 	"fmt"
+	"maps"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 )
 
 const operation = "config parsing"
@@ -14,8 +18,53 @@ const (
 	portEnvKey              = "SUB_PORT"
 	dsnEnvKey               = "SUB_DSN"
 	mailerFromAddrEnvKey    = "MAILER_FROM_ADDR"
+	sendScheduleEnvKey      = "SUB_SEND_SCHEDULE"
+	catchUpGraceEnvKey      = "SUB_CATCH_UP_GRACE"
+	adminTokenEnvKey        = "SUB_ADMIN_TOKEN"
+	privacySecretEnvKey     = "SUB_PRIVACY_SECRET"
+	providerRulesEnvKey     = "SUB_EMAIL_PROVIDER_RULES"
+	checkMailServerEnvKey   = "SUB_CHECK_MAIL_SERVER"
+	disposableFileEnvKey    = "SUB_DISPOSABLE_DOMAINS_FILE"
+	autoMigrateEnvKey       = "SUB_AUTO_MIGRATE"
+	softBounceLimitEnvKey   = "SUB_SOFT_BOUNCE_LIMIT"
+	telegramTokenEnvKey     = "SUB_TELEGRAM_TOKEN"
+	telegramAPIURLEnvKey    = "SUB_TELEGRAM_API_URL"
+	telegramPollingEnvKey   = "SUB_TELEGRAM_POLLING"
+	channelRateLimitsEnvKey = "SUB_CHANNEL_RATE_LIMITS"
 )
 
+// defaultSendSchedule is a cron expression of the daily
+// send, which is used when it's not provided: every day at 12:00 UTC.
+const defaultSendSchedule = "0 12 * * *"
+
+// defaultCatchUpGrace is a period after the scheduled time, during
+// which missed run is still caught up on start-up.
+const defaultCatchUpGrace = time.Hour * 12
+
+// defaultSoftBounceLimit is a number of soft bounces, after
+// which email is suppressed, when it's not provided.
+const defaultSoftBounceLimit = 3
+
+// defaultTelegramAPIURL is a base URL of the Telegram
+// Bot API, which is used when it's not provided.
+const defaultTelegramAPIURL = "https://api.telegram.org"
+
+// defaultChannelRateLimits are maximum numbers of notifications sent
+// per second through each of the channels, which are used when they're
+// not provided. Slack and Discord limits apply to each webhook URL on
+// its own, like the limits of the chats' incoming webhooks.
+var defaultChannelRateLimits = map[string]int{
+	"email":    10,
+	"telegram": 25,
+	"webhook":  20,
+	"slack":    1,
+	"discord":  5,
+}
+
+// defaultMigrationLogLevel is a log level of the
+// migrate command, which is used when it's not provided.
+const defaultMigrationLogLevel = "info"
+
 // Config struct represents application config,
 // which is used application-wide.
 type Config struct {
@@ -25,6 +74,41 @@ type Config struct {
 	Port            string
 	LogLevel        string
 	MailerFromAddr  string
+	SendSchedule    string
+	CatchUpGrace    time.Duration
+	// AdminToken guards admin service. Admin service
+	// is disabled, when token is empty.
+	AdminToken string
+	// PrivacySecret signs tokens of the personal data export
+	// and erasure. Privacy service is disabled, when secret is empty.
+	PrivacySecret string
+	// ProviderRules enables provider-specific rules of the email
+	// normalisation, i.e. Gmail dots and plus tags are ignored.
+	ProviderRules bool
+	// CheckMailServer enables MX/A records lookup of the
+	// subscriber's email domain. Enabled by default.
+	CheckMailServer bool
+	// DisposableDomainsFile is a path to the blocklist of the disposable
+	// mail providers. Built-in blocklist is used, when it's empty.
+	DisposableDomainsFile string
+	// AutoMigrate enables migration of the schema on start-up.
+	AutoMigrate bool
+	// SoftBounceLimit is a number of soft bounces,
+	// after which email is suppressed.
+	SoftBounceLimit int
+	// TelegramToken is a token of the Telegram bot. Bot
+	// and Telegram channel are disabled, when it's empty.
+	TelegramToken string
+	// TelegramAPIURL is a base URL of the Telegram Bot API.
+	TelegramAPIURL string
+	// TelegramPolling enables polling of the bot updates. Bot API
+	// allows only a single poller per bot, so it should be enabled
+	// on a single replica, while others only send notifications.
+	TelegramPolling bool
+	// ChannelRateLimits are maximum numbers of notifications sent
+	// per second keyed by the channel. Provided limits override
+	// the default ones, i.e. "email=50,telegram=30".
+	ChannelRateLimits map[string]int
 }
 
 // Must is a handly wrapper around return results from
@@ -72,12 +156,130 @@ func NewFromEnv() (*Config, error) {
 		return nil, fmt.Errorf("%s: mailer from addr can't be empty", mailerFromAddr)
 	}
 
+	sendSchedule := os.Getenv(sendScheduleEnvKey)
+	if sendSchedule == "" {
+		sendSchedule = defaultSendSchedule
+	}
+
+	catchUpGrace := defaultCatchUpGrace
+	if g := os.Getenv(catchUpGraceEnvKey); g != "" {
+		var err error
+		catchUpGrace, err = time.ParseDuration(g)
+		if err != nil || catchUpGrace < 0 {
+			return nil, fmt.Errorf("%s: catch up grace should be non-negative duration: %s", operation, g)
+		}
+	}
+
+	providerRules, err := parseBool(providerRulesEnvKey, false)
+	if err != nil {
+		return nil, fmt.Errorf("%s: email provider rules should be boolean: %w", operation, err)
+	}
+
+	checkMailServer, err := parseBool(checkMailServerEnvKey, true)
+	if err != nil {
+		return nil, fmt.Errorf("%s: check mail server should be boolean: %w", operation, err)
+	}
+
+	autoMigrate, err := parseBool(autoMigrateEnvKey, false)
+	if err != nil {
+		return nil, fmt.Errorf("%s: auto migrate should be boolean: %w", operation, err)
+	}
+
+	softBounceLimit := defaultSoftBounceLimit
+	if l := os.Getenv(softBounceLimitEnvKey); l != "" {
+		var err error
+		softBounceLimit, err = strconv.Atoi(l)
+		if err != nil || softBounceLimit <= 0 {
+			return nil, fmt.Errorf("%s: soft bounce limit should be positive integer: %s", operation, l)
+		}
+	}
+
+	telegramAPIURL := os.Getenv(telegramAPIURLEnvKey)
+	if telegramAPIURL == "" {
+		telegramAPIURL = defaultTelegramAPIURL
+	}
+
+	telegramPolling, err := parseBool(telegramPollingEnvKey, false)
+	if err != nil {
+		return nil, fmt.Errorf("%s: telegram polling should be boolean: %w", operation, err)
+	}
+
+	rateLimits, err := parseRateLimits(os.Getenv(channelRateLimitsEnvKey))
+	if err != nil {
+		return nil, fmt.Errorf("%s: channel rate limits are invalid: %w", operation, err)
+	}
+
 	return &Config{
-		LogLevel:        logLevel,
-		Port:            port,
-		RateWatcherAddr: rwAddr,
-		MailerAddr:      mAddr,
-		Dsn:             dsn,
-		MailerFromAddr:  mailerFromAddr,
+		ChannelRateLimits:     rateLimits,
+		SendSchedule:          sendSchedule,
+		CatchUpGrace:          catchUpGrace,
+		AdminToken:            os.Getenv(adminTokenEnvKey),
+		PrivacySecret:         os.Getenv(privacySecretEnvKey),
+		ProviderRules:         providerRules,
+		CheckMailServer:       checkMailServer,
+		DisposableDomainsFile: os.Getenv(disposableFileEnvKey),
+		AutoMigrate:           autoMigrate,
+		SoftBounceLimit:       softBounceLimit,
+		TelegramToken:         os.Getenv(telegramTokenEnvKey),
+		TelegramAPIURL:        telegramAPIURL,
+		TelegramPolling:       telegramPolling,
+		LogLevel:              logLevel,
+		Port:                  port,
+		RateWatcherAddr:       rwAddr,
+		MailerAddr:            mAddr,
+		Dsn:                   dsn,
+		MailerFromAddr:        mailerFromAddr,
 	}, nil
 }
+
+// NewMigrationFromEnv parses only env vars needed to migrate the
+// schema, so migrations could be run without the rest of the config.
+// Log level defaults to info. Returns an error if dsn is missing.
+func NewMigrationFromEnv() (*Config, error) {
+	dsn := os.Getenv(dsnEnvKey)
+	if dsn == "" {
+		return nil, fmt.Errorf("%s: dsn can't be empty", operation)
+	}
+
+	logLevel := os.Getenv(logLevelEnvKey)
+	if logLevel == "" {
+		logLevel = defaultMigrationLogLevel
+	}
+
+	return &Config{Dsn: dsn, LogLevel: logLevel}, nil
+}
+
+// parseBool parses boolean env var with the given key.
+// Returns def, when env var is empty.
+func parseBool(key string, def bool) (bool, error) {
+	v := os.Getenv(key)
+	if v == "" {
+		return def, nil
+	}
+
+	return strconv.ParseBool(v)
+}
+
+// parseRateLimits parses comma-separated channel=limit pairs over the
+// default rate limits. Each limit should be positive integer.
+func parseRateLimits(v string) (map[string]int, error) {
+	limits := maps.Clone(defaultChannelRateLimits)
+	if v == "" {
+		return limits, nil
+	}
+
+	for _, pair := range strings.Split(v, ",") {
+		channel, limit, ok := strings.Cut(strings.TrimSpace(pair), "=")
+		if !ok || channel == "" {
+			return nil, fmt.Errorf("%q should be in the channel=limit form", pair)
+		}
+
+		n, err := strconv.Atoi(limit)
+		if err != nil || n <= 0 {
+			return nil, fmt.Errorf("limit of %s should be positive integer: %s", channel, limit)
+		}
+		limits[channel] = n
+	}
+
+	return limits, nil
+}
@@ -5,6 +5,7 @@ import (
 	"os"
 	"reflect"
 	"testing"
+	"time"
 )
 
 func TestMust(t *testing.T) {
@@ -71,17 +72,160 @@ func TestNewFromEnv(t *testing.T) {
 				os.Setenv(portEnvKey, "3030")
 				os.Setenv(dsnEnvKey, "mysql://test:tests@(db:testse)/shgsoh")
 				os.Setenv(mailerFromAddrEnvKey, "from@from.com")
+				os.Setenv(sendScheduleEnvKey, "CRON_TZ=Europe/Kyiv 30 9 * * 1-5")
+				os.Setenv(catchUpGraceEnvKey, "6h")
+				os.Setenv(adminTokenEnvKey, "secret")
+				os.Setenv(privacySecretEnvKey, "privacy")
+				os.Setenv(providerRulesEnvKey, "true")
+				os.Setenv(checkMailServerEnvKey, "false")
+				os.Setenv(disposableFileEnvKey, "/etc/sub/disposable.txt")
+				os.Setenv(autoMigrateEnvKey, "true")
+				os.Setenv(softBounceLimitEnvKey, "5")
+				os.Setenv(telegramTokenEnvKey, "123:token")
+				os.Setenv(telegramAPIURLEnvKey, "http://telegram:8081")
+				os.Setenv(telegramPollingEnvKey, "true")
+				os.Setenv(channelRateLimitsEnvKey, "email=50, telegram=30")
 			},
 			want: &Config{
-				MailerAddr:      "mailer:80",
-				RateWatcherAddr: "rw:8080",
-				LogLevel:        "debug",
-				Port:            "3030",
-				Dsn:             "mysql://test:tests@(db:testse)/shgsoh",
-				MailerFromAddr:  "from@from.com",
+				MailerAddr:            "mailer:80",
+				RateWatcherAddr:       "rw:8080",
+				LogLevel:              "debug",
+				Port:                  "3030",
+				Dsn:                   "mysql://test:tests@(db:testse)/shgsoh",
+				MailerFromAddr:        "from@from.com",
+				SendSchedule:          "CRON_TZ=Europe/Kyiv 30 9 * * 1-5",
+				CatchUpGrace:          time.Hour * 6,
+				AdminToken:            "secret",
+				PrivacySecret:         "privacy",
+				ProviderRules:         true,
+				DisposableDomainsFile: "/etc/sub/disposable.txt",
+				AutoMigrate:           true,
+				SoftBounceLimit:       5,
+				TelegramToken:         "123:token",
+				TelegramAPIURL:        "http://telegram:8081",
+				TelegramPolling:       true,
+				ChannelRateLimits: map[string]int{
+					"email":    50,
+					"telegram": 30,
+					"webhook":  20,
+					"slack":    1,
+					"discord":  5,
+				},
 			},
 			wantErr: false,
 		},
+		{
+			name: "Should use default send schedule and catch up grace when they're missing",
+			setup: func() {
+				os.Setenv(mailerServiceAddrEnvKey, "mailer:80")
+				os.Setenv(rateWatchAddrEnvKey, "rw:8080")
+				os.Setenv(logLevelEnvKey, "debug")
+				os.Setenv(portEnvKey, "3030")
+				os.Setenv(dsnEnvKey, "mysql://test:tests@(db:testse)/shgsoh")
+				os.Setenv(mailerFromAddrEnvKey, "from@from.com")
+				os.Setenv(sendScheduleEnvKey, "")
+				os.Setenv(catchUpGraceEnvKey, "")
+			},
+			want: &Config{
+				MailerAddr:        "mailer:80",
+				RateWatcherAddr:   "rw:8080",
+				LogLevel:          "debug",
+				Port:              "3030",
+				Dsn:               "mysql://test:tests@(db:testse)/shgsoh",
+				MailerFromAddr:    "from@from.com",
+				SendSchedule:      defaultSendSchedule,
+				CatchUpGrace:      defaultCatchUpGrace,
+				CheckMailServer:   true,
+				SoftBounceLimit:   defaultSoftBounceLimit,
+				TelegramAPIURL:    defaultTelegramAPIURL,
+				ChannelRateLimits: defaultChannelRateLimits,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Should not parse config when catch up grace is invalid",
+			setup: func() {
+				os.Setenv(mailerServiceAddrEnvKey, "mailer:80")
+				os.Setenv(rateWatchAddrEnvKey, "rw:8080")
+				os.Setenv(logLevelEnvKey, "debug")
+				os.Setenv(portEnvKey, "3030")
+				os.Setenv(dsnEnvKey, "mysql://test:tests@(db:testse)/shgsoh")
+				os.Setenv(mailerFromAddrEnvKey, "from@from.com")
+				os.Setenv(catchUpGraceEnvKey, "twelve hours")
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "Should not parse config when soft bounce limit is not positive",
+			setup: func() {
+				os.Setenv(mailerServiceAddrEnvKey, "mailer:80")
+				os.Setenv(rateWatchAddrEnvKey, "rw:8080")
+				os.Setenv(logLevelEnvKey, "debug")
+				os.Setenv(portEnvKey, "3030")
+				os.Setenv(dsnEnvKey, "mysql://test:tests@(db:testse)/shgsoh")
+				os.Setenv(mailerFromAddrEnvKey, "from@from.com")
+				os.Setenv(softBounceLimitEnvKey, "0")
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "Should not parse config when channel rate limit is not positive",
+			setup: func() {
+				os.Setenv(mailerServiceAddrEnvKey, "mailer:80")
+				os.Setenv(rateWatchAddrEnvKey, "rw:8080")
+				os.Setenv(logLevelEnvKey, "debug")
+				os.Setenv(portEnvKey, "3030")
+				os.Setenv(dsnEnvKey, "mysql://test:tests@(db:testse)/shgsoh")
+				os.Setenv(mailerFromAddrEnvKey, "from@from.com")
+				os.Setenv(channelRateLimitsEnvKey, "slack=0")
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "Should not parse config when channel rate limits are malformed",
+			setup: func() {
+				os.Setenv(mailerServiceAddrEnvKey, "mailer:80")
+				os.Setenv(rateWatchAddrEnvKey, "rw:8080")
+				os.Setenv(logLevelEnvKey, "debug")
+				os.Setenv(portEnvKey, "3030")
+				os.Setenv(dsnEnvKey, "mysql://test:tests@(db:testse)/shgsoh")
+				os.Setenv(mailerFromAddrEnvKey, "from@from.com")
+				os.Setenv(channelRateLimitsEnvKey, "slack:1")
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "Should not parse config when email provider rules are invalid",
+			setup: func() {
+				os.Setenv(mailerServiceAddrEnvKey, "mailer:80")
+				os.Setenv(rateWatchAddrEnvKey, "rw:8080")
+				os.Setenv(logLevelEnvKey, "debug")
+				os.Setenv(portEnvKey, "3030")
+				os.Setenv(dsnEnvKey, "mysql://test:tests@(db:testse)/shgsoh")
+				os.Setenv(mailerFromAddrEnvKey, "from@from.com")
+				os.Setenv(providerRulesEnvKey, "gmail")
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "Should not parse config when check mail server is invalid",
+			setup: func() {
+				os.Setenv(mailerServiceAddrEnvKey, "mailer:80")
+				os.Setenv(rateWatchAddrEnvKey, "rw:8080")
+				os.Setenv(logLevelEnvKey, "debug")
+				os.Setenv(portEnvKey, "3030")
+				os.Setenv(dsnEnvKey, "mysql://test:tests@(db:testse)/shgsoh")
+				os.Setenv(mailerFromAddrEnvKey, "from@from.com")
+				os.Setenv(checkMailServerEnvKey, "mx")
+			},
+			want:    nil,
+			wantErr: true,
+		},
 		{
 			name: "Should not parse config when mailer addr is missing",
 			setup: func() {
@@ -171,6 +315,19 @@ func TestNewFromEnv(t *testing.T) {
 				os.Unsetenv(portEnvKey)
 				os.Unsetenv(dsnEnvKey)
 				os.Unsetenv(mailerFromAddrEnvKey)
+				os.Unsetenv(sendScheduleEnvKey)
+				os.Unsetenv(catchUpGraceEnvKey)
+				os.Unsetenv(adminTokenEnvKey)
+				os.Unsetenv(privacySecretEnvKey)
+				os.Unsetenv(providerRulesEnvKey)
+				os.Unsetenv(checkMailServerEnvKey)
+				os.Unsetenv(disposableFileEnvKey)
+				os.Unsetenv(autoMigrateEnvKey)
+				os.Unsetenv(softBounceLimitEnvKey)
+				os.Unsetenv(telegramTokenEnvKey)
+				os.Unsetenv(telegramAPIURLEnvKey)
+				os.Unsetenv(telegramPollingEnvKey)
+				os.Unsetenv(channelRateLimitsEnvKey)
 			})
 
 			tt.setup()
@@ -185,3 +342,60 @@ func TestNewFromEnv(t *testing.T) {
 		})
 	}
 }
+
+func TestNewMigrationFromEnv(t *testing.T) {
+	tests := []struct {
+		name    string
+		setup   func()
+		want    *Config
+		wantErr bool
+	}{
+		{
+			name: "Should parse dsn and log level only",
+			setup: func() {
+				os.Setenv(dsnEnvKey, "mysql://test:tests@(db:testse)/shgsoh")
+				os.Setenv(logLevelEnvKey, "debug")
+			},
+			want: &Config{
+				Dsn:      "mysql://test:tests@(db:testse)/shgsoh",
+				LogLevel: "debug",
+			},
+		},
+		{
+			name: "Should use default log level when it's missing",
+			setup: func() {
+				os.Setenv(dsnEnvKey, "mysql://test:tests@(db:testse)/shgsoh")
+			},
+			want: &Config{
+				Dsn:      "mysql://test:tests@(db:testse)/shgsoh",
+				LogLevel: defaultMigrationLogLevel,
+			},
+		},
+		{
+			name: "Should not parse config when dsn is missing",
+			setup: func() {
+				os.Setenv(logLevelEnvKey, "debug")
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Cleanup(func() {
+				os.Unsetenv(dsnEnvKey)
+				os.Unsetenv(logLevelEnvKey)
+			})
+
+			tt.setup()
+			got, err := NewMigrationFromEnv()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewMigrationFromEnv() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewMigrationFromEnv() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
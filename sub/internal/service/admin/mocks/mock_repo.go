@@ -0,0 +1,143 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/hrvadl/converter/sub/internal/service/admin (interfaces: SubscriberRepo)
+//
+// Generated by this command:
+//
+//	mockgen -destination=./mocks/mock_repo.go -package=mocks . SubscriberRepo
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	subscriber "github.com/hrvadl/converter/sub/internal/storage/subscriber"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockSubscriberRepo is a mock of SubscriberRepo interface.
+type MockSubscriberRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockSubscriberRepoMockRecorder
+}
+
+// MockSubscriberRepoMockRecorder is the mock recorder for MockSubscriberRepo.
+type MockSubscriberRepoMockRecorder struct {
+	mock *MockSubscriberRepo
+}
+
+// NewMockSubscriberRepo creates a new mock instance.
+func NewMockSubscriberRepo(ctrl *gomock.Controller) *MockSubscriberRepo {
+	mock := &MockSubscriberRepo{ctrl: ctrl}
+	mock.recorder = &MockSubscriberRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSubscriberRepo) EXPECT() *MockSubscriberRepoMockRecorder {
+	return m.recorder
+}
+
+// Count mocks base method.
+func (m *MockSubscriberRepo) Count(arg0 context.Context, arg1 subscriber.Filter) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Count", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Count indicates an expected call of Count.
+func (mr *MockSubscriberRepoMockRecorder) Count(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Count", reflect.TypeOf((*MockSubscriberRepo)(nil).Count), arg0, arg1)
+}
+
+// DeleteAddress mocks base method.
+func (m *MockSubscriberRepo) DeleteAddress(arg0 context.Context, arg1 int64, arg2 subscriber.Channel) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAddress", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAddress indicates an expected call of DeleteAddress.
+func (mr *MockSubscriberRepoMockRecorder) DeleteAddress(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAddress", reflect.TypeOf((*MockSubscriberRepo)(nil).DeleteAddress), arg0, arg1, arg2)
+}
+
+// Get mocks base method.
+func (m *MockSubscriberRepo) Get(arg0 context.Context, arg1 subscriber.Filter) ([]subscriber.Subscriber, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", arg0, arg1)
+	ret0, _ := ret[0].([]subscriber.Subscriber)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockSubscriberRepoMockRecorder) Get(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockSubscriberRepo)(nil).Get), arg0, arg1)
+}
+
+// GetAddresses mocks base method.
+func (m *MockSubscriberRepo) GetAddresses(arg0 context.Context, arg1 int64) ([]subscriber.Address, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAddresses", arg0, arg1)
+	ret0, _ := ret[0].([]subscriber.Address)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAddresses indicates an expected call of GetAddresses.
+func (mr *MockSubscriberRepoMockRecorder) GetAddresses(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAddresses", reflect.TypeOf((*MockSubscriberRepo)(nil).GetAddresses), arg0, arg1)
+}
+
+// GetByID mocks base method.
+func (m *MockSubscriberRepo) GetByID(arg0 context.Context, arg1 int64) (subscriber.Subscriber, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", arg0, arg1)
+	ret0, _ := ret[0].(subscriber.Subscriber)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockSubscriberRepoMockRecorder) GetByID(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockSubscriberRepo)(nil).GetByID), arg0, arg1)
+}
+
+// SaveAddress mocks base method.
+func (m *MockSubscriberRepo) SaveAddress(arg0 context.Context, arg1 subscriber.Address) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveAddress", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveAddress indicates an expected call of SaveAddress.
+func (mr *MockSubscriberRepoMockRecorder) SaveAddress(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveAddress", reflect.TypeOf((*MockSubscriberRepo)(nil).SaveAddress), arg0, arg1)
+}
+
+// Unsubscribe mocks base method.
+func (m *MockSubscriberRepo) Unsubscribe(arg0 context.Context, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Unsubscribe", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Unsubscribe indicates an expected call of Unsubscribe.
+func (mr *MockSubscriberRepoMockRecorder) Unsubscribe(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unsubscribe", reflect.TypeOf((*MockSubscriberRepo)(nil).Unsubscribe), arg0, arg1)
+}
@@ -0,0 +1,208 @@
+package admin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/mail"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/hrvadl/converter/sub/internal/storage/subscriber"
+)
+
+const operation = "admin service"
+
+const (
+	defaultLimit = 50
+	maxLimit     = 500
+)
+
+// maxAddressLength is a length of the address column.
+const maxAddressLength = 255
+
+var (
+	// ErrInvalidChannel is returned when channel of the
+	// address isn't an email, telegram, slack or discord one.
+	ErrInvalidChannel = errors.New("address channel must be email, telegram, slack or discord")
+	// ErrInvalidAddress is returned when address isn't valid for its channel.
+	ErrInvalidAddress = errors.New("address is invalid for the channel")
+)
+
+// NewService constructs new Service with provided arguments.
+// NOTE: neither of arguments can't be nil, or service will panic in
+// the future.
+func NewService(sr SubscriberRepo) *Service {
+	return &Service{
+		repo: sr,
+	}
+}
+
+//go:generate mockgen -destination=./mocks/mock_repo.go -package=mocks . SubscriberRepo
+type SubscriberRepo interface {
+	Get(ctx context.Context, f subscriber.Filter) ([]subscriber.Subscriber, error)
+	Count(ctx context.Context, f subscriber.Filter) (int64, error)
+	GetByID(ctx context.Context, id int64) (subscriber.Subscriber, error)
+	Unsubscribe(ctx context.Context, id int64) error
+	GetAddresses(ctx context.Context, subscriberID int64) ([]subscriber.Address, error)
+	SaveAddress(ctx context.Context, a subscriber.Address) error
+	DeleteAddress(ctx context.Context, subscriberID int64, c subscriber.Channel) error
+}
+
+// Service is a main structure, responsible for
+// inspecting and managing subscribers by operators.
+type Service struct {
+	repo SubscriberRepo
+}
+
+// ListSubscribers method returns page of email subscribers matching the
+// filter, ordered by ID. Subscribers of other channels aren't listed, since
+// their addresses are chat IDs and webhook URLs, which are secrets. Limit
+// falls back to the default one when it's not positive and is capped with
+// the maximum one. One extra subscriber is requested to find out whether
+// there's a next page.
+func (s *Service) ListSubscribers(ctx context.Context, f subscriber.Filter) (subscriber.Page, error) {
+	f.Channel = subscriber.ChannelEmail
+	if f.Limit <= 0 {
+		f.Limit = defaultLimit
+	}
+	f.Limit = min(f.Limit, maxLimit)
+
+	limit := f.Limit
+	f.Limit++
+
+	subs, err := s.repo.Get(ctx, f)
+	if err != nil {
+		return subscriber.Page{}, fmt.Errorf("%s: failed to get subscribers: %w", operation, err)
+	}
+
+	if len(subs) <= limit {
+		return subscriber.Page{Subscribers: subs}, nil
+	}
+
+	subs = subs[:limit]
+	return subscriber.Page{Subscribers: subs, NextAfterID: subs[limit-1].ID}, nil
+}
+
+// GetSubscriber method returns subscriber with the given ID alongside its
+// additional addresses. Returns subscriber.ErrNotFound if there's no such
+// subscriber.
+func (s *Service) GetSubscriber(ctx context.Context, id int64) (subscriber.Subscriber, error) {
+	sub, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return subscriber.Subscriber{}, fmt.Errorf("%s: failed to get subscriber: %w", operation, err)
+	}
+
+	sub.Addresses, err = s.repo.GetAddresses(ctx, id)
+	if err != nil {
+		return subscriber.Subscriber{}, fmt.Errorf("%s: failed to get addresses: %w", operation, err)
+	}
+
+	return sub, nil
+}
+
+// DeleteSubscriber method unsubscribes subscriber with the given ID, so
+// no more mails are sent to it. Subscriber is kept, so its delivery
+// history is still meaningful. Returns subscriber.ErrNotFound if there's
+// no such subscriber.
+func (s *Service) DeleteSubscriber(ctx context.Context, id int64) error {
+	if err := s.repo.Unsubscribe(ctx, id); err != nil {
+		return fmt.Errorf("%s: failed to unsubscribe subscriber: %w", operation, err)
+	}
+
+	return nil
+}
+
+// CountSubscribers method returns number of email subscribers
+// matching the filter, so it agrees with the listed ones.
+func (s *Service) CountSubscribers(ctx context.Context, f subscriber.Filter) (int64, error) {
+	f.Channel = subscriber.ChannelEmail
+	n, err := s.repo.Count(ctx, f)
+	if err != nil {
+		return 0, fmt.Errorf("%s: failed to count subscribers: %w", operation, err)
+	}
+
+	return n, nil
+}
+
+// AddAddress method validates the address and adds it to the subscriber,
+// so notifications are sent through its channel as well. Webhooks can't
+// be added, since they need a secret. Returns ErrInvalidChannel or
+// ErrInvalidAddress if arguments are invalid, subscriber.ErrNotFound if
+// there's no such subscriber and subscriber.ErrAddressExists if subscriber
+// already has an address of the same channel.
+func (s *Service) AddAddress(ctx context.Context, a subscriber.Address) error {
+	address, err := parseAddress(a.Channel, a.Address)
+	if err != nil {
+		return fmt.Errorf("%s: %w", operation, err)
+	}
+
+	a.Address = address
+	if err := s.repo.SaveAddress(ctx, a); err != nil {
+		return fmt.Errorf("%s: failed to save address: %w", operation, err)
+	}
+
+	return nil
+}
+
+// RemoveAddress method removes the channel address of the subscriber, so
+// no more notifications are sent through it. Returns ErrInvalidChannel if
+// channel is invalid and subscriber.ErrAddressNotFound if subscriber doesn't
+// have such address.
+func (s *Service) RemoveAddress(ctx context.Context, subscriberID int64, c subscriber.Channel) error {
+	if !isAddressChannel(c) {
+		return fmt.Errorf("%s: %w", operation, ErrInvalidChannel)
+	}
+
+	if err := s.repo.DeleteAddress(ctx, subscriberID, c); err != nil {
+		return fmt.Errorf("%s: failed to delete address: %w", operation, err)
+	}
+
+	return nil
+}
+
+// parseAddress validates address of the given channel and returns its
+// normalised form: emails are lowercased, chat IDs are integers and chat
+// webhooks are absolute HTTPS URLs.
+func parseAddress(c subscriber.Channel, address string) (string, error) {
+	if !isAddressChannel(c) {
+		return "", ErrInvalidChannel
+	}
+
+	address = strings.TrimSpace(address)
+	if address == "" || len(address) > maxAddressLength {
+		return "", ErrInvalidAddress
+	}
+
+	switch c {
+	case subscriber.ChannelEmail:
+		a, err := mail.ParseAddress(address)
+		if err != nil || a.Address != address {
+			return "", ErrInvalidAddress
+		}
+		return strings.ToLower(address), nil
+	case subscriber.ChannelTelegram:
+		if _, err := strconv.ParseInt(address, 10, 64); err != nil {
+			return "", ErrInvalidAddress
+		}
+		return address, nil
+	default:
+		u, err := url.Parse(address)
+		if err != nil || !strings.EqualFold(u.Scheme, "https") || u.Host == "" || u.User != nil {
+			return "", ErrInvalidAddress
+		}
+		return address, nil
+	}
+}
+
+// isAddressChannel reports whether address of the channel
+// can be added to the subscriber.
+func isAddressChannel(c subscriber.Channel) bool {
+	switch c {
+	case subscriber.ChannelEmail, subscriber.ChannelTelegram, subscriber.ChannelSlack, subscriber.ChannelDiscord:
+		return true
+	default:
+		return false
+	}
+}
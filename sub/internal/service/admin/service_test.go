@@ -0,0 +1,734 @@
+package admin
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"go.uber.org/mock/gomock"
+
+	"github.com/hrvadl/converter/sub/internal/service/admin/mocks"
+	"github.com/hrvadl/converter/sub/internal/storage/subscriber"
+)
+
+func TestNewService(t *testing.T) {
+	t.Parallel()
+	type args struct {
+		sr SubscriberRepo
+	}
+	tests := []struct {
+		name string
+		args args
+		want *Service
+	}{
+		{
+			name: "Should create new service correctly when correct arguments are provided",
+			args: args{
+				sr: mocks.NewMockSubscriberRepo(gomock.NewController(t)),
+			},
+			want: &Service{
+				repo: mocks.NewMockSubscriberRepo(gomock.NewController(t)),
+			},
+		},
+		{
+			name: "Should create new service correctly when allowed arguments are provided",
+			args: args{
+				sr: nil,
+			},
+			want: &Service{
+				repo: nil,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := NewService(tt.args.sr); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewService() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestServiceListSubscribers(t *testing.T) {
+	t.Parallel()
+	type fields struct {
+		repo SubscriberRepo
+	}
+	type args struct {
+		ctx context.Context
+		f   subscriber.Filter
+	}
+	subs := []subscriber.Subscriber{{ID: 1}, {ID: 2}, {ID: 3}}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		setup   func(t *testing.T, r SubscriberRepo)
+		want    subscriber.Page
+		wantErr bool
+	}{
+		{
+			name: "Should return last page without cursor when there're no more subscribers",
+			fields: fields{
+				repo: mocks.NewMockSubscriberRepo(gomock.NewController(t)),
+			},
+			args: args{
+				ctx: context.Background(),
+				f:   subscriber.Filter{Email: "test", Limit: 3},
+			},
+			setup: func(t *testing.T, r SubscriberRepo) {
+				t.Helper()
+				rr, ok := r.(*mocks.MockSubscriberRepo)
+				if !ok {
+					t.Fatal("failed to cast repo to mock")
+				}
+				rr.EXPECT().
+					Get(gomock.Any(), subscriber.Filter{Channel: subscriber.ChannelEmail, Email: "test", Limit: 4}).
+					Times(1).
+					Return(subs, nil)
+			},
+			want:    subscriber.Page{Subscribers: subs},
+			wantErr: false,
+		},
+		{
+			name: "Should return page with cursor when there're more subscribers",
+			fields: fields{
+				repo: mocks.NewMockSubscriberRepo(gomock.NewController(t)),
+			},
+			args: args{
+				ctx: context.Background(),
+				f:   subscriber.Filter{AfterID: 10, Limit: 2},
+			},
+			setup: func(t *testing.T, r SubscriberRepo) {
+				t.Helper()
+				rr, ok := r.(*mocks.MockSubscriberRepo)
+				if !ok {
+					t.Fatal("failed to cast repo to mock")
+				}
+				rr.EXPECT().
+					Get(gomock.Any(), subscriber.Filter{Channel: subscriber.ChannelEmail, AfterID: 10, Limit: 3}).
+					Times(1).
+					Return(subs, nil)
+			},
+			want:    subscriber.Page{Subscribers: subs[:2], NextAfterID: 2},
+			wantErr: false,
+		},
+		{
+			name: "Should fallback to default limit when limit is not positive",
+			fields: fields{
+				repo: mocks.NewMockSubscriberRepo(gomock.NewController(t)),
+			},
+			args: args{
+				ctx: context.Background(),
+				f:   subscriber.Filter{},
+			},
+			setup: func(t *testing.T, r SubscriberRepo) {
+				t.Helper()
+				rr, ok := r.(*mocks.MockSubscriberRepo)
+				if !ok {
+					t.Fatal("failed to cast repo to mock")
+				}
+				rr.EXPECT().
+					Get(gomock.Any(), subscriber.Filter{Channel: subscriber.ChannelEmail, Limit: defaultLimit + 1}).
+					Times(1).
+					Return(subs, nil)
+			},
+			want:    subscriber.Page{Subscribers: subs},
+			wantErr: false,
+		},
+		{
+			name: "Should cap limit with maximum",
+			fields: fields{
+				repo: mocks.NewMockSubscriberRepo(gomock.NewController(t)),
+			},
+			args: args{
+				ctx: context.Background(),
+				f:   subscriber.Filter{Limit: maxLimit * 2},
+			},
+			setup: func(t *testing.T, r SubscriberRepo) {
+				t.Helper()
+				rr, ok := r.(*mocks.MockSubscriberRepo)
+				if !ok {
+					t.Fatal("failed to cast repo to mock")
+				}
+				rr.EXPECT().
+					Get(gomock.Any(), subscriber.Filter{Channel: subscriber.ChannelEmail, Limit: maxLimit + 1}).
+					Times(1).
+					Return(subs, nil)
+			},
+			want:    subscriber.Page{Subscribers: subs},
+			wantErr: false,
+		},
+		{
+			name: "Should return error when repo failed",
+			fields: fields{
+				repo: mocks.NewMockSubscriberRepo(gomock.NewController(t)),
+			},
+			args: args{
+				ctx: context.Background(),
+				f:   subscriber.Filter{Limit: 2},
+			},
+			setup: func(t *testing.T, r SubscriberRepo) {
+				t.Helper()
+				rr, ok := r.(*mocks.MockSubscriberRepo)
+				if !ok {
+					t.Fatal("failed to cast repo to mock")
+				}
+				rr.EXPECT().
+					Get(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil, errors.New("failed to get subscribers"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			tt.setup(t, tt.fields.repo)
+			s := &Service{
+				repo: tt.fields.repo,
+			}
+			got, err := s.ListSubscribers(tt.args.ctx, tt.args.f)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Service.ListSubscribers() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Service.ListSubscribers() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestServiceGetSubscriber(t *testing.T) {
+	t.Parallel()
+	errAddresses := errors.New("failed to get addresses")
+	type fields struct {
+		repo SubscriberRepo
+	}
+	type args struct {
+		ctx context.Context
+		id  int64
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		setup   func(t *testing.T, r SubscriberRepo)
+		want    subscriber.Subscriber
+		wantErr error
+	}{
+		{
+			name: "Should return subscriber when it exists",
+			fields: fields{
+				repo: mocks.NewMockSubscriberRepo(gomock.NewController(t)),
+			},
+			args: args{
+				ctx: context.Background(),
+				id:  1,
+			},
+			setup: func(t *testing.T, r SubscriberRepo) {
+				t.Helper()
+				rr, ok := r.(*mocks.MockSubscriberRepo)
+				if !ok {
+					t.Fatal("failed to cast repo to mock")
+				}
+				rr.EXPECT().
+					GetByID(gomock.Any(), int64(1)).
+					Times(1).
+					Return(subscriber.Subscriber{ID: 1, Email: "test@test.com"}, nil)
+				rr.EXPECT().
+					GetAddresses(gomock.Any(), int64(1)).
+					Times(1).
+					Return([]subscriber.Address{{SubscriberID: 1, Channel: subscriber.ChannelTelegram, Address: "42"}}, nil)
+			},
+			want: subscriber.Subscriber{
+				ID:        1,
+				Email:     "test@test.com",
+				Addresses: []subscriber.Address{{SubscriberID: 1, Channel: subscriber.ChannelTelegram, Address: "42"}},
+			},
+		},
+		{
+			name: "Should return error when addresses can't be loaded",
+			fields: fields{
+				repo: mocks.NewMockSubscriberRepo(gomock.NewController(t)),
+			},
+			args: args{
+				ctx: context.Background(),
+				id:  1,
+			},
+			setup: func(t *testing.T, r SubscriberRepo) {
+				t.Helper()
+				rr, ok := r.(*mocks.MockSubscriberRepo)
+				if !ok {
+					t.Fatal("failed to cast repo to mock")
+				}
+				rr.EXPECT().
+					GetByID(gomock.Any(), int64(1)).
+					Times(1).
+					Return(subscriber.Subscriber{ID: 1, Email: "test@test.com"}, nil)
+				rr.EXPECT().
+					GetAddresses(gomock.Any(), int64(1)).
+					Times(1).
+					Return(nil, errAddresses)
+			},
+			wantErr: errAddresses,
+		},
+		{
+			name: "Should return not found error when subscriber doesn't exist",
+			fields: fields{
+				repo: mocks.NewMockSubscriberRepo(gomock.NewController(t)),
+			},
+			args: args{
+				ctx: context.Background(),
+				id:  2,
+			},
+			setup: func(t *testing.T, r SubscriberRepo) {
+				t.Helper()
+				rr, ok := r.(*mocks.MockSubscriberRepo)
+				if !ok {
+					t.Fatal("failed to cast repo to mock")
+				}
+				rr.EXPECT().
+					GetByID(gomock.Any(), int64(2)).
+					Times(1).
+					Return(subscriber.Subscriber{}, subscriber.ErrNotFound)
+			},
+			wantErr: subscriber.ErrNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			tt.setup(t, tt.fields.repo)
+			s := &Service{
+				repo: tt.fields.repo,
+			}
+			got, err := s.GetSubscriber(tt.args.ctx, tt.args.id)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Service.GetSubscriber() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Service.GetSubscriber() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestServiceDeleteSubscriber(t *testing.T) {
+	t.Parallel()
+	type fields struct {
+		repo SubscriberRepo
+	}
+	type args struct {
+		ctx context.Context
+		id  int64
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		setup   func(t *testing.T, r SubscriberRepo)
+		wantErr error
+	}{
+		{
+			name: "Should unsubscribe subscriber when it exists",
+			fields: fields{
+				repo: mocks.NewMockSubscriberRepo(gomock.NewController(t)),
+			},
+			args: args{
+				ctx: context.Background(),
+				id:  1,
+			},
+			setup: func(t *testing.T, r SubscriberRepo) {
+				t.Helper()
+				rr, ok := r.(*mocks.MockSubscriberRepo)
+				if !ok {
+					t.Fatal("failed to cast repo to mock")
+				}
+				rr.EXPECT().Unsubscribe(gomock.Any(), int64(1)).Times(1).Return(nil)
+			},
+		},
+		{
+			name: "Should return not found error when subscriber doesn't exist",
+			fields: fields{
+				repo: mocks.NewMockSubscriberRepo(gomock.NewController(t)),
+			},
+			args: args{
+				ctx: context.Background(),
+				id:  2,
+			},
+			setup: func(t *testing.T, r SubscriberRepo) {
+				t.Helper()
+				rr, ok := r.(*mocks.MockSubscriberRepo)
+				if !ok {
+					t.Fatal("failed to cast repo to mock")
+				}
+				rr.EXPECT().Unsubscribe(gomock.Any(), int64(2)).Times(1).Return(subscriber.ErrNotFound)
+			},
+			wantErr: subscriber.ErrNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			tt.setup(t, tt.fields.repo)
+			s := &Service{
+				repo: tt.fields.repo,
+			}
+			if err := s.DeleteSubscriber(tt.args.ctx, tt.args.id); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Service.DeleteSubscriber() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestServiceCountSubscribers(t *testing.T) {
+	t.Parallel()
+	type fields struct {
+		repo SubscriberRepo
+	}
+	type args struct {
+		ctx context.Context
+		f   subscriber.Filter
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		setup   func(t *testing.T, r SubscriberRepo)
+		want    int64
+		wantErr bool
+	}{
+		{
+			name: "Should return number of matching subscribers",
+			fields: fields{
+				repo: mocks.NewMockSubscriberRepo(gomock.NewController(t)),
+			},
+			args: args{
+				ctx: context.Background(),
+				f:   subscriber.Filter{Status: subscriber.StatusActive},
+			},
+			setup: func(t *testing.T, r SubscriberRepo) {
+				t.Helper()
+				rr, ok := r.(*mocks.MockSubscriberRepo)
+				if !ok {
+					t.Fatal("failed to cast repo to mock")
+				}
+				rr.EXPECT().
+					Count(gomock.Any(), subscriber.Filter{Channel: subscriber.ChannelEmail, Status: subscriber.StatusActive}).
+					Times(1).
+					Return(int64(42), nil)
+			},
+			want:    42,
+			wantErr: false,
+		},
+		{
+			name: "Should return error when repo failed",
+			fields: fields{
+				repo: mocks.NewMockSubscriberRepo(gomock.NewController(t)),
+			},
+			args: args{
+				ctx: context.Background(),
+				f:   subscriber.Filter{},
+			},
+			setup: func(t *testing.T, r SubscriberRepo) {
+				t.Helper()
+				rr, ok := r.(*mocks.MockSubscriberRepo)
+				if !ok {
+					t.Fatal("failed to cast repo to mock")
+				}
+				rr.EXPECT().
+					Count(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(int64(0), errors.New("failed to count"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			tt.setup(t, tt.fields.repo)
+			s := &Service{
+				repo: tt.fields.repo,
+			}
+			got, err := s.CountSubscribers(tt.args.ctx, tt.args.f)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Service.CountSubscribers() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("Service.CountSubscribers() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestServiceAddAddress(t *testing.T) {
+	t.Parallel()
+	type fields struct {
+		repo SubscriberRepo
+	}
+	type args struct {
+		ctx context.Context
+		a   subscriber.Address
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		setup   func(t *testing.T, r SubscriberRepo)
+		wantErr error
+	}{
+		{
+			name: "Should save normalised email address",
+			fields: fields{
+				repo: mocks.NewMockSubscriberRepo(gomock.NewController(t)),
+			},
+			args: args{
+				ctx: context.Background(),
+				a:   subscriber.Address{SubscriberID: 1, Channel: subscriber.ChannelEmail, Address: " Test@Test.com "},
+			},
+			setup: func(t *testing.T, r SubscriberRepo) {
+				t.Helper()
+				rr, ok := r.(*mocks.MockSubscriberRepo)
+				if !ok {
+					t.Fatal("failed to cast repo to mock")
+				}
+				rr.EXPECT().
+					SaveAddress(gomock.Any(), subscriber.Address{
+						SubscriberID: 1,
+						Channel:      subscriber.ChannelEmail,
+						Address:      "test@test.com",
+					}).
+					Times(1).
+					Return(nil)
+			},
+		},
+		{
+			name: "Should save telegram chat ID",
+			fields: fields{
+				repo: mocks.NewMockSubscriberRepo(gomock.NewController(t)),
+			},
+			args: args{
+				ctx: context.Background(),
+				a:   subscriber.Address{SubscriberID: 1, Channel: subscriber.ChannelTelegram, Address: "-100123"},
+			},
+			setup: func(t *testing.T, r SubscriberRepo) {
+				t.Helper()
+				rr, ok := r.(*mocks.MockSubscriberRepo)
+				if !ok {
+					t.Fatal("failed to cast repo to mock")
+				}
+				rr.EXPECT().
+					SaveAddress(gomock.Any(), subscriber.Address{
+						SubscriberID: 1,
+						Channel:      subscriber.ChannelTelegram,
+						Address:      "-100123",
+					}).
+					Times(1).
+					Return(nil)
+			},
+		},
+		{
+			name: "Should save slack webhook URL",
+			fields: fields{
+				repo: mocks.NewMockSubscriberRepo(gomock.NewController(t)),
+			},
+			args: args{
+				ctx: context.Background(),
+				a: subscriber.Address{
+					SubscriberID: 1,
+					Channel:      subscriber.ChannelSlack,
+					Address:      "https://hooks.slack.com/services/T/B/X",
+				},
+			},
+			setup: func(t *testing.T, r SubscriberRepo) {
+				t.Helper()
+				rr, ok := r.(*mocks.MockSubscriberRepo)
+				if !ok {
+					t.Fatal("failed to cast repo to mock")
+				}
+				rr.EXPECT().SaveAddress(gomock.Any(), gomock.Any()).Times(1).Return(nil)
+			},
+		},
+		{
+			name: "Should return error when channel is webhook",
+			fields: fields{
+				repo: mocks.NewMockSubscriberRepo(gomock.NewController(t)),
+			},
+			args: args{
+				ctx: context.Background(),
+				a:   subscriber.Address{SubscriberID: 1, Channel: subscriber.ChannelWebhook, Address: "https://test.com"},
+			},
+			setup:   func(t *testing.T, _ SubscriberRepo) { t.Helper() },
+			wantErr: ErrInvalidChannel,
+		},
+		{
+			name: "Should return error when email is invalid",
+			fields: fields{
+				repo: mocks.NewMockSubscriberRepo(gomock.NewController(t)),
+			},
+			args: args{
+				ctx: context.Background(),
+				a:   subscriber.Address{SubscriberID: 1, Channel: subscriber.ChannelEmail, Address: "Test <test@test.com>"},
+			},
+			setup:   func(t *testing.T, _ SubscriberRepo) { t.Helper() },
+			wantErr: ErrInvalidAddress,
+		},
+		{
+			name: "Should return error when chat ID isn't integer",
+			fields: fields{
+				repo: mocks.NewMockSubscriberRepo(gomock.NewController(t)),
+			},
+			args: args{
+				ctx: context.Background(),
+				a:   subscriber.Address{SubscriberID: 1, Channel: subscriber.ChannelTelegram, Address: "@channel"},
+			},
+			setup:   func(t *testing.T, _ SubscriberRepo) { t.Helper() },
+			wantErr: ErrInvalidAddress,
+		},
+		{
+			name: "Should return error when discord URL isn't https",
+			fields: fields{
+				repo: mocks.NewMockSubscriberRepo(gomock.NewController(t)),
+			},
+			args: args{
+				ctx: context.Background(),
+				a:   subscriber.Address{SubscriberID: 1, Channel: subscriber.ChannelDiscord, Address: "http://discord.com/api"},
+			},
+			setup:   func(t *testing.T, _ SubscriberRepo) { t.Helper() },
+			wantErr: ErrInvalidAddress,
+		},
+		{
+			name: "Should return error when subscriber already has address of the channel",
+			fields: fields{
+				repo: mocks.NewMockSubscriberRepo(gomock.NewController(t)),
+			},
+			args: args{
+				ctx: context.Background(),
+				a:   subscriber.Address{SubscriberID: 1, Channel: subscriber.ChannelTelegram, Address: "42"},
+			},
+			setup: func(t *testing.T, r SubscriberRepo) {
+				t.Helper()
+				rr, ok := r.(*mocks.MockSubscriberRepo)
+				if !ok {
+					t.Fatal("failed to cast repo to mock")
+				}
+				rr.EXPECT().SaveAddress(gomock.Any(), gomock.Any()).Times(1).Return(subscriber.ErrAddressExists)
+			},
+			wantErr: subscriber.ErrAddressExists,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			tt.setup(t, tt.fields.repo)
+			s := &Service{
+				repo: tt.fields.repo,
+			}
+			if err := s.AddAddress(tt.args.ctx, tt.args.a); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Service.AddAddress() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestServiceRemoveAddress(t *testing.T) {
+	t.Parallel()
+	type fields struct {
+		repo SubscriberRepo
+	}
+	type args struct {
+		ctx context.Context
+		id  int64
+		c   subscriber.Channel
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		setup   func(t *testing.T, r SubscriberRepo)
+		wantErr error
+	}{
+		{
+			name: "Should delete address when it exists",
+			fields: fields{
+				repo: mocks.NewMockSubscriberRepo(gomock.NewController(t)),
+			},
+			args: args{
+				ctx: context.Background(),
+				id:  1,
+				c:   subscriber.ChannelTelegram,
+			},
+			setup: func(t *testing.T, r SubscriberRepo) {
+				t.Helper()
+				rr, ok := r.(*mocks.MockSubscriberRepo)
+				if !ok {
+					t.Fatal("failed to cast repo to mock")
+				}
+				rr.EXPECT().DeleteAddress(gomock.Any(), int64(1), subscriber.ChannelTelegram).Times(1).Return(nil)
+			},
+		},
+		{
+			name: "Should return error when channel is invalid",
+			fields: fields{
+				repo: mocks.NewMockSubscriberRepo(gomock.NewController(t)),
+			},
+			args: args{
+				ctx: context.Background(),
+				id:  1,
+				c:   "sms",
+			},
+			setup:   func(t *testing.T, _ SubscriberRepo) { t.Helper() },
+			wantErr: ErrInvalidChannel,
+		},
+		{
+			name: "Should return not found error when address doesn't exist",
+			fields: fields{
+				repo: mocks.NewMockSubscriberRepo(gomock.NewController(t)),
+			},
+			args: args{
+				ctx: context.Background(),
+				id:  2,
+				c:   subscriber.ChannelSlack,
+			},
+			setup: func(t *testing.T, r SubscriberRepo) {
+				t.Helper()
+				rr, ok := r.(*mocks.MockSubscriberRepo)
+				if !ok {
+					t.Fatal("failed to cast repo to mock")
+				}
+				rr.EXPECT().
+					DeleteAddress(gomock.Any(), int64(2), subscriber.ChannelSlack).
+					Times(1).
+					Return(subscriber.ErrAddressNotFound)
+			},
+			wantErr: subscriber.ErrAddressNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			tt.setup(t, tt.fields.repo)
+			s := &Service{
+				repo: tt.fields.repo,
+			}
+			if err := s.RemoveAddress(tt.args.ctx, tt.args.id, tt.args.c); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Service.RemoveAddress() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,132 @@
+package canonical
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"golang.org/x/net/idna"
+)
+
+// ErrInvalidEmail is returned when email couldn't be normalised.
+var ErrInvalidEmail = errors.New("invalid email")
+
+// version is a version of the normalisation, which isn't described by
+// the provider rules. It should be bumped, when Normalize changes, so
+// stored canonical emails are recomputed.
+const version = "v1"
+
+// Gmail is a provider rule for the Gmail addresses: dots in the
+// local part are ignored, everything after plus is a tag and
+// googlemail.com is an alias of the gmail.com.
+var Gmail = ProviderRule{
+	Domains:   []string{"gmail.com", "googlemail.com"},
+	Domain:    "gmail.com",
+	StripDots: true,
+	StripTag:  true,
+}
+
+// ProviderRule describes how provider treats addresses, which
+// are delivered to the same mailbox.
+type ProviderRule struct {
+	// Domains are the punycode domains the rule applies to.
+	Domains []string
+	// Domain replaces any of the Domains when it's not empty.
+	Domain string
+	// StripDots removes dots from the local part.
+	StripDots bool
+	// StripTag removes plus sign and everything after it
+	// from the local part.
+	StripTag bool
+}
+
+// New constructs new Normalizer with provided provider rules.
+// NOTE: rules are optional, without them only case and
+// international domain are normalised.
+func New(rules ...ProviderRule) *Normalizer {
+	byDomain := make(map[string]ProviderRule)
+	for _, r := range rules {
+		for _, d := range r.Domains {
+			byDomain[d] = r
+		}
+	}
+
+	return &Normalizer{
+		rules: byDomain,
+	}
+}
+
+// Normalizer builds canonical form of the email, so addresses
+// delivered to the same mailbox are detected as duplicates.
+type Normalizer struct {
+	rules map[string]ProviderRule
+}
+
+// Normalize method returns canonical form of the email. Email is
+// lowercased, international domain is converted to the punycode
+// and provider rules are applied. Local part is lowercased as well,
+// because virtually all of the providers ignore its case.
+func (n *Normalizer) Normalize(email string) (string, error) {
+	at := strings.LastIndexByte(email, '@')
+	if at <= 0 || at == len(email)-1 {
+		return "", fmt.Errorf("%w: %q", ErrInvalidEmail, email)
+	}
+
+	local := strings.ToLower(strings.TrimSpace(email[:at]))
+	domain, err := idna.Lookup.ToASCII(strings.TrimSuffix(strings.TrimSpace(email[at+1:]), "."))
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", ErrInvalidEmail, err)
+	}
+
+	if r, ok := n.rules[domain]; ok {
+		local, domain = r.apply(local, domain)
+	}
+
+	if local == "" {
+		return "", fmt.Errorf("%w: %q", ErrInvalidEmail, email)
+	}
+
+	return local + "@" + domain, nil
+}
+
+// Rules method returns identifier of the normalisation and its provider
+// rules. It changes, when the rules change, so canonical emails computed
+// with the other rules could be detected and recomputed.
+func (n *Normalizer) Rules() string {
+	rules := make([]string, 0, len(n.rules))
+	for _, r := range n.rules {
+		rules = append(rules, r.String())
+	}
+	slices.Sort(rules)
+
+	return strings.Join(slices.Insert(slices.Compact(rules), 0, version), ";")
+}
+
+// String method returns description of the rule, which
+// differs for the rules treating addresses differently.
+func (r ProviderRule) String() string {
+	return fmt.Sprintf(
+		"%s>%s,dots=%t,tag=%t",
+		strings.Join(r.Domains, ","),
+		r.Domain,
+		r.StripDots,
+		r.StripTag,
+	)
+}
+
+func (r ProviderRule) apply(local, domain string) (string, string) {
+	if r.StripTag {
+		local, _, _ = strings.Cut(local, "+")
+	}
+
+	if r.StripDots {
+		local = strings.ReplaceAll(local, ".", "")
+	}
+
+	if r.Domain != "" {
+		domain = r.Domain
+	}
+
+	return local, domain
+}
@@ -0,0 +1,132 @@
+package canonical
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestNormalizerNormalize(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		rules   []ProviderRule
+		email   string
+		want    string
+		wantErr bool
+	}{
+		{
+			name:  "Should lowercase email",
+			email: "Alice@Example.COM",
+			want:  "alice@example.com",
+		},
+		{
+			name:  "Should trim spaces and trailing dot of the domain",
+			email: " alice@example.com. ",
+			want:  "alice@example.com",
+		},
+		{
+			name:  "Should convert international domain to the punycode",
+			email: "Alice@Приклад.Укр",
+			want:  "alice@xn--80aikifvh.xn--j1amh",
+		},
+		{
+			name:  "Should keep punycode domain as is",
+			email: "alice@xn--80aikifvh.xn--j1amh",
+			want:  "alice@xn--80aikifvh.xn--j1amh",
+		},
+		{
+			name:  "Should keep dots and tags when there're no provider rules",
+			email: "a.lice+news@gmail.com",
+			want:  "a.lice+news@gmail.com",
+		},
+		{
+			name:  "Should strip dots and tags of the gmail address",
+			rules: []ProviderRule{Gmail},
+			email: "A.Lice+News@GMail.com",
+			want:  "alice@gmail.com",
+		},
+		{
+			name:  "Should replace googlemail domain with gmail one",
+			rules: []ProviderRule{Gmail},
+			email: "a.lice@googlemail.com",
+			want:  "alice@gmail.com",
+		},
+		{
+			name:  "Should not apply gmail rule to the other domains",
+			rules: []ProviderRule{Gmail},
+			email: "a.lice+news@example.com",
+			want:  "a.lice+news@example.com",
+		},
+		{
+			name:    "Should return error when local part is empty after the rules",
+			rules:   []ProviderRule{Gmail},
+			email:   "+news@gmail.com",
+			wantErr: true,
+		},
+		{
+			name:    "Should return error when there's no at sign",
+			email:   "alice.example.com",
+			wantErr: true,
+		},
+		{
+			name:    "Should return error when domain is empty",
+			email:   "alice@",
+			wantErr: true,
+		},
+		{
+			name:    "Should return error when domain is invalid",
+			email:   "alice@exa mple.com",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := New(tt.rules...).Normalize(tt.email)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Normalize() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr && !errors.Is(err, ErrInvalidEmail) {
+				t.Errorf("Normalize() error = %v, want %v", err, ErrInvalidEmail)
+			}
+			if got != tt.want {
+				t.Errorf("Normalize() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNormalizerRules(t *testing.T) {
+	t.Parallel()
+	other := ProviderRule{Domains: []string{"example.com"}, StripTag: true}
+	tests := []struct {
+		name  string
+		rules []ProviderRule
+		want  string
+	}{
+		{
+			name: "Should return version only when there're no provider rules",
+			want: "v1",
+		},
+		{
+			name:  "Should describe provider rule once for all of its domains",
+			rules: []ProviderRule{Gmail},
+			want:  "v1;gmail.com,googlemail.com>gmail.com,dots=true,tag=true",
+		},
+		{
+			name:  "Should not depend on the order of the rules",
+			rules: []ProviderRule{other, Gmail},
+			want:  New(Gmail, other).Rules(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := New(tt.rules...).Rules(); got != tt.want {
+				t.Errorf("Rules() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
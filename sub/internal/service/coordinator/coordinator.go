@@ -0,0 +1,301 @@
+package coordinator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/hrvadl/converter/sub/internal/storage/jobrun"
+)
+
+const operation = "daily run coordinator"
+
+const (
+	// leaseTTL is a period after which run of the crashed
+	// replica is taken over by another one.
+	leaseTTL = time.Minute * 2
+	// renewInterval is an interval between the renewals of the lease
+	// of the run in progress. It's several times shorter than leaseTTL,
+	// so a single slow renewal doesn't let the lease expire.
+	renewInterval = leaseTTL / 4
+	// retryInterval is an interval between the attempts to
+	// acquire the run, which is held by another replica or failed.
+	retryInterval = time.Second * 30
+	// queryTimeout is a timeout of the single lease query.
+	queryTimeout = time.Second * 10
+)
+
+// NewDailyRun constructs DailyRun, which coordinates the job between
+// replicas. Owner should uniquely identify the replica. Loc is the
+// location of the job's schedule, which days of the runs are counted in.
+// NOTE: neither of arguments can't be nil, or service will panic in the future.
+func NewDailyRun(name, owner string, loc *time.Location, rl RunLocker, j Job, log *slog.Logger) *DailyRun {
+	return &DailyRun{
+		name:          name,
+		owner:         owner,
+		loc:           loc,
+		locker:        rl,
+		job:           j,
+		log:           log,
+		leaseTTL:      leaseTTL,
+		renewInterval: renewInterval,
+		retryInterval: retryInterval,
+		now:           time.Now,
+		stop:          make(chan struct{}),
+	}
+}
+
+//go:generate mockgen -destination=./mocks/mock_locker.go -package=mocks . RunLocker
+type RunLocker interface {
+	Acquire(ctx context.Context, name string, day time.Time, owner string, ttl time.Duration) (int64, error)
+	Renew(ctx context.Context, name string, day time.Time, token int64, ttl time.Duration) error
+	Complete(ctx context.Context, name string, day time.Time, token int64) error
+	GetLastDone(ctx context.Context, name string) (jobrun.Run, error)
+}
+
+//go:generate mockgen -destination=./mocks/mock_job.go -package=mocks . Job
+type Job interface {
+	DoAt(ctx context.Context, at time.Time) error
+}
+
+// Schedule is a schedule of the job, which is used
+// to find out runs, missed while all replicas were down.
+type Schedule interface {
+	Next(t time.Time) time.Time
+}
+
+// DailyRun is a wrapper around the job, which makes sure it's
+// done exactly once a day across all replicas. Replica, which acquired
+// the lease, renews it while doing the job and marks the run as done.
+// Other replicas wait until the run is done, so if the leader crashes,
+// its run is taken over after the lease expires. If the lease couldn't
+// be renewed, the job is cancelled, since the run could be taken over.
+// Day of the run is the one in the schedule's location, so the run
+// scheduled after the local midnight belongs to the next day, even if
+// it's still the previous day in UTC.
+type DailyRun struct {
+	name          string
+	owner         string
+	loc           *time.Location
+	locker        RunLocker
+	job           Job
+	log           *slog.Logger
+	leaseTTL      time.Duration
+	renewInterval time.Duration
+	retryInterval time.Duration
+	now           func() time.Time
+
+	stopOnce sync.Once
+	stop     chan struct{}
+}
+
+// Stop method stops waiting for the runs, so Do and CatchUp return
+// right away instead of the next attempt, while the job in progress,
+// if any, is finished. Hence replica doesn't wait for the run held by
+// another one until it's cancelled on shutdown.
+func (d *DailyRun) Stop() {
+	d.stopOnce.Do(func() { close(d.stop) })
+}
+
+// Do method tries to acquire today's run and do the job. If the run is
+// already done, it's skipped. If it's held by another replica or the job
+// has failed, Do retries until the run is done, the day is over, ctx is
+// done or Stop is called. Returns an error only if the run wasn't done by
+// then. Unfinished run is taken over by another replica after its lease
+// expires.
+func (d *DailyRun) Do(ctx context.Context) error {
+	now := d.now()
+	return d.runAt(ctx, now, d.endOfDay(now))
+}
+
+// CatchUp method finds out runs of the schedule, which were missed since
+// the last done run, i.e. when all replicas were down at the scheduled time.
+// The latest missed run is done once, if it was scheduled within the grace
+// window, and older ones are only logged, so subscribers don't receive
+// a mail per each missed day. If the job has never been done, there's
+// nothing to catch up. Should be called on start-up.
+func (d *DailyRun) CatchUp(ctx context.Context, s Schedule, grace time.Duration) error {
+	qctx, cancel := context.WithTimeout(ctx, queryTimeout)
+	defer cancel()
+
+	last, err := d.locker.GetLastDone(qctx, d.name)
+	if errors.Is(err, jobrun.ErrNotFound) {
+		d.log.Info("Job has never been done, nothing to catch up", "job", d.name)
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("%s: failed to get last done run: %w", operation, err)
+	}
+
+	now := d.now()
+	m := d.findMissed(s, last.RunDate, now)
+	if m.count == 0 {
+		d.log.Info("There're no missed runs", "job", d.name, "lastRun", last.RunDate)
+		return nil
+	}
+
+	if now.Sub(m.latest) > grace {
+		d.log.Warn(
+			"Missed runs are out of the grace window, skipping",
+			"job", d.name,
+			"missed", m.count,
+			"first", m.first,
+			"latest", m.latest,
+			"grace", grace,
+		)
+		return nil
+	}
+
+	d.log.Info(
+		"Catching up missed run",
+		"job", d.name,
+		"missed", m.count,
+		"first", m.first,
+		"at", m.latest,
+	)
+	return d.runAt(ctx, m.latest, d.endOfDay(now))
+}
+
+// runAt method does the run scheduled at the given point of time,
+// retrying until the deadline, until ctx is done or Stop is called. Run
+// is keyed by the day of that point of time in the schedule's location,
+// so caught up run doesn't clash with today's one. Job receives the
+// point of time in that location too.
+func (d *DailyRun) runAt(ctx context.Context, at, deadline time.Time) error {
+	at = at.In(d.loc)
+	day := dateOf(at)
+	for {
+		token, err := d.acquire(ctx, day)
+		switch {
+		case errors.Is(err, jobrun.ErrDone):
+			d.log.Info("Run is already done, skipping", "job", d.name)
+			return nil
+		case errors.Is(err, jobrun.ErrHeld):
+			d.log.Info("Run is held by another replica, waiting", "job", d.name)
+		case err != nil:
+			d.log.Error("Failed to acquire run", "job", d.name, "err", err)
+		default:
+			if err = d.run(ctx, day, at, token); err == nil {
+				return nil
+			}
+			d.log.Error("Failed to do run, retrying", "job", d.name, "err", err)
+		}
+
+		if !d.now().Before(deadline) {
+			return fmt.Errorf("%s: run of %s wasn't done by the end of the day", operation, d.name)
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%s: run of %s was interrupted: %w", operation, d.name, ctx.Err())
+		case <-d.stop:
+			return fmt.Errorf("%s: run of %s was stopped", operation, d.name)
+		case <-time.After(d.retryInterval):
+		}
+	}
+}
+
+func (d *DailyRun) acquire(ctx context.Context, day time.Time) (int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, queryTimeout)
+	defer cancel()
+	return d.locker.Acquire(ctx, d.name, day, d.owner, d.leaseTTL)
+}
+
+// run does the job at the given point of time, while the lease of the
+// day is renewed, and marks the run as done. Job is cancelled, if the
+// lease couldn't be renewed. Run is marked even if ctx is cancelled
+// after the job has finished, so it isn't taken over in vain.
+func (d *DailyRun) run(ctx context.Context, day, at time.Time, token int64) error {
+	jobCtx, cancelJob := context.WithCancel(ctx)
+	defer cancelJob()
+
+	var renewErr error
+	renewed := make(chan struct{})
+	go func() {
+		defer close(renewed)
+		if renewErr = d.renew(jobCtx, day, token); renewErr != nil {
+			cancelJob()
+		}
+	}()
+
+	err := d.job.DoAt(jobCtx, at)
+	cancelJob()
+	<-renewed
+
+	if renewErr != nil {
+		return fmt.Errorf("failed to renew lease: %w", renewErr)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to do job: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), queryTimeout)
+	defer cancel()
+
+	if err := d.locker.Complete(ctx, d.name, day, token); err != nil {
+		return fmt.Errorf("failed to complete run: %w", err)
+	}
+
+	return nil
+}
+
+// renew extends the lease every renewInterval until ctx is done.
+// Returns an error, if the lease was lost or couldn't be renewed.
+func (d *DailyRun) renew(ctx context.Context, day time.Time, token int64) error {
+	ticker := time.NewTicker(d.renewInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+
+		qctx, cancel := context.WithTimeout(ctx, queryTimeout)
+		err := d.locker.Renew(qctx, d.name, day, token, d.leaseTTL)
+		cancel()
+		if err != nil && ctx.Err() == nil {
+			return err
+		}
+	}
+}
+
+// missed describes runs of the schedule, which were missed.
+type missed struct {
+	count  int
+	first  time.Time
+	latest time.Time
+}
+
+// findMissed method finds runs of the schedule from the day after
+// the last done run up until now, both ends inclusive.
+func (d *DailyRun) findMissed(s Schedule, lastDay, now time.Time) missed {
+	var m missed
+	lastDay = lastDay.UTC()
+	from := time.Date(lastDay.Year(), lastDay.Month(), lastDay.Day()+1, 0, 0, 0, 0, d.loc).Add(-time.Nanosecond)
+	for t := s.Next(from); !t.IsZero() && !t.After(now); t = s.Next(t) {
+		if m.count == 0 {
+			m.first = t
+		}
+		m.count++
+		m.latest = t
+	}
+	return m
+}
+
+// endOfDay method returns the start of the next day
+// after t in the schedule's location.
+func (d *DailyRun) endOfDay(t time.Time) time.Time {
+	t = t.In(d.loc)
+	return time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, d.loc)
+}
+
+// dateOf returns the day of t in its location as a UTC midnight,
+// which runs are keyed by.
+func dateOf(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
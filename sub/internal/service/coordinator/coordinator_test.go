@@ -0,0 +1,661 @@
+package coordinator
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"reflect"
+	"testing"
+	"time"
+
+	"go.uber.org/mock/gomock"
+
+	"github.com/hrvadl/converter/sub/internal/service/coordinator/mocks"
+	"github.com/hrvadl/converter/sub/internal/service/cron"
+	"github.com/hrvadl/converter/sub/internal/storage/jobrun"
+)
+
+func TestNewDailyRun(t *testing.T) {
+	t.Parallel()
+	type args struct {
+		name  string
+		owner string
+		loc   *time.Location
+		rl    RunLocker
+		j     Job
+		log   *slog.Logger
+	}
+	tests := []struct {
+		name string
+		args args
+		want *DailyRun
+	}{
+		{
+			name: "Should construct daily run with default intervals when correct arguments are provided",
+			args: args{
+				name:  "enqueue",
+				owner: "replica-1",
+				loc:   time.UTC,
+				rl:    mocks.NewMockRunLocker(gomock.NewController(t)),
+				j:     mocks.NewMockJob(gomock.NewController(t)),
+				log:   slog.Default(),
+			},
+			want: &DailyRun{
+				name:          "enqueue",
+				owner:         "replica-1",
+				loc:           time.UTC,
+				locker:        mocks.NewMockRunLocker(gomock.NewController(t)),
+				job:           mocks.NewMockJob(gomock.NewController(t)),
+				log:           slog.Default(),
+				leaseTTL:      leaseTTL,
+				renewInterval: renewInterval,
+				retryInterval: retryInterval,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := NewDailyRun(tt.args.name, tt.args.owner, tt.args.loc, tt.args.rl, tt.args.j, tt.args.log)
+			if got.now == nil {
+				t.Fatal("NewDailyRun() now func is nil")
+			}
+			if got.stop == nil {
+				t.Fatal("NewDailyRun() stop chan is nil")
+			}
+			got.now, got.stop = nil, nil
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewDailyRun() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDailyRunDo(t *testing.T) {
+	t.Parallel()
+	type fields struct {
+		locker RunLocker
+		job    Job
+	}
+	type mocked struct {
+		locker *mocks.MockRunLocker
+		job    *mocks.MockJob
+	}
+	newFields := func(t *testing.T) fields {
+		t.Helper()
+		return fields{
+			locker: mocks.NewMockRunLocker(gomock.NewController(t)),
+			job:    mocks.NewMockJob(gomock.NewController(t)),
+		}
+	}
+	cast := func(t *testing.T, f *fields) mocked {
+		t.Helper()
+		var (
+			m   mocked
+			ok1 bool
+			ok2 bool
+		)
+		m.locker, ok1 = f.locker.(*mocks.MockRunLocker)
+		m.job, ok2 = f.job.(*mocks.MockJob)
+		if !ok1 || !ok2 {
+			t.Fatal("failed to cast dependencies to mocks")
+		}
+		return m
+	}
+
+	const (
+		name  = "enqueue"
+		owner = "replica-1"
+	)
+	day := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	date := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	kyiv := time.FixedZone("Kyiv", 3*60*60)
+
+	tests := []struct {
+		name    string
+		fields  fields
+		loc     *time.Location
+		now     []time.Time
+		cancel  bool
+		stop    bool
+		renew   bool
+		setup   func(t *testing.T, f *fields)
+		wantErr bool
+	}{
+		{
+			name:   "Should do job and complete run when lease is acquired",
+			fields: newFields(t),
+			setup: func(t *testing.T, f *fields) {
+				t.Helper()
+				m := cast(t, f)
+				gomock.InOrder(
+					m.locker.EXPECT().
+						Acquire(gomock.Any(), name, date, owner, leaseTTL).
+						Times(1).
+						Return(int64(1), nil),
+					m.job.EXPECT().DoAt(gomock.Any(), day).Times(1).Return(nil),
+					m.locker.EXPECT().Complete(gomock.Any(), name, date, int64(1)).Times(1).Return(nil),
+				)
+			},
+			wantErr: false,
+		},
+		{
+			name:   "Should key run by the local day when it's still the previous day in UTC",
+			fields: newFields(t),
+			loc:    kyiv,
+			now:    []time.Time{time.Date(2024, 4, 30, 22, 30, 0, 0, time.UTC)},
+			setup: func(t *testing.T, f *fields) {
+				t.Helper()
+				m := cast(t, f)
+				at := time.Date(2024, 5, 1, 1, 30, 0, 0, kyiv)
+				gomock.InOrder(
+					m.locker.EXPECT().
+						Acquire(gomock.Any(), name, date, owner, leaseTTL).
+						Times(1).
+						Return(int64(1), nil),
+					m.job.EXPECT().DoAt(gomock.Any(), at).Times(1).Return(nil),
+					m.locker.EXPECT().Complete(gomock.Any(), name, date, int64(1)).Times(1).Return(nil),
+				)
+			},
+			wantErr: false,
+		},
+		{
+			name:   "Should skip job when run is already done",
+			fields: newFields(t),
+			setup: func(t *testing.T, f *fields) {
+				t.Helper()
+				m := cast(t, f)
+				m.locker.EXPECT().
+					Acquire(gomock.Any(), name, date, owner, leaseTTL).
+					Times(1).
+					Return(int64(0), jobrun.ErrDone)
+				m.job.EXPECT().DoAt(gomock.Any(), gomock.Any()).Times(0)
+			},
+			wantErr: false,
+		},
+		{
+			name:   "Should wait until run held by another replica is done",
+			fields: newFields(t),
+			setup: func(t *testing.T, f *fields) {
+				t.Helper()
+				m := cast(t, f)
+				gomock.InOrder(
+					m.locker.EXPECT().
+						Acquire(gomock.Any(), name, date, owner, leaseTTL).
+						Times(2).
+						Return(int64(0), jobrun.ErrHeld),
+					m.locker.EXPECT().
+						Acquire(gomock.Any(), name, date, owner, leaseTTL).
+						Times(1).
+						Return(int64(0), jobrun.ErrDone),
+				)
+				m.job.EXPECT().DoAt(gomock.Any(), gomock.Any()).Times(0)
+			},
+			wantErr: false,
+		},
+		{
+			name:   "Should take over run when lease of another replica has expired",
+			fields: newFields(t),
+			setup: func(t *testing.T, f *fields) {
+				t.Helper()
+				m := cast(t, f)
+				gomock.InOrder(
+					m.locker.EXPECT().
+						Acquire(gomock.Any(), name, date, owner, leaseTTL).
+						Times(1).
+						Return(int64(0), jobrun.ErrHeld),
+					m.locker.EXPECT().
+						Acquire(gomock.Any(), name, date, owner, leaseTTL).
+						Times(1).
+						Return(int64(2), nil),
+					m.job.EXPECT().DoAt(gomock.Any(), day).Times(1).Return(nil),
+					m.locker.EXPECT().Complete(gomock.Any(), name, date, int64(2)).Times(1).Return(nil),
+				)
+			},
+			wantErr: false,
+		},
+		{
+			name:   "Should retry job when it has failed",
+			fields: newFields(t),
+			setup: func(t *testing.T, f *fields) {
+				t.Helper()
+				m := cast(t, f)
+				gomock.InOrder(
+					m.locker.EXPECT().
+						Acquire(gomock.Any(), name, date, owner, leaseTTL).
+						Times(1).
+						Return(int64(1), nil),
+					m.job.EXPECT().DoAt(gomock.Any(), day).Times(1).Return(errors.New("failed to do job")),
+					m.locker.EXPECT().
+						Acquire(gomock.Any(), name, date, owner, leaseTTL).
+						Times(1).
+						Return(int64(2), nil),
+					m.job.EXPECT().DoAt(gomock.Any(), day).Times(1).Return(nil),
+					m.locker.EXPECT().Complete(gomock.Any(), name, date, int64(2)).Times(1).Return(nil),
+				)
+			},
+			wantErr: false,
+		},
+		{
+			name:   "Should not complete run when lease was lost",
+			fields: newFields(t),
+			setup: func(t *testing.T, f *fields) {
+				t.Helper()
+				m := cast(t, f)
+				gomock.InOrder(
+					m.locker.EXPECT().
+						Acquire(gomock.Any(), name, date, owner, leaseTTL).
+						Times(1).
+						Return(int64(1), nil),
+					m.job.EXPECT().DoAt(gomock.Any(), day).Times(1).Return(nil),
+					m.locker.EXPECT().
+						Complete(gomock.Any(), name, date, int64(1)).
+						Times(1).
+						Return(jobrun.ErrLeaseLost),
+					m.locker.EXPECT().
+						Acquire(gomock.Any(), name, date, owner, leaseTTL).
+						Times(1).
+						Return(int64(0), jobrun.ErrDone),
+				)
+			},
+			wantErr: false,
+		},
+		{
+			name:   "Should renew lease while job is running",
+			fields: newFields(t),
+			renew:  true,
+			setup: func(t *testing.T, f *fields) {
+				t.Helper()
+				m := cast(t, f)
+				renewed := make(chan struct{})
+				m.locker.EXPECT().
+					Renew(gomock.Any(), name, date, int64(1), leaseTTL).
+					MinTimes(1).
+					DoAndReturn(func(context.Context, string, time.Time, int64, time.Duration) error {
+						select {
+						case renewed <- struct{}{}:
+						default:
+						}
+						return nil
+					})
+				gomock.InOrder(
+					m.locker.EXPECT().
+						Acquire(gomock.Any(), name, date, owner, leaseTTL).
+						Times(1).
+						Return(int64(1), nil),
+					m.job.EXPECT().
+						DoAt(gomock.Any(), day).
+						Times(1).
+						DoAndReturn(func(context.Context, time.Time) error {
+							<-renewed
+							return nil
+						}),
+					m.locker.EXPECT().Complete(gomock.Any(), name, date, int64(1)).Times(1).Return(nil),
+				)
+			},
+			wantErr: false,
+		},
+		{
+			name:   "Should cancel job when lease couldn't be renewed",
+			fields: newFields(t),
+			renew:  true,
+			setup: func(t *testing.T, f *fields) {
+				t.Helper()
+				m := cast(t, f)
+				m.locker.EXPECT().
+					Renew(gomock.Any(), name, date, int64(1), leaseTTL).
+					Times(1).
+					Return(jobrun.ErrLeaseLost)
+				m.locker.EXPECT().Complete(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+				gomock.InOrder(
+					m.locker.EXPECT().
+						Acquire(gomock.Any(), name, date, owner, leaseTTL).
+						Times(1).
+						Return(int64(1), nil),
+					m.job.EXPECT().
+						DoAt(gomock.Any(), day).
+						Times(1).
+						DoAndReturn(func(ctx context.Context, _ time.Time) error {
+							<-ctx.Done()
+							return ctx.Err()
+						}),
+					m.locker.EXPECT().
+						Acquire(gomock.Any(), name, date, owner, leaseTTL).
+						Times(1).
+						Return(int64(0), jobrun.ErrDone),
+				)
+			},
+			wantErr: false,
+		},
+		{
+			name:   "Should return error when run wasn't done by the end of the day",
+			fields: newFields(t),
+			now:    []time.Time{day, day.Add(time.Hour * 12)},
+			setup: func(t *testing.T, f *fields) {
+				t.Helper()
+				m := cast(t, f)
+				m.locker.EXPECT().
+					Acquire(gomock.Any(), name, date, owner, leaseTTL).
+					Times(1).
+					Return(int64(0), errors.New("failed to connect"))
+				m.job.EXPECT().DoAt(gomock.Any(), gomock.Any()).Times(0)
+			},
+			wantErr: true,
+		},
+		{
+			name:   "Should stop waiting for run when context is done",
+			fields: newFields(t),
+			cancel: true,
+			setup: func(t *testing.T, f *fields) {
+				t.Helper()
+				m := cast(t, f)
+				m.locker.EXPECT().
+					Acquire(gomock.Any(), name, date, owner, leaseTTL).
+					Times(1).
+					Return(int64(0), jobrun.ErrHeld)
+				m.job.EXPECT().DoAt(gomock.Any(), gomock.Any()).Times(0)
+			},
+			wantErr: true,
+		},
+		{
+			name:   "Should stop waiting for run when daily run is stopped",
+			fields: newFields(t),
+			stop:   true,
+			setup: func(t *testing.T, f *fields) {
+				t.Helper()
+				m := cast(t, f)
+				m.locker.EXPECT().
+					Acquire(gomock.Any(), name, date, owner, leaseTTL).
+					Times(1).
+					Return(int64(0), jobrun.ErrHeld)
+				m.job.EXPECT().DoAt(gomock.Any(), gomock.Any()).Times(0)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			tt.setup(t, &tt.fields)
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			if tt.cancel {
+				cancel()
+			}
+			calls := 0
+			d := &DailyRun{
+				name:          name,
+				owner:         owner,
+				loc:           time.UTC,
+				locker:        tt.fields.locker,
+				job:           tt.fields.job,
+				log:           slog.Default(),
+				leaseTTL:      leaseTTL,
+				renewInterval: time.Hour,
+				retryInterval: time.Millisecond,
+				now: func() time.Time {
+					defer func() { calls++ }()
+					if calls < len(tt.now) {
+						return tt.now[calls]
+					}
+					return day
+				},
+				stop: make(chan struct{}),
+			}
+			if tt.stop {
+				d.retryInterval = time.Hour
+				d.Stop()
+			}
+			if tt.loc != nil {
+				d.loc = tt.loc
+			}
+			if tt.renew {
+				d.renewInterval = time.Millisecond
+			}
+
+			if err := d.Do(ctx); (err != nil) != tt.wantErr {
+				t.Errorf("DailyRun.Do() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestDailyRunCatchUp(t *testing.T) {
+	t.Parallel()
+	type fields struct {
+		locker RunLocker
+		job    Job
+	}
+	type mocked struct {
+		locker *mocks.MockRunLocker
+		job    *mocks.MockJob
+	}
+	newFields := func(t *testing.T) fields {
+		t.Helper()
+		return fields{
+			locker: mocks.NewMockRunLocker(gomock.NewController(t)),
+			job:    mocks.NewMockJob(gomock.NewController(t)),
+		}
+	}
+	cast := func(t *testing.T, f *fields) mocked {
+		t.Helper()
+		var (
+			m   mocked
+			ok1 bool
+			ok2 bool
+		)
+		m.locker, ok1 = f.locker.(*mocks.MockRunLocker)
+		m.job, ok2 = f.job.(*mocks.MockJob)
+		if !ok1 || !ok2 {
+			t.Fatal("failed to cast dependencies to mocks")
+		}
+		return m
+	}
+
+	const (
+		name  = "enqueue"
+		owner = "replica-1"
+	)
+	schedule := cron.MustParse("0 12 * * *", time.UTC)
+	scheduled := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	date := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	kyiv := time.FixedZone("Kyiv", 3*60*60)
+	lastRun := func(day time.Time) jobrun.Run {
+		return jobrun.Run{Name: name, RunDate: day, Status: jobrun.StatusDone}
+	}
+
+	tests := []struct {
+		name     string
+		fields   fields
+		schedule Schedule
+		now      time.Time
+		grace    time.Duration
+		setup    func(t *testing.T, f *fields)
+		wantErr  bool
+	}{
+		{
+			name:   "Should not catch up when job has never been done",
+			fields: newFields(t),
+			now:    scheduled.Add(time.Hour),
+			grace:  time.Hour * 12,
+			setup: func(t *testing.T, f *fields) {
+				t.Helper()
+				m := cast(t, f)
+				m.locker.EXPECT().
+					GetLastDone(gomock.Any(), name).
+					Times(1).
+					Return(jobrun.Run{}, jobrun.ErrNotFound)
+				m.locker.EXPECT().Acquire(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+				m.job.EXPECT().DoAt(gomock.Any(), gomock.Any()).Times(0)
+			},
+			wantErr: false,
+		},
+		{
+			name:   "Should not catch up when today's run isn't due yet",
+			fields: newFields(t),
+			now:    scheduled.Add(-time.Hour),
+			grace:  time.Hour * 12,
+			setup: func(t *testing.T, f *fields) {
+				t.Helper()
+				m := cast(t, f)
+				m.locker.EXPECT().
+					GetLastDone(gomock.Any(), name).
+					Times(1).
+					Return(lastRun(time.Date(2024, 4, 30, 0, 0, 0, 0, time.UTC)), nil)
+				m.job.EXPECT().DoAt(gomock.Any(), gomock.Any()).Times(0)
+			},
+			wantErr: false,
+		},
+		{
+			name:   "Should not catch up when today's run is already done",
+			fields: newFields(t),
+			now:    scheduled.Add(time.Hour),
+			grace:  time.Hour * 12,
+			setup: func(t *testing.T, f *fields) {
+				t.Helper()
+				m := cast(t, f)
+				m.locker.EXPECT().
+					GetLastDone(gomock.Any(), name).
+					Times(1).
+					Return(lastRun(time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)), nil)
+				m.job.EXPECT().DoAt(gomock.Any(), gomock.Any()).Times(0)
+			},
+			wantErr: false,
+		},
+		{
+			name:   "Should do the latest missed run once when several days were missed",
+			fields: newFields(t),
+			now:    scheduled.Add(time.Hour * 3),
+			grace:  time.Hour * 12,
+			setup: func(t *testing.T, f *fields) {
+				t.Helper()
+				m := cast(t, f)
+				gomock.InOrder(
+					m.locker.EXPECT().
+						GetLastDone(gomock.Any(), name).
+						Times(1).
+						Return(lastRun(time.Date(2024, 4, 27, 0, 0, 0, 0, time.UTC)), nil),
+					m.locker.EXPECT().
+						Acquire(gomock.Any(), name, date, owner, leaseTTL).
+						Times(1).
+						Return(int64(1), nil),
+					m.job.EXPECT().DoAt(gomock.Any(), scheduled).Times(1).Return(nil),
+					m.locker.EXPECT().Complete(gomock.Any(), name, date, int64(1)).Times(1).Return(nil),
+				)
+			},
+			wantErr: false,
+		},
+		{
+			name:   "Should do yesterday's missed run when it's within the grace window",
+			fields: newFields(t),
+			now:    scheduled.Add(-time.Hour * 2),
+			grace:  time.Hour * 24,
+			setup: func(t *testing.T, f *fields) {
+				t.Helper()
+				m := cast(t, f)
+				yesterday := scheduled.AddDate(0, 0, -1)
+				gomock.InOrder(
+					m.locker.EXPECT().
+						GetLastDone(gomock.Any(), name).
+						Times(1).
+						Return(lastRun(time.Date(2024, 4, 29, 0, 0, 0, 0, time.UTC)), nil),
+					m.locker.EXPECT().
+						Acquire(gomock.Any(), name, date.AddDate(0, 0, -1), owner, leaseTTL).
+						Times(1).
+						Return(int64(1), nil),
+					m.job.EXPECT().DoAt(gomock.Any(), yesterday).Times(1).Return(nil),
+					m.locker.EXPECT().Complete(gomock.Any(), name, date.AddDate(0, 0, -1), int64(1)).Times(1).Return(nil),
+				)
+			},
+			wantErr: false,
+		},
+		{
+			name:     "Should do missed run of the local day when it's still the previous day in UTC",
+			fields:   newFields(t),
+			schedule: cron.MustParse("30 1 * * *", kyiv),
+			now:      time.Date(2024, 4, 30, 23, 0, 0, 0, time.UTC),
+			grace:    time.Hour * 12,
+			setup: func(t *testing.T, f *fields) {
+				t.Helper()
+				m := cast(t, f)
+				at := time.Date(2024, 5, 1, 1, 30, 0, 0, kyiv)
+				gomock.InOrder(
+					m.locker.EXPECT().
+						GetLastDone(gomock.Any(), name).
+						Times(1).
+						Return(lastRun(time.Date(2024, 4, 30, 0, 0, 0, 0, time.UTC)), nil),
+					m.locker.EXPECT().
+						Acquire(gomock.Any(), name, date, owner, leaseTTL).
+						Times(1).
+						Return(int64(1), nil),
+					m.job.EXPECT().DoAt(gomock.Any(), at).Times(1).Return(nil),
+					m.locker.EXPECT().Complete(gomock.Any(), name, date, int64(1)).Times(1).Return(nil),
+				)
+			},
+			wantErr: false,
+		},
+		{
+			name:   "Should skip missed runs when they're out of the grace window",
+			fields: newFields(t),
+			now:    scheduled.Add(time.Hour * 3),
+			grace:  time.Hour,
+			setup: func(t *testing.T, f *fields) {
+				t.Helper()
+				m := cast(t, f)
+				m.locker.EXPECT().
+					GetLastDone(gomock.Any(), name).
+					Times(1).
+					Return(lastRun(time.Date(2024, 4, 27, 0, 0, 0, 0, time.UTC)), nil)
+				m.locker.EXPECT().Acquire(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+				m.job.EXPECT().DoAt(gomock.Any(), gomock.Any()).Times(0)
+			},
+			wantErr: false,
+		},
+		{
+			name:   "Should return error when last run couldn't be got",
+			fields: newFields(t),
+			now:    scheduled.Add(time.Hour),
+			grace:  time.Hour * 12,
+			setup: func(t *testing.T, f *fields) {
+				t.Helper()
+				m := cast(t, f)
+				m.locker.EXPECT().
+					GetLastDone(gomock.Any(), name).
+					Times(1).
+					Return(jobrun.Run{}, errors.New("failed to connect"))
+				m.job.EXPECT().DoAt(gomock.Any(), gomock.Any()).Times(0)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			tt.setup(t, &tt.fields)
+			d := &DailyRun{
+				name:          name,
+				owner:         owner,
+				loc:           time.UTC,
+				locker:        tt.fields.locker,
+				job:           tt.fields.job,
+				log:           slog.Default(),
+				leaseTTL:      leaseTTL,
+				renewInterval: time.Hour,
+				retryInterval: time.Millisecond,
+				now:           func() time.Time { return tt.now },
+			}
+
+			s := Schedule(schedule)
+			if tt.schedule != nil {
+				s, d.loc = tt.schedule, kyiv
+			}
+
+			if err := d.CatchUp(context.Background(), s, tt.grace); (err != nil) != tt.wantErr {
+				t.Errorf("DailyRun.CatchUp() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
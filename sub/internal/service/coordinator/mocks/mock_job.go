@@ -0,0 +1,55 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/hrvadl/converter/sub/internal/service/coordinator (interfaces: Job)
+//
+// Generated by this command:
+//
+//	mockgen -destination=./mocks/mock_job.go -package=mocks . Job
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockJob is a mock of Job interface.
+type MockJob struct {
+	ctrl     *gomock.Controller
+	recorder *MockJobMockRecorder
+}
+
+// MockJobMockRecorder is the mock recorder for MockJob.
+type MockJobMockRecorder struct {
+	mock *MockJob
+}
+
+// NewMockJob creates a new mock instance.
+func NewMockJob(ctrl *gomock.Controller) *MockJob {
+	mock := &MockJob{ctrl: ctrl}
+	mock.recorder = &MockJobMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockJob) EXPECT() *MockJobMockRecorder {
+	return m.recorder
+}
+
+// DoAt mocks base method.
+func (m *MockJob) DoAt(arg0 context.Context, arg1 time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DoAt", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DoAt indicates an expected call of DoAt.
+func (mr *MockJobMockRecorder) DoAt(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DoAt", reflect.TypeOf((*MockJob)(nil).DoAt), arg0, arg1)
+}
@@ -0,0 +1,100 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/hrvadl/converter/sub/internal/service/coordinator (interfaces: RunLocker)
+//
+// Generated by this command:
+//
+//	mockgen -destination=./mocks/mock_locker.go -package=mocks . RunLocker
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	jobrun "github.com/hrvadl/converter/sub/internal/storage/jobrun"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRunLocker is a mock of RunLocker interface.
+type MockRunLocker struct {
+	ctrl     *gomock.Controller
+	recorder *MockRunLockerMockRecorder
+}
+
+// MockRunLockerMockRecorder is the mock recorder for MockRunLocker.
+type MockRunLockerMockRecorder struct {
+	mock *MockRunLocker
+}
+
+// NewMockRunLocker creates a new mock instance.
+func NewMockRunLocker(ctrl *gomock.Controller) *MockRunLocker {
+	mock := &MockRunLocker{ctrl: ctrl}
+	mock.recorder = &MockRunLockerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRunLocker) EXPECT() *MockRunLockerMockRecorder {
+	return m.recorder
+}
+
+// Acquire mocks base method.
+func (m *MockRunLocker) Acquire(arg0 context.Context, arg1 string, arg2 time.Time, arg3 string, arg4 time.Duration) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Acquire", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Acquire indicates an expected call of Acquire.
+func (mr *MockRunLockerMockRecorder) Acquire(arg0, arg1, arg2, arg3, arg4 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Acquire", reflect.TypeOf((*MockRunLocker)(nil).Acquire), arg0, arg1, arg2, arg3, arg4)
+}
+
+// Complete mocks base method.
+func (m *MockRunLocker) Complete(arg0 context.Context, arg1 string, arg2 time.Time, arg3 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Complete", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Complete indicates an expected call of Complete.
+func (mr *MockRunLockerMockRecorder) Complete(arg0, arg1, arg2, arg3 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Complete", reflect.TypeOf((*MockRunLocker)(nil).Complete), arg0, arg1, arg2, arg3)
+}
+
+// GetLastDone mocks base method.
+func (m *MockRunLocker) GetLastDone(arg0 context.Context, arg1 string) (jobrun.Run, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLastDone", arg0, arg1)
+	ret0, _ := ret[0].(jobrun.Run)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLastDone indicates an expected call of GetLastDone.
+func (mr *MockRunLockerMockRecorder) GetLastDone(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLastDone", reflect.TypeOf((*MockRunLocker)(nil).GetLastDone), arg0, arg1)
+}
+
+// Renew mocks base method.
+func (m *MockRunLocker) Renew(arg0 context.Context, arg1 string, arg2 time.Time, arg3 int64, arg4 time.Duration) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Renew", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Renew indicates an expected call of Renew.
+func (mr *MockRunLockerMockRecorder) Renew(arg0, arg1, arg2, arg3, arg4 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Renew", reflect.TypeOf((*MockRunLocker)(nil).Renew), arg0, arg1, arg2, arg3, arg4)
+}
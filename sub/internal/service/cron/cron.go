@@ -1,71 +1,116 @@
 package cron
 
 import (
+	"context"
 	"log/slog"
 	"sync"
-	"time"
 )
 
-// NewDailyJob constructs job which will  be triggered on the
-// daily basis at the given point of time.
-// NOTE: it expects time in UTC timezone. It's -3 hours compared to Kyiv Time:
-// 12:00 UTC = 15:00 by Kyiv
-func NewDailyJob(hour, min int, log *slog.Logger) *Job {
+// NewJob constructs job which will be triggered according
+// to the provided schedule. Schedule could be parsed from the
+// cron expression with Parse, or created with Every.
+// NOTE: neither of arguments can't be nil, or job will panic later.
+func NewJob(s Schedule, c Clock, log *slog.Logger) *Job {
 	return &Job{
-		interval: time.Hour * 24,
-		ticker:   time.NewTicker(calculateFirstTick(hour, min)),
+		schedule: s,
+		clock:    c,
 		log:      log,
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
 	}
 }
 
-// NewJob constructs job which will  be triggered after
-// provided interval.
-func NewJob(interval time.Duration, log *slog.Logger) *Job {
-	return &Job{
-		interval: interval,
-		ticker:   time.NewTicker(interval),
-		log:      log,
-	}
-}
-
-// Job reporesents Cron Job which could be runned in some
-// interval or on daily basis. It's a thin wrapper around stdlib's
-// time.Ticker.
+// Job reporesents Cron Job which is triggered according
+// to the schedule. Time is taken from the Clock, so schedules
+// could be tested without sleeping.
 type Job struct {
-	interval time.Duration
-	ticker   *time.Ticker
+	schedule Schedule
+	clock    Clock
 	log      *slog.Logger
+
+	mu      sync.Mutex
+	started bool
+	stopped bool
+	cancel  context.CancelFunc
+	stop    chan struct{}
+	done    chan struct{}
 }
 
 //go:generate mockgen -destination=./mocks/mock_doer.go -package=mocks . Doer
 type Doer interface {
-	Do() error
+	Do(ctx context.Context) error
 }
 
-// Do method calls provided fn with the given interval.
-// Does not stop on error, only logs it and then goes on.
-// Reset is needed for the daily job, to change it to 24 hours and it's done only once
-// after first run.
-func (j *Job) Do(fn Doer) {
-	var once sync.Once
-	go func() {
-		for range j.ticker.C {
-			once.Do(func() {
-				j.ticker.Reset(j.interval)
-			})
-			if err := fn.Do(); err != nil {
-				j.log.Error("Failed to do cron task", "err", err)
-			}
-		}
-	}()
+// Do method calls provided fn in the background according to the schedule,
+// until ctx is done or Stop is called. Next activation time is computed
+// after each call, so it doesn't drift. Does not stop on error, only logs it
+// and then goes on. fn is called with the context derived from ctx, which
+// is cancelled when the job is stopped forcibly. Job could be started only
+// once.
+func (j *Job) Do(ctx context.Context, fn Doer) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if j.started || j.stopped {
+		return
+	}
+
+	ctx, j.cancel = context.WithCancel(ctx)
+	j.started = true
+	go j.run(ctx, fn)
 }
 
-func calculateFirstTick(hour, min int) time.Duration {
-	now := time.Now()
-	tickAt := time.Date(now.Year(), now.Month(), now.Day(), hour, min, 0, 0, time.UTC)
-	if now.After(tickAt) {
-		tickAt = tickAt.Add(time.Hour * 24)
+// Stop method stops the job, so fn isn't called anymore, and waits until
+// the current call of fn, if any, is finished. If ctx is done before that,
+// context of the call is cancelled, so fn could save its progress and
+// return, and ctx.Err() is returned. Hence fn should return promptly after
+// its context is cancelled.
+func (j *Job) Stop(ctx context.Context) error {
+	j.mu.Lock()
+	if !j.stopped {
+		j.stopped = true
+		close(j.stop)
+	}
+	started := j.started
+	j.mu.Unlock()
+
+	if !started {
+		return nil
+	}
+
+	select {
+	case <-j.done:
+		j.cancel()
+		return nil
+	case <-ctx.Done():
+		j.cancel()
+		<-j.done
+		return ctx.Err()
 	}
+}
+
+func (j *Job) run(ctx context.Context, fn Doer) {
+	defer close(j.done)
+	for {
+		now := j.clock.Now()
+		next := j.schedule.Next(now)
+		if next.IsZero() {
+			j.log.Warn("Schedule has no next activation time, stopping")
+			return
+		}
+
+		t := j.clock.NewTimer(next.Sub(now))
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			return
+		case <-j.stop:
+			t.Stop()
+			return
+		case <-t.C():
+		}
 
-	return tickAt.Sub(now)
+		if err := fn.Do(ctx); err != nil {
+			j.log.Error("Failed to do cron task", "err", err)
+		}
+	}
 }
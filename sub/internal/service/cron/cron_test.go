@@ -1,6 +1,7 @@
 package cron
 
 import (
+	"context"
 	"log/slog"
 	"reflect"
 	"testing"
@@ -11,12 +12,12 @@ import (
 	"github.com/hrvadl/converter/sub/internal/service/cron/mocks"
 )
 
-func TestNewDailyJob(t *testing.T) {
+func TestNewJob(t *testing.T) {
 	t.Parallel()
 	type args struct {
-		hour int
-		min  int
-		log  *slog.Logger
+		s   Schedule
+		c   Clock
+		log *slog.Logger
 	}
 	tests := []struct {
 		name string
@@ -24,75 +25,80 @@ func TestNewDailyJob(t *testing.T) {
 		want *Job
 	}{
 		{
-			name: "Should return correct daily job when arguments are correct",
+			name: "Should create job correctly when arguments are correct",
 			args: args{
-				hour: 12,
-				min:  0,
-				log:  slog.Default(),
+				s:   Every(time.Hour),
+				c:   NewRealClock(),
+				log: slog.Default(),
 			},
 			want: &Job{
+				schedule: Every(time.Hour),
+				clock:    NewRealClock(),
 				log:      slog.Default(),
-				interval: time.Hour * 24,
 			},
 		},
 		{
-			name: "Should return correct daily job when arguments are allowed",
+			name: "Should create job correctly when arguments are allowed",
 			args: args{
-				hour: 0,
-				min:  0,
-				log:  nil,
-			},
-			want: &Job{
-				log:      nil,
-				interval: time.Hour * 24,
+				s:   nil,
+				c:   nil,
+				log: nil,
 			},
+			want: &Job{},
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
-			if got := NewDailyJob(tt.args.hour, tt.args.min, tt.args.log); !reflect.DeepEqual(
-				got.interval,
-				tt.want.interval,
-			) {
-				t.Errorf("NewDailyJob() = %v, want %v", got, tt.want)
+			got := NewJob(tt.args.s, tt.args.c, tt.args.log)
+			if got.stop == nil || got.done == nil {
+				t.Fatal("NewJob() channels are not initialized")
+			}
+			if !reflect.DeepEqual(got.schedule, tt.want.schedule) ||
+				!reflect.DeepEqual(got.clock, tt.want.clock) ||
+				got.log != tt.want.log {
+				t.Errorf("NewJob() = %v, want %v", got, tt.want)
 			}
 		})
 	}
 }
 
-func TestNewJob(t *testing.T) {
+func TestJobDo(t *testing.T) {
 	t.Parallel()
-	type args struct {
-		interval time.Duration
-		log      *slog.Logger
+	type fields struct {
+		schedule Schedule
+		clock    *FakeClock
 	}
 	tests := []struct {
-		name string
-		args args
-		want *Job
+		name    string
+		fields  fields
+		advance []time.Duration
+		wantAt  []time.Time
 	}{
 		{
-			name: "Should create job correctly when arguments are correct",
-			args: args{
-				interval: time.Hour * 2,
-				log:      slog.Default(),
+			name: "Should trigger daily job at the scheduled time every day",
+			fields: fields{
+				schedule: MustParse("0 12 * * *", time.UTC),
+				clock:    NewFakeClock(time.Date(2024, 5, 1, 11, 0, 0, 0, time.UTC)),
 			},
-			want: &Job{
-				interval: time.Hour * 2,
-				log:      slog.Default(),
+			advance: []time.Duration{time.Hour, time.Hour * 24},
+			wantAt: []time.Time{
+				time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC),
+				time.Date(2024, 5, 2, 12, 0, 0, 0, time.UTC),
 			},
 		},
 		{
-			name: "Should create job correctly when arguments are correct",
-			args: args{
-				interval: time.Hour * 1,
-				log:      nil,
+			name: "Should trigger interval job after each interval",
+			fields: fields{
+				schedule: Every(time.Minute),
+				clock:    NewFakeClock(time.Date(2024, 5, 1, 11, 0, 0, 0, time.UTC)),
 			},
-			want: &Job{
-				interval: time.Hour * 1,
-				log:      nil,
+			advance: []time.Duration{time.Minute, time.Minute, time.Minute},
+			wantAt: []time.Time{
+				time.Date(2024, 5, 1, 11, 1, 0, 0, time.UTC),
+				time.Date(2024, 5, 1, 11, 2, 0, 0, time.UTC),
+				time.Date(2024, 5, 1, 11, 3, 0, 0, time.UTC),
 			},
 		},
 	}
@@ -100,94 +106,143 @@ func TestNewJob(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
-			if got := NewJob(tt.args.interval, tt.args.log); !reflect.DeepEqual(
-				got.interval,
-				tt.want.interval,
-			) {
-				t.Errorf("NewJob() = %v, want %v", got, tt.want)
+			calls := make(chan time.Time)
+			d := mocks.NewMockDoer(gomock.NewController(t))
+			d.EXPECT().Do(gomock.Any()).Times(len(tt.wantAt)).DoAndReturn(func(context.Context) error {
+				calls <- tt.fields.clock.Now()
+				return nil
+			})
+
+			j := NewJob(tt.fields.schedule, tt.fields.clock, slog.Default())
+			j.Do(context.Background(), d)
+			defer j.Stop(context.Background())
+
+			for i := range tt.advance {
+				tt.fields.clock.BlockUntil(1)
+				tt.fields.clock.Advance(tt.advance[i])
+				if got := <-calls; !got.Equal(tt.wantAt[i]) {
+					t.Errorf("Job.Do() triggered at %v, want %v", got, tt.wantAt[i])
+				}
 			}
 		})
 	}
 }
 
-func TestJobDo(t *testing.T) {
+func TestJobStop(t *testing.T) {
 	t.Parallel()
-	type fields struct {
-		interval time.Duration
-		log      *slog.Logger
-	}
-	type args struct {
-		doer Doer
-	}
 	tests := []struct {
 		name   string
-		fields fields
-		args   args
-		setup  func(t *testing.T, doer Doer)
+		cancel bool
 	}{
 		{
-			name: "Should execute job correcty with 5ms timeout",
-			fields: fields{
-				interval: time.Millisecond * 3,
-				log:      slog.Default(),
-			},
-			args: args{
-				doer: mocks.NewMockDoer(gomock.NewController(t)),
-			},
-			setup: func(t *testing.T, doer Doer) {
-				t.Helper()
-				d, ok := doer.(*mocks.MockDoer)
-				if !ok {
-					t.Fatalf("failed to cast doer to mock doer")
-				}
-				d.EXPECT().Do().MinTimes(3).Return(nil)
-			},
+			name:   "Should stop job when Stop is called",
+			cancel: false,
 		},
 		{
-			name: "Should execute job correcty with 3ms timeout",
-			fields: fields{
-				interval: time.Millisecond,
-				log:      slog.Default(),
-			},
-			args: args{
-				doer: mocks.NewMockDoer(gomock.NewController(t)),
-			},
-			setup: func(t *testing.T, doer Doer) {
-				t.Helper()
-				d, ok := doer.(*mocks.MockDoer)
-				if !ok {
-					t.Fatalf("failed to cast doer to mock doer")
+			name:   "Should stop job when context is done",
+			cancel: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			c := NewFakeClock(time.Date(2024, 5, 1, 11, 0, 0, 0, time.UTC))
+			d := mocks.NewMockDoer(gomock.NewController(t))
+			d.EXPECT().Do(gomock.Any()).Times(0)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			j := NewJob(Every(time.Hour), c, slog.Default())
+			j.Do(ctx, d)
+			c.BlockUntil(1)
+
+			if tt.cancel {
+				cancel()
+				<-j.done
+			} else {
+				if err := j.Stop(context.Background()); err != nil {
+					t.Fatalf("Job.Stop() error = %v, want nil", err)
 				}
-				d.EXPECT().Do().MinTimes(3).Return(nil)
-			},
+			}
+
+			c.Advance(time.Hour * 2)
+			if err := j.Stop(context.Background()); err != nil {
+				t.Errorf("Job.Stop() error = %v, want nil", err)
+			}
+		})
+	}
+}
+
+func TestJobStopRunning(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		finish   bool
+		wantErr  error
+		wantDone error
+	}{
+		{
+			name:    "Should wait until running call is finished",
+			finish:  true,
+			wantErr: nil,
 		},
 		{
-			name: "Should execute job correcty with 3ms timeout",
-			fields: fields{
-				interval: time.Millisecond * 3,
-				log:      slog.Default(),
-			},
-			args: args{
-				doer: mocks.NewMockDoer(gomock.NewController(t)),
-			},
-			setup: func(t *testing.T, doer Doer) {
-				t.Helper()
-				d, ok := doer.(*mocks.MockDoer)
-				if !ok {
-					t.Fatalf("failed to cast doer to mock doer")
-				}
-				d.EXPECT().Do().MinTimes(3).Return(nil)
-			},
+			name:     "Should cancel running call when deadline is exceeded",
+			finish:   false,
+			wantErr:  context.DeadlineExceeded,
+			wantDone: context.Canceled,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
-			tt.setup(t, tt.args.doer)
-			j := NewJob(tt.fields.interval, tt.fields.log)
-			j.Do(tt.args.doer)
-			time.Sleep(tt.fields.interval * 10)
+			c := NewFakeClock(time.Date(2024, 5, 1, 11, 0, 0, 0, time.UTC))
+			started := make(chan struct{})
+			finish := make(chan struct{})
+			done := make(chan error, 1)
+			d := mocks.NewMockDoer(gomock.NewController(t))
+			d.EXPECT().Do(gomock.Any()).Times(1).DoAndReturn(func(ctx context.Context) error {
+				close(started)
+				select {
+				case <-finish:
+				case <-ctx.Done():
+				}
+				done <- ctx.Err()
+				return nil
+			})
+
+			j := NewJob(Every(time.Hour), c, slog.Default())
+			j.Do(context.Background(), d)
+			c.BlockUntil(1)
+			c.Advance(time.Hour)
+			<-started
+
+			ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*50)
+			defer cancel()
+			if tt.finish {
+				close(finish)
+			}
+
+			if err := j.Stop(ctx); err != tt.wantErr {
+				t.Errorf("Job.Stop() error = %v, want %v", err, tt.wantErr)
+			}
+			if err := <-done; err != tt.wantDone {
+				t.Errorf("Doer.Do() context error = %v, want %v", err, tt.wantDone)
+			}
 		})
 	}
 }
+
+func TestJobStopNotStarted(t *testing.T) {
+	t.Parallel()
+	j := NewJob(Every(time.Hour), NewFakeClock(time.Now()), slog.Default())
+	if err := j.Stop(context.Background()); err != nil {
+		t.Errorf("Job.Stop() error = %v, want nil", err)
+	}
+	if err := j.Stop(context.Background()); err != nil {
+		t.Errorf("Job.Stop() error = %v, want nil", err)
+	}
+}
@@ -0,0 +1,337 @@
+package cron
+
+import (
+	"errors"
+	"fmt"
+	"math/bits"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxYears is a number of years Next looks ahead
+// before it gives up, i.e for 30th of February.
+const maxYears = 5
+
+var macros = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+var (
+	months = map[string]int{
+		"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+		"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+	}
+	weekdays = map[string]int{
+		"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+	}
+)
+
+type bounds struct {
+	min   int
+	max   int
+	names map[string]int
+}
+
+var (
+	minutes     = bounds{min: 0, max: 59}
+	hours       = bounds{min: 0, max: 23}
+	daysOfMonth = bounds{min: 1, max: 31}
+	monthsOfYr  = bounds{min: 1, max: 12, names: months}
+	// daysOfWeek allows 7 as a Sunday.
+	daysOfWeek = bounds{min: 0, max: 7, names: weekdays}
+)
+
+// Schedule describes when the job should be triggered.
+type Schedule interface {
+	// Next returns the next activation time after t,
+	// or zero time if there's no such time.
+	Next(t time.Time) time.Time
+	// Location returns the location, which the schedule is evaluated in.
+	Location() *time.Location
+}
+
+// Parse parses standard 5-field cron expression
+// (minute, hour, day of month, month, day of week) or one of the
+// macros: @yearly, @annually, @monthly, @weekly, @daily, @midnight,
+// @hourly and @every <duration>. Expression is evaluated in the given
+// location, unless it's prefixed with CRON_TZ=<location>. Nil location
+// is treated as UTC.
+func Parse(expr string, loc *time.Location) (Schedule, error) {
+	if loc == nil {
+		loc = time.UTC
+	}
+
+	expr = strings.TrimSpace(expr)
+	if strings.HasPrefix(expr, "CRON_TZ=") || strings.HasPrefix(expr, "TZ=") {
+		tz, rest, _ := strings.Cut(expr, " ")
+		_, name, _ := strings.Cut(tz, "=")
+		var err error
+		if loc, err = time.LoadLocation(name); err != nil {
+			return nil, fmt.Errorf("invalid timezone %s: %w", name, err)
+		}
+		expr = strings.TrimSpace(rest)
+	}
+
+	if d, ok := strings.CutPrefix(expr, "@every "); ok {
+		return parseEvery(d)
+	}
+
+	if m, ok := macros[strings.ToLower(expr)]; ok {
+		expr = m
+	}
+
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("expected 5 fields, got %d in %q", len(fields), expr)
+	}
+
+	var (
+		s   = &SpecSchedule{loc: loc}
+		err error
+	)
+
+	if s.minute, err = parseField(fields[0], minutes); err != nil {
+		return nil, fmt.Errorf("invalid minute: %w", err)
+	}
+	if s.hour, err = parseField(fields[1], hours); err != nil {
+		return nil, fmt.Errorf("invalid hour: %w", err)
+	}
+	if s.dom, err = parseField(fields[2], daysOfMonth); err != nil {
+		return nil, fmt.Errorf("invalid day of month: %w", err)
+	}
+	if s.month, err = parseField(fields[3], monthsOfYr); err != nil {
+		return nil, fmt.Errorf("invalid month: %w", err)
+	}
+	if s.dow, err = parseField(fields[4], daysOfWeek); err != nil {
+		return nil, fmt.Errorf("invalid day of week: %w", err)
+	}
+
+	if s.dow.has(7) {
+		s.dow |= 1
+	}
+	s.domAny, s.dowAny = isAny(fields[2]), isAny(fields[4])
+
+	return s, nil
+}
+
+// MustParse is a handy wrapper around Parse, which will panic
+// in case of error. Should be used only with the constant expressions.
+func MustParse(expr string, loc *time.Location) Schedule {
+	s, err := Parse(expr, loc)
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+// Every returns schedule, which is activated with the given interval.
+func Every(d time.Duration) Schedule {
+	return EverySchedule{Interval: d}
+}
+
+func parseEvery(d string) (Schedule, error) {
+	interval, err := time.ParseDuration(strings.TrimSpace(d))
+	if err != nil {
+		return nil, fmt.Errorf("invalid interval: %w", err)
+	}
+
+	if interval <= 0 {
+		return nil, errors.New("interval should be positive")
+	}
+
+	return Every(interval), nil
+}
+
+// EverySchedule is activated with the fixed interval.
+type EverySchedule struct {
+	Interval time.Duration
+}
+
+// Next method returns t increased by the interval.
+func (s EverySchedule) Next(t time.Time) time.Time {
+	return t.Add(s.Interval)
+}
+
+// Location method returns UTC, since interval doesn't depend on the location.
+func (s EverySchedule) Location() *time.Location {
+	return time.UTC
+}
+
+// bitset holds allowed values of the single field.
+type bitset uint64
+
+func (b bitset) has(v int) bool {
+	return b&(1<<uint(v)) != 0
+}
+
+// first returns the smallest value in the set.
+func (b bitset) first() int {
+	return bits.TrailingZeros64(uint64(b))
+}
+
+// SpecSchedule is a schedule parsed from the cron expression.
+// Like in the standard cron, when both day of month and day of week are
+// restricted, the day matches if either of them matches.
+type SpecSchedule struct {
+	minute bitset
+	hour   bitset
+	dom    bitset
+	month  bitset
+	dow    bitset
+	domAny bool
+	dowAny bool
+	loc    *time.Location
+}
+
+// Location method returns the location of the schedule: the
+// CRON_TZ one, if it was provided, or the one given to Parse.
+func (s *SpecSchedule) Location() *time.Location {
+	return s.loc
+}
+
+// Next method returns the next activation time after t in the
+// schedule's location. Since it's computed from the wall clock, job
+// runs at the same local time after DST changes, and activation
+// times, which don't exist because of DST, are moved forward.
+func (s *SpecSchedule) Next(t time.Time) time.Time {
+	t = t.In(s.loc).Truncate(time.Minute).Add(time.Minute)
+	limit := t.Year() + maxYears
+
+wrap:
+	if t.Year() > limit {
+		return time.Time{}
+	}
+
+	for !s.month.has(int(t.Month())) {
+		t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, s.loc)
+		if t.Month() == time.January {
+			goto wrap
+		}
+	}
+
+	for !s.dayMatches(t) {
+		t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, s.loc)
+		if t.Day() == 1 {
+			goto wrap
+		}
+	}
+
+	for !s.hour.has(t.Hour()) {
+		h := t.Hour() + 1
+		next := time.Date(t.Year(), t.Month(), t.Day(), h, 0, 0, 0, s.loc)
+		if next.Day() != t.Day() {
+			t = next
+			goto wrap
+		}
+		if next.Hour() != h && s.hour.has(h) {
+			// Hour was skipped because of DST, so activation is moved
+			// forward by the DST offset instead of being skipped.
+			return time.Date(t.Year(), t.Month(), t.Day(), h, s.minute.first(), 0, 0, s.loc)
+		}
+		t = next
+	}
+
+	for !s.minute.has(t.Minute()) {
+		t = t.Add(time.Minute)
+		if t.Minute() == 0 {
+			goto wrap
+		}
+	}
+
+	return t
+}
+
+func (s *SpecSchedule) dayMatches(t time.Time) bool {
+	dom, dow := s.dom.has(t.Day()), s.dow.has(int(t.Weekday()))
+	if s.domAny || s.dowAny {
+		return dom && dow
+	}
+	return dom || dow
+}
+
+// isAny reports whether the field is unrestricted. Like in the
+// standard cron, fields starting with an asterisk, i.e "*/2", are
+// treated as unrestricted when day of month is matched with day of week.
+func isAny(field string) bool {
+	return strings.HasPrefix(field, "*") || field == "?"
+}
+
+// parseField parses comma-separated list of values, ranges
+// and steps, i.e "1,5-10,*/15" within the given bounds.
+func parseField(field string, b bounds) (bitset, error) {
+	var set bitset
+	for _, part := range strings.Split(field, ",") {
+		v, err := parsePart(part, b)
+		if err != nil {
+			return 0, err
+		}
+		set |= v
+	}
+	return set, nil
+}
+
+func parsePart(part string, b bounds) (bitset, error) {
+	rng, stepStr, hasStep := strings.Cut(part, "/")
+	step := 1
+	if hasStep {
+		var err error
+		if step, err = strconv.Atoi(stepStr); err != nil || step <= 0 {
+			return 0, fmt.Errorf("invalid step %q", stepStr)
+		}
+	}
+
+	lo, hi := b.min, b.max
+	switch {
+	case rng == "*" || rng == "?":
+	case strings.Contains(rng, "-"):
+		loStr, hiStr, _ := strings.Cut(rng, "-")
+		var err error
+		if lo, err = parseValue(loStr, b); err != nil {
+			return 0, err
+		}
+		if hi, err = parseValue(hiStr, b); err != nil {
+			return 0, err
+		}
+		if lo > hi {
+			return 0, fmt.Errorf("invalid range %q", rng)
+		}
+	default:
+		var err error
+		if lo, err = parseValue(rng, b); err != nil {
+			return 0, err
+		}
+		if !hasStep {
+			hi = lo
+		}
+	}
+
+	var set bitset
+	for v := lo; v <= hi; v += step {
+		set |= 1 << uint(v)
+	}
+	return set, nil
+}
+
+func parseValue(s string, b bounds) (int, error) {
+	if v, ok := b.names[strings.ToLower(s)]; ok {
+		return v, nil
+	}
+
+	v, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value %q", s)
+	}
+
+	if v < b.min || v > b.max {
+		return 0, fmt.Errorf("value %d is out of range [%d, %d]", v, b.min, b.max)
+	}
+
+	return v, nil
+}
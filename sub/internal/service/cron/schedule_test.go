@@ -0,0 +1,179 @@
+package cron
+
+import (
+	"testing"
+	"time"
+	_ "time/tzdata"
+)
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		expr    string
+		wantErr bool
+	}{
+		{name: "Should parse wildcard expression", expr: "* * * * *"},
+		{name: "Should parse lists, ranges and steps", expr: "0,30 9-17/2 1-15 */3 1-5"},
+		{name: "Should parse month and weekday names", expr: "30 9 * jan-mar MON-FRI"},
+		{name: "Should parse macro", expr: "@daily"},
+		{name: "Should parse interval", expr: "@every 1h30m"},
+		{name: "Should parse expression with timezone", expr: "CRON_TZ=Europe/Kyiv 30 9 * * 1-5"},
+		{name: "Should return error when there're too few fields", expr: "0 12 * *", wantErr: true},
+		{name: "Should return error when value is out of range", expr: "60 12 * * *", wantErr: true},
+		{name: "Should return error when range is reversed", expr: "0 12 * * 5-1", wantErr: true},
+		{name: "Should return error when step is invalid", expr: "*/0 * * * *", wantErr: true},
+		{name: "Should return error when value isn't a number", expr: "a * * * *", wantErr: true},
+		{name: "Should return error when interval is invalid", expr: "@every soon", wantErr: true},
+		{name: "Should return error when interval is negative", expr: "@every -1h", wantErr: true},
+		{name: "Should return error when timezone is unknown", expr: "CRON_TZ=Mars/Base 0 * * * *", wantErr: true},
+		{name: "Should return error when macro is unknown", expr: "@fortnightly", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if _, err := Parse(tt.expr, time.UTC); (err != nil) != tt.wantErr {
+				t.Errorf("Parse() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestScheduleNext(t *testing.T) {
+	t.Parallel()
+	kyiv, err := time.LoadLocation("Europe/Kyiv")
+	if err != nil {
+		t.Fatalf("failed to load location: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		expr string
+		loc  *time.Location
+		from time.Time
+		want time.Time
+	}{
+		{
+			name: "Should return today's time when it's not passed yet",
+			expr: "0 12 * * *",
+			from: time.Date(2024, 5, 1, 11, 0, 0, 0, time.UTC),
+			want: time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "Should return tomorrow's time when it's already passed",
+			expr: "0 12 * * *",
+			from: time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC),
+			want: time.Date(2024, 5, 2, 12, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "Should skip weekend for weekdays schedule",
+			expr: "30 9 * * 1-5",
+			loc:  kyiv,
+			from: time.Date(2024, 5, 3, 9, 30, 0, 0, kyiv),
+			want: time.Date(2024, 5, 6, 9, 30, 0, 0, kyiv),
+		},
+		{
+			name: "Should keep local time after DST starts",
+			expr: "30 9 * * *",
+			loc:  kyiv,
+			from: time.Date(2024, 3, 30, 9, 30, 0, 0, kyiv),
+			want: time.Date(2024, 3, 31, 9, 30, 0, 0, kyiv),
+		},
+		{
+			name: "Should keep local time after DST ends",
+			expr: "CRON_TZ=Europe/Kyiv 30 9 * * *",
+			from: time.Date(2024, 10, 26, 9, 30, 0, 0, kyiv),
+			want: time.Date(2024, 10, 27, 9, 30, 0, 0, kyiv),
+		},
+		{
+			name: "Should move time, which doesn't exist because of DST, forward",
+			expr: "30 3 * * *",
+			loc:  kyiv,
+			from: time.Date(2024, 3, 30, 12, 0, 0, 0, kyiv),
+			want: time.Date(2024, 3, 31, 4, 30, 0, 0, kyiv),
+		},
+		{
+			name: "Should match either day of month or day of week when both are restricted",
+			expr: "0 0 13 * 5",
+			from: time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC),
+			want: time.Date(2024, 5, 3, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "Should treat 7 as Sunday",
+			expr: "0 0 * * 7",
+			from: time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC),
+			want: time.Date(2024, 5, 5, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "Should wrap to the next year",
+			expr: "@yearly",
+			from: time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC),
+			want: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "Should find leap day",
+			expr: "0 0 29 2 *",
+			from: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+			want: time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "Should return zero time when there's no such day",
+			expr: "0 0 30 2 *",
+			from: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+			want: time.Time{},
+		},
+		{
+			name: "Should return time after the interval",
+			expr: "@every 90m",
+			from: time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC),
+			want: time.Date(2024, 5, 1, 1, 30, 0, 0, time.UTC),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			s, err := Parse(tt.expr, tt.loc)
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+			if got := s.Next(tt.from); !got.Equal(tt.want) {
+				t.Errorf("Schedule.Next() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestScheduleLocation(t *testing.T) {
+	t.Parallel()
+	kyiv, err := time.LoadLocation("Europe/Kyiv")
+	if err != nil {
+		t.Fatalf("failed to load location: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		expr string
+		loc  *time.Location
+		want string
+	}{
+		{name: "Should return UTC when location isn't provided", expr: "0 12 * * *", want: "UTC"},
+		{name: "Should return given location", expr: "0 12 * * *", loc: kyiv, want: "Europe/Kyiv"},
+		{name: "Should return location of the prefix", expr: "CRON_TZ=America/New_York 0 12 * * *", loc: kyiv, want: "America/New_York"},
+		{name: "Should return UTC for interval", expr: "@every 1h", loc: kyiv, want: "UTC"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			s, err := Parse(tt.expr, tt.loc)
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+			if got := s.Location().String(); got != tt.want {
+				t.Errorf("Schedule.Location() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
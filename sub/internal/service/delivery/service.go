@@ -0,0 +1,61 @@
+package delivery
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hrvadl/converter/sub/internal/storage/delivery"
+)
+
+const operation = "delivery service"
+
+const (
+	defaultLimit = 50
+	maxLimit     = 500
+)
+
+// NewService constructs new Service with provided arguments.
+// NOTE: neither of arguments can't be nil, or service will panic in
+// the future.
+func NewService(hr HistoryRepo) *Service {
+	return &Service{
+		repo: hr,
+	}
+}
+
+//go:generate mockgen -destination=./mocks/mock_repo.go -package=mocks . HistoryRepo
+type HistoryRepo interface {
+	Get(ctx context.Context, f delivery.Filter) ([]delivery.Delivery, error)
+}
+
+// Service is a main structure, responsible for looking up
+// history of the sent notifications.
+type Service struct {
+	repo HistoryRepo
+}
+
+// GetHistory method returns page of deliveries matching the filter,
+// newest first. Limit falls back to the default one when it's not positive
+// and is capped with the maximum one. One extra delivery is requested to find
+// out whether there's a next page.
+func (s *Service) GetHistory(ctx context.Context, f delivery.Filter) (delivery.Page, error) {
+	if f.Limit <= 0 {
+		f.Limit = defaultLimit
+	}
+	f.Limit = min(f.Limit, maxLimit)
+
+	limit := f.Limit
+	f.Limit++
+
+	d, err := s.repo.Get(ctx, f)
+	if err != nil {
+		return delivery.Page{}, fmt.Errorf("%s: failed to get deliveries: %w", operation, err)
+	}
+
+	if len(d) <= limit {
+		return delivery.Page{Deliveries: d}, nil
+	}
+
+	d = d[:limit]
+	return delivery.Page{Deliveries: d, NextBeforeID: d[limit-1].ID}, nil
+}
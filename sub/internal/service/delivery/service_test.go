@@ -0,0 +1,206 @@
+package delivery
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"go.uber.org/mock/gomock"
+
+	"github.com/hrvadl/converter/sub/internal/service/delivery/mocks"
+	"github.com/hrvadl/converter/sub/internal/storage/delivery"
+)
+
+func TestNewService(t *testing.T) {
+	t.Parallel()
+	type args struct {
+		hr HistoryRepo
+	}
+	tests := []struct {
+		name string
+		args args
+		want *Service
+	}{
+		{
+			name: "Should create new service correctly when correct arguments are provided",
+			args: args{
+				hr: mocks.NewMockHistoryRepo(gomock.NewController(t)),
+			},
+			want: &Service{
+				repo: mocks.NewMockHistoryRepo(gomock.NewController(t)),
+			},
+		},
+		{
+			name: "Should create new service correctly when allowed arguments are provided",
+			args: args{
+				hr: nil,
+			},
+			want: &Service{
+				repo: nil,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := NewService(tt.args.hr); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewService() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestServiceGetHistory(t *testing.T) {
+	t.Parallel()
+	type fields struct {
+		repo HistoryRepo
+	}
+	type args struct {
+		ctx context.Context
+		f   delivery.Filter
+	}
+	deliveries := []delivery.Delivery{{ID: 3}, {ID: 2}, {ID: 1}}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		setup   func(t *testing.T, r HistoryRepo)
+		want    delivery.Page
+		wantErr bool
+	}{
+		{
+			name: "Should return last page without cursor when there're no more deliveries",
+			fields: fields{
+				repo: mocks.NewMockHistoryRepo(gomock.NewController(t)),
+			},
+			args: args{
+				ctx: context.Background(),
+				f:   delivery.Filter{Email: "test@test.com", Limit: 3},
+			},
+			setup: func(t *testing.T, r HistoryRepo) {
+				t.Helper()
+				rr, ok := r.(*mocks.MockHistoryRepo)
+				if !ok {
+					t.Fatal("failed to cast repo to mock")
+				}
+				rr.EXPECT().
+					Get(gomock.Any(), delivery.Filter{Email: "test@test.com", Limit: 4}).
+					Times(1).
+					Return(deliveries, nil)
+			},
+			want:    delivery.Page{Deliveries: deliveries},
+			wantErr: false,
+		},
+		{
+			name: "Should return page with cursor when there're more deliveries",
+			fields: fields{
+				repo: mocks.NewMockHistoryRepo(gomock.NewController(t)),
+			},
+			args: args{
+				ctx: context.Background(),
+				f:   delivery.Filter{Limit: 2},
+			},
+			setup: func(t *testing.T, r HistoryRepo) {
+				t.Helper()
+				rr, ok := r.(*mocks.MockHistoryRepo)
+				if !ok {
+					t.Fatal("failed to cast repo to mock")
+				}
+				rr.EXPECT().
+					Get(gomock.Any(), delivery.Filter{Limit: 3}).
+					Times(1).
+					Return(deliveries, nil)
+			},
+			want:    delivery.Page{Deliveries: deliveries[:2], NextBeforeID: 2},
+			wantErr: false,
+		},
+		{
+			name: "Should fallback to default limit when limit is not positive",
+			fields: fields{
+				repo: mocks.NewMockHistoryRepo(gomock.NewController(t)),
+			},
+			args: args{
+				ctx: context.Background(),
+				f:   delivery.Filter{},
+			},
+			setup: func(t *testing.T, r HistoryRepo) {
+				t.Helper()
+				rr, ok := r.(*mocks.MockHistoryRepo)
+				if !ok {
+					t.Fatal("failed to cast repo to mock")
+				}
+				rr.EXPECT().
+					Get(gomock.Any(), delivery.Filter{Limit: defaultLimit + 1}).
+					Times(1).
+					Return(deliveries, nil)
+			},
+			want:    delivery.Page{Deliveries: deliveries},
+			wantErr: false,
+		},
+		{
+			name: "Should cap limit with maximum",
+			fields: fields{
+				repo: mocks.NewMockHistoryRepo(gomock.NewController(t)),
+			},
+			args: args{
+				ctx: context.Background(),
+				f:   delivery.Filter{Limit: maxLimit * 2},
+			},
+			setup: func(t *testing.T, r HistoryRepo) {
+				t.Helper()
+				rr, ok := r.(*mocks.MockHistoryRepo)
+				if !ok {
+					t.Fatal("failed to cast repo to mock")
+				}
+				rr.EXPECT().
+					Get(gomock.Any(), delivery.Filter{Limit: maxLimit + 1}).
+					Times(1).
+					Return(deliveries, nil)
+			},
+			want:    delivery.Page{Deliveries: deliveries},
+			wantErr: false,
+		},
+		{
+			name: "Should return error when repo failed",
+			fields: fields{
+				repo: mocks.NewMockHistoryRepo(gomock.NewController(t)),
+			},
+			args: args{
+				ctx: context.Background(),
+				f:   delivery.Filter{Limit: 2},
+			},
+			setup: func(t *testing.T, r HistoryRepo) {
+				t.Helper()
+				rr, ok := r.(*mocks.MockHistoryRepo)
+				if !ok {
+					t.Fatal("failed to cast repo to mock")
+				}
+				rr.EXPECT().
+					Get(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil, errors.New("failed to get deliveries"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			tt.setup(t, tt.fields.repo)
+			s := &Service{
+				repo: tt.fields.repo,
+			}
+			got, err := s.GetHistory(tt.args.ctx, tt.args.f)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Service.GetHistory() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Service.GetHistory() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
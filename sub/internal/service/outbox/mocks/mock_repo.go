@@ -0,0 +1,76 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/hrvadl/converter/sub/internal/service/outbox (interfaces: DeadLetterRepo)
+//
+// Generated by this command:
+//
+//	mockgen -destination=./mocks/mock_repo.go -package=mocks . DeadLetterRepo
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	outbox "github.com/hrvadl/converter/sub/internal/storage/outbox"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockDeadLetterRepo is a mock of DeadLetterRepo interface.
+type MockDeadLetterRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockDeadLetterRepoMockRecorder
+}
+
+// MockDeadLetterRepoMockRecorder is the mock recorder for MockDeadLetterRepo.
+type MockDeadLetterRepoMockRecorder struct {
+	mock *MockDeadLetterRepo
+}
+
+// NewMockDeadLetterRepo creates a new mock instance.
+func NewMockDeadLetterRepo(ctrl *gomock.Controller) *MockDeadLetterRepo {
+	mock := &MockDeadLetterRepo{ctrl: ctrl}
+	mock.recorder = &MockDeadLetterRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDeadLetterRepo) EXPECT() *MockDeadLetterRepoMockRecorder {
+	return m.recorder
+}
+
+// GetDead mocks base method.
+func (m *MockDeadLetterRepo) GetDead(arg0 context.Context, arg1 int64, arg2 int) ([]outbox.Notification, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDead", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]outbox.Notification)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDead indicates an expected call of GetDead.
+func (mr *MockDeadLetterRepoMockRecorder) GetDead(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDead", reflect.TypeOf((*MockDeadLetterRepo)(nil).GetDead), arg0, arg1, arg2)
+}
+
+// Requeue mocks base method.
+func (m *MockDeadLetterRepo) Requeue(arg0 context.Context, arg1 ...int64) (int64, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{arg0}
+	for _, a := range arg1 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Requeue", varargs...)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Requeue indicates an expected call of Requeue.
+func (mr *MockDeadLetterRepoMockRecorder) Requeue(arg0 any, arg1 ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{arg0}, arg1...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Requeue", reflect.TypeOf((*MockDeadLetterRepo)(nil).Requeue), varargs...)
+}
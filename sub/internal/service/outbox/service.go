@@ -0,0 +1,70 @@
+package outbox
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hrvadl/converter/sub/internal/storage/outbox"
+)
+
+const operation = "outbox service"
+
+const (
+	defaultLimit = 50
+	maxLimit     = 500
+)
+
+// NewService constructs new Service with provided arguments.
+// NOTE: neither of arguments can't be nil, or service will panic in
+// the future.
+func NewService(dl DeadLetterRepo) *Service {
+	return &Service{
+		repo: dl,
+	}
+}
+
+//go:generate mockgen -destination=./mocks/mock_repo.go -package=mocks . DeadLetterRepo
+type DeadLetterRepo interface {
+	GetDead(ctx context.Context, afterID int64, limit int) ([]outbox.Notification, error)
+	Requeue(ctx context.Context, ids ...int64) (int64, error)
+}
+
+// Service is a main structure, responsible for managing
+// notifications, which exceeded maximum number of delivery attempts.
+type Service struct {
+	repo DeadLetterRepo
+}
+
+// ListDeadLetters method returns page of dead letters with ID greater
+// than afterID. Limit falls back to the default one when it's not positive
+// and is capped with the maximum one.
+func (s *Service) ListDeadLetters(
+	ctx context.Context,
+	afterID int64,
+	limit int,
+) ([]outbox.Notification, error) {
+	if limit <= 0 {
+		limit = defaultLimit
+	}
+	limit = min(limit, maxLimit)
+
+	n, err := s.repo.GetDead(ctx, afterID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to get dead letters: %w", operation, err)
+	}
+
+	return n, nil
+}
+
+// RequeueDeadLetters method moves dead letters with given IDs back
+// to the pending state, so they're delivered with the next delivery job.
+// All dead letters are requeued if no IDs are provided.
+// Returns number of requeued notifications.
+func (s *Service) RequeueDeadLetters(ctx context.Context, ids ...int64) (int64, error) {
+	n, err := s.repo.Requeue(ctx, ids...)
+	if err != nil {
+		return 0, fmt.Errorf("%s: failed to requeue dead letters: %w", operation, err)
+	}
+
+	return n, nil
+}
@@ -0,0 +1,277 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"go.uber.org/mock/gomock"
+
+	"github.com/hrvadl/converter/sub/internal/service/outbox/mocks"
+	"github.com/hrvadl/converter/sub/internal/storage/outbox"
+)
+
+func TestNewService(t *testing.T) {
+	t.Parallel()
+	type args struct {
+		dl DeadLetterRepo
+	}
+	tests := []struct {
+		name string
+		args args
+		want *Service
+	}{
+		{
+			name: "Should create new service correctly when correct arguments are provided",
+			args: args{
+				dl: mocks.NewMockDeadLetterRepo(gomock.NewController(t)),
+			},
+			want: &Service{
+				repo: mocks.NewMockDeadLetterRepo(gomock.NewController(t)),
+			},
+		},
+		{
+			name: "Should create new service correctly when allowed arguments are provided",
+			args: args{
+				dl: nil,
+			},
+			want: &Service{
+				repo: nil,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := NewService(tt.args.dl); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewService() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestServiceListDeadLetters(t *testing.T) {
+	t.Parallel()
+	type fields struct {
+		repo DeadLetterRepo
+	}
+	type args struct {
+		ctx     context.Context
+		afterID int64
+		limit   int
+	}
+	dead := []outbox.Notification{
+		{ID: 11, Email: "test@test.com", Status: outbox.StatusDead},
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		setup   func(t *testing.T, r DeadLetterRepo)
+		want    []outbox.Notification
+		wantErr bool
+	}{
+		{
+			name: "Should return dead letters when repo succeeded",
+			fields: fields{
+				repo: mocks.NewMockDeadLetterRepo(gomock.NewController(t)),
+			},
+			args: args{
+				ctx:     context.Background(),
+				afterID: 10,
+				limit:   20,
+			},
+			setup: func(t *testing.T, r DeadLetterRepo) {
+				t.Helper()
+				rr, ok := r.(*mocks.MockDeadLetterRepo)
+				if !ok {
+					t.Fatal("failed to cast repo to mock")
+				}
+				rr.EXPECT().GetDead(gomock.Any(), int64(10), 20).Times(1).Return(dead, nil)
+			},
+			want:    dead,
+			wantErr: false,
+		},
+		{
+			name: "Should fallback to default limit when limit is not positive",
+			fields: fields{
+				repo: mocks.NewMockDeadLetterRepo(gomock.NewController(t)),
+			},
+			args: args{
+				ctx:   context.Background(),
+				limit: 0,
+			},
+			setup: func(t *testing.T, r DeadLetterRepo) {
+				t.Helper()
+				rr, ok := r.(*mocks.MockDeadLetterRepo)
+				if !ok {
+					t.Fatal("failed to cast repo to mock")
+				}
+				rr.EXPECT().GetDead(gomock.Any(), int64(0), defaultLimit).Times(1).Return(dead, nil)
+			},
+			want:    dead,
+			wantErr: false,
+		},
+		{
+			name: "Should cap limit with maximum",
+			fields: fields{
+				repo: mocks.NewMockDeadLetterRepo(gomock.NewController(t)),
+			},
+			args: args{
+				ctx:   context.Background(),
+				limit: maxLimit + 1,
+			},
+			setup: func(t *testing.T, r DeadLetterRepo) {
+				t.Helper()
+				rr, ok := r.(*mocks.MockDeadLetterRepo)
+				if !ok {
+					t.Fatal("failed to cast repo to mock")
+				}
+				rr.EXPECT().GetDead(gomock.Any(), int64(0), maxLimit).Times(1).Return(dead, nil)
+			},
+			want:    dead,
+			wantErr: false,
+		},
+		{
+			name: "Should return error when repo failed",
+			fields: fields{
+				repo: mocks.NewMockDeadLetterRepo(gomock.NewController(t)),
+			},
+			args: args{
+				ctx:   context.Background(),
+				limit: 20,
+			},
+			setup: func(t *testing.T, r DeadLetterRepo) {
+				t.Helper()
+				rr, ok := r.(*mocks.MockDeadLetterRepo)
+				if !ok {
+					t.Fatal("failed to cast repo to mock")
+				}
+				rr.EXPECT().
+					GetDead(gomock.Any(), int64(0), 20).
+					Times(1).
+					Return(nil, errors.New("failed to get dead letters"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			tt.setup(t, tt.fields.repo)
+			s := &Service{
+				repo: tt.fields.repo,
+			}
+			got, err := s.ListDeadLetters(tt.args.ctx, tt.args.afterID, tt.args.limit)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Service.ListDeadLetters() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Service.ListDeadLetters() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestServiceRequeueDeadLetters(t *testing.T) {
+	t.Parallel()
+	type fields struct {
+		repo DeadLetterRepo
+	}
+	type args struct {
+		ctx context.Context
+		ids []int64
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		setup   func(t *testing.T, r DeadLetterRepo)
+		want    int64
+		wantErr bool
+	}{
+		{
+			name: "Should requeue given dead letters when repo succeeded",
+			fields: fields{
+				repo: mocks.NewMockDeadLetterRepo(gomock.NewController(t)),
+			},
+			args: args{
+				ctx: context.Background(),
+				ids: []int64{1, 2},
+			},
+			setup: func(t *testing.T, r DeadLetterRepo) {
+				t.Helper()
+				rr, ok := r.(*mocks.MockDeadLetterRepo)
+				if !ok {
+					t.Fatal("failed to cast repo to mock")
+				}
+				rr.EXPECT().Requeue(gomock.Any(), int64(1), int64(2)).Times(1).Return(int64(2), nil)
+			},
+			want:    2,
+			wantErr: false,
+		},
+		{
+			name: "Should requeue all dead letters when ids are empty",
+			fields: fields{
+				repo: mocks.NewMockDeadLetterRepo(gomock.NewController(t)),
+			},
+			args: args{
+				ctx: context.Background(),
+			},
+			setup: func(t *testing.T, r DeadLetterRepo) {
+				t.Helper()
+				rr, ok := r.(*mocks.MockDeadLetterRepo)
+				if !ok {
+					t.Fatal("failed to cast repo to mock")
+				}
+				rr.EXPECT().Requeue(gomock.Any()).Times(1).Return(int64(5), nil)
+			},
+			want:    5,
+			wantErr: false,
+		},
+		{
+			name: "Should return error when repo failed",
+			fields: fields{
+				repo: mocks.NewMockDeadLetterRepo(gomock.NewController(t)),
+			},
+			args: args{
+				ctx: context.Background(),
+				ids: []int64{1},
+			},
+			setup: func(t *testing.T, r DeadLetterRepo) {
+				t.Helper()
+				rr, ok := r.(*mocks.MockDeadLetterRepo)
+				if !ok {
+					t.Fatal("failed to cast repo to mock")
+				}
+				rr.EXPECT().
+					Requeue(gomock.Any(), int64(1)).
+					Times(1).
+					Return(int64(0), errors.New("failed to requeue"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			tt.setup(t, tt.fields.repo)
+			s := &Service{
+				repo: tt.fields.repo,
+			}
+			got, err := s.RequeueDeadLetters(tt.args.ctx, tt.args.ids...)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Service.RequeueDeadLetters() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("Service.RequeueDeadLetters() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,56 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/hrvadl/converter/sub/internal/service/privacy (interfaces: DeliveryRepo)
+//
+// Generated by this command:
+//
+//	mockgen -destination=./mocks/mock_delivery.go -package=mocks . DeliveryRepo
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	delivery "github.com/hrvadl/converter/sub/internal/storage/delivery"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockDeliveryRepo is a mock of DeliveryRepo interface.
+type MockDeliveryRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockDeliveryRepoMockRecorder
+}
+
+// MockDeliveryRepoMockRecorder is the mock recorder for MockDeliveryRepo.
+type MockDeliveryRepoMockRecorder struct {
+	mock *MockDeliveryRepo
+}
+
+// NewMockDeliveryRepo creates a new mock instance.
+func NewMockDeliveryRepo(ctrl *gomock.Controller) *MockDeliveryRepo {
+	mock := &MockDeliveryRepo{ctrl: ctrl}
+	mock.recorder = &MockDeliveryRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDeliveryRepo) EXPECT() *MockDeliveryRepoMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method.
+func (m *MockDeliveryRepo) Get(arg0 context.Context, arg1 delivery.Filter) ([]delivery.Delivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", arg0, arg1)
+	ret0, _ := ret[0].([]delivery.Delivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockDeliveryRepoMockRecorder) Get(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockDeliveryRepo)(nil).Get), arg0, arg1)
+}
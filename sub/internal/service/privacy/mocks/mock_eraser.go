@@ -0,0 +1,56 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/hrvadl/converter/sub/internal/service/privacy (interfaces: Eraser)
+//
+// Generated by this command:
+//
+//	mockgen -destination=./mocks/mock_eraser.go -package=mocks . Eraser
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	erasure "github.com/hrvadl/converter/sub/internal/storage/erasure"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockEraser is a mock of Eraser interface.
+type MockEraser struct {
+	ctrl     *gomock.Controller
+	recorder *MockEraserMockRecorder
+}
+
+// MockEraserMockRecorder is the mock recorder for MockEraser.
+type MockEraserMockRecorder struct {
+	mock *MockEraser
+}
+
+// NewMockEraser creates a new mock instance.
+func NewMockEraser(ctrl *gomock.Controller) *MockEraser {
+	mock := &MockEraser{ctrl: ctrl}
+	mock.recorder = &MockEraserMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEraser) EXPECT() *MockEraserMockRecorder {
+	return m.recorder
+}
+
+// Erase mocks base method.
+func (m *MockEraser) Erase(arg0 context.Context, arg1, arg2 string) (erasure.Audit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Erase", arg0, arg1, arg2)
+	ret0, _ := ret[0].(erasure.Audit)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Erase indicates an expected call of Erase.
+func (mr *MockEraserMockRecorder) Erase(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Erase", reflect.TypeOf((*MockEraser)(nil).Erase), arg0, arg1, arg2)
+}
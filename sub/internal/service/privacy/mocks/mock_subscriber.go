@@ -0,0 +1,71 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/hrvadl/converter/sub/internal/service/privacy (interfaces: SubscriberRepo)
+//
+// Generated by this command:
+//
+//	mockgen -destination=./mocks/mock_subscriber.go -package=mocks . SubscriberRepo
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	subscriber "github.com/hrvadl/converter/sub/internal/storage/subscriber"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockSubscriberRepo is a mock of SubscriberRepo interface.
+type MockSubscriberRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockSubscriberRepoMockRecorder
+}
+
+// MockSubscriberRepoMockRecorder is the mock recorder for MockSubscriberRepo.
+type MockSubscriberRepoMockRecorder struct {
+	mock *MockSubscriberRepo
+}
+
+// NewMockSubscriberRepo creates a new mock instance.
+func NewMockSubscriberRepo(ctrl *gomock.Controller) *MockSubscriberRepo {
+	mock := &MockSubscriberRepo{ctrl: ctrl}
+	mock.recorder = &MockSubscriberRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSubscriberRepo) EXPECT() *MockSubscriberRepoMockRecorder {
+	return m.recorder
+}
+
+// GetAddresses mocks base method.
+func (m *MockSubscriberRepo) GetAddresses(arg0 context.Context, arg1 int64) ([]subscriber.Address, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAddresses", arg0, arg1)
+	ret0, _ := ret[0].([]subscriber.Address)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAddresses indicates an expected call of GetAddresses.
+func (mr *MockSubscriberRepoMockRecorder) GetAddresses(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAddresses", reflect.TypeOf((*MockSubscriberRepo)(nil).GetAddresses), arg0, arg1)
+}
+
+// GetByCanonicalEmail mocks base method.
+func (m *MockSubscriberRepo) GetByCanonicalEmail(arg0 context.Context, arg1 string) (subscriber.Subscriber, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByCanonicalEmail", arg0, arg1)
+	ret0, _ := ret[0].(subscriber.Subscriber)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByCanonicalEmail indicates an expected call of GetByCanonicalEmail.
+func (mr *MockSubscriberRepoMockRecorder) GetByCanonicalEmail(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByCanonicalEmail", reflect.TypeOf((*MockSubscriberRepo)(nil).GetByCanonicalEmail), arg0, arg1)
+}
@@ -0,0 +1,57 @@
+package privacy
+
+import "time"
+
+// Export is a JSON document with all personal data
+// held about the person.
+type Export struct {
+	Email         string         `json:"email"`
+	ExportedAt    time.Time      `json:"exported_at"`
+	Subscription  *Subscription  `json:"subscription"`
+	Notifications []Notification `json:"notifications"`
+	Deliveries    []Delivery     `json:"deliveries"`
+}
+
+// Subscription represents subscription row and
+// mail preferences of the person.
+type Subscription struct {
+	ID        int64     `json:"id"`
+	Frequency string    `json:"frequency"`
+	Weekday   int       `json:"weekday"`
+	MonthDay  int       `json:"month_day"`
+	Locale    string    `json:"locale"`
+	Status    string    `json:"status"`
+	CreatedAt time.Time `json:"created_at"`
+	Addresses []Address `json:"addresses"`
+}
+
+// Address represents additional channel address
+// of the person, i.e. ID of the Telegram chat.
+type Address struct {
+	Channel   string    `json:"channel"`
+	Address   string    `json:"address"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Notification represents mail, which was enqueued to the person.
+type Notification struct {
+	ID        int64     `json:"id"`
+	Subject   string    `json:"subject"`
+	Body      string    `json:"body"`
+	Rate      float32   `json:"rate"`
+	Status    string    `json:"status"`
+	Attempts  int       `json:"attempts"`
+	RunDate   time.Time `json:"run_date"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Delivery represents single attempt to send mail to the person.
+type Delivery struct {
+	ID          int64     `json:"id"`
+	ScheduledAt time.Time `json:"scheduled_at"`
+	Rate        float32   `json:"rate"`
+	MessageID   string    `json:"message_id"`
+	Status      string    `json:"status"`
+	Error       string    `json:"error,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+}
@@ -0,0 +1,277 @@
+package privacy
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hrvadl/converter/sub/internal/storage/delivery"
+	"github.com/hrvadl/converter/sub/internal/storage/erasure"
+	"github.com/hrvadl/converter/sub/internal/storage/outbox"
+	"github.com/hrvadl/converter/sub/internal/storage/subscriber"
+)
+
+const operation = "privacy service"
+
+// exportBatchSize is a number of deliveries
+// read at once during the export.
+const exportBatchSize = 1000
+
+const tokenSubject = "Your personal data request"
+
+// NewService constructs new Service with provided arguments.
+// NOTE: neither of arguments can't be nil, or service will panic in
+// the future.
+func NewService(
+	sr SubscriberRepo,
+	nr NotificationRepo,
+	dr DeliveryRepo,
+	e Eraser,
+	m Mailer,
+	t Tokens,
+	n Normalizer,
+) *Service {
+	return &Service{
+		subscribers:   sr,
+		notifications: nr,
+		deliveries:    dr,
+		eraser:        e,
+		mailer:        m,
+		tokens:        t,
+		normalizer:    n,
+		now:           time.Now,
+	}
+}
+
+//go:generate mockgen -destination=./mocks/mock_subscriber.go -package=mocks . SubscriberRepo
+type SubscriberRepo interface {
+	GetByCanonicalEmail(ctx context.Context, canonical string) (subscriber.Subscriber, error)
+	GetAddresses(ctx context.Context, subscriberID int64) ([]subscriber.Address, error)
+}
+
+//go:generate mockgen -destination=./mocks/mock_notification.go -package=mocks . NotificationRepo
+type NotificationRepo interface {
+	GetByOwner(ctx context.Context, email string, subscriberID int64) ([]outbox.Notification, error)
+}
+
+//go:generate mockgen -destination=./mocks/mock_delivery.go -package=mocks . DeliveryRepo
+type DeliveryRepo interface {
+	Get(ctx context.Context, f delivery.Filter) ([]delivery.Delivery, error)
+}
+
+//go:generate mockgen -destination=./mocks/mock_eraser.go -package=mocks . Eraser
+type Eraser interface {
+	Erase(ctx context.Context, email, canonical string) (erasure.Audit, error)
+}
+
+//go:generate mockgen -destination=./mocks/mock_mailer.go -package=mocks . Mailer
+type Mailer interface {
+	Send(ctx context.Context, html, text, subject string, to ...string) (string, error)
+}
+
+//go:generate mockgen -destination=./mocks/mock_tokens.go -package=mocks . Tokens
+type Tokens interface {
+	Sign(email string) string
+	Verify(email, token string) error
+}
+
+//go:generate mockgen -destination=./mocks/mock_normalizer.go -package=mocks . Normalizer
+type Normalizer interface {
+	Normalize(email string) (string, error)
+}
+
+// Service is a main structure, responsible for exporting and
+// erasing personal data on the request of its owner. Ownership
+// of the email is proven with the token, mailed to it.
+type Service struct {
+	subscribers   SubscriberRepo
+	notifications NotificationRepo
+	deliveries    DeliveryRepo
+	eraser        Eraser
+	mailer        Mailer
+	tokens        Tokens
+	normalizer    Normalizer
+	now           func() time.Time
+}
+
+// RequestToken method mails the token to the given email, if it's
+// subscribed in any spelling. Unknown and invalid emails aren't reported
+// as an error, so the caller couldn't find out who is subscribed.
+func (s *Service) RequestToken(ctx context.Context, email string) error {
+	canonical, err := s.normalizer.Normalize(email)
+	if err != nil {
+		return nil
+	}
+
+	_, err = s.subscribers.GetByCanonicalEmail(ctx, canonical)
+	if errors.Is(err, subscriber.ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("%s: failed to get subscriber: %w", operation, err)
+	}
+
+	token := s.tokens.Sign(email)
+	msg := fmt.Sprintf(
+		"<p>Use the following token to export or erase your personal data:</p><p><code>%s</code></p>"+
+			"<p>If you didn't request it, just ignore this mail.</p>",
+		token,
+	)
+	text := fmt.Sprintf(
+		"Use the following token to export or erase your personal data:\n\n%s\n\n"+
+			"If you didn't request it, just ignore this mail.\n",
+		token,
+	)
+	if _, err := s.mailer.Send(ctx, msg, text, tokenSubject, email); err != nil {
+		return fmt.Errorf("%s: failed to send token: %w", operation, err)
+	}
+
+	return nil
+}
+
+// Export method returns JSON document with the subscription, notifications
+// and delivery history of the given email. History is matched both by the
+// email and the subscriber ID, like Erase matches it, so history of the
+// other addresses of the subscriber is exported as well. Returns
+// ErrInvalidToken if token wasn't issued for the email or is expired.
+func (s *Service) Export(ctx context.Context, email, token string) ([]byte, error) {
+	if err := s.tokens.Verify(email, token); err != nil {
+		return nil, fmt.Errorf("%s: %w", operation, err)
+	}
+
+	e := Export{
+		Email:         email,
+		ExportedAt:    s.now().UTC(),
+		Notifications: []Notification{},
+		Deliveries:    []Delivery{},
+	}
+
+	canonical, err := s.normalizer.Normalize(email)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to normalize email: %w", operation, err)
+	}
+
+	sub, err := s.subscribers.GetByCanonicalEmail(ctx, canonical)
+	if err != nil && !errors.Is(err, subscriber.ErrNotFound) {
+		return nil, fmt.Errorf("%s: failed to get subscriber: %w", operation, err)
+	}
+	if err == nil {
+		if sub.Addresses, err = s.subscribers.GetAddresses(ctx, sub.ID); err != nil {
+			return nil, fmt.Errorf("%s: failed to get addresses: %w", operation, err)
+		}
+		e.Subscription = mapSubscription(sub)
+	}
+
+	notifications, err := s.notifications.GetByOwner(ctx, email, sub.ID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to get notifications: %w", operation, err)
+	}
+	for i := range notifications {
+		e.Notifications = append(e.Notifications, mapNotification(notifications[i]))
+	}
+
+	owner := delivery.Owner{Email: email, SubscriberID: sub.ID}
+	if e.Deliveries, err = s.exportDeliveries(ctx, owner); err != nil {
+		return nil, fmt.Errorf("%s: failed to get deliveries: %w", operation, err)
+	}
+
+	data, err := json.Marshal(e)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to marshal export: %w", operation, err)
+	}
+
+	return data, nil
+}
+
+// Erase method deletes subscription, notifications and delivery history
+// of the given email and returns anonymised audit entry of it. Subscription
+// is found by the canonical email, so it's erased regardless of the
+// spelling. Returns ErrInvalidToken if token wasn't issued for the email
+// or is expired.
+func (s *Service) Erase(ctx context.Context, email, token string) (erasure.Audit, error) {
+	if err := s.tokens.Verify(email, token); err != nil {
+		return erasure.Audit{}, fmt.Errorf("%s: %w", operation, err)
+	}
+
+	canonical, err := s.normalizer.Normalize(email)
+	if err != nil {
+		return erasure.Audit{}, fmt.Errorf("%s: failed to normalize email: %w", operation, err)
+	}
+
+	a, err := s.eraser.Erase(ctx, email, canonical)
+	if err != nil {
+		return erasure.Audit{}, fmt.Errorf("%s: failed to erase personal data: %w", operation, err)
+	}
+
+	return a, nil
+}
+
+func (s *Service) exportDeliveries(ctx context.Context, owner delivery.Owner) ([]Delivery, error) {
+	res := []Delivery{}
+	f := delivery.Filter{Owner: owner, Limit: exportBatchSize}
+	for {
+		batch, err := s.deliveries.Get(ctx, f)
+		if err != nil {
+			return nil, err
+		}
+
+		for i := range batch {
+			res = append(res, mapDelivery(batch[i]))
+		}
+
+		if len(batch) < exportBatchSize {
+			return res, nil
+		}
+		f.BeforeID = batch[len(batch)-1].ID
+	}
+}
+
+func mapSubscription(s subscriber.Subscriber) *Subscription {
+	res := &Subscription{
+		ID:        s.ID,
+		Frequency: string(s.Frequency),
+		Weekday:   s.Weekday,
+		MonthDay:  s.MonthDay,
+		Locale:    string(s.Locale),
+		Status:    string(s.Status),
+		CreatedAt: s.CreatedAt.UTC(),
+		Addresses: make([]Address, 0, len(s.Addresses)),
+	}
+
+	for _, a := range s.Addresses {
+		res.Addresses = append(res.Addresses, Address{
+			Channel:   string(a.Channel),
+			Address:   a.Address,
+			CreatedAt: a.CreatedAt.UTC(),
+		})
+	}
+
+	return res
+}
+
+func mapNotification(n outbox.Notification) Notification {
+	return Notification{
+		ID:        n.ID,
+		Subject:   n.Subject,
+		Body:      n.Body,
+		Rate:      n.Rate,
+		Status:    string(n.Status),
+		Attempts:  n.Attempts,
+		RunDate:   n.RunDate.UTC(),
+		CreatedAt: n.CreatedAt.UTC(),
+	}
+}
+
+func mapDelivery(d delivery.Delivery) Delivery {
+	return Delivery{
+		ID:          d.ID,
+		ScheduledAt: d.ScheduledAt.UTC(),
+		Rate:        d.Rate,
+		MessageID:   d.MessageID,
+		Status:      string(d.Status),
+		Error:       d.Error,
+		CreatedAt:   d.CreatedAt.UTC(),
+	}
+}
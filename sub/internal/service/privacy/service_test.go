@@ -0,0 +1,512 @@
+package privacy
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"go.uber.org/mock/gomock"
+
+	"github.com/hrvadl/converter/sub/internal/service/privacy/mocks"
+	"github.com/hrvadl/converter/sub/internal/storage/delivery"
+	"github.com/hrvadl/converter/sub/internal/storage/erasure"
+	"github.com/hrvadl/converter/sub/internal/storage/outbox"
+	"github.com/hrvadl/converter/sub/internal/storage/subscriber"
+)
+
+type deps struct {
+	subscribers   *mocks.MockSubscriberRepo
+	notifications *mocks.MockNotificationRepo
+	deliveries    *mocks.MockDeliveryRepo
+	eraser        *mocks.MockEraser
+	mailer        *mocks.MockMailer
+	tokens        *mocks.MockTokens
+	normalizer    *mocks.MockNormalizer
+}
+
+func newTestService(t *testing.T) (*Service, deps) {
+	t.Helper()
+	ctrl := gomock.NewController(t)
+	d := deps{
+		subscribers:   mocks.NewMockSubscriberRepo(ctrl),
+		notifications: mocks.NewMockNotificationRepo(ctrl),
+		deliveries:    mocks.NewMockDeliveryRepo(ctrl),
+		eraser:        mocks.NewMockEraser(ctrl),
+		mailer:        mocks.NewMockMailer(ctrl),
+		tokens:        mocks.NewMockTokens(ctrl),
+		normalizer:    mocks.NewMockNormalizer(ctrl),
+	}
+	svc := NewService(
+		d.subscribers,
+		d.notifications,
+		d.deliveries,
+		d.eraser,
+		d.mailer,
+		d.tokens,
+		d.normalizer,
+	)
+	return svc, d
+}
+
+func TestServiceRequestToken(t *testing.T) {
+	t.Parallel()
+	containsToken := gomock.Cond(func(msg any) bool {
+		return strings.Contains(msg.(string), "token")
+	})
+	tests := []struct {
+		name    string
+		email   string
+		setup   func(d deps)
+		wantErr bool
+	}{
+		{
+			name:  "Should mail token when subscriber exists",
+			email: "test@test.com",
+			setup: func(d deps) {
+				d.normalizer.EXPECT().Normalize("test@test.com").Times(1).Return("test@test.com", nil)
+				d.subscribers.EXPECT().
+					GetByCanonicalEmail(gomock.Any(), "test@test.com").
+					Times(1).
+					Return(subscriber.Subscriber{ID: 1, Email: "test@test.com"}, nil)
+				d.tokens.EXPECT().Sign("test@test.com").Times(1).Return("token")
+				d.mailer.EXPECT().
+					Send(gomock.Any(), containsToken, containsToken, tokenSubject, "test@test.com").
+					Times(1).
+					Return("id", nil)
+			},
+		},
+		{
+			name:  "Should not mail token nor fail when subscriber doesn't exist",
+			email: "test@test.com",
+			setup: func(d deps) {
+				d.normalizer.EXPECT().Normalize("test@test.com").Times(1).Return("test@test.com", nil)
+				d.subscribers.EXPECT().
+					GetByCanonicalEmail(gomock.Any(), "test@test.com").
+					Times(1).
+					Return(subscriber.Subscriber{}, subscriber.ErrNotFound)
+			},
+		},
+		{
+			name:  "Should mail token when subscriber exists with email spelled differently",
+			email: "Test@Test.com",
+			setup: func(d deps) {
+				d.normalizer.EXPECT().Normalize("Test@Test.com").Times(1).Return("test@test.com", nil)
+				d.subscribers.EXPECT().
+					GetByCanonicalEmail(gomock.Any(), "test@test.com").
+					Times(1).
+					Return(subscriber.Subscriber{ID: 1, Email: "test@test.com"}, nil)
+				d.tokens.EXPECT().Sign("Test@Test.com").Times(1).Return("token")
+				d.mailer.EXPECT().
+					Send(gomock.Any(), containsToken, containsToken, tokenSubject, "Test@Test.com").
+					Times(1).
+					Return("id", nil)
+			},
+		},
+		{
+			name:  "Should not mail token nor fail when email is invalid",
+			email: "test",
+			setup: func(d deps) {
+				d.normalizer.EXPECT().Normalize("test").Times(1).Return("", errors.New("invalid email"))
+			},
+		},
+		{
+			name:  "Should return err when failed to get subscriber",
+			email: "test@test.com",
+			setup: func(d deps) {
+				d.normalizer.EXPECT().Normalize("test@test.com").Times(1).Return("test@test.com", nil)
+				d.subscribers.EXPECT().
+					GetByCanonicalEmail(gomock.Any(), "test@test.com").
+					Times(1).
+					Return(subscriber.Subscriber{}, errors.New("failed to get"))
+			},
+			wantErr: true,
+		},
+		{
+			name:  "Should return err when failed to mail token",
+			email: "test@test.com",
+			setup: func(d deps) {
+				d.normalizer.EXPECT().Normalize("test@test.com").Times(1).Return("test@test.com", nil)
+				d.subscribers.EXPECT().
+					GetByCanonicalEmail(gomock.Any(), "test@test.com").
+					Times(1).
+					Return(subscriber.Subscriber{ID: 1, Email: "test@test.com"}, nil)
+				d.tokens.EXPECT().Sign("test@test.com").Times(1).Return("token")
+				d.mailer.EXPECT().
+					Send(gomock.Any(), gomock.Any(), gomock.Any(), tokenSubject, "test@test.com").
+					Times(1).
+					Return("", errors.New("failed to send"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			svc, d := newTestService(t)
+			tt.setup(d)
+			err := svc.RequestToken(context.Background(), tt.email)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("RequestToken() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestServiceExport(t *testing.T) {
+	t.Parallel()
+	now := time.Date(2024, 5, 2, 12, 0, 0, 0, time.UTC)
+	createdAt := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	full := make([]delivery.Delivery, exportBatchSize)
+	exported := make([]Delivery, 0, exportBatchSize+1)
+	for i := range full {
+		full[i] = delivery.Delivery{ID: int64(exportBatchSize + 1 - i), Status: delivery.StatusSent}
+		exported = append(exported, Delivery{ID: full[i].ID, Status: "sent"})
+	}
+	exported = append(exported, Delivery{ID: 1, Status: "failed", Error: "timeout"})
+	tests := []struct {
+		name    string
+		email   string
+		token   string
+		setup   func(d deps)
+		want    *Export
+		wantErr error
+	}{
+		{
+			name:  "Should export subscription, notifications and paged deliveries",
+			email: "test@test.com",
+			token: "token",
+			setup: func(d deps) {
+				d.tokens.EXPECT().Verify("test@test.com", "token").Times(1).Return(nil)
+				d.normalizer.EXPECT().Normalize("test@test.com").Times(1).Return("test@test.com", nil)
+				d.subscribers.EXPECT().
+					GetByCanonicalEmail(gomock.Any(), "test@test.com").
+					Times(1).
+					Return(subscriber.Subscriber{
+						ID:        1,
+						Email:     "test@test.com",
+						Frequency: subscriber.FrequencyWeekly,
+						Weekday:   1,
+						Locale:    subscriber.LocaleUkrainian,
+						Status:    subscriber.StatusActive,
+						CreatedAt: createdAt,
+					}, nil)
+				d.subscribers.EXPECT().
+					GetAddresses(gomock.Any(), int64(1)).
+					Times(1).
+					Return([]subscriber.Address{{
+						SubscriberID: 1,
+						Channel:      subscriber.ChannelTelegram,
+						Address:      "42",
+						CreatedAt:    createdAt,
+					}}, nil)
+				d.notifications.EXPECT().
+					GetByOwner(gomock.Any(), "test@test.com", int64(1)).
+					Times(1).
+					Return([]outbox.Notification{{
+						ID:        2,
+						Email:     "test@test.com",
+						Subject:   "Rate",
+						Body:      "41.5",
+						Rate:      41.5,
+						Status:    outbox.StatusSent,
+						Attempts:  1,
+						RunDate:   createdAt,
+						CreatedAt: createdAt,
+					}}, nil)
+
+				gomock.InOrder(
+					d.deliveries.EXPECT().
+						Get(gomock.Any(), delivery.Filter{
+							Owner: delivery.Owner{Email: "test@test.com", SubscriberID: 1},
+							Limit: exportBatchSize,
+						}).
+						Times(1).
+						Return(full, nil),
+					d.deliveries.EXPECT().
+						Get(gomock.Any(), delivery.Filter{
+							Owner:    delivery.Owner{Email: "test@test.com", SubscriberID: 1},
+							Limit:    exportBatchSize,
+							BeforeID: 2,
+						}).
+						Times(1).
+						Return([]delivery.Delivery{{ID: 1, Status: delivery.StatusFailed, Error: "timeout"}}, nil),
+				)
+			},
+			want: &Export{
+				Email:      "test@test.com",
+				ExportedAt: now,
+				Subscription: &Subscription{
+					ID:        1,
+					Frequency: "weekly",
+					Weekday:   1,
+					Locale:    "uk",
+					Status:    "active",
+					CreatedAt: createdAt,
+					Addresses: []Address{{
+						Channel:   "telegram",
+						Address:   "42",
+						CreatedAt: createdAt,
+					}},
+				},
+				Notifications: []Notification{{
+					ID:        2,
+					Subject:   "Rate",
+					Body:      "41.5",
+					Rate:      41.5,
+					Status:    "sent",
+					Attempts:  1,
+					RunDate:   createdAt,
+					CreatedAt: createdAt,
+				}},
+				Deliveries: exported,
+			},
+		},
+		{
+			name:  "Should export history of the subscriber when email is spelled differently",
+			email: "Test@Test.com",
+			token: "token",
+			setup: func(d deps) {
+				d.tokens.EXPECT().Verify("Test@Test.com", "token").Times(1).Return(nil)
+				d.normalizer.EXPECT().Normalize("Test@Test.com").Times(1).Return("test@test.com", nil)
+				d.subscribers.EXPECT().
+					GetByCanonicalEmail(gomock.Any(), "test@test.com").
+					Times(1).
+					Return(subscriber.Subscriber{
+						ID:        1,
+						Email:     "test@test.com",
+						Frequency: subscriber.FrequencyDaily,
+						Locale:    subscriber.LocaleEnglish,
+						Status:    subscriber.StatusActive,
+						CreatedAt: createdAt,
+					}, nil)
+				d.subscribers.EXPECT().
+					GetAddresses(gomock.Any(), int64(1)).
+					Times(1).
+					Return([]subscriber.Address{}, nil)
+				d.notifications.EXPECT().
+					GetByOwner(gomock.Any(), "Test@Test.com", int64(1)).
+					Times(1).
+					Return([]outbox.Notification{{ID: 2, Email: "test@test.com", Status: outbox.StatusSent}}, nil)
+				d.deliveries.EXPECT().
+					Get(gomock.Any(), delivery.Filter{
+						Owner: delivery.Owner{Email: "Test@Test.com", SubscriberID: 1},
+						Limit: exportBatchSize,
+					}).
+					Times(1).
+					Return([]delivery.Delivery{{ID: 3, Email: "test@test.com", Status: delivery.StatusSent}}, nil)
+			},
+			want: &Export{
+				Email:      "Test@Test.com",
+				ExportedAt: now,
+				Subscription: &Subscription{
+					ID:        1,
+					Frequency: "daily",
+					Locale:    "en",
+					Status:    "active",
+					CreatedAt: createdAt,
+					Addresses: []Address{},
+				},
+				Notifications: []Notification{{ID: 2, Status: "sent"}},
+				Deliveries:    []Delivery{{ID: 3, Status: "sent"}},
+			},
+		},
+		{
+			name:  "Should export history without subscription when subscriber doesn't exist",
+			email: "test@test.com",
+			token: "token",
+			setup: func(d deps) {
+				d.tokens.EXPECT().Verify("test@test.com", "token").Times(1).Return(nil)
+				d.normalizer.EXPECT().Normalize("test@test.com").Times(1).Return("test@test.com", nil)
+				d.subscribers.EXPECT().
+					GetByCanonicalEmail(gomock.Any(), "test@test.com").
+					Times(1).
+					Return(subscriber.Subscriber{}, subscriber.ErrNotFound)
+				d.notifications.EXPECT().
+					GetByOwner(gomock.Any(), "test@test.com", int64(0)).
+					Times(1).
+					Return([]outbox.Notification{}, nil)
+				d.deliveries.EXPECT().
+					Get(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return([]delivery.Delivery{}, nil)
+			},
+			want: &Export{
+				Email:         "test@test.com",
+				ExportedAt:    now,
+				Notifications: []Notification{},
+				Deliveries:    []Delivery{},
+			},
+		},
+		{
+			name:  "Should return err when token is invalid",
+			email: "test@test.com",
+			token: "token",
+			setup: func(d deps) {
+				d.tokens.EXPECT().Verify("test@test.com", "token").Times(1).Return(ErrInvalidToken)
+			},
+			wantErr: ErrInvalidToken,
+		},
+		{
+			name:  "Should return err when failed to get deliveries",
+			email: "test@test.com",
+			token: "token",
+			setup: func(d deps) {
+				d.tokens.EXPECT().Verify("test@test.com", "token").Times(1).Return(nil)
+				d.normalizer.EXPECT().Normalize("test@test.com").Times(1).Return("test@test.com", nil)
+				d.subscribers.EXPECT().
+					GetByCanonicalEmail(gomock.Any(), "test@test.com").
+					Times(1).
+					Return(subscriber.Subscriber{}, subscriber.ErrNotFound)
+				d.notifications.EXPECT().
+					GetByOwner(gomock.Any(), "test@test.com", int64(0)).
+					Times(1).
+					Return([]outbox.Notification{}, nil)
+				d.deliveries.EXPECT().
+					Get(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil, errors.New("failed to get"))
+			},
+			wantErr: errors.New("failed to get"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			svc, d := newTestService(t)
+			svc.now = func() time.Time { return now }
+			tt.setup(d)
+
+			data, err := svc.Export(context.Background(), tt.email, tt.token)
+			if (err != nil) != (tt.wantErr != nil) {
+				t.Fatalf("Export() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if errors.Is(tt.wantErr, ErrInvalidToken) && !errors.Is(err, ErrInvalidToken) {
+				t.Fatalf("Export() error = %v, want %v", err, ErrInvalidToken)
+			}
+			if tt.want == nil {
+				return
+			}
+
+			var got Export
+			if err := json.Unmarshal(data, &got); err != nil {
+				t.Fatalf("Failed to unmarshal export: %v", err)
+			}
+
+			if !reflect.DeepEqual(&got, tt.want) {
+				t.Errorf("Export() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestServiceErase(t *testing.T) {
+	t.Parallel()
+	erasedAt := time.Date(2024, 5, 2, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name    string
+		email   string
+		token   string
+		setup   func(d deps)
+		want    erasure.Audit
+		wantErr bool
+	}{
+		{
+			name:  "Should erase personal data when token is valid",
+			email: "test@test.com",
+			token: "token",
+			setup: func(d deps) {
+				d.tokens.EXPECT().Verify("test@test.com", "token").Times(1).Return(nil)
+				d.normalizer.EXPECT().Normalize("test@test.com").Times(1).Return("test@test.com", nil)
+				d.eraser.EXPECT().
+					Erase(gomock.Any(), "test@test.com", "test@test.com").
+					Times(1).
+					Return(erasure.Audit{
+						ID:                   1,
+						SubscriberDeleted:    true,
+						NotificationsDeleted: 2,
+						DeliveriesDeleted:    3,
+						CreatedAt:            erasedAt,
+					}, nil)
+			},
+			want: erasure.Audit{
+				ID:                   1,
+				SubscriberDeleted:    true,
+				NotificationsDeleted: 2,
+				DeliveriesDeleted:    3,
+				CreatedAt:            erasedAt,
+			},
+		},
+		{
+			name:  "Should erase personal data by canonical email",
+			email: "Test@Test.com",
+			token: "token",
+			setup: func(d deps) {
+				d.tokens.EXPECT().Verify("Test@Test.com", "token").Times(1).Return(nil)
+				d.normalizer.EXPECT().Normalize("Test@Test.com").Times(1).Return("test@test.com", nil)
+				d.eraser.EXPECT().
+					Erase(gomock.Any(), "Test@Test.com", "test@test.com").
+					Times(1).
+					Return(erasure.Audit{ID: 1, SubscriberDeleted: true, CreatedAt: erasedAt}, nil)
+			},
+			want: erasure.Audit{ID: 1, SubscriberDeleted: true, CreatedAt: erasedAt},
+		},
+		{
+			name:  "Should not erase personal data when token is invalid",
+			email: "test@test.com",
+			token: "token",
+			setup: func(d deps) {
+				d.tokens.EXPECT().Verify("test@test.com", "token").Times(1).Return(ErrInvalidToken)
+			},
+			wantErr: true,
+		},
+		{
+			name:  "Should return err when failed to normalize email",
+			email: "test@test.com",
+			token: "token",
+			setup: func(d deps) {
+				d.tokens.EXPECT().Verify("test@test.com", "token").Times(1).Return(nil)
+				d.normalizer.EXPECT().
+					Normalize("test@test.com").
+					Times(1).
+					Return("", errors.New("failed to normalize"))
+			},
+			wantErr: true,
+		},
+		{
+			name:  "Should return err when failed to erase personal data",
+			email: "test@test.com",
+			token: "token",
+			setup: func(d deps) {
+				d.tokens.EXPECT().Verify("test@test.com", "token").Times(1).Return(nil)
+				d.normalizer.EXPECT().Normalize("test@test.com").Times(1).Return("test@test.com", nil)
+				d.eraser.EXPECT().
+					Erase(gomock.Any(), "test@test.com", "test@test.com").
+					Times(1).
+					Return(erasure.Audit{}, errors.New("failed to erase"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			svc, d := newTestService(t)
+			tt.setup(d)
+
+			got, err := svc.Erase(context.Background(), tt.email, tt.token)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Erase() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Erase() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
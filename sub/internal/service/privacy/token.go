@@ -0,0 +1,76 @@
+package privacy
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrInvalidToken is returned, when token is malformed, expired
+// or was issued for the other email.
+var ErrInvalidToken = errors.New("invalid privacy token")
+
+// tokenPurpose is mixed into the signature, so token signed
+// with the same secret for other purposes couldn't be reused.
+const tokenPurpose = "privacy"
+
+// NewSigner constructs new Signer with provided secret and TTL
+// of the issued tokens.
+// NOTE: secret shouldn't be empty, otherwise anyone could forge the token.
+func NewSigner(secret string, ttl time.Duration) *Signer {
+	return &Signer{
+		secret: []byte(secret),
+		ttl:    ttl,
+		now:    time.Now,
+	}
+}
+
+// Signer issues and verifies tokens, which prove that the
+// caller owns the email. Token is stateless: it consists of the
+// expiration time and HMAC-SHA256 of the email and expiration time.
+type Signer struct {
+	secret []byte
+	ttl    time.Duration
+	now    func() time.Time
+}
+
+// Sign method issues new token for the given email.
+func (s *Signer) Sign(email string) string {
+	exp := strconv.FormatInt(s.now().Add(s.ttl).Unix(), 10)
+	return exp + "." + base64.RawURLEncoding.EncodeToString(s.mac(email, exp))
+}
+
+// Verify method checks that token was issued for the given
+// email and isn't expired yet. Returns ErrInvalidToken otherwise.
+func (s *Signer) Verify(email, token string) error {
+	exp, sig, ok := strings.Cut(token, ".")
+	if !ok {
+		return ErrInvalidToken
+	}
+
+	expUnix, err := strconv.ParseInt(exp, 10, 64)
+	if err != nil {
+		return ErrInvalidToken
+	}
+
+	got, err := base64.RawURLEncoding.DecodeString(sig)
+	if err != nil || !hmac.Equal(got, s.mac(email, exp)) {
+		return ErrInvalidToken
+	}
+
+	if !s.now().Before(time.Unix(expUnix, 0)) {
+		return ErrInvalidToken
+	}
+
+	return nil
+}
+
+func (s *Signer) mac(email, exp string) []byte {
+	h := hmac.New(sha256.New, s.secret)
+	h.Write([]byte(tokenPurpose + "|" + strings.ToLower(email) + "|" + exp))
+	return h.Sum(nil)
+}
@@ -0,0 +1,92 @@
+package privacy
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestSignerVerify(t *testing.T) {
+	t.Parallel()
+	issuedAt := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name    string
+		email   string
+		token   func(s *Signer) string
+		now     time.Time
+		wantErr bool
+	}{
+		{
+			name:  "Should accept token when it was issued for the email",
+			email: "test@test.com",
+			token: func(s *Signer) string { return s.Sign("test@test.com") },
+			now:   issuedAt.Add(time.Hour),
+		},
+		{
+			name:  "Should accept token when email differs only in case",
+			email: "Test@Test.com",
+			token: func(s *Signer) string { return s.Sign("test@test.com") },
+			now:   issuedAt.Add(time.Hour),
+		},
+		{
+			name:    "Should reject token when it was issued for the other email",
+			email:   "other@test.com",
+			token:   func(s *Signer) string { return s.Sign("test@test.com") },
+			now:     issuedAt.Add(time.Hour),
+			wantErr: true,
+		},
+		{
+			name:    "Should reject token when it's expired",
+			email:   "test@test.com",
+			token:   func(s *Signer) string { return s.Sign("test@test.com") },
+			now:     issuedAt.Add(time.Hour * 24),
+			wantErr: true,
+		},
+		{
+			name:  "Should reject token when expiration time is tampered",
+			email: "test@test.com",
+			token: func(s *Signer) string {
+				tok := s.Sign("test@test.com")
+				return "9999999999" + tok[len("1714651200"):]
+			},
+			now:     issuedAt.Add(time.Hour),
+			wantErr: true,
+		},
+		{
+			name:  "Should reject token when it was signed with the other secret",
+			email: "test@test.com",
+			token: func(_ *Signer) string {
+				s := NewSigner("other", time.Hour*24)
+				s.now = func() time.Time { return issuedAt }
+				return s.Sign("test@test.com")
+			},
+			now:     issuedAt.Add(time.Hour),
+			wantErr: true,
+		},
+		{
+			name:    "Should reject token when it's malformed",
+			email:   "test@test.com",
+			token:   func(_ *Signer) string { return "token" },
+			now:     issuedAt.Add(time.Hour),
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			s := NewSigner("secret", time.Hour*24)
+			s.now = func() time.Time { return issuedAt }
+			token := tt.token(s)
+
+			s.now = func() time.Time { return tt.now }
+			err := s.Verify(tt.email, token)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Verify() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr && !errors.Is(err, ErrInvalidToken) {
+				t.Errorf("Verify() error = %v, want %v", err, ErrInvalidToken)
+			}
+		})
+	}
+}
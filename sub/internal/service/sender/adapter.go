@@ -2,37 +2,104 @@ package sender
 
 import (
 	"context"
+	"fmt"
 	"log/slog"
 	"time"
+
+	"github.com/hrvadl/converter/sub/internal/service/sender/report"
 )
 
-// NewCronJobAdapter constructs CronJobAdapter for Sender interface
+// NewCronJobAdapter constructs CronJobAdapter for Enqueuer interface
 // compatible structure.
 // NOTE: neither of arguments can't be nil, or service will panic in the future.
-func NewCronJobAdapter(s Sender, log *slog.Logger) *CronJobAdapter {
+func NewCronJobAdapter(e Enqueuer, log *slog.Logger) *CronJobAdapter {
 	return &CronJobAdapter{
-		sender: s,
-		log:    log,
+		enqueuer: e,
+		log:      log,
 	}
 }
 
-//go:generate mockgen -destination=./mocks/mock_sender.go -package=mocks . Sender
-type Sender interface {
-	Send(ctx context.Context) error
+//go:generate mockgen -destination=./mocks/mock_enqueuer.go -package=mocks . Enqueuer
+type Enqueuer interface {
+	Enqueue(ctx context.Context, at time.Time) (int, error)
 }
 
-// CronJobAdapter is a handy wrapper to help Sender compatible
+// CronJobAdapter is a handy wrapper to help Enqueuer compatible
 // structure fit to the CronJob required interface.
 type CronJobAdapter struct {
-	sender Sender
-	log    *slog.Logger
+	enqueuer Enqueuer
+	log      *slog.Logger
 }
 
-// Do method log's each call then creates context with default timeout of 10 seconds
-// and then executes original function, returning the error if any.
-func (c *CronJobAdapter) Do() error {
-	c.log.Info("Sending mails in cron job")
-	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+// enqueueTimeout is a timeout of the single enqueue run. Run, which
+// has timed out, continues where it has stopped on retry.
+const enqueueTimeout = time.Minute
+
+// DoAt method log's each call then creates context with enqueueTimeout
+// and then executes original function for the run scheduled at the given point
+// of time, returning the error if any. Each batch is saved in its own
+// transaction, so cancelled run continues where it has stopped on retry.
+func (c *CronJobAdapter) DoAt(ctx context.Context, at time.Time) error {
+	c.log.Info("Enqueueing mails in cron job", "at", at)
+	ctx, cancel := context.WithTimeout(ctx, enqueueTimeout)
 	defer cancel()
-	return c.sender.Send(ctx)
+
+	n, err := c.enqueuer.Enqueue(ctx, at)
+	if err != nil {
+		return err
+	}
+
+	c.log.Info("Finished enqueueing mails", "enqueued", n)
+	return nil
+}
+
+// NewDeliveryJobAdapter constructs DeliveryJobAdapter for Deliverer interface
+// compatible structure.
+// NOTE: neither of arguments can't be nil, or service will panic in the future.
+func NewDeliveryJobAdapter(d Deliverer, log *slog.Logger) *DeliveryJobAdapter {
+	return &DeliveryJobAdapter{
+		deliverer: d,
+		log:       log,
+	}
+}
+
+//go:generate mockgen -destination=./mocks/mock_deliverer.go -package=mocks . Deliverer
+type Deliverer interface {
+	Deliver(ctx context.Context) (report.Report, error)
+}
+
+// DeliveryJobAdapter is a handy wrapper to help Deliverer compatible
+// structure fit to the CronJob required interface.
+type DeliveryJobAdapter struct {
+	deliverer Deliverer
+	log       *slog.Logger
+}
+
+// Do method creates context with default timeout of 1 minute
+// and then executes original function. Each recipient, who didn't receive
+// the notification, is logged separately, as well as the summary of each
+// channel, and the error is returned if there's at least one of them.
+func (d *DeliveryJobAdapter) Do(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, time.Minute)
+	defer cancel()
+
+	rep, err := d.deliverer.Deliver(ctx)
+	failed := rep.Failed()
+	for _, f := range failed {
+		d.log.Error("Failed to send notification", "channel", f.Channel, "recipient", f.Email, "err", f.Err)
+	}
+
+	for c, s := range rep.ByChannel() {
+		d.log.Info("Finished sending notifications", "channel", c, "sent", s.Sent, "failed", s.Failed)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if len(failed) != 0 {
+		return fmt.Errorf("failed to send notifications to %d of %d recipients", len(failed), len(rep.Results))
+	}
+
+	return nil
 }
@@ -1,59 +1,63 @@
 package sender
 
 import (
+	"context"
 	"errors"
 	"log/slog"
 	"reflect"
 	"testing"
+	"time"
 
 	"go.uber.org/mock/gomock"
 
 	"github.com/hrvadl/converter/sub/internal/service/sender/mocks"
+	"github.com/hrvadl/converter/sub/internal/service/sender/report"
 )
 
-func TestCronJobAdapterDo(t *testing.T) {
+func TestCronJobAdapterDoAt(t *testing.T) {
 	t.Parallel()
 	type fields struct {
-		sender Sender
-		log    *slog.Logger
+		enqueuer Enqueuer
+		log      *slog.Logger
 	}
+	at := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
 	tests := []struct {
 		name    string
 		fields  fields
-		setup   func(t *testing.T, m Sender)
+		setup   func(t *testing.T, m Enqueuer)
 		wantErr bool
 	}{
 		{
-			name: "Should return err when sender failed",
+			name: "Should return err when enqueuer failed",
 			fields: fields{
-				sender: mocks.NewMockSender(gomock.NewController(t)),
-				log:    slog.Default(),
+				enqueuer: mocks.NewMockEnqueuer(gomock.NewController(t)),
+				log:      slog.Default(),
 			},
 			wantErr: true,
-			setup: func(t *testing.T, m Sender) {
+			setup: func(t *testing.T, m Enqueuer) {
 				t.Helper()
-				ss, ok := m.(*mocks.MockSender)
+				e, ok := m.(*mocks.MockEnqueuer)
 				if !ok {
-					t.Fatal("failed to cast sender to mock")
+					t.Fatal("failed to cast enqueuer to mock")
 				}
-				err := errors.New("failed to send")
-				ss.EXPECT().Send(gomock.Any()).Times(1).Return(err)
+				err := errors.New("failed to enqueue")
+				e.EXPECT().Enqueue(gomock.Any(), at).Times(1).Return(0, err)
 			},
 		},
 		{
-			name: "Should not return err when sender succeeded",
+			name: "Should not return err when enqueuer succeeded",
 			fields: fields{
-				sender: mocks.NewMockSender(gomock.NewController(t)),
-				log:    slog.Default(),
+				enqueuer: mocks.NewMockEnqueuer(gomock.NewController(t)),
+				log:      slog.Default(),
 			},
 			wantErr: false,
-			setup: func(t *testing.T, m Sender) {
+			setup: func(t *testing.T, m Enqueuer) {
 				t.Helper()
-				ss, ok := m.(*mocks.MockSender)
+				e, ok := m.(*mocks.MockEnqueuer)
 				if !ok {
-					t.Fatal("failed to cast sender to mock")
+					t.Fatal("failed to cast enqueuer to mock")
 				}
-				ss.EXPECT().Send(gomock.Any()).Times(1).Return(nil)
+				e.EXPECT().Enqueue(gomock.Any(), at).Times(1).Return(2, nil)
 			},
 		},
 	}
@@ -61,14 +65,14 @@ func TestCronJobAdapterDo(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
-			tt.setup(t, tt.fields.sender)
+			tt.setup(t, tt.fields.enqueuer)
 			c := &CronJobAdapter{
-				sender: tt.fields.sender,
-				log:    tt.fields.log,
+				enqueuer: tt.fields.enqueuer,
+				log:      tt.fields.log,
 			}
 
-			if err := c.Do(); (err != nil) != tt.wantErr {
-				t.Errorf("CronJobAdapter.Do() error = %v, wantErr %v", err, tt.wantErr)
+			if err := c.DoAt(context.Background(), at); (err != nil) != tt.wantErr {
+				t.Errorf("CronJobAdapter.DoAt() error = %v, wantErr %v", err, tt.wantErr)
 			}
 		})
 	}
@@ -77,7 +81,7 @@ func TestCronJobAdapterDo(t *testing.T) {
 func TestNewCronJobAdapter(t *testing.T) {
 	t.Parallel()
 	type args struct {
-		s   Sender
+		e   Enqueuer
 		log *slog.Logger
 	}
 	tests := []struct {
@@ -88,34 +92,34 @@ func TestNewCronJobAdapter(t *testing.T) {
 		{
 			name: "Should return crob job adapter with correct arguments provided",
 			args: args{
-				s:   mocks.NewMockSender(gomock.NewController(t)),
+				e:   mocks.NewMockEnqueuer(gomock.NewController(t)),
 				log: slog.Default(),
 			},
 			want: &CronJobAdapter{
-				sender: mocks.NewMockSender(gomock.NewController(t)),
-				log:    slog.Default(),
+				enqueuer: mocks.NewMockEnqueuer(gomock.NewController(t)),
+				log:      slog.Default(),
 			},
 		},
 		{
 			name: "Should return crob job adapter with allowed arguments provided",
 			args: args{
-				s:   nil,
+				e:   nil,
 				log: nil,
 			},
 			want: &CronJobAdapter{
-				sender: nil,
-				log:    nil,
+				enqueuer: nil,
+				log:      nil,
 			},
 		},
 		{
 			name: "Should return crob job adapter with allowed arguments provided",
 			args: args{
-				s:   nil,
+				e:   nil,
 				log: slog.Default(),
 			},
 			want: &CronJobAdapter{
-				sender: nil,
-				log:    slog.Default(),
+				enqueuer: nil,
+				log:      slog.Default(),
 			},
 		},
 	}
@@ -123,9 +127,140 @@ func TestNewCronJobAdapter(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
-			if got := NewCronJobAdapter(tt.args.s, tt.args.log); !reflect.DeepEqual(got, tt.want) {
+			if got := NewCronJobAdapter(tt.args.e, tt.args.log); !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("NewCronJobAdapter() = %v, want %v", got, tt.want)
 			}
 		})
 	}
 }
+
+func TestDeliveryJobAdapterDo(t *testing.T) {
+	t.Parallel()
+	type fields struct {
+		deliverer Deliverer
+		log       *slog.Logger
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		setup   func(t *testing.T, m Deliverer)
+		wantErr bool
+	}{
+		{
+			name: "Should return err when deliverer failed",
+			fields: fields{
+				deliverer: mocks.NewMockDeliverer(gomock.NewController(t)),
+				log:       slog.Default(),
+			},
+			wantErr: true,
+			setup: func(t *testing.T, m Deliverer) {
+				t.Helper()
+				d, ok := m.(*mocks.MockDeliverer)
+				if !ok {
+					t.Fatal("failed to cast deliverer to mock")
+				}
+				err := errors.New("failed to deliver")
+				d.EXPECT().Deliver(gomock.Any()).Times(1).Return(report.Report{}, err)
+			},
+		},
+		{
+			name: "Should not return err when deliverer succeeded",
+			fields: fields{
+				deliverer: mocks.NewMockDeliverer(gomock.NewController(t)),
+				log:       slog.Default(),
+			},
+			wantErr: false,
+			setup: func(t *testing.T, m Deliverer) {
+				t.Helper()
+				d, ok := m.(*mocks.MockDeliverer)
+				if !ok {
+					t.Fatal("failed to cast deliverer to mock")
+				}
+				d.EXPECT().Deliver(gomock.Any()).Times(1).Return(report.Report{
+					Results: []report.Result{{Email: "test@test.com"}},
+				}, nil)
+			},
+		},
+		{
+			name: "Should return err when some recipients didn't receive mail",
+			fields: fields{
+				deliverer: mocks.NewMockDeliverer(gomock.NewController(t)),
+				log:       slog.Default(),
+			},
+			wantErr: true,
+			setup: func(t *testing.T, m Deliverer) {
+				t.Helper()
+				d, ok := m.(*mocks.MockDeliverer)
+				if !ok {
+					t.Fatal("failed to cast deliverer to mock")
+				}
+				d.EXPECT().Deliver(gomock.Any()).Times(1).Return(report.Report{
+					Results: []report.Result{
+						{Email: "test@test.com"},
+						{Email: "test2@test.com", Err: errors.New("failed to send")},
+					},
+				}, nil)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			tt.setup(t, tt.fields.deliverer)
+			d := &DeliveryJobAdapter{
+				deliverer: tt.fields.deliverer,
+				log:       tt.fields.log,
+			}
+
+			if err := d.Do(context.Background()); (err != nil) != tt.wantErr {
+				t.Errorf("DeliveryJobAdapter.Do() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestNewDeliveryJobAdapter(t *testing.T) {
+	t.Parallel()
+	type args struct {
+		d   Deliverer
+		log *slog.Logger
+	}
+	tests := []struct {
+		name string
+		args args
+		want *DeliveryJobAdapter
+	}{
+		{
+			name: "Should return delivery job adapter with correct arguments provided",
+			args: args{
+				d:   mocks.NewMockDeliverer(gomock.NewController(t)),
+				log: slog.Default(),
+			},
+			want: &DeliveryJobAdapter{
+				deliverer: mocks.NewMockDeliverer(gomock.NewController(t)),
+				log:       slog.Default(),
+			},
+		},
+		{
+			name: "Should return delivery job adapter with allowed arguments provided",
+			args: args{
+				d:   nil,
+				log: nil,
+			},
+			want: &DeliveryJobAdapter{
+				deliverer: nil,
+				log:       nil,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := NewDeliveryJobAdapter(tt.args.d, tt.args.log); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewDeliveryJobAdapter() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,55 @@
+// Package channel implements notification channels of the sender.
+// Each channel formats the rate to the message of its own kind
+// and delivers the notification through its transport.
+package channel
+
+import (
+	"time"
+
+	"github.com/hrvadl/converter/sub/internal/service/sender/formatter"
+	"github.com/hrvadl/converter/sub/internal/storage/rate"
+	"github.com/hrvadl/converter/sub/internal/storage/subscriber"
+)
+
+// Content is everything channels need to format the notification.
+// Trend is always set, while Stats are set only for the digests.
+type Content struct {
+	Frequency subscriber.Frequency
+	Locale    subscriber.Locale
+	Quote     rate.Rate
+	Trend     formatter.Trend
+	Stats     rate.Stats
+	// At is the scheduled run, while FetchedAt
+	// is the moment, when Quote was fetched.
+	At        time.Time
+	FetchedAt time.Time
+}
+
+// Message is a formatted notification, which is persisted to
+// the outbox. Channels fill only the fields they need.
+type Message struct {
+	Subject string
+	Body    string
+	Text    string
+}
+
+//go:generate mockgen -destination=./mocks/mock_mailformatter.go -package=mocks . MailFormatter
+type MailFormatter interface {
+	Format(l subscriber.Locale, t formatter.Trend, at time.Time) (formatter.Message, error)
+	FormatDigest(
+		l subscriber.Locale,
+		f subscriber.Frequency,
+		s rate.Stats,
+		at time.Time,
+	) (formatter.Message, error)
+}
+
+// formatMail formats the mail of the content's frequency. Daily subscribers
+// get the spot rate with its trend, while weekly and monthly subscribers
+// get the stats over the digest period.
+func formatMail(f MailFormatter, c Content) (formatter.Message, error) {
+	if c.Frequency == "" || c.Frequency == subscriber.FrequencyDaily {
+		return f.Format(c.Locale, c.Trend, c.At)
+	}
+	return f.FormatDigest(c.Locale, c.Frequency, c.Stats, c.At)
+}
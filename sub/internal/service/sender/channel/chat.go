@@ -0,0 +1,55 @@
+package channel
+
+import (
+	"context"
+	"time"
+
+	"github.com/hrvadl/converter/sub/internal/service/sender/formatter"
+	"github.com/hrvadl/converter/sub/internal/storage/outbox"
+	"github.com/hrvadl/converter/sub/internal/storage/subscriber"
+)
+
+// NewChat constructs channel, which posts rich messages to the incoming
+// webhook of the given chat, i.e. Slack or Discord.
+// NOTE: neither of arguments can't be nil, or service will panic in the future.
+func NewChat(c subscriber.Channel, cl ChatClient, f ChatFormatter) *Chat {
+	return &Chat{
+		channel:   c,
+		client:    cl,
+		formatter: f,
+	}
+}
+
+//go:generate mockgen -destination=./mocks/mock_chatclient.go -package=mocks . ChatClient
+type ChatClient interface {
+	Send(ctx context.Context, url, payload string) (string, error)
+}
+
+//go:generate mockgen -destination=./mocks/mock_chatformatter.go -package=mocks . ChatFormatter
+type ChatFormatter interface {
+	FormatChat(c subscriber.Channel, l subscriber.Locale, t formatter.Trend, at time.Time) (string, error)
+}
+
+// Chat is a channel of the chat's incoming webhook. Address
+// of the chat subscriber is the URL of the webhook.
+type Chat struct {
+	channel   subscriber.Channel
+	client    ChatClient
+	formatter ChatFormatter
+}
+
+// Format method formats the message of the chat with the spot rate
+// and its change since the earlier days regardless of the frequency.
+func (c *Chat) Format(ct Content) (Message, error) {
+	p, err := c.formatter.FormatChat(c.channel, ct.Locale, ct.Trend, ct.At)
+	if err != nil {
+		return Message{}, err
+	}
+	return Message{Body: p}, nil
+}
+
+// Send method posts the message to the notification's webhook
+// and returns ID of the posted message, if chat reports it.
+func (c *Chat) Send(ctx context.Context, n outbox.Notification) (string, error) {
+	return c.client.Send(ctx, n.Email, n.Body)
+}
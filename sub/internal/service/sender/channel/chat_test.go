@@ -0,0 +1,95 @@
+package channel
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"go.uber.org/mock/gomock"
+
+	"github.com/hrvadl/converter/sub/internal/service/sender/channel/mocks"
+	"github.com/hrvadl/converter/sub/internal/service/sender/formatter"
+	"github.com/hrvadl/converter/sub/internal/storage/outbox"
+	"github.com/hrvadl/converter/sub/internal/storage/subscriber"
+)
+
+func TestChatFormat(t *testing.T) {
+	t.Parallel()
+	at := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	trend := formatter.Trend{Rate: 40.5}
+	tests := []struct {
+		name    string
+		channel subscriber.Channel
+		c       Content
+		setup   func(f *mocks.MockChatFormatter)
+		want    Message
+		wantErr bool
+	}{
+		{
+			name:    "Should format message of the chat with the trend",
+			channel: subscriber.ChannelSlack,
+			c:       Content{Frequency: subscriber.FrequencyDaily, Locale: subscriber.LocaleUkrainian, Trend: trend, At: at},
+			setup: func(f *mocks.MockChatFormatter) {
+				f.EXPECT().
+					FormatChat(subscriber.ChannelSlack, subscriber.LocaleUkrainian, trend, at).
+					Times(1).
+					Return(`{"blocks":[]}`, nil)
+			},
+			want: Message{Body: `{"blocks":[]}`},
+		},
+		{
+			name:    "Should format message with the trend for digest frequency",
+			channel: subscriber.ChannelDiscord,
+			c:       Content{Frequency: subscriber.FrequencyWeekly, Locale: subscriber.LocaleEnglish, Trend: trend, At: at},
+			setup: func(f *mocks.MockChatFormatter) {
+				f.EXPECT().
+					FormatChat(subscriber.ChannelDiscord, subscriber.LocaleEnglish, trend, at).
+					Times(1).
+					Return(`{"embeds":[]}`, nil)
+			},
+			want: Message{Body: `{"embeds":[]}`},
+		},
+		{
+			name:    "Should return error when formatter failed",
+			channel: subscriber.ChannelSlack,
+			c:       Content{At: at},
+			setup: func(f *mocks.MockChatFormatter) {
+				f.EXPECT().
+					FormatChat(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					Return("", errors.New("failed to encode"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			f := mocks.NewMockChatFormatter(gomock.NewController(t))
+			tt.setup(f)
+			got, err := NewChat(tt.channel, nil, f).Format(tt.c)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Chat.Format() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("Chat.Format() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestChatSend(t *testing.T) {
+	t.Parallel()
+	cl := mocks.NewMockChatClient(gomock.NewController(t))
+	cl.EXPECT().Send(gomock.Any(), "https://discord.com/api/webhooks/1/a", `{"embeds":[]}`).Times(1).Return("9", nil)
+	got, err := NewChat(subscriber.ChannelDiscord, cl, nil).Send(context.Background(), outbox.Notification{
+		Channel: subscriber.ChannelDiscord,
+		Email:   "https://discord.com/api/webhooks/1/a",
+		Body:    `{"embeds":[]}`,
+	})
+	if err != nil || got != "9" {
+		t.Errorf("Chat.Send() = %v, %v, want 9, nil", got, err)
+	}
+}
@@ -0,0 +1,45 @@
+package channel
+
+import (
+	"context"
+
+	"github.com/hrvadl/converter/sub/internal/storage/outbox"
+)
+
+// NewEmail constructs email channel, which sends
+// HTML mails with the plain-text alternative.
+// NOTE: neither of arguments can't be nil, or service will panic in the future.
+func NewEmail(m Mailer, f MailFormatter) *Email {
+	return &Email{
+		mailer:    m,
+		formatter: f,
+	}
+}
+
+//go:generate mockgen -destination=./mocks/mock_mailer.go -package=mocks . Mailer
+type Mailer interface {
+	Send(ctx context.Context, html, text, subject string, to ...string) (string, error)
+}
+
+// Email is a default channel. Each subscriber receives an
+// individual mail, so recipients never see each other's addresses.
+type Email struct {
+	mailer    Mailer
+	formatter MailFormatter
+}
+
+// Format method formats the mail with the subject,
+// HTML body and its plain-text version.
+func (e *Email) Format(c Content) (Message, error) {
+	m, err := formatMail(e.formatter, c)
+	if err != nil {
+		return Message{}, err
+	}
+	return Message{Subject: m.Subject, Body: m.HTML, Text: m.Text}, nil
+}
+
+// Send method sends the mail to the notification's email
+// and returns ID of the mail assigned by the provider.
+func (e *Email) Send(ctx context.Context, n outbox.Notification) (string, error) {
+	return e.mailer.Send(ctx, n.Body, n.Text, n.Subject, n.Email)
+}
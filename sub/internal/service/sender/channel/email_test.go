@@ -0,0 +1,159 @@
+package channel
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"go.uber.org/mock/gomock"
+
+	"github.com/hrvadl/converter/sub/internal/service/sender/channel/mocks"
+	"github.com/hrvadl/converter/sub/internal/service/sender/formatter"
+	"github.com/hrvadl/converter/sub/internal/storage/outbox"
+	"github.com/hrvadl/converter/sub/internal/storage/rate"
+	"github.com/hrvadl/converter/sub/internal/storage/subscriber"
+)
+
+func TestNewEmail(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	m := mocks.NewMockMailer(ctrl)
+	f := mocks.NewMockMailFormatter(ctrl)
+	want := &Email{mailer: m, formatter: f}
+	if got := NewEmail(m, f); !reflect.DeepEqual(got, want) {
+		t.Errorf("NewEmail() = %v, want %v", got, want)
+	}
+}
+
+func TestEmailFormat(t *testing.T) {
+	t.Parallel()
+	at := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	trend := formatter.Trend{Rate: 40.5}
+	stats := rate.Stats{Min: 40, Max: 41, Avg: 40.5, Count: 7}
+	tests := []struct {
+		name    string
+		c       Content
+		setup   func(f *mocks.MockMailFormatter)
+		want    Message
+		wantErr bool
+	}{
+		{
+			name: "Should format daily mail with the trend",
+			c:    Content{Frequency: subscriber.FrequencyDaily, Locale: subscriber.LocaleUkrainian, Trend: trend, At: at},
+			setup: func(f *mocks.MockMailFormatter) {
+				f.EXPECT().
+					Format(subscriber.LocaleUkrainian, trend, at).
+					Times(1).
+					Return(formatter.Message{Subject: "Курс", HTML: "<p>40.5</p>", Text: "40.5"}, nil)
+			},
+			want: Message{Subject: "Курс", Body: "<p>40.5</p>", Text: "40.5"},
+		},
+		{
+			name: "Should format daily mail when frequency is missing",
+			c:    Content{Locale: subscriber.LocaleEnglish, Trend: trend, At: at},
+			setup: func(f *mocks.MockMailFormatter) {
+				f.EXPECT().
+					Format(subscriber.LocaleEnglish, trend, at).
+					Times(1).
+					Return(formatter.Message{Subject: "Rate", HTML: "<p>40.5</p>", Text: "40.5"}, nil)
+			},
+			want: Message{Subject: "Rate", Body: "<p>40.5</p>", Text: "40.5"},
+		},
+		{
+			name: "Should format digest with the stats",
+			c: Content{
+				Frequency: subscriber.FrequencyWeekly,
+				Locale:    subscriber.LocaleEnglish,
+				Trend:     trend,
+				Stats:     stats,
+				At:        at,
+			},
+			setup: func(f *mocks.MockMailFormatter) {
+				f.EXPECT().
+					FormatDigest(subscriber.LocaleEnglish, subscriber.FrequencyWeekly, stats, at).
+					Times(1).
+					Return(formatter.Message{Subject: "Digest", HTML: "<p>40.5</p>", Text: "40.5"}, nil)
+			},
+			want: Message{Subject: "Digest", Body: "<p>40.5</p>", Text: "40.5"},
+		},
+		{
+			name: "Should return error when formatter failed",
+			c:    Content{Frequency: subscriber.FrequencyDaily, At: at},
+			setup: func(f *mocks.MockMailFormatter) {
+				f.EXPECT().Format(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(
+					formatter.Message{},
+					errors.New("failed to execute template"),
+				)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			f := mocks.NewMockMailFormatter(gomock.NewController(t))
+			tt.setup(f)
+			got, err := NewEmail(nil, f).Format(tt.c)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Email.Format() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("Email.Format() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEmailSend(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		setup   func(m *mocks.MockMailer)
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "Should send mail to the notification's email",
+			setup: func(m *mocks.MockMailer) {
+				m.EXPECT().
+					Send(gomock.Any(), "<p>40.5</p>", "40.5", "Rate", "test@test.com").
+					Times(1).
+					Return("mail-id", nil)
+			},
+			want: "mail-id",
+		},
+		{
+			name: "Should return error when mailer failed",
+			setup: func(m *mocks.MockMailer) {
+				m.EXPECT().
+					Send(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					Return("", errors.New("mailer is down"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			m := mocks.NewMockMailer(gomock.NewController(t))
+			tt.setup(m)
+			got, err := NewEmail(m, nil).Send(context.Background(), outbox.Notification{
+				Email:   "test@test.com",
+				Subject: "Rate",
+				Body:    "<p>40.5</p>",
+				Text:    "40.5",
+			})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Email.Send() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("Email.Send() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,148 @@
+package channel
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/hrvadl/converter/sub/internal/storage/outbox"
+)
+
+// Channel formats and sends notifications of the single kind.
+type Channel interface {
+	Format(c Content) (Message, error)
+	Send(ctx context.Context, n outbox.Notification) (string, error)
+}
+
+// sweepInterval is an interval between the removals of the
+// idle limiters of the addresses, so they don't pile up.
+const sweepInterval = time.Minute
+
+// NewLimited wraps the channel, so at most perSecond notifications
+// are sent through it per second. Up to perSecond notifications
+// could be sent at once after the idle period.
+// NOTE: channel can't be nil and perSecond should be positive.
+func NewLimited(c Channel, perSecond int) *Limited {
+	return newLimited(c, perSecond, func(outbox.Notification) string { return "" })
+}
+
+// NewLimitedPerAddress wraps the channel, so at most perSecond
+// notifications are sent to each address per second, while different
+// addresses don't wait for each other. It suits providers, which limit
+// each recipient on its own, i.e. the single incoming webhook of the chat.
+// NOTE: channel can't be nil and perSecond should be positive.
+func NewLimitedPerAddress(c Channel, perSecond int) *Limited {
+	return newLimited(c, perSecond, func(n outbox.Notification) string { return n.Email })
+}
+
+func newLimited(c Channel, perSecond int, key func(n outbox.Notification) string) *Limited {
+	return &Limited{
+		Channel:   c,
+		perSecond: perSecond,
+		key:       key,
+		limiters:  make(map[string]*limiter),
+	}
+}
+
+// Limited is a channel, which waits for its turn before
+// each send, so provider's rate limits aren't exceeded.
+// Turns are counted per key of the notification.
+type Limited struct {
+	Channel
+	perSecond int
+	key       func(n outbox.Notification) string
+
+	mu       sync.Mutex
+	limiters map[string]*limiter
+	swept    time.Time
+}
+
+// Send method waits for the turn and then sends notification through
+// the underlying channel. Returns context's error if it's done earlier.
+func (l *Limited) Send(ctx context.Context, n outbox.Notification) (string, error) {
+	if err := l.limiterOf(n).wait(ctx); err != nil {
+		return "", err
+	}
+	return l.Channel.Send(ctx, n)
+}
+
+// limiterOf returns limiter of the notification's key. Idle limiters
+// are removed every sweepInterval, since the new one is the same as
+// the idle one.
+func (l *Limited) limiterOf(n outbox.Notification) *limiter {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	if now.Sub(l.swept) >= sweepInterval {
+		for k, lim := range l.limiters {
+			if lim.idle(now) {
+				delete(l.limiters, k)
+			}
+		}
+		l.swept = now
+	}
+
+	k := l.key(n)
+	lim, ok := l.limiters[k]
+	if !ok {
+		lim = newLimiter(time.Second/time.Duration(l.perSecond), l.perSecond)
+		l.limiters[k] = lim
+	}
+	return lim
+}
+
+// limiter is a token bucket of the given size refilled
+// with one token per interval. Instead of counting tokens
+// it tracks the point of time, when the next token is available.
+type limiter struct {
+	mu       sync.Mutex
+	interval time.Duration
+	burst    int
+	next     time.Time
+}
+
+func newLimiter(interval time.Duration, burst int) *limiter {
+	return &limiter{interval: interval, burst: burst}
+}
+
+// idle reports whether the bucket is full, so the limiter
+// doesn't differ from the new one.
+func (l *limiter) idle(now time.Time) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return !l.next.After(l.full(now))
+}
+
+// full returns the point of time of the next token,
+// when the bucket is full at the given moment.
+func (l *limiter) full(now time.Time) time.Time {
+	return now.Add(-time.Duration(l.burst-1) * l.interval)
+}
+
+// wait blocks until the token is available. Token is taken
+// right away, so it's lost if context is done while waiting.
+func (l *limiter) wait(ctx context.Context) error {
+	l.mu.Lock()
+	now := time.Now()
+	if full := l.full(now); l.next.Before(full) {
+		l.next = full
+	}
+	at := l.next
+	l.next = l.next.Add(l.interval)
+	l.mu.Unlock()
+
+	d := at.Sub(now)
+	if d <= 0 {
+		return nil
+	}
+
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
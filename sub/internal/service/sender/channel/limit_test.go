@@ -0,0 +1,163 @@
+package channel
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hrvadl/converter/sub/internal/storage/outbox"
+)
+
+// countingChannel is a fake channel, which counts sends. It's
+// hand-written, because mock of the Channel would import this package.
+type countingChannel struct {
+	sent atomic.Int32
+}
+
+func (c *countingChannel) Format(Content) (Message, error) {
+	return Message{Text: "40.5"}, nil
+}
+
+func (c *countingChannel) Send(context.Context, outbox.Notification) (string, error) {
+	c.sent.Add(1)
+	return "id", nil
+}
+
+func TestLimitedFormat(t *testing.T) {
+	t.Parallel()
+	got, err := NewLimited(&countingChannel{}, 1).Format(Content{})
+	if err != nil || got != (Message{Text: "40.5"}) {
+		t.Errorf("Limited.Format() = %v, %v, want underlying message", got, err)
+	}
+}
+
+func TestLimitedSend(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		perSecond int
+		sends     int
+		timeout   time.Duration
+		wantSent  int32
+		wantErr   error
+		minElapse time.Duration
+	}{
+		{
+			name:      "Should send burst of notifications at once",
+			perSecond: 5,
+			sends:     5,
+			timeout:   time.Second,
+			wantSent:  5,
+		},
+		{
+			name:      "Should wait for the turn when burst is exhausted",
+			perSecond: 20,
+			sends:     22,
+			timeout:   time.Second,
+			wantSent:  22,
+			minElapse: time.Millisecond * 90,
+		},
+		{
+			name:      "Should return error when context is done while waiting",
+			perSecond: 1,
+			sends:     2,
+			timeout:   time.Millisecond * 50,
+			wantSent:  1,
+			wantErr:   context.DeadlineExceeded,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctx, cancel := context.WithTimeout(context.Background(), tt.timeout)
+			defer cancel()
+
+			c := &countingChannel{}
+			l := NewLimited(c, tt.perSecond)
+			start := time.Now()
+			var err error
+			for range tt.sends {
+				if _, err = l.Send(ctx, outbox.Notification{}); err != nil {
+					break
+				}
+			}
+
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Limited.Send() error = %v, want %v", err, tt.wantErr)
+			}
+			if got := c.sent.Load(); got != tt.wantSent {
+				t.Errorf("Limited.Send() sent %d notifications, want %d", got, tt.wantSent)
+			}
+			if elapsed := time.Since(start); elapsed < tt.minElapse {
+				t.Errorf("Limited.Send() took %v, want at least %v", elapsed, tt.minElapse)
+			}
+		})
+	}
+}
+
+func TestLimitedPerAddressSend(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		addresses []string
+		wantSent  int32
+		wantErr   error
+	}{
+		{
+			name:      "Should not wait for the turn of another address",
+			addresses: []string{"https://hooks.slack.com/a", "https://hooks.slack.com/b"},
+			wantSent:  2,
+		},
+		{
+			name:      "Should wait for the turn of the same address",
+			addresses: []string{"https://hooks.slack.com/a", "https://hooks.slack.com/a"},
+			wantSent:  1,
+			wantErr:   context.DeadlineExceeded,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*50)
+			defer cancel()
+
+			c := &countingChannel{}
+			l := NewLimitedPerAddress(c, 1)
+			var err error
+			for _, a := range tt.addresses {
+				if _, err = l.Send(ctx, outbox.Notification{Email: a}); err != nil {
+					break
+				}
+			}
+
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Limited.Send() error = %v, want %v", err, tt.wantErr)
+			}
+			if got := c.sent.Load(); got != tt.wantSent {
+				t.Errorf("Limited.Send() sent %d notifications, want %d", got, tt.wantSent)
+			}
+		})
+	}
+}
+
+func TestLimitedSweep(t *testing.T) {
+	t.Parallel()
+	l := NewLimitedPerAddress(&countingChannel{}, 100)
+	if _, err := l.Send(context.Background(), outbox.Notification{Email: "a"}); err != nil {
+		t.Fatalf("Limited.Send() error = %v", err)
+	}
+
+	time.Sleep(time.Millisecond * 20)
+	l.swept = time.Time{}
+	if _, err := l.Send(context.Background(), outbox.Notification{Email: "b"}); err != nil {
+		t.Fatalf("Limited.Send() error = %v", err)
+	}
+
+	if _, ok := l.limiters["a"]; ok || len(l.limiters) != 1 {
+		t.Errorf("Limited.limiters = %v, want only limiter of b after idle one is swept", l.limiters)
+	}
+}
@@ -0,0 +1,55 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/hrvadl/converter/sub/internal/service/sender/channel (interfaces: ChatClient)
+//
+// Generated by this command:
+//
+//	mockgen -destination=./mocks/mock_chatclient.go -package=mocks . ChatClient
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockChatClient is a mock of ChatClient interface.
+type MockChatClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockChatClientMockRecorder
+}
+
+// MockChatClientMockRecorder is the mock recorder for MockChatClient.
+type MockChatClientMockRecorder struct {
+	mock *MockChatClient
+}
+
+// NewMockChatClient creates a new mock instance.
+func NewMockChatClient(ctrl *gomock.Controller) *MockChatClient {
+	mock := &MockChatClient{ctrl: ctrl}
+	mock.recorder = &MockChatClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockChatClient) EXPECT() *MockChatClientMockRecorder {
+	return m.recorder
+}
+
+// Send mocks base method.
+func (m *MockChatClient) Send(arg0 context.Context, arg1, arg2 string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Send", arg0, arg1, arg2)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Send indicates an expected call of Send.
+func (mr *MockChatClientMockRecorder) Send(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Send", reflect.TypeOf((*MockChatClient)(nil).Send), arg0, arg1, arg2)
+}
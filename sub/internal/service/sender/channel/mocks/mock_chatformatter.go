@@ -0,0 +1,57 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/hrvadl/converter/sub/internal/service/sender/channel (interfaces: ChatFormatter)
+//
+// Generated by this command:
+//
+//	mockgen -destination=./mocks/mock_chatformatter.go -package=mocks . ChatFormatter
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+	time "time"
+
+	formatter "github.com/hrvadl/converter/sub/internal/service/sender/formatter"
+	subscriber "github.com/hrvadl/converter/sub/internal/storage/subscriber"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockChatFormatter is a mock of ChatFormatter interface.
+type MockChatFormatter struct {
+	ctrl     *gomock.Controller
+	recorder *MockChatFormatterMockRecorder
+}
+
+// MockChatFormatterMockRecorder is the mock recorder for MockChatFormatter.
+type MockChatFormatterMockRecorder struct {
+	mock *MockChatFormatter
+}
+
+// NewMockChatFormatter creates a new mock instance.
+func NewMockChatFormatter(ctrl *gomock.Controller) *MockChatFormatter {
+	mock := &MockChatFormatter{ctrl: ctrl}
+	mock.recorder = &MockChatFormatterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockChatFormatter) EXPECT() *MockChatFormatterMockRecorder {
+	return m.recorder
+}
+
+// FormatChat mocks base method.
+func (m *MockChatFormatter) FormatChat(arg0 subscriber.Channel, arg1 subscriber.Locale, arg2 formatter.Trend, arg3 time.Time) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FormatChat", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FormatChat indicates an expected call of FormatChat.
+func (mr *MockChatFormatterMockRecorder) FormatChat(arg0, arg1, arg2, arg3 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FormatChat", reflect.TypeOf((*MockChatFormatter)(nil).FormatChat), arg0, arg1, arg2, arg3)
+}
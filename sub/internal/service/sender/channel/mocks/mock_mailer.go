@@ -1,5 +1,5 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: github.com/hrvadl/converter/sub/internal/service/sender (interfaces: Mailer)
+// Source: github.com/hrvadl/converter/sub/internal/service/sender/channel (interfaces: Mailer)
 //
 // Generated by this command:
 //
@@ -40,20 +40,21 @@ func (m *MockMailer) EXPECT() *MockMailerMockRecorder {
 }
 
 // Send mocks base method.
-func (m *MockMailer) Send(arg0 context.Context, arg1, arg2 string, arg3 ...string) error {
+func (m *MockMailer) Send(arg0 context.Context, arg1, arg2, arg3 string, arg4 ...string) (string, error) {
 	m.ctrl.T.Helper()
-	varargs := []any{arg0, arg1, arg2}
-	for _, a := range arg3 {
+	varargs := []any{arg0, arg1, arg2, arg3}
+	for _, a := range arg4 {
 		varargs = append(varargs, a)
 	}
 	ret := m.ctrl.Call(m, "Send", varargs...)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // Send indicates an expected call of Send.
-func (mr *MockMailerMockRecorder) Send(arg0, arg1, arg2 any, arg3 ...any) *gomock.Call {
+func (mr *MockMailerMockRecorder) Send(arg0, arg1, arg2, arg3 any, arg4 ...any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	varargs := append([]any{arg0, arg1, arg2}, arg3...)
+	varargs := append([]any{arg0, arg1, arg2, arg3}, arg4...)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Send", reflect.TypeOf((*MockMailer)(nil).Send), varargs...)
 }
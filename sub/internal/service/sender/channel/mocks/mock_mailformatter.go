@@ -0,0 +1,73 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/hrvadl/converter/sub/internal/service/sender/channel (interfaces: MailFormatter)
+//
+// Generated by this command:
+//
+//	mockgen -destination=./mocks/mock_mailformatter.go -package=mocks . MailFormatter
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+	time "time"
+
+	formatter "github.com/hrvadl/converter/sub/internal/service/sender/formatter"
+	rate "github.com/hrvadl/converter/sub/internal/storage/rate"
+	subscriber "github.com/hrvadl/converter/sub/internal/storage/subscriber"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockMailFormatter is a mock of MailFormatter interface.
+type MockMailFormatter struct {
+	ctrl     *gomock.Controller
+	recorder *MockMailFormatterMockRecorder
+}
+
+// MockMailFormatterMockRecorder is the mock recorder for MockMailFormatter.
+type MockMailFormatterMockRecorder struct {
+	mock *MockMailFormatter
+}
+
+// NewMockMailFormatter creates a new mock instance.
+func NewMockMailFormatter(ctrl *gomock.Controller) *MockMailFormatter {
+	mock := &MockMailFormatter{ctrl: ctrl}
+	mock.recorder = &MockMailFormatterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMailFormatter) EXPECT() *MockMailFormatterMockRecorder {
+	return m.recorder
+}
+
+// Format mocks base method.
+func (m *MockMailFormatter) Format(arg0 subscriber.Locale, arg1 formatter.Trend, arg2 time.Time) (formatter.Message, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Format", arg0, arg1, arg2)
+	ret0, _ := ret[0].(formatter.Message)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Format indicates an expected call of Format.
+func (mr *MockMailFormatterMockRecorder) Format(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Format", reflect.TypeOf((*MockMailFormatter)(nil).Format), arg0, arg1, arg2)
+}
+
+// FormatDigest mocks base method.
+func (m *MockMailFormatter) FormatDigest(arg0 subscriber.Locale, arg1 subscriber.Frequency, arg2 rate.Stats, arg3 time.Time) (formatter.Message, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FormatDigest", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(formatter.Message)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FormatDigest indicates an expected call of FormatDigest.
+func (mr *MockMailFormatterMockRecorder) FormatDigest(arg0, arg1, arg2, arg3 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FormatDigest", reflect.TypeOf((*MockMailFormatter)(nil).FormatDigest), arg0, arg1, arg2, arg3)
+}
@@ -0,0 +1,55 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/hrvadl/converter/sub/internal/service/sender/channel (interfaces: Messenger)
+//
+// Generated by this command:
+//
+//	mockgen -destination=./mocks/mock_messenger.go -package=mocks . Messenger
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockMessenger is a mock of Messenger interface.
+type MockMessenger struct {
+	ctrl     *gomock.Controller
+	recorder *MockMessengerMockRecorder
+}
+
+// MockMessengerMockRecorder is the mock recorder for MockMessenger.
+type MockMessengerMockRecorder struct {
+	mock *MockMessenger
+}
+
+// NewMockMessenger creates a new mock instance.
+func NewMockMessenger(ctrl *gomock.Controller) *MockMessenger {
+	mock := &MockMessenger{ctrl: ctrl}
+	mock.recorder = &MockMessengerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMessenger) EXPECT() *MockMessengerMockRecorder {
+	return m.recorder
+}
+
+// Send mocks base method.
+func (m *MockMessenger) Send(arg0 context.Context, arg1, arg2 string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Send", arg0, arg1, arg2)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Send indicates an expected call of Send.
+func (mr *MockMessengerMockRecorder) Send(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Send", reflect.TypeOf((*MockMessenger)(nil).Send), arg0, arg1, arg2)
+}
@@ -0,0 +1,44 @@
+package channel
+
+import (
+	"context"
+
+	"github.com/hrvadl/converter/sub/internal/storage/outbox"
+)
+
+// NewTelegram constructs Telegram channel, which sends plain-text
+// version of the mail as a message from the bot.
+// NOTE: neither of arguments can't be nil, or service will panic in the future.
+func NewTelegram(m Messenger, f MailFormatter) *Telegram {
+	return &Telegram{
+		messenger: m,
+		formatter: f,
+	}
+}
+
+//go:generate mockgen -destination=./mocks/mock_messenger.go -package=mocks . Messenger
+type Messenger interface {
+	Send(ctx context.Context, chatID, text string) (string, error)
+}
+
+// Telegram is a channel of the Telegram bot. Address
+// of the Telegram subscriber is an ID of the chat.
+type Telegram struct {
+	messenger Messenger
+	formatter MailFormatter
+}
+
+// Format method formats the plain-text message.
+func (t *Telegram) Format(c Content) (Message, error) {
+	m, err := formatMail(t.formatter, c)
+	if err != nil {
+		return Message{}, err
+	}
+	return Message{Text: m.Text}, nil
+}
+
+// Send method sends the message to the notification's
+// chat and returns ID of the sent message.
+func (t *Telegram) Send(ctx context.Context, n outbox.Notification) (string, error) {
+	return t.messenger.Send(ctx, n.Email, n.Text)
+}
@@ -0,0 +1,81 @@
+package channel
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"go.uber.org/mock/gomock"
+
+	"github.com/hrvadl/converter/sub/internal/service/sender/channel/mocks"
+	"github.com/hrvadl/converter/sub/internal/service/sender/formatter"
+	"github.com/hrvadl/converter/sub/internal/storage/outbox"
+	"github.com/hrvadl/converter/sub/internal/storage/rate"
+	"github.com/hrvadl/converter/sub/internal/storage/subscriber"
+)
+
+func TestTelegramFormat(t *testing.T) {
+	t.Parallel()
+	at := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	stats := rate.Stats{Min: 40, Max: 41, Avg: 40.5, Count: 30}
+	tests := []struct {
+		name    string
+		c       Content
+		setup   func(f *mocks.MockMailFormatter)
+		want    Message
+		wantErr bool
+	}{
+		{
+			name: "Should format only plain-text version of the mail",
+			c:    Content{Frequency: subscriber.FrequencyMonthly, Locale: subscriber.LocaleEnglish, Stats: stats, At: at},
+			setup: func(f *mocks.MockMailFormatter) {
+				f.EXPECT().
+					FormatDigest(subscriber.LocaleEnglish, subscriber.FrequencyMonthly, stats, at).
+					Times(1).
+					Return(formatter.Message{Subject: "Digest", HTML: "<p>40.5</p>", Text: "40.5"}, nil)
+			},
+			want: Message{Text: "40.5"},
+		},
+		{
+			name: "Should return error when formatter failed",
+			c:    Content{Frequency: subscriber.FrequencyDaily, At: at},
+			setup: func(f *mocks.MockMailFormatter) {
+				f.EXPECT().Format(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(
+					formatter.Message{},
+					errors.New("failed to execute template"),
+				)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			f := mocks.NewMockMailFormatter(gomock.NewController(t))
+			tt.setup(f)
+			got, err := NewTelegram(nil, f).Format(tt.c)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Telegram.Format() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("Telegram.Format() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTelegramSend(t *testing.T) {
+	t.Parallel()
+	m := mocks.NewMockMessenger(gomock.NewController(t))
+	m.EXPECT().Send(gomock.Any(), "42", "40.5").Times(1).Return("7", nil)
+	got, err := NewTelegram(m, nil).Send(context.Background(), outbox.Notification{
+		Channel: subscriber.ChannelTelegram,
+		Email:   "42",
+		Text:    "40.5",
+	})
+	if err != nil || got != "7" {
+		t.Errorf("Telegram.Send() = %v, %v, want 7, nil", got, err)
+	}
+}
@@ -0,0 +1,60 @@
+package channel
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/hrvadl/converter/sub/internal/storage/outbox"
+)
+
+// NewWebhook constructs webhook channel, which posts
+// signed JSON payload with the rate.
+// NOTE: neither of arguments can't be nil, or service will panic in the future.
+func NewWebhook(wh Webhooks) *Webhook {
+	return &Webhook{webhooks: wh}
+}
+
+//go:generate mockgen -destination=./mocks/mock_webhooks.go -package=mocks . Webhooks
+type Webhooks interface {
+	Send(ctx context.Context, n outbox.Notification) (string, error)
+}
+
+// Webhook is a channel of the outgoing webhooks. Address
+// of the webhook subscriber is the URL.
+type Webhook struct {
+	webhooks Webhooks
+}
+
+// webhookPair is a currency pair of the rate in the webhook payload.
+const webhookPair = "USD/UAH"
+
+// webhookPayload is a JSON body, which is posted to the webhooks.
+type webhookPayload struct {
+	Pair      string  `json:"pair"`
+	Rate      float32 `json:"rate"`
+	Timestamp string  `json:"timestamp"`
+	Provider  string  `json:"provider"`
+}
+
+// Format method encodes the rate to the JSON payload. Payload
+// doesn't depend on the locale and frequency, and its timestamp
+// is the moment, when rate was fetched.
+func (w *Webhook) Format(c Content) (Message, error) {
+	b, err := json.Marshal(webhookPayload{
+		Pair:      webhookPair,
+		Rate:      c.Quote.Rate,
+		Timestamp: c.FetchedAt.UTC().Format(time.RFC3339),
+		Provider:  c.Quote.Provider,
+	})
+	if err != nil {
+		return Message{}, err
+	}
+	return Message{Body: string(b)}, nil
+}
+
+// Send method posts the payload to the notification's URL
+// and returns ID of the delivery.
+func (w *Webhook) Send(ctx context.Context, n outbox.Notification) (string, error) {
+	return w.webhooks.Send(ctx, n)
+}
@@ -0,0 +1,67 @@
+package channel
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go.uber.org/mock/gomock"
+
+	"github.com/hrvadl/converter/sub/internal/service/sender/channel/mocks"
+	"github.com/hrvadl/converter/sub/internal/storage/outbox"
+	"github.com/hrvadl/converter/sub/internal/storage/rate"
+	"github.com/hrvadl/converter/sub/internal/storage/subscriber"
+)
+
+func TestWebhookFormat(t *testing.T) {
+	t.Parallel()
+	fetchedAt := time.Date(2024, 5, 1, 15, 4, 5, 0, time.FixedZone("EEST", 3*60*60))
+	tests := []struct {
+		name string
+		c    Content
+		want Message
+	}{
+		{
+			name: "Should encode rate with the fetch time in UTC",
+			c: Content{
+				Frequency: subscriber.FrequencyDaily,
+				Locale:    subscriber.LocaleUkrainian,
+				Quote:     rate.Rate{Rate: 40.5, Provider: "exchangerate-api"},
+				FetchedAt: fetchedAt,
+				At:        time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC),
+			},
+			want: Message{
+				Body: `{"pair":"USD/UAH","rate":40.5,"timestamp":"2024-05-01T12:04:05Z","provider":"exchangerate-api"}`,
+			},
+		},
+		{
+			name: "Should encode rate with empty provider when it's unknown",
+			c:    Content{Quote: rate.Rate{Rate: 41}, FetchedAt: time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)},
+			want: Message{Body: `{"pair":"USD/UAH","rate":41,"timestamp":"2024-05-01T12:00:00Z","provider":""}`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := NewWebhook(nil).Format(tt.c)
+			if err != nil {
+				t.Fatalf("Webhook.Format() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Webhook.Format() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWebhookSend(t *testing.T) {
+	t.Parallel()
+	n := outbox.Notification{ID: 3, Channel: subscriber.ChannelWebhook, Email: "https://test.com/hook", Body: "{}"}
+	wh := mocks.NewMockWebhooks(gomock.NewController(t))
+	wh.EXPECT().Send(gomock.Any(), n).Times(1).Return("3", nil)
+	got, err := NewWebhook(wh).Send(context.Background(), n)
+	if err != nil || got != "3" {
+		t.Errorf("Webhook.Send() = %v, %v, want 3, nil", got, err)
+	}
+}
@@ -0,0 +1,105 @@
+package sender
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"runtime"
+	"testing"
+	"time"
+
+	"go.uber.org/mock/gomock"
+
+	"github.com/hrvadl/converter/sub/internal/service/sender/channel"
+	"github.com/hrvadl/converter/sub/internal/service/sender/mocks"
+	"github.com/hrvadl/converter/sub/internal/storage/outbox"
+	"github.com/hrvadl/converter/sub/internal/storage/rate"
+	"github.com/hrvadl/converter/sub/internal/storage/subscriber"
+)
+
+// syntheticSubscribers generates due subscribers on the fly, so the
+// data set itself doesn't take memory. Every 7th subscriber is weekly
+// and every 30th one is monthly.
+type syntheticSubscribers struct {
+	total int64
+}
+
+func (s syntheticSubscribers) GetDue(
+	_ context.Context,
+	_ time.Time,
+	afterID int64,
+	limit int,
+) ([]subscriber.Subscriber, error) {
+	subs := make([]subscriber.Subscriber, 0, limit)
+	for id := afterID + 1; id <= s.total && len(subs) < limit; id++ {
+		f := subscriber.FrequencyDaily
+		switch {
+		case id%30 == 0:
+			f = subscriber.FrequencyMonthly
+		case id%7 == 0:
+			f = subscriber.FrequencyWeekly
+		}
+		subs = append(subs, subscriber.Subscriber{
+			ID:        id,
+			Email:     fmt.Sprintf("subscriber-%d@test.com", id),
+			Frequency: f,
+		})
+	}
+	return subs, nil
+}
+
+// peakOutbox counts saved notifications and samples heap
+// in use after each batch.
+type peakOutbox struct {
+	Outbox
+	saved int
+	peak  uint64
+}
+
+func (o *peakOutbox) Save(_ context.Context, n []outbox.Notification) (int, error) {
+	o.saved += len(n)
+	var m runtime.MemStats
+	runtime.ReadMemStats(&m)
+	o.peak = max(o.peak, m.HeapInuse)
+	return len(n), nil
+}
+
+func BenchmarkServiceEnqueue(b *testing.B) {
+	for _, total := range []int64{10_000, 100_000, 500_000} {
+		b.Run(fmt.Sprintf("subscribers=%d", total), func(b *testing.B) {
+			ctrl := gomock.NewController(b)
+			rg := mocks.NewMockRateGetter(ctrl)
+			rg.EXPECT().GetQuote(gomock.Any()).AnyTimes().Return(rate.Rate{Rate: 40.5}, nil)
+			rh := mocks.NewMockRateHistory(ctrl)
+			rh.EXPECT().Save(gomock.Any(), gomock.Any()).AnyTimes().Return(int64(1), nil)
+			rh.EXPECT().GetStats(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes().Return(rate.Stats{}, nil)
+			rh.EXPECT().GetDaily(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes().Return(nil, nil)
+			email := mocks.NewMockChannel(ctrl)
+			email.EXPECT().
+				Format(gomock.Any()).
+				AnyTimes().
+				Return(channel.Message{Subject: "subject", Body: "rate"}, nil)
+
+			ob := &peakOutbox{}
+			w := &Service{
+				subGetter:   syntheticSubscribers{total: total},
+				rateGetter:  rg,
+				rateHistory: rh,
+				outbox:      ob,
+				channels:    map[subscriber.Channel]Channel{subscriber.ChannelEmail: email},
+				log:         slog.Default(),
+			}
+
+			at := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+			b.ReportAllocs()
+			b.ResetTimer()
+			for range b.N {
+				n, err := w.Enqueue(context.Background(), at)
+				if err != nil || int64(n) != total {
+					b.Fatalf("Service.Enqueue() = %v, %v, want %v", n, err, total)
+				}
+			}
+			b.ReportMetric(float64(ob.peak)/(1<<20), "peak-heap-MiB")
+		})
+	}
+}
@@ -0,0 +1,68 @@
+package formatter
+
+import (
+	"math"
+
+	"github.com/hrvadl/converter/sub/internal/storage/rate"
+)
+
+// Height of the chart and of the shortest bar in pixels. Shortest
+// bar isn't empty, so the day with the lowest rate is still visible.
+const (
+	chartHeight = 120
+	chartMinBar = 8
+)
+
+// chart is a bar chart of the daily rates with their range. It's
+// rendered as the table with the coloured cells, since mail clients
+// (Gmail, Outlook) strip SVG and don't load the remote images by default.
+type chart struct {
+	Bars   []bar
+	Height int
+	// First and Last are labels of the first and last days.
+	First string
+	Last  string
+	Min   float32
+	Max   float32
+}
+
+// bar is a column of the single day. Title is shown on hover.
+type bar struct {
+	Height int
+	Title  string
+}
+
+// newChart draws the daily rates, labelled with the format of the locale.
+// Returns nil, if there're less than 2 rates, since trend couldn't be seen.
+func (l locale) newChart(history []rate.Daily) *chart {
+	if len(history) < 2 {
+		return nil
+	}
+
+	lo, hi := history[0].Rate, history[0].Rate
+	for _, d := range history[1:] {
+		lo, hi = min(lo, d.Rate), max(hi, d.Rate)
+	}
+
+	bars := make([]bar, len(history))
+	for i, d := range history {
+		h := chartHeight / 2
+		if hi > lo {
+			scale := float64(d.Rate-lo) / float64(hi-lo)
+			h = chartMinBar + int(math.Round(scale*(chartHeight-chartMinBar)))
+		}
+		bars[i] = bar{
+			Height: h,
+			Title:  d.Day.UTC().Format(l.day) + ": " + l.formatRate(d.Rate),
+		}
+	}
+
+	return &chart{
+		Bars:   bars,
+		Height: chartHeight,
+		First:  history[0].Day.UTC().Format(l.day),
+		Last:   history[len(history)-1].Day.UTC().Format(l.day),
+		Min:    lo,
+		Max:    hi,
+	}
+}
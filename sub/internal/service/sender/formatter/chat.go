@@ -0,0 +1,153 @@
+package formatter
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/hrvadl/converter/sub/internal/storage/subscriber"
+)
+
+// Colors of the Discord embed. Rising rate means
+// hryvnia has weakened, so it's highlighted in red.
+const (
+	embedColorUp   = 0xdc2626
+	embedColorDown = 0x16a34a
+	embedColorFlat = 0x2563eb
+)
+
+type slackMessage struct {
+	// Text is shown in the notifications, where blocks aren't rendered.
+	Text   string       `json:"text"`
+	Blocks []slackBlock `json:"blocks"`
+}
+
+type slackBlock struct {
+	Type     string      `json:"type"`
+	Text     *slackText  `json:"text,omitempty"`
+	Fields   []slackText `json:"fields,omitempty"`
+	Elements []slackText `json:"elements,omitempty"`
+}
+
+type slackText struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+type discordMessage struct {
+	Embeds []discordEmbed `json:"embeds"`
+}
+
+type discordEmbed struct {
+	Title       string         `json:"title"`
+	Description string         `json:"description"`
+	Color       int            `json:"color"`
+	Fields      []discordField `json:"fields,omitempty"`
+	Footer      discordFooter  `json:"footer"`
+	Timestamp   string         `json:"timestamp"`
+}
+
+type discordField struct {
+	Name   string `json:"name"`
+	Value  string `json:"value"`
+	Inline bool   `json:"inline"`
+}
+
+type discordFooter struct {
+	Text string `json:"text"`
+}
+
+// chatField is a labelled change of the rate.
+type chatField struct {
+	name  string
+	value string
+}
+
+// FormatChat method renders JSON payload of the daily message for the
+// incoming webhook of the chat: Block Kit message for Slack and embed for
+// Discord. Message contains the exchange rate as of the given time and its
+// change since yesterday and last week. Changes are omitted, if history is
+// missing. Could return an error if channel isn't a chat one.
+func (t *Template) FormatChat(
+	c subscriber.Channel,
+	l subscriber.Locale,
+	tr Trend,
+	at time.Time,
+) (string, error) {
+	loc := locales[resolve(l)]
+	title := loc.subjects[subscriber.FrequencyDaily]
+	rate := "1 USD = " + loc.formatRate(tr.Rate) + " UAH"
+	asOf := fmt.Sprintf(loc.asOf, loc.formatDate(t.loc)(at))
+
+	yesterday := tr.since(at.AddDate(0, 0, -1))
+	var fields []chatField
+	if yesterday != nil {
+		fields = append(fields, chatField{name: loc.yesterday, value: loc.formatChange(*yesterday)})
+	}
+	if lastWeek := tr.since(at.AddDate(0, 0, -7)); lastWeek != nil {
+		fields = append(fields, chatField{name: loc.lastWeek, value: loc.formatChange(*lastWeek)})
+	}
+
+	var msg any
+	switch c {
+	case subscriber.ChannelSlack:
+		msg = newSlackMessage(title, rate, asOf, fields)
+	case subscriber.ChannelDiscord:
+		msg = newDiscordMessage(title, rate, asOf, fields, yesterday, at)
+	default:
+		return "", fmt.Errorf("failed to format message: %s isn't a chat channel", c)
+	}
+
+	b, err := json.Marshal(msg)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode %s message: %w", c, err)
+	}
+
+	return string(b), nil
+}
+
+func newSlackMessage(title, rate, asOf string, fields []chatField) slackMessage {
+	section := slackBlock{Type: "section", Text: &slackText{Type: "mrkdwn", Text: "*" + rate + "*"}}
+	for _, f := range fields {
+		section.Fields = append(section.Fields, slackText{Type: "mrkdwn", Text: "*" + f.name + "*\n" + f.value})
+	}
+
+	return slackMessage{
+		Text: title + ": " + rate,
+		Blocks: []slackBlock{
+			{Type: "header", Text: &slackText{Type: "plain_text", Text: title}},
+			section,
+			{Type: "context", Elements: []slackText{{Type: "mrkdwn", Text: asOf}}},
+		},
+	}
+}
+
+// newDiscordMessage builds the embed colored
+// by the direction of the daily change.
+func newDiscordMessage(
+	title, rate, asOf string,
+	fields []chatField,
+	yesterday *change,
+	at time.Time,
+) discordMessage {
+	e := discordEmbed{
+		Title:       title,
+		Description: "**" + rate + "**",
+		Color:       embedColorFlat,
+		Footer:      discordFooter{Text: asOf},
+		Timestamp:   at.UTC().Format(time.RFC3339),
+	}
+	for _, f := range fields {
+		e.Fields = append(e.Fields, discordField{Name: f.name, Value: f.value, Inline: true})
+	}
+
+	switch {
+	case yesterday == nil:
+	case yesterday.Diff >= minChange:
+		e.Color = embedColorUp
+	case yesterday.Diff <= -minChange:
+		e.Color = embedColorDown
+	}
+
+	return discordMessage{Embeds: []discordEmbed{e}}
+}
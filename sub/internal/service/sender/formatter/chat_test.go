@@ -0,0 +1,129 @@
+package formatter
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/hrvadl/converter/sub/internal/storage/subscriber"
+)
+
+func TestTemplateFormatChat(t *testing.T) {
+	t.Parallel()
+	f, err := NewTemplate()
+	if err != nil {
+		t.Fatalf("NewTemplate() error = %v", err)
+	}
+
+	at := time.Date(2024, 5, 2, 9, 0, 0, 0, time.UTC)
+	trend := Trend{Rate: 41.5, History: history(at, 39.5, 41.25, 40.1, 41.2, 41.3, 41.1, 41.05, 41.5)}
+	tests := []struct {
+		name    string
+		channel subscriber.Channel
+		locale  subscriber.Locale
+		trend   Trend
+		golden  string
+		wantErr bool
+	}{
+		{
+			name:    "Should render english slack message",
+			channel: subscriber.ChannelSlack,
+			locale:  subscriber.LocaleEnglish,
+			trend:   trend,
+			golden:  "en_slack",
+		},
+		{
+			name:    "Should render slack message without changes when history is empty",
+			channel: subscriber.ChannelSlack,
+			locale:  subscriber.LocaleEnglish,
+			trend:   Trend{Rate: 41.5},
+			golden:  "en_slack_no_history",
+		},
+		{
+			name:    "Should render ukrainian slack message",
+			channel: subscriber.ChannelSlack,
+			locale:  subscriber.LocaleUkrainian,
+			trend:   trend,
+			golden:  "uk_slack",
+		},
+		{
+			name:    "Should render english discord embed",
+			channel: subscriber.ChannelDiscord,
+			locale:  subscriber.LocaleEnglish,
+			trend:   trend,
+			golden:  "en_discord",
+		},
+		{
+			name:    "Should render discord embed without changes when history is empty",
+			channel: subscriber.ChannelDiscord,
+			locale:  subscriber.LocaleEnglish,
+			trend:   Trend{Rate: 41.5},
+			golden:  "en_discord_no_history",
+		},
+		{
+			name:    "Should return error when channel isn't a chat one",
+			channel: subscriber.ChannelEmail,
+			locale:  subscriber.LocaleEnglish,
+			trend:   trend,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := f.FormatChat(tt.channel, tt.locale, tt.trend, at)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("FormatChat() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			if !json.Valid([]byte(got)) {
+				t.Fatalf("FormatChat() = %v, want valid JSON", got)
+			}
+			assertGolden(t, tt.golden+".golden.json", got)
+		})
+	}
+}
+
+func TestNewDiscordMessageColor(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		yesterday *change
+		want      int
+	}{
+		{
+			name:      "Should color embed red when rate has risen",
+			yesterday: &change{Diff: 0.25},
+			want:      embedColorUp,
+		},
+		{
+			name:      "Should color embed green when rate has fallen",
+			yesterday: &change{Diff: -0.25},
+			want:      embedColorDown,
+		},
+		{
+			name:      "Should color embed blue when rate hasn't changed",
+			yesterday: &change{Diff: 0.001},
+			want:      embedColorFlat,
+		},
+		{
+			name:      "Should color embed blue when change is unknown",
+			yesterday: nil,
+			want:      embedColorFlat,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := newDiscordMessage("title", "rate", "as of", nil, tt.yesterday, time.Now())
+			if got.Embeds[0].Color != tt.want {
+				t.Errorf("newDiscordMessage() color = %#x, want %#x", got.Embeds[0].Color, tt.want)
+			}
+		})
+	}
+}
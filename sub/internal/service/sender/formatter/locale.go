@@ -0,0 +1,103 @@
+package formatter
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hrvadl/converter/sub/internal/storage/subscriber"
+)
+
+// locale describes how mails are formatted in the language.
+type locale struct {
+	// decimal is a decimal separator of the rates.
+	decimal string
+	// date is a layout of the date and time.
+	date string
+	// day is a short layout of the day, used in the chart.
+	day      string
+	subjects map[subscriber.Frequency]string
+	// periods are adjectives of the digest frequencies.
+	periods map[subscriber.Frequency]string
+	// asOf, yesterday and lastWeek are labels of the chat messages.
+	asOf      string
+	yesterday string
+	lastWeek  string
+}
+
+var locales = map[subscriber.Locale]locale{
+	subscriber.LocaleEnglish: {
+		decimal: ".",
+		date:    "January 2, 2006 15:04",
+		day:     "Jan 2",
+		subjects: map[subscriber.Frequency]string{
+			subscriber.FrequencyDaily:   "USD to UAH rate exchange",
+			subscriber.FrequencyWeekly:  "Weekly USD to UAH rate digest",
+			subscriber.FrequencyMonthly: "Monthly USD to UAH rate digest",
+		},
+		periods: map[subscriber.Frequency]string{
+			subscriber.FrequencyWeekly:  "weekly",
+			subscriber.FrequencyMonthly: "monthly",
+		},
+		asOf:      "As of %s (Kyiv time)",
+		yesterday: "Since yesterday",
+		lastWeek:  "Since last week",
+	},
+	subscriber.LocaleUkrainian: {
+		decimal: ",",
+		date:    "02.01.2006 15:04",
+		day:     "02.01",
+		subjects: map[subscriber.Frequency]string{
+			subscriber.FrequencyDaily:   "Курс обміну USD до UAH",
+			subscriber.FrequencyWeekly:  "Тижневий дайджест курсу USD до UAH",
+			subscriber.FrequencyMonthly: "Місячний дайджест курсу USD до UAH",
+		},
+		periods: map[subscriber.Frequency]string{
+			subscriber.FrequencyWeekly:  "тижневий",
+			subscriber.FrequencyMonthly: "місячний",
+		},
+		asOf:      "Станом на %s (за київським часом)",
+		yesterday: "Від учора",
+		lastWeek:  "Від минулого тижня",
+	},
+}
+
+// formatRate formats rate with 2 point precision
+// and the decimal separator of the locale.
+func (l locale) formatRate(r float32) string {
+	return l.formatNumber(float64(r))
+}
+
+// minChange is a minimal change of the rate, which
+// isn't rounded to zero with 2 point precision.
+const minChange = 0.005
+
+// formatChange formats change of the rate with the direction arrow,
+// sign and percentage, i.e. "▲ +0.25 (+0.61%)". Change, which is
+// less than 0.01, is formatted as unchanged.
+func (l locale) formatChange(c change) string {
+	if math.Abs(c.Diff) < minChange {
+		return "= " + l.formatNumber(0) + " (" + l.formatNumber(0) + "%)"
+	}
+
+	arrow, sign := "▲", "+"
+	if c.Diff < 0 {
+		arrow, sign = "▼", "-"
+	}
+
+	return arrow + " " + sign + l.formatNumber(math.Abs(c.Diff)) +
+		" (" + sign + l.formatNumber(math.Abs(c.Percent)) + "%)"
+}
+
+func (l locale) formatNumber(v float64) string {
+	return strings.Replace(strconv.FormatFloat(v, 'f', 2, 32), ".", l.decimal, 1)
+}
+
+// formatDate formats time in the given location with
+// the date layout of the locale.
+func (l locale) formatDate(loc *time.Location) func(t time.Time) string {
+	return func(t time.Time) string {
+		return t.In(loc).Format(l.date)
+	}
+}
@@ -0,0 +1,146 @@
+package formatter
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"html/template"
+	"time"
+
+	"github.com/hrvadl/converter/sub/internal/storage/rate"
+	"github.com/hrvadl/converter/sub/internal/storage/subscriber"
+)
+
+//go:embed templates
+var templates embed.FS
+
+// displayLocation is a time zone, in which
+// time is displayed in the mails.
+const displayLocation = "Europe/Kyiv"
+
+// fallbackLocale is used, when subscriber's locale is unknown.
+const fallbackLocale = subscriber.LocaleEnglish
+
+// Message is a rendered mail.
+type Message struct {
+	Subject string
+	HTML    string
+	// Text is a plain-text alternative of the HTML,
+	// generated from it.
+	Text string
+}
+
+// NewTemplate constructs new HTML formatter for mails, which parses
+// per-locale template sets. Could return an error if Kyiv time zone
+// or any of the templates couldn't be loaded.
+func NewTemplate() (*Template, error) {
+	loc, err := time.LoadLocation(displayLocation)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load %s location: %w", displayLocation, err)
+	}
+
+	sets := make(map[subscriber.Locale]*template.Template, len(locales))
+	for name, l := range locales {
+		t, err := template.New(string(name)).
+			Funcs(template.FuncMap{
+				"rate":   l.formatRate,
+				"change": l.formatChange,
+				"date":   l.formatDate(loc),
+				"period": func(f subscriber.Frequency) string { return l.periods[f] },
+			}).
+			ParseFS(templates, "templates/layout.html", fmt.Sprintf("templates/%s/*.html", name))
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s templates: %w", name, err)
+		}
+		sets[name] = t
+	}
+
+	return &Template{sets: sets, loc: loc}, nil
+}
+
+// Template is a formatter for mails, which renders message in the
+// subscriber's language with the branded responsive layout and the
+// plain-text alternative. Rates are formatted to 2 point precision,
+// time is displayed in Kyiv time zone.
+type Template struct {
+	sets map[subscriber.Locale]*template.Template
+	loc  *time.Location
+}
+
+// meta is a data shared by all templates.
+type meta struct {
+	Lang    subscriber.Locale
+	Subject string
+	At      time.Time
+}
+
+type rateData struct {
+	meta
+	Rate      float32
+	Yesterday *change
+	LastWeek  *change
+	Chart     *chart
+	Days      int
+}
+
+type digestData struct {
+	meta
+	Frequency subscriber.Frequency
+	Stats     rate.Stats
+}
+
+// Format method renders daily message with the exchange rate as of the
+// given time, its change since yesterday and last week, and the chart
+// of the daily rates. Changes and chart are omitted, if history is missing.
+func (t *Template) Format(l subscriber.Locale, tr Trend, at time.Time) (Message, error) {
+	l = resolve(l)
+	m := meta{Lang: l, Subject: locales[l].subjects[subscriber.FrequencyDaily], At: at}
+	return t.execute("rate", m, rateData{
+		meta:      m,
+		Rate:      tr.Rate,
+		Yesterday: tr.since(at.AddDate(0, 0, -1)),
+		LastWeek:  tr.since(at.AddDate(0, 0, -7)),
+		Chart:     locales[l].newChart(tr.History),
+		Days:      HistoryDays,
+	})
+}
+
+// FormatDigest method renders digest message with min/max/average rates
+// over the digest period.
+func (t *Template) FormatDigest(
+	l subscriber.Locale,
+	f subscriber.Frequency,
+	s rate.Stats,
+	at time.Time,
+) (Message, error) {
+	l = resolve(l)
+	m := meta{Lang: l, Subject: locales[l].subjects[f], At: at}
+	return t.execute("digest", m, digestData{
+		meta:      m,
+		Frequency: f,
+		Stats:     s,
+	})
+}
+
+func (t *Template) execute(name string, m meta, data any) (Message, error) {
+	var buf bytes.Buffer
+	if err := t.sets[m.Lang].ExecuteTemplate(&buf, name, data); err != nil {
+		return Message{}, fmt.Errorf("failed to execute %s template of %s locale: %w", name, m.Lang, err)
+	}
+
+	text, err := plainText(buf.String())
+	if err != nil {
+		return Message{}, fmt.Errorf("failed to generate plain text: %w", err)
+	}
+
+	return Message{Subject: m.Subject, HTML: buf.String(), Text: text}, nil
+}
+
+// resolve returns the given locale if it's known,
+// or fallback one otherwise.
+func resolve(l subscriber.Locale) subscriber.Locale {
+	if _, ok := locales[l]; ok {
+		return l
+	}
+	return fallbackLocale
+}
@@ -0,0 +1,297 @@
+package formatter
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+	_ "time/tzdata"
+
+	"github.com/hrvadl/converter/sub/internal/storage/rate"
+	"github.com/hrvadl/converter/sub/internal/storage/subscriber"
+)
+
+var update = flag.Bool("update", false, "update golden files")
+
+func TestTemplateFormat(t *testing.T) {
+	t.Parallel()
+	f, err := NewTemplate()
+	if err != nil {
+		t.Fatalf("NewTemplate() error = %v", err)
+	}
+
+	// 09:00 UTC is 12:00 in Kyiv during the summer time.
+	at := time.Date(2024, 5, 2, 9, 0, 0, 0, time.UTC)
+	trend := Trend{Rate: 41.5, History: history(at, 39.5, 41.25, 40.1, 41.2, 41.3, 41.1, 41.05, 41.5)}
+	stats := rate.Stats{Min: 39.5, Max: 41.255, Avg: 40.1, Count: 7}
+	tests := []struct {
+		name        string
+		golden      string
+		format      func() (Message, error)
+		wantSubject string
+	}{
+		{
+			name:   "Should render english daily mail",
+			golden: "en_rate",
+			format: func() (Message, error) {
+				return f.Format(subscriber.LocaleEnglish, trend, at)
+			},
+			wantSubject: "USD to UAH rate exchange",
+		},
+		{
+			name:   "Should render english daily mail without trend when history is empty",
+			golden: "en_rate_no_history",
+			format: func() (Message, error) {
+				return f.Format(subscriber.LocaleEnglish, Trend{Rate: 41.5}, at)
+			},
+			wantSubject: "USD to UAH rate exchange",
+		},
+		{
+			name:   "Should render english weekly digest",
+			golden: "en_digest",
+			format: func() (Message, error) {
+				return f.FormatDigest(subscriber.LocaleEnglish, subscriber.FrequencyWeekly, stats, at)
+			},
+			wantSubject: "Weekly USD to UAH rate digest",
+		},
+		{
+			name:   "Should render ukrainian daily mail",
+			golden: "uk_rate",
+			format: func() (Message, error) {
+				return f.Format(subscriber.LocaleUkrainian, trend, at)
+			},
+			wantSubject: "Курс обміну USD до UAH",
+		},
+		{
+			name:   "Should render ukrainian monthly digest",
+			golden: "uk_digest",
+			format: func() (Message, error) {
+				return f.FormatDigest(subscriber.LocaleUkrainian, subscriber.FrequencyMonthly, stats, at)
+			},
+			wantSubject: "Місячний дайджест курсу USD до UAH",
+		},
+		{
+			name:   "Should fallback to english when locale is unknown",
+			golden: "en_rate",
+			format: func() (Message, error) {
+				return f.Format(subscriber.Locale("fr"), trend, at)
+			},
+			wantSubject: "USD to UAH rate exchange",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			m, err := tt.format()
+			if err != nil {
+				t.Fatalf("format error = %v", err)
+			}
+
+			if m.Subject != tt.wantSubject {
+				t.Errorf("Subject = %v, want %v", m.Subject, tt.wantSubject)
+			}
+
+			assertGolden(t, tt.golden+".golden.html", m.HTML)
+			assertGolden(t, tt.golden+".golden.txt", m.Text)
+		})
+	}
+}
+
+func TestLocaleFormatRate(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name   string
+		locale subscriber.Locale
+		rate   float32
+		want   string
+	}{
+		{
+			name:   "Should use dot as decimal separator in english",
+			locale: subscriber.LocaleEnglish,
+			rate:   41.256,
+			want:   "41.26",
+		},
+		{
+			name:   "Should use comma as decimal separator in ukrainian",
+			locale: subscriber.LocaleUkrainian,
+			rate:   41.256,
+			want:   "41,26",
+		},
+		{
+			name:   "Should pad rate to 2 point precision",
+			locale: subscriber.LocaleUkrainian,
+			rate:   41,
+			want:   "41,00",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := locales[tt.locale].formatRate(tt.rate); got != tt.want {
+				t.Errorf("formatRate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLocaleFormatChange(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name   string
+		locale subscriber.Locale
+		change change
+		want   string
+	}{
+		{
+			name:   "Should format growth with the up arrow",
+			locale: subscriber.LocaleEnglish,
+			change: change{Diff: 0.25, Percent: 0.6117},
+			want:   "▲ +0.25 (+0.61%)",
+		},
+		{
+			name:   "Should format fall with the down arrow",
+			locale: subscriber.LocaleUkrainian,
+			change: change{Diff: -0.1, Percent: -0.2433},
+			want:   "▼ -0,10 (-0,24%)",
+		},
+		{
+			name:   "Should format change less than a kopeck as unchanged",
+			locale: subscriber.LocaleEnglish,
+			change: change{Diff: 0.004, Percent: 0.0097},
+			want:   "= 0.00 (0.00%)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := locales[tt.locale].formatChange(tt.change); got != tt.want {
+				t.Errorf("formatChange() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTrendSince(t *testing.T) {
+	t.Parallel()
+	at := time.Date(2024, 5, 2, 9, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name  string
+		trend Trend
+		day   time.Time
+		want  *change
+	}{
+		{
+			name:  "Should return change since the day",
+			trend: Trend{Rate: 42, History: history(at, 40, 41, 42)},
+			day:   at.AddDate(0, 0, -2),
+			want:  &change{Diff: 2, Percent: 5},
+		},
+		{
+			name:  "Should return nil when there's no rate of the day",
+			trend: Trend{Rate: 42, History: history(at, 41, 42)},
+			day:   at.AddDate(0, 0, -7),
+			want:  nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := tt.trend.since(tt.day)
+			if (got == nil) != (tt.want == nil) || got != nil && *got != *tt.want {
+				t.Errorf("since() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLocaleNewChart(t *testing.T) {
+	t.Parallel()
+	at := time.Date(2024, 5, 2, 9, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name        string
+		history     []rate.Daily
+		wantNil     bool
+		wantMin     float32
+		wantMax     float32
+		wantHeights []int
+	}{
+		{
+			name:        "Should draw chart with the range of rates",
+			history:     history(at, 40, 42.5, 39, 41),
+			wantMin:     39,
+			wantMax:     42.5,
+			wantHeights: []int{40, chartHeight, chartMinBar, 72},
+		},
+		{
+			name:        "Should draw flat chart when rates are equal",
+			history:     history(at, 41, 41),
+			wantMin:     41,
+			wantMax:     41,
+			wantHeights: []int{chartHeight / 2, chartHeight / 2},
+		},
+		{
+			name:    "Should not draw chart of the single rate",
+			history: history(at, 41),
+			wantNil: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := locales[subscriber.LocaleEnglish].newChart(tt.history)
+			if (got == nil) != tt.wantNil {
+				t.Fatalf("newChart() = %v, wantNil %v", got, tt.wantNil)
+			}
+			if got == nil {
+				return
+			}
+			if got.Min != tt.wantMin || got.Max != tt.wantMax {
+				t.Errorf("newChart() range = %v-%v, want %v-%v", got.Min, got.Max, tt.wantMin, tt.wantMax)
+			}
+
+			heights := make([]int, len(got.Bars))
+			for i, b := range got.Bars {
+				heights[i] = b.Height
+			}
+			if !reflect.DeepEqual(heights, tt.wantHeights) {
+				t.Errorf("newChart() heights = %v, want %v", heights, tt.wantHeights)
+			}
+		})
+	}
+}
+
+// history builds daily rates, the last of which is the rate of at.
+func history(at time.Time, rates ...float32) []rate.Daily {
+	day := time.Date(at.Year(), at.Month(), at.Day(), 0, 0, 0, 0, time.UTC)
+	h := make([]rate.Daily, len(rates))
+	for i, r := range rates {
+		h[i] = rate.Daily{Day: day.AddDate(0, 0, i-len(rates)+1), Rate: r}
+	}
+	return h
+}
+
+func assertGolden(t *testing.T, name, got string) {
+	t.Helper()
+	golden := filepath.Join("testdata", name)
+	if *update {
+		if err := os.WriteFile(golden, []byte(got), 0o600); err != nil {
+			t.Fatalf("Failed to update golden file: %v", err)
+		}
+	}
+
+	want, err := os.ReadFile(golden)
+	if err != nil {
+		t.Fatalf("Failed to read golden file: %v", err)
+	}
+
+	if got != string(want) {
+		t.Errorf("%s = %v, want %v", name, got, string(want))
+	}
+}
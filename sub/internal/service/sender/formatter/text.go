@@ -0,0 +1,153 @@
+package formatter
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// plainText converts rendered HTML mail into the plain-text alternative.
+// Paragraphs, headings and tables are separated with the blank lines,
+// rows and list items start on the new line, links are followed by their
+// URL. Content of the head, styles and tables hidden with aria-hidden,
+// i.e. charts, is dropped.
+func plainText(src string) (string, error) {
+	var (
+		w    textWriter
+		skip int
+		// hidden is a depth of the tables inside the hidden one.
+		hidden int
+		href   string
+	)
+	z := html.NewTokenizer(strings.NewReader(src))
+	for {
+		switch z.Next() {
+		case html.ErrorToken:
+			if err := z.Err(); !errors.Is(err, io.EOF) {
+				return "", fmt.Errorf("failed to parse html: %w", err)
+			}
+			return strings.TrimSpace(w.b.String()) + "\n", nil
+		case html.TextToken:
+			if skip == 0 && hidden == 0 {
+				w.text(string(z.Text()))
+			}
+		case html.StartTagToken, html.SelfClosingTagToken:
+			t := z.Token()
+			if t.Type == html.StartTagToken && t.DataAtom == atom.Table &&
+				(hidden > 0 || attr(t, "aria-hidden") == "true") {
+				hidden++
+			}
+			if hidden > 0 {
+				continue
+			}
+
+			switch t.DataAtom {
+			case atom.Head, atom.Style, atom.Script:
+				if t.Type == html.StartTagToken {
+					skip++
+				}
+			case atom.P, atom.H1, atom.H2, atom.H3, atom.Table, atom.Ul, atom.Ol:
+				w.paragraph()
+			case atom.Div, atom.Tr, atom.Br:
+				w.newline()
+			case atom.Li:
+				w.newline()
+				w.b.WriteString("- ")
+			case atom.Td, atom.Th:
+				w.space = true
+			case atom.A:
+				href = attr(t, "href")
+			}
+		case html.EndTagToken:
+			t := z.Token()
+			if hidden > 0 {
+				if t.DataAtom == atom.Table {
+					hidden--
+				}
+				continue
+			}
+
+			switch t.DataAtom {
+			case atom.Head, atom.Style, atom.Script:
+				skip = max(skip-1, 0)
+			case atom.P, atom.H1, atom.H2, atom.H3, atom.Table, atom.Ul, atom.Ol:
+				w.paragraph()
+			case atom.Div, atom.Tr, atom.Li:
+				w.newline()
+			case atom.A:
+				if href != "" && !strings.HasPrefix(href, "mailto:") {
+					w.text(" (" + href + ")")
+				}
+				href = ""
+			}
+		}
+	}
+}
+
+func attr(t html.Token, key string) string {
+	for _, a := range t.Attr {
+		if a.Key == key {
+			return a.Val
+		}
+	}
+	return ""
+}
+
+// textWriter collapses whitespaces of the HTML text
+// and keeps at most one blank line between the blocks.
+type textWriter struct {
+	b strings.Builder
+	// space means there was a whitespace since the last written word.
+	space bool
+}
+
+func (w *textWriter) text(s string) {
+	if s == "" {
+		return
+	}
+
+	words := strings.Fields(s)
+	if len(words) == 0 {
+		w.space = true
+		return
+	}
+
+	if w.space || startsWithSpace(s) {
+		w.sep()
+	}
+	w.b.WriteString(strings.Join(words, " "))
+	w.space = endsWithSpace(s)
+}
+
+// sep writes a single space, unless it's the start of the line.
+func (w *textWriter) sep() {
+	if out := w.b.String(); out != "" && !strings.HasSuffix(out, "\n") && !strings.HasSuffix(out, " ") {
+		w.b.WriteByte(' ')
+	}
+}
+
+func (w *textWriter) newline() {
+	if out := w.b.String(); out != "" && !strings.HasSuffix(out, "\n") {
+		w.b.WriteByte('\n')
+	}
+	w.space = false
+}
+
+func (w *textWriter) paragraph() {
+	w.newline()
+	if out := w.b.String(); out != "" && !strings.HasSuffix(out, "\n\n") {
+		w.b.WriteByte('\n')
+	}
+}
+
+func startsWithSpace(s string) bool {
+	return strings.TrimLeft(s, " \t\r\n") != s
+}
+
+func endsWithSpace(s string) bool {
+	return strings.TrimRight(s, " \t\r\n") != s
+}
@@ -0,0 +1,54 @@
+package formatter
+
+import "testing"
+
+func TestPlainText(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name string
+		html string
+		want string
+	}{
+		{
+			name: "Should separate paragraphs with blank line",
+			html: "<p>Hello!</p>\n  <p>Rate is\n   <b>41.50</b> UAH</p>",
+			want: "Hello!\n\nRate is 41.50 UAH\n",
+		},
+		{
+			name: "Should drop head, styles and charts",
+			html: `<html><head><title>Rate</title><style>p { color: red; }</style></head>` +
+				`<body><p>Rate</p><table aria-hidden="true"><tr><td><table><tr><td>41.50</td></tr></table>` +
+				`</td><td>42.00</td></tr></table><p>End</p></body></html>`,
+			want: "Rate\n\nEnd\n",
+		},
+		{
+			name: "Should put table rows and list items on separate lines",
+			html: "<table><tr><td>Min:</td><td>39.50</td></tr><tr><td>Max:</td><td>41.50</td></tr></table>" +
+				"<ul><li>first</li><li>second</li></ul>",
+			want: "Min: 39.50\nMax: 41.50\n\n- first\n- second\n",
+		},
+		{
+			name: "Should follow links with their URLs",
+			html: `<p>See <a href="https://example.com">site</a> or <a href="mailto:a@b.com">mail us</a>.</p>`,
+			want: "See site (https://example.com) or mail us.\n",
+		},
+		{
+			name: "Should unescape entities",
+			html: "<p>USD &amp; UAH &lt;3</p>",
+			want: "USD & UAH <3\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := plainText(tt.html)
+			if err != nil {
+				t.Fatalf("plainText() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("plainText() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
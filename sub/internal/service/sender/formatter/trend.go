@@ -0,0 +1,50 @@
+package formatter
+
+import (
+	"time"
+
+	"github.com/hrvadl/converter/sub/internal/storage/rate"
+)
+
+// HistoryDays is a number of days, which are
+// drawn on the chart of the daily mail.
+const HistoryDays = 30
+
+// Trend is the latest exchange rate with the daily
+// rates preceding it.
+type Trend struct {
+	Rate float32
+	// History is average rates of the last HistoryDays days,
+	// oldest first. It could include the current day.
+	History []rate.Daily
+}
+
+// change is a difference between the latest
+// rate and the rate of the earlier day.
+type change struct {
+	Diff    float64
+	Percent float64
+}
+
+// since returns change of the latest rate since the given day.
+// Returns nil, if there's no rate of the day in the history.
+func (t Trend) since(day time.Time) *change {
+	prev, ok := rateOn(t.History, day)
+	if !ok || prev == 0 {
+		return nil
+	}
+
+	diff := float64(t.Rate) - float64(prev)
+	return &change{Diff: diff, Percent: diff / float64(prev) * 100}
+}
+
+// rateOn returns rate of the given day from the history.
+func rateOn(history []rate.Daily, day time.Time) (float32, bool) {
+	y, m, d := day.UTC().Date()
+	for _, h := range history {
+		if hy, hm, hd := h.Day.UTC().Date(); hy == y && hm == m && hd == d {
+			return h.Rate, true
+		}
+	}
+	return 0, false
+}
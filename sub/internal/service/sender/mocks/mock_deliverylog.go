@@ -0,0 +1,56 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/hrvadl/converter/sub/internal/service/sender (interfaces: DeliveryLog)
+//
+// Generated by this command:
+//
+//	mockgen -destination=./mocks/mock_deliverylog.go -package=mocks . DeliveryLog
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	delivery "github.com/hrvadl/converter/sub/internal/storage/delivery"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockDeliveryLog is a mock of DeliveryLog interface.
+type MockDeliveryLog struct {
+	ctrl     *gomock.Controller
+	recorder *MockDeliveryLogMockRecorder
+}
+
+// MockDeliveryLogMockRecorder is the mock recorder for MockDeliveryLog.
+type MockDeliveryLogMockRecorder struct {
+	mock *MockDeliveryLog
+}
+
+// NewMockDeliveryLog creates a new mock instance.
+func NewMockDeliveryLog(ctrl *gomock.Controller) *MockDeliveryLog {
+	mock := &MockDeliveryLog{ctrl: ctrl}
+	mock.recorder = &MockDeliveryLogMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDeliveryLog) EXPECT() *MockDeliveryLogMockRecorder {
+	return m.recorder
+}
+
+// Save mocks base method.
+func (m *MockDeliveryLog) Save(arg0 context.Context, arg1 delivery.Delivery) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockDeliveryLogMockRecorder) Save(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockDeliveryLog)(nil).Save), arg0, arg1)
+}
@@ -13,6 +13,7 @@ import (
 	context "context"
 	reflect "reflect"
 
+	rate "github.com/hrvadl/converter/sub/internal/storage/rate"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -39,17 +40,17 @@ func (m *MockRateGetter) EXPECT() *MockRateGetterMockRecorder {
 	return m.recorder
 }
 
-// GetRate mocks base method.
-func (m *MockRateGetter) GetRate(arg0 context.Context) (float32, error) {
+// GetQuote mocks base method.
+func (m *MockRateGetter) GetQuote(arg0 context.Context) (rate.Rate, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetRate", arg0)
-	ret0, _ := ret[0].(float32)
+	ret := m.ctrl.Call(m, "GetQuote", arg0)
+	ret0, _ := ret[0].(rate.Rate)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetRate indicates an expected call of GetRate.
-func (mr *MockRateGetterMockRecorder) GetRate(arg0 any) *gomock.Call {
+// GetQuote indicates an expected call of GetQuote.
+func (mr *MockRateGetterMockRecorder) GetQuote(arg0 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRate", reflect.TypeOf((*MockRateGetter)(nil).GetRate), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetQuote", reflect.TypeOf((*MockRateGetter)(nil).GetQuote), arg0)
 }
@@ -0,0 +1,87 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/hrvadl/converter/sub/internal/service/sender (interfaces: RateHistory)
+//
+// Generated by this command:
+//
+//	mockgen -destination=./mocks/mock_ratehistory.go -package=mocks . RateHistory
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	rate "github.com/hrvadl/converter/sub/internal/storage/rate"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRateHistory is a mock of RateHistory interface.
+type MockRateHistory struct {
+	ctrl     *gomock.Controller
+	recorder *MockRateHistoryMockRecorder
+}
+
+// MockRateHistoryMockRecorder is the mock recorder for MockRateHistory.
+type MockRateHistoryMockRecorder struct {
+	mock *MockRateHistory
+}
+
+// NewMockRateHistory creates a new mock instance.
+func NewMockRateHistory(ctrl *gomock.Controller) *MockRateHistory {
+	mock := &MockRateHistory{ctrl: ctrl}
+	mock.recorder = &MockRateHistoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRateHistory) EXPECT() *MockRateHistoryMockRecorder {
+	return m.recorder
+}
+
+// GetDaily mocks base method.
+func (m *MockRateHistory) GetDaily(arg0 context.Context, arg1, arg2 time.Time) ([]rate.Daily, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDaily", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]rate.Daily)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDaily indicates an expected call of GetDaily.
+func (mr *MockRateHistoryMockRecorder) GetDaily(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDaily", reflect.TypeOf((*MockRateHistory)(nil).GetDaily), arg0, arg1, arg2)
+}
+
+// GetStats mocks base method.
+func (m *MockRateHistory) GetStats(arg0 context.Context, arg1, arg2 time.Time) (rate.Stats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStats", arg0, arg1, arg2)
+	ret0, _ := ret[0].(rate.Stats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStats indicates an expected call of GetStats.
+func (mr *MockRateHistoryMockRecorder) GetStats(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStats", reflect.TypeOf((*MockRateHistory)(nil).GetStats), arg0, arg1, arg2)
+}
+
+// Save mocks base method.
+func (m *MockRateHistory) Save(arg0 context.Context, arg1 rate.Rate) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockRateHistoryMockRecorder) Save(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockRateHistory)(nil).Save), arg0, arg1)
+}
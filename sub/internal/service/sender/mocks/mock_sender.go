@@ -1,54 +0,0 @@
-// Code generated by MockGen. DO NOT EDIT.
-// Source: github.com/hrvadl/converter/sub/internal/service/sender (interfaces: Sender)
-//
-// Generated by this command:
-//
-//	mockgen -destination=./mocks/mock_sender.go -package=mocks . Sender
-//
-
-// Package mocks is a generated GoMock package.
-package mocks
-
-import (
-	context "context"
-	reflect "reflect"
-
-	gomock "go.uber.org/mock/gomock"
-)
-
-// MockSender is a mock of Sender interface.
-type MockSender struct {
-	ctrl     *gomock.Controller
-	recorder *MockSenderMockRecorder
-}
-
-// MockSenderMockRecorder is the mock recorder for MockSender.
-type MockSenderMockRecorder struct {
-	mock *MockSender
-}
-
-// NewMockSender creates a new mock instance.
-func NewMockSender(ctrl *gomock.Controller) *MockSender {
-	mock := &MockSender{ctrl: ctrl}
-	mock.recorder = &MockSenderMockRecorder{mock}
-	return mock
-}
-
-// EXPECT returns an object that allows the caller to indicate expected use.
-func (m *MockSender) EXPECT() *MockSenderMockRecorder {
-	return m.recorder
-}
-
-// Send mocks base method.
-func (m *MockSender) Send(arg0 context.Context) error {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Send", arg0)
-	ret0, _ := ret[0].(error)
-	return ret0
-}
-
-// Send indicates an expected call of Send.
-func (mr *MockSenderMockRecorder) Send(arg0 any) *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Send", reflect.TypeOf((*MockSender)(nil).Send), arg0)
-}
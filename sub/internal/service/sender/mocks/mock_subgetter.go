@@ -12,6 +12,7 @@ package mocks
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	subscriber "github.com/hrvadl/converter/sub/internal/storage/subscriber"
 	gomock "go.uber.org/mock/gomock"
@@ -40,17 +41,17 @@ func (m *MockSubscriberGetter) EXPECT() *MockSubscriberGetterMockRecorder {
 	return m.recorder
 }
 
-// Get mocks base method.
-func (m *MockSubscriberGetter) Get(arg0 context.Context) ([]subscriber.Subscriber, error) {
+// GetDue mocks base method.
+func (m *MockSubscriberGetter) GetDue(arg0 context.Context, arg1 time.Time, arg2 int64, arg3 int) ([]subscriber.Subscriber, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Get", arg0)
+	ret := m.ctrl.Call(m, "GetDue", arg0, arg1, arg2, arg3)
 	ret0, _ := ret[0].([]subscriber.Subscriber)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// Get indicates an expected call of Get.
-func (mr *MockSubscriberGetterMockRecorder) Get(arg0 any) *gomock.Call {
+// GetDue indicates an expected call of GetDue.
+func (mr *MockSubscriberGetterMockRecorder) GetDue(arg0, arg1, arg2, arg3 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockSubscriberGetter)(nil).Get), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDue", reflect.TypeOf((*MockSubscriberGetter)(nil).GetDue), arg0, arg1, arg2, arg3)
 }
@@ -0,0 +1,56 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/hrvadl/converter/sub/internal/service/sender (interfaces: SuppressionList)
+//
+// Generated by this command:
+//
+//	mockgen -destination=./mocks/mock_suppressionlist.go -package=mocks . SuppressionList
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	suppression "github.com/hrvadl/converter/sub/internal/storage/suppression"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockSuppressionList is a mock of SuppressionList interface.
+type MockSuppressionList struct {
+	ctrl     *gomock.Controller
+	recorder *MockSuppressionListMockRecorder
+}
+
+// MockSuppressionListMockRecorder is the mock recorder for MockSuppressionList.
+type MockSuppressionListMockRecorder struct {
+	mock *MockSuppressionList
+}
+
+// NewMockSuppressionList creates a new mock instance.
+func NewMockSuppressionList(ctrl *gomock.Controller) *MockSuppressionList {
+	mock := &MockSuppressionList{ctrl: ctrl}
+	mock.recorder = &MockSuppressionListMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSuppressionList) EXPECT() *MockSuppressionListMockRecorder {
+	return m.recorder
+}
+
+// GetSuppressed mocks base method.
+func (m *MockSuppressionList) GetSuppressed(arg0 context.Context, arg1 []string) ([]suppression.Suppression, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSuppressed", arg0, arg1)
+	ret0, _ := ret[0].([]suppression.Suppression)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSuppressed indicates an expected call of GetSuppressed.
+func (mr *MockSuppressionListMockRecorder) GetSuppressed(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSuppressed", reflect.TypeOf((*MockSuppressionList)(nil).GetSuppressed), arg0, arg1)
+}
@@ -0,0 +1,65 @@
+package report
+
+import "github.com/hrvadl/converter/sub/internal/storage/subscriber"
+
+// Result represents outcome of the delivery to the single
+// recipient of the channel. Err is nil when notification was sent.
+// MessageID is an ID of the message assigned by the provider.
+type Result struct {
+	Channel   subscriber.Channel
+	Email     string
+	MessageID string
+	Err       error
+}
+
+// Summary represents number of sent and failed
+// notifications of the single channel.
+type Summary struct {
+	Sent   int
+	Failed int
+}
+
+// Report contains per-recipient results of the single
+// send run, so one failed recipient doesn't fail the others.
+type Report struct {
+	Results []Result
+}
+
+// Sent method returns number of recipients,
+// who successfully received the mail.
+func (r Report) Sent() int {
+	return len(r.Results) - len(r.Failed())
+}
+
+// Failed method returns results of the recipients,
+// who didn't receive the mail.
+func (r Report) Failed() []Result {
+	failed := make([]Result, 0)
+	for i := range r.Results {
+		if r.Results[i].Err != nil {
+			failed = append(failed, r.Results[i])
+		}
+	}
+	return failed
+}
+
+// ByChannel method returns number of sent and failed notifications
+// per channel. Results without channel are counted as the email ones.
+func (r Report) ByChannel() map[subscriber.Channel]Summary {
+	summaries := make(map[subscriber.Channel]Summary)
+	for i := range r.Results {
+		c := r.Results[i].Channel
+		if c == "" {
+			c = subscriber.ChannelEmail
+		}
+
+		s := summaries[c]
+		if r.Results[i].Err != nil {
+			s.Failed++
+		} else {
+			s.Sent++
+		}
+		summaries[c] = s
+	}
+	return summaries
+}
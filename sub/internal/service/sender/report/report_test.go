@@ -0,0 +1,115 @@
+package report
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/hrvadl/converter/sub/internal/storage/subscriber"
+)
+
+func TestReportSent(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name   string
+		report Report
+		want   int
+	}{
+		{
+			name: "Should count only successful results",
+			report: Report{Results: []Result{
+				{Email: "test@test.com"},
+				{Email: "test2@test.com", Err: errors.New("failed")},
+				{Email: "test3@test.com"},
+			}},
+			want: 2,
+		},
+		{
+			name:   "Should return zero for empty report",
+			report: Report{},
+			want:   0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := tt.report.Sent(); got != tt.want {
+				t.Errorf("Report.Sent() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReportFailed(t *testing.T) {
+	t.Parallel()
+	err := errors.New("failed")
+	tests := []struct {
+		name   string
+		report Report
+		want   []Result
+	}{
+		{
+			name: "Should return only failed results",
+			report: Report{Results: []Result{
+				{Email: "test@test.com"},
+				{Email: "test2@test.com", Err: err},
+			}},
+			want: []Result{{Email: "test2@test.com", Err: err}},
+		},
+		{
+			name: "Should return empty slice when everything was sent",
+			report: Report{Results: []Result{
+				{Email: "test@test.com"},
+			}},
+			want: []Result{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := tt.report.Failed(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Report.Failed() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReportByChannel(t *testing.T) {
+	t.Parallel()
+	err := errors.New("failed")
+	tests := []struct {
+		name   string
+		report Report
+		want   map[subscriber.Channel]Summary
+	}{
+		{
+			name: "Should count results per channel",
+			report: Report{Results: []Result{
+				{Channel: subscriber.ChannelEmail, Email: "test@test.com"},
+				{Channel: subscriber.ChannelEmail, Email: "test2@test.com", Err: err},
+				{Channel: subscriber.ChannelTelegram, Email: "42"},
+				{Email: "test3@test.com"},
+			}},
+			want: map[subscriber.Channel]Summary{
+				subscriber.ChannelEmail:    {Sent: 2, Failed: 1},
+				subscriber.ChannelTelegram: {Sent: 1},
+			},
+		},
+		{
+			name:   "Should return empty map for empty report",
+			report: Report{},
+			want:   map[subscriber.Channel]Summary{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := tt.report.ByChannel(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Report.ByChannel() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
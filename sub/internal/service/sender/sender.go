@@ -2,98 +2,622 @@ package sender
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
+	"strings"
+	"sync"
+	"time"
 
+	"github.com/hrvadl/converter/sub/internal/service/sender/channel"
+	"github.com/hrvadl/converter/sub/internal/service/sender/formatter"
+	"github.com/hrvadl/converter/sub/internal/service/sender/report"
+	"github.com/hrvadl/converter/sub/internal/storage/delivery"
+	"github.com/hrvadl/converter/sub/internal/storage/outbox"
+	"github.com/hrvadl/converter/sub/internal/storage/rate"
 	"github.com/hrvadl/converter/sub/internal/storage/subscriber"
+	"github.com/hrvadl/converter/sub/internal/storage/suppression"
 )
 
+const operation = "sender cron job"
+
+// sendConcurrency is a maximum number of notifications,
+// which are being sent simultaneously across all channels.
+const sendConcurrency = 10
+
 const (
-	operation = "sender cron job"
-	subject   = "USD to UAH rate exchange"
+	// enqueueBatchSize is a number of due subscribers read
+	// from the DB and saved to the outbox at once.
+	enqueueBatchSize = 1000
+	// deliverBatchSize is a number of pending notifications
+	// read from the outbox at once.
+	deliverBatchSize = 100
+	// maxAttempts is a number of attempts after which notification
+	// is moved to the dead letters.
+	maxAttempts = 5
+	// baseBackoff is a delay before the second attempt. Each
+	// next attempt is delayed twice as long as previous one.
+	baseBackoff = time.Minute
+	// maxBackoff is a maximum delay between the attempts.
+	maxBackoff = time.Hour
+	// claimTimeout is a period during which claimed notifications
+	// aren't picked up by other replicas. It should be longer than
+	// the delivery itself.
+	claimTimeout = time.Minute * 5
+	// persistTimeout is a timeout of saving outcomes of the sent
+	// batch, which isn't cancelled together with the delivery.
+	persistTimeout = time.Second * 10
 )
 
+// ErrSuppressed is returned as a delivery error of the notification,
+// which wasn't sent because recipient's email is suppressed.
+var ErrSuppressed = errors.New("recipient is suppressed")
+
+// ErrUnsupportedChannel is returned as a delivery error of the
+// notification, whose channel isn't enabled.
+var ErrUnsupportedChannel = errors.New("channel is not supported")
+
+// errInterrupted marks notifications, which weren't sent because
+// delivery was cancelled. They're left claimed, so they're picked
+// up again after the claim expires, without losing an attempt.
+var errInterrupted = errors.New("delivery is interrupted")
+
 // New will construct new sender responsible for sending
-// message to the provided recipients.
+// notifications to the provided recipients through the given channels.
+// Notifications to the channels, which aren't provided, fail
+// with ErrUnsupportedChannel.
 // NOTE: neither of arguments cannot be empty, or service will
 // panic later.
 func New(
-	m Mailer,
 	sg SubscriberGetter,
-	mf RateMessageFormatter,
 	rg RateGetter,
+	rh RateHistory,
+	ob Outbox,
+	dl DeliveryLog,
+	sl SuppressionList,
+	channels map[subscriber.Channel]Channel,
 	log *slog.Logger,
 ) *Service {
 	return &Service{
-		mailer:     m,
-		subGetter:  sg,
-		formatter:  mf,
-		rateGetter: rg,
-		log:        log,
+		subGetter:    sg,
+		rateGetter:   rg,
+		rateHistory:  rh,
+		outbox:       ob,
+		deliveryLog:  dl,
+		suppressions: sl,
+		channels:     channels,
+		log:          log,
 	}
 }
 
 //go:generate mockgen -destination=./mocks/mock_rategetter.go -package=mocks . RateGetter
 type RateGetter interface {
-	GetRate(ctx context.Context) (float32, error)
+	GetQuote(ctx context.Context) (rate.Rate, error)
+}
+
+//go:generate mockgen -destination=./mocks/mock_ratehistory.go -package=mocks . RateHistory
+type RateHistory interface {
+	Save(ctx context.Context, r rate.Rate) (int64, error)
+	GetStats(ctx context.Context, from, to time.Time) (rate.Stats, error)
+	GetDaily(ctx context.Context, from, to time.Time) ([]rate.Daily, error)
 }
 
 //go:generate mockgen -destination=./mocks/mock_subgetter.go -package=mocks . SubscriberGetter
 type SubscriberGetter interface {
-	Get(ctx context.Context) ([]subscriber.Subscriber, error)
+	GetDue(ctx context.Context, at time.Time, afterID int64, limit int) ([]subscriber.Subscriber, error)
 }
 
-//go:generate mockgen -destination=./mocks/mock_formatter.go -package=mocks . RateMessageFormatter
-type RateMessageFormatter interface {
-	Format(r float32) string
+// Channel formats and sends notifications of the single kind.
+//
+//go:generate mockgen -destination=./mocks/mock_channel.go -package=mocks . Channel
+type Channel = channel.Channel
+
+//go:generate mockgen -destination=./mocks/mock_outbox.go -package=mocks . Outbox
+type Outbox interface {
+	Save(ctx context.Context, n []outbox.Notification) (int, error)
+	Claim(ctx context.Context, at, until time.Time, limit int) ([]outbox.Notification, error)
+	MarkSent(ctx context.Context, id int64) error
+	Reschedule(ctx context.Context, id int64, next time.Time, cause error) error
+	MarkDead(ctx context.Context, id int64, cause error) error
 }
 
-//go:generate mockgen -destination=./mocks/mock_mailer.go -package=mocks . Mailer
-type Mailer interface {
-	Send(ctx context.Context, msg, subject string, to ...string) error
+//go:generate mockgen -destination=./mocks/mock_deliverylog.go -package=mocks . DeliveryLog
+type DeliveryLog interface {
+	Save(ctx context.Context, d delivery.Delivery) (int64, error)
 }
 
-// Service is main structure, responsible for sending
-// mails to the subscribers, getting exchange rate and
-// formatting email messages.
+//go:generate mockgen -destination=./mocks/mock_suppressionlist.go -package=mocks . SuppressionList
+type SuppressionList interface {
+	GetSuppressed(ctx context.Context, emails []string) ([]suppression.Suppression, error)
+}
+
+// Service is main structure, responsible for getting exchange
+// rate and sending notifications about it to the subscribers
+// through their channels.
 type Service struct {
-	mailer     Mailer
-	formatter  RateMessageFormatter
-	subGetter  SubscriberGetter
-	rateGetter RateGetter
-	log        *slog.Logger
+	subGetter    SubscriberGetter
+	rateGetter   RateGetter
+	rateHistory  RateHistory
+	outbox       Outbox
+	deliveryLog  DeliveryLog
+	suppressions SuppressionList
+	channels     map[subscriber.Channel]Channel
+	log          *slog.Logger
 }
 
-// Send methods tries to get all subscribers, then
-// gets all latest rate and format message. At the end
-// it delegetes sending to the underlying sender.
+// Enqueue methods tries to get the latest rate and records it
+// to the rate history once per run date, so digests could be built
+// from it later and repeated runs don't skew them. Then
+// it reads subscribers, who are due on the day of the given point of
+// time, in batches, and persists a notification per channel address of
+// each subscriber to the outbox. Message is formatted by the channel
+// once per frequency and locale. Each batch is saved in a single
+// transaction, so only a single batch is held in memory and saved
+// notifications could be delivered while the rest are enqueued.
+// Notifications are sent later by the Deliver method, so they're not lost
+// if sub crashes mid-send. Subscriber gets at most one notification per
+// channel a day, so repeated run doesn't enqueue the same notification
+// twice and continues where the failed one has stopped. Point of time is
+// the scheduled run in the schedule's location, so missed run could be
+// caught up later with the current rate, and the run date and due
+// subscribers are the ones of the local day. Notifications to the channels, which aren't provided,
+// are enqueued without the message, so they're dead-lettered on delivery.
+// Returns number of enqueued notifications.
 // Could return an error if any of above steps has failed.
-// NOTE: don't call mailer send if there're zero subscribers.
-func (w *Service) Send(ctx context.Context) error {
-	subs, err := w.subGetter.Get(ctx)
+func (w *Service) Enqueue(ctx context.Context, at time.Time) (int, error) {
+	day := runDate(at)
+	q, err := w.rateGetter.GetQuote(ctx)
 	if err != nil {
-		return fmt.Errorf("%s: failed to get subscribers: %w", operation, err)
+		return 0, fmt.Errorf("%s: failed to get rate: %w", operation, err)
+	}
+	fetchedAt := time.Now()
+
+	history := q
+	history.RunDate = day
+	if _, err = w.rateHistory.Save(ctx, history); err != nil && !errors.Is(err, rate.ErrAlreadyExists) {
+		return 0, fmt.Errorf("%s: failed to save rate: %w", operation, err)
+	}
+
+	it := subscriber.NewIterator(
+		func(ctx context.Context, afterID int64, limit int) ([]subscriber.Subscriber, error) {
+			return w.subGetter.GetDue(ctx, at, afterID, limit)
+		},
+		enqueueBatchSize,
+	)
+
+	c := composer{
+		svc:      w,
+		base:     channel.Content{Quote: q, At: at.UTC(), FetchedAt: fetchedAt},
+		contents: make(map[subscriber.Frequency]channel.Content),
+		messages: make(map[messageKey]channel.Message),
+	}
+	var due, saved int
+	for {
+		subs, err := it.Next(ctx)
+		if err != nil {
+			return saved, fmt.Errorf("%s: failed to get subscribers: %w", operation, err)
+		}
+
+		if len(subs) == 0 {
+			break
+		}
+		due += len(subs)
+
+		notifications := make([]outbox.Notification, 0, len(subs))
+		for _, s := range subs {
+			for _, t := range s.Targets() {
+				key := messageKey{channel: t.Channel, frequency: frequencyOf(s), locale: s.Locale}
+				m, err := c.message(ctx, key)
+				if err != nil {
+					return saved, fmt.Errorf(
+						"%s: failed to compose %s %s message in %s: %w",
+						operation, key.frequency, key.channel, key.locale, err,
+					)
+				}
+
+				notifications = append(notifications, outbox.Notification{
+					SubscriberID:  s.ID,
+					Channel:       t.Channel,
+					Email:         t.Address,
+					Subject:       m.Subject,
+					Body:          m.Body,
+					Text:          m.Text,
+					Rate:          q.Rate,
+					RunDate:       day,
+					NextAttemptAt: time.Now().UTC(),
+				})
+			}
+		}
+
+		n, err := w.outbox.Save(ctx, notifications)
+		if err != nil {
+			return saved, fmt.Errorf("%s: failed to save notifications: %w", operation, err)
+		}
+		saved += n
+	}
+
+	if due == 0 {
+		w.log.Info("There're no subscribers due, skipping", "at", at)
+	}
+
+	return saved, nil
+}
+
+// messageKey identifies subscribers, which share the same message.
+// Message is formatted once per channel, frequency and locale.
+type messageKey struct {
+	channel   subscriber.Channel
+	frequency subscriber.Frequency
+	locale    subscriber.Locale
+}
+
+// composer formats messages of the single enqueue run. Content of
+// each frequency is read from the rate history once, and each message
+// is formatted once, no matter how many subscribers share it.
+type composer struct {
+	svc      *Service
+	base     channel.Content
+	trend    *formatter.Trend
+	contents map[subscriber.Frequency]channel.Content
+	messages map[messageKey]channel.Message
+}
+
+// message returns message of the given channel for the frequency in
+// the locale. Message is empty when channel isn't provided.
+func (c *composer) message(ctx context.Context, key messageKey) (channel.Message, error) {
+	if m, ok := c.messages[key]; ok {
+		return m, nil
 	}
 
-	if len(subs) == 0 {
-		return fmt.Errorf("%s: can't send emails when subscribers are empty", operation)
+	ch, ok := c.svc.channels[key.channel]
+	if !ok {
+		return channel.Message{}, nil
 	}
 
-	r, err := w.rateGetter.GetRate(ctx)
+	content, err := c.content(ctx, key.frequency)
 	if err != nil {
-		return fmt.Errorf("%s: failed to get rate: %w", operation, err)
+		return channel.Message{}, err
 	}
+	content.Locale = key.locale
 
-	return w.mailer.Send(ctx, w.formatter.Format(r), subject, mapSubsToMails(subs)...)
+	m, err := ch.Format(content)
+	if err != nil {
+		return channel.Message{}, err
+	}
+
+	c.messages[key] = m
+	return m, nil
+}
+
+// content returns content of the given frequency. All of them carry
+// the spot rate with the daily rates of the last formatter.HistoryDays
+// days, while weekly and monthly ones carry the stats over the digest
+// period as well.
+func (c *composer) content(ctx context.Context, f subscriber.Frequency) (channel.Content, error) {
+	if content, ok := c.contents[f]; ok {
+		return content, nil
+	}
+
+	if c.trend == nil {
+		t, err := c.svc.trend(ctx, c.base.Quote.Rate, c.base.At)
+		if err != nil {
+			return channel.Content{}, err
+		}
+		c.trend = &t
+	}
+
+	content := c.base
+	content.Frequency, content.Trend = f, *c.trend
+	if f != subscriber.FrequencyDaily {
+		stats, err := c.svc.stats(ctx, f, c.base.Quote.Rate, c.base.At)
+		if err != nil {
+			return channel.Content{}, err
+		}
+		content.Stats = stats
+	}
+
+	c.contents[f] = content
+	return content, nil
+}
+
+// Deliver method claims pending notifications from the outbox in batches
+// and sends them. Claimed notifications aren't picked up by other replicas
+// until claimTimeout expires. Each notification is sent individually
+// through its channel, which waits for its turn, if it's rate limited.
+// Failed notifications are rescheduled with exponential backoff and moved
+// to the dead letters after maxAttempts. Notifications to the suppressed
+// emails aren't sent and are moved to the dead letters right away with
+// ErrSuppressed, as well as the ones to the disabled channels with
+// ErrUnsupportedChannel. Every attempt is recorded to the delivery log.
+// When ctx is cancelled, i.e. on shutdown, no more notifications are sent,
+// while outcomes of the sent ones are still saved. Unsent notifications
+// are left claimed and aren't included into the report. Returns report
+// with per-recipient results of all channels. Could return an error if
+// outbox or suppression list couldn't be read, outbox couldn't be
+// updated, or delivery couldn't be recorded.
+func (w *Service) Deliver(ctx context.Context) (report.Report, error) {
+	var rep report.Report
+	for ctx.Err() == nil {
+		now := time.Now().UTC()
+		pending, err := w.outbox.Claim(ctx, now, now.Add(claimTimeout), deliverBatchSize)
+		if err != nil {
+			return rep, fmt.Errorf("%s: failed to get pending notifications: %w", operation, err)
+		}
+
+		suppressed, err := w.suppressed(ctx, pending)
+		if err != nil {
+			return rep, fmt.Errorf("%s: failed to get suppressions: %w", operation, err)
+		}
+
+		results := w.deliver(ctx, pending, suppressed)
+		for i := range results {
+			if !errors.Is(results[i].Err, errInterrupted) {
+				rep.Results = append(rep.Results, results[i])
+			}
+		}
+
+		if err := w.persist(ctx, pending, results); err != nil {
+			return rep, fmt.Errorf("%s: failed to update notifications: %w", operation, err)
+		}
+
+		if len(pending) < deliverBatchSize {
+			break
+		}
+	}
+
+	return rep, nil
 }
 
-// mapSubsToMails takes slice of Subscriber as a argument
-// and then extracts mail from them, returning the slice
-// of subscribers's mails.
-func mapSubsToMails(s []subscriber.Subscriber) []string {
-	mails := make([]string, 0, len(s))
+// persist records outcomes of the sent notifications to the delivery log and
+// the outbox. It isn't cancelled together with ctx, so sent notifications
+// aren't sent again after the shutdown. Interrupted ones are skipped.
+func (w *Service) persist(ctx context.Context, n []outbox.Notification, results []report.Result) error {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), persistTimeout)
+	defer cancel()
+
+	var (
+		errs        []error
+		interrupted int
+	)
+	for i := range n {
+		if errors.Is(results[i].Err, errInterrupted) {
+			interrupted++
+			continue
+		}
+		if err := w.record(ctx, n[i], results[i]); err != nil {
+			errs = append(errs, err)
+		}
+		if err := w.complete(ctx, n[i], results[i].Err); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if interrupted != 0 {
+		w.log.Info("Delivery is interrupted, leaving notifications claimed", "left", interrupted)
+	}
+
+	return errors.Join(errs...)
+}
+
+// stats returns the rate stats over the digest period of the given
+// frequency. Stats consist of the given rate only, when there's no history.
+func (w *Service) stats(ctx context.Context, f subscriber.Frequency, r float32, now time.Time) (rate.Stats, error) {
+	from := now.AddDate(0, 0, -7)
+	if f == subscriber.FrequencyMonthly {
+		from = now.AddDate(0, -1, 0)
+	}
+
+	stats, err := w.rateHistory.GetStats(ctx, from, now)
+	if err != nil {
+		return rate.Stats{}, fmt.Errorf("failed to get rate stats: %w", err)
+	}
+
+	if stats.Count == 0 {
+		stats = rate.Stats{Min: r, Max: r, Avg: r, Count: 1}
+	}
+
+	return stats, nil
+}
+
+// trend returns the spot rate with the daily rates
+// of the last formatter.HistoryDays days.
+func (w *Service) trend(ctx context.Context, r float32, now time.Time) (formatter.Trend, error) {
+	from := runDate(now).AddDate(0, 0, 1-formatter.HistoryDays)
+	history, err := w.rateHistory.GetDaily(ctx, from, now)
+	if err != nil {
+		return formatter.Trend{}, fmt.Errorf("failed to get daily rates: %w", err)
+	}
+	return formatter.Trend{Rate: r, History: history}, nil
+}
+
+// suppressed returns suppressions of the notifications' recipients
+// keyed by the lower-cased email. Only mails could be suppressed.
+func (w *Service) suppressed(
+	ctx context.Context,
+	n []outbox.Notification,
+) (map[string]suppression.Suppression, error) {
+	emails := make([]string, 0, len(n))
+	for i := range n {
+		if isEmail(n[i]) {
+			emails = append(emails, n[i].Email)
+		}
+	}
+
+	if len(emails) == 0 {
+		return nil, nil
+	}
+
+	s, err := w.suppressions.GetSuppressed(ctx, emails)
+	if err != nil {
+		return nil, err
+	}
+
+	suppressed := make(map[string]suppression.Suppression, len(s))
 	for i := range s {
-		mails = append(mails, s[i].Email)
+		suppressed[strings.ToLower(s[i].Email)] = s[i]
+	}
+
+	return suppressed, nil
+}
+
+// deliver sends each of the notifications through its channel, except
+// ones to the suppressed emails. At most sendConcurrency notifications
+// are sent simultaneously. Failure to deliver to one recipient doesn't affect
+// the others.
+func (w *Service) deliver(
+	ctx context.Context,
+	n []outbox.Notification,
+	suppressed map[string]suppression.Suppression,
+) []report.Result {
+	var (
+		wg      sync.WaitGroup
+		sem     = make(chan struct{}, sendConcurrency)
+		results = make([]report.Result, len(n))
+	)
+
+	for i := range n {
+		if s, ok := suppressed[strings.ToLower(strings.TrimSpace(n[i].Email))]; ok && isEmail(n[i]) {
+			results[i] = report.Result{
+				Channel: channelOf(n[i]),
+				Email:   n[i].Email,
+				Err:     fmt.Errorf("%w: %s", ErrSuppressed, s.Reason),
+			}
+			continue
+		}
+
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			results[i] = report.Result{Channel: channelOf(n[i]), Email: n[i].Email, Err: errInterrupted}
+			continue
+		}
+
+		wg.Add(1)
+		go func(i int) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			id, err := w.send(ctx, n[i])
+			if err != nil && ctx.Err() != nil {
+				err = fmt.Errorf("%w: %w", errInterrupted, err)
+			}
+			results[i] = report.Result{
+				Channel:   channelOf(n[i]),
+				Email:     n[i].Email,
+				MessageID: id,
+				Err:       err,
+			}
+		}(i)
+	}
+
+	wg.Wait()
+	return results
+}
+
+// send delivers the notification through its channel
+// and returns ID of the sent message.
+func (w *Service) send(ctx context.Context, n outbox.Notification) (string, error) {
+	ch, ok := w.channels[channelOf(n)]
+	if !ok {
+		return "", fmt.Errorf("%w: %s", ErrUnsupportedChannel, n.Channel)
+	}
+
+	return ch.Send(ctx, n)
+}
+
+// isEmail reports whether notification is a mail.
+func isEmail(n outbox.Notification) bool {
+	return channelOf(n) == subscriber.ChannelEmail
+}
+
+// channelOf returns channel of the notification. Notifications
+// without channel were enqueued before channels were introduced,
+// so they're treated as mails.
+func channelOf(n outbox.Notification) subscriber.Channel {
+	if n.Channel == "" {
+		return subscriber.ChannelEmail
+	}
+	return n.Channel
+}
+
+// record saves the delivery attempt to the delivery log.
+func (w *Service) record(ctx context.Context, n outbox.Notification, r report.Result) error {
+	d := delivery.Delivery{
+		NotificationID: n.ID,
+		SubscriberID:   n.SubscriberID,
+		Email:          n.Email,
+		ScheduledAt:    n.CreatedAt,
+		Rate:           n.Rate,
+		MessageID:      r.MessageID,
+		Status:         delivery.StatusSent,
+	}
+
+	if r.Err != nil {
+		d.Status, d.Error = delivery.StatusFailed, r.Err.Error()
+	}
+
+	if _, err := w.deliveryLog.Save(ctx, d); err != nil {
+		return fmt.Errorf("failed to record delivery: %w", err)
+	}
+
+	return nil
+}
+
+// complete records the outcome of the delivery attempt to the outbox.
+func (w *Service) complete(ctx context.Context, n outbox.Notification, sendErr error) error {
+	if sendErr == nil {
+		return w.outbox.MarkSent(ctx, n.ID)
+	}
+
+	if errors.Is(sendErr, ErrSuppressed) {
+		w.log.Info("Skipping notification to suppressed recipient", "id", n.ID, "recipient", n.Email)
+		return w.outbox.MarkDead(ctx, n.ID, sendErr)
+	}
+
+	if errors.Is(sendErr, ErrUnsupportedChannel) {
+		w.log.Error("Skipping notification to disabled channel", "id", n.ID, "channel", n.Channel)
+		return w.outbox.MarkDead(ctx, n.ID, sendErr)
+	}
+
+	attempts := n.Attempts + 1
+	if attempts >= maxAttempts {
+		w.log.Error(
+			"Moving notification to dead letters",
+			"id", n.ID,
+			"recipient", n.Email,
+			"attempts", attempts,
+			"err", sendErr,
+		)
+		return w.outbox.MarkDead(ctx, n.ID, sendErr)
+	}
+
+	return w.outbox.Reschedule(ctx, n.ID, time.Now().UTC().Add(backoff(attempts)), sendErr)
+}
+
+// backoff returns delay before the next attempt, after
+// given number of failed attempts.
+func backoff(attempts int) time.Duration {
+	d := baseBackoff << (attempts - 1)
+	if d <= 0 || d > maxBackoff {
+		return maxBackoff
+	}
+	return d
+}
+
+// runDate returns the day of the given point of time in its
+// location as a UTC midnight, which notifications are keyed by.
+func runDate(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// frequencyOf returns frequency of the subscriber. Subscribers
+// without frequency are treated as daily ones.
+func frequencyOf(s subscriber.Subscriber) subscriber.Frequency {
+	if s.Frequency == "" {
+		return subscriber.FrequencyDaily
 	}
-	return mails
+	return s.Frequency
 }
@@ -3,24 +3,43 @@ package sender
 import (
 	"context"
 	"errors"
+	"fmt"
 	"log/slog"
 	"reflect"
+	"sync"
 	"testing"
+	"time"
 
 	"go.uber.org/mock/gomock"
 
+	"github.com/hrvadl/converter/sub/internal/service/sender/channel"
+	"github.com/hrvadl/converter/sub/internal/service/sender/formatter"
 	"github.com/hrvadl/converter/sub/internal/service/sender/mocks"
+	"github.com/hrvadl/converter/sub/internal/storage/delivery"
+	"github.com/hrvadl/converter/sub/internal/storage/outbox"
+	"github.com/hrvadl/converter/sub/internal/storage/rate"
 	"github.com/hrvadl/converter/sub/internal/storage/subscriber"
+	"github.com/hrvadl/converter/sub/internal/storage/suppression"
+)
+
+const (
+	subject        = "USD to UAH rate exchange"
+	ukSubject      = "Курс обміну USD до UAH"
+	weeklySubject  = "Weekly USD to UAH rate digest"
+	monthlySubject = "Monthly USD to UAH rate digest"
 )
 
 func TestNew(t *testing.T) {
 	t.Parallel()
 	type args struct {
-		m   Mailer
-		sg  SubscriberGetter
-		mf  RateMessageFormatter
-		rg  RateGetter
-		log *slog.Logger
+		sg       SubscriberGetter
+		rg       RateGetter
+		rh       RateHistory
+		ob       Outbox
+		dl       DeliveryLog
+		sl       SuppressionList
+		channels map[subscriber.Channel]Channel
+		log      *slog.Logger
 	}
 	tests := []struct {
 		name string
@@ -30,60 +49,50 @@ func TestNew(t *testing.T) {
 		{
 			name: "Shoild initialize sender service when correct arguments are provided",
 			args: args{
-				m:   mocks.NewMockMailer(gomock.NewController(t)),
-				sg:  mocks.NewMockSubscriberGetter(gomock.NewController(t)),
-				mf:  mocks.NewMockRateMessageFormatter(gomock.NewController(t)),
-				rg:  mocks.NewMockRateGetter(gomock.NewController(t)),
+				sg: mocks.NewMockSubscriberGetter(gomock.NewController(t)),
+				rg: mocks.NewMockRateGetter(gomock.NewController(t)),
+				rh: mocks.NewMockRateHistory(gomock.NewController(t)),
+				ob: mocks.NewMockOutbox(gomock.NewController(t)),
+				dl: mocks.NewMockDeliveryLog(gomock.NewController(t)),
+				sl: mocks.NewMockSuppressionList(gomock.NewController(t)),
+				channels: map[subscriber.Channel]Channel{
+					subscriber.ChannelEmail: mocks.NewMockChannel(gomock.NewController(t)),
+				},
 				log: slog.Default(),
 			},
 			want: &Service{
-				mailer:     mocks.NewMockMailer(gomock.NewController(t)),
-				subGetter:  mocks.NewMockSubscriberGetter(gomock.NewController(t)),
-				formatter:  mocks.NewMockRateMessageFormatter(gomock.NewController(t)),
-				rateGetter: mocks.NewMockRateGetter(gomock.NewController(t)),
-				log:        slog.Default(),
-			},
-		},
-		{
-			name: "Shoild initialize sender service when allowed arguments are provided",
-			args: args{
-				m:   mocks.NewMockMailer(gomock.NewController(t)),
-				sg:  mocks.NewMockSubscriberGetter(gomock.NewController(t)),
-				mf:  mocks.NewMockRateMessageFormatter(gomock.NewController(t)),
-				rg:  nil,
-				log: nil,
-			},
-			want: &Service{
-				mailer:     mocks.NewMockMailer(gomock.NewController(t)),
-				subGetter:  mocks.NewMockSubscriberGetter(gomock.NewController(t)),
-				formatter:  mocks.NewMockRateMessageFormatter(gomock.NewController(t)),
-				rateGetter: nil,
-				log:        nil,
+				subGetter:    mocks.NewMockSubscriberGetter(gomock.NewController(t)),
+				rateGetter:   mocks.NewMockRateGetter(gomock.NewController(t)),
+				rateHistory:  mocks.NewMockRateHistory(gomock.NewController(t)),
+				outbox:       mocks.NewMockOutbox(gomock.NewController(t)),
+				deliveryLog:  mocks.NewMockDeliveryLog(gomock.NewController(t)),
+				suppressions: mocks.NewMockSuppressionList(gomock.NewController(t)),
+				channels: map[subscriber.Channel]Channel{
+					subscriber.ChannelEmail: mocks.NewMockChannel(gomock.NewController(t)),
+				},
+				log: slog.Default(),
 			},
 		},
 		{
 			name: "Shoild initialize sender service when nil arguments are provided",
-			args: args{
-				m:   nil,
-				sg:  nil,
-				mf:  nil,
-				rg:  nil,
-				log: nil,
-			},
-			want: &Service{
-				mailer:     nil,
-				subGetter:  nil,
-				formatter:  nil,
-				rateGetter: nil,
-				log:        nil,
-			},
+			args: args{},
+			want: &Service{},
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
-			if got := New(tt.args.m, tt.args.sg, tt.args.mf, tt.args.rg, tt.args.log); !reflect.DeepEqual(
+			if got := New(
+				tt.args.sg,
+				tt.args.rg,
+				tt.args.rh,
+				tt.args.ob,
+				tt.args.dl,
+				tt.args.sl,
+				tt.args.channels,
+				tt.args.log,
+			); !reflect.DeepEqual(
 				got,
 				tt.want,
 			) {
@@ -93,298 +102,694 @@ func TestNew(t *testing.T) {
 	}
 }
 
-func TestServiceSend(t *testing.T) {
+func TestServiceEnqueue(t *testing.T) {
 	t.Parallel()
 	type fields struct {
-		mailer     Mailer
-		formatter  RateMessageFormatter
-		subGetter  SubscriberGetter
-		rateGetter RateGetter
-		log        *slog.Logger
+		subGetter   SubscriberGetter
+		rateGetter  RateGetter
+		rateHistory RateHistory
+		outbox      Outbox
+		email       Channel
+		telegram    Channel
+		webhook     Channel
+		slack       Channel
+		discord     Channel
+		log         *slog.Logger
 	}
 	type args struct {
 		ctx context.Context
+		at  time.Time
+	}
+	type mocked struct {
+		subGetter   *mocks.MockSubscriberGetter
+		rateGetter  *mocks.MockRateGetter
+		rateHistory *mocks.MockRateHistory
+		outbox      *mocks.MockOutbox
+		email       *mocks.MockChannel
+		telegram    *mocks.MockChannel
+		webhook     *mocks.MockChannel
+		slack       *mocks.MockChannel
+		discord     *mocks.MockChannel
 	}
+	newFields := func(t *testing.T) fields {
+		t.Helper()
+		return fields{
+			subGetter:   mocks.NewMockSubscriberGetter(gomock.NewController(t)),
+			rateGetter:  mocks.NewMockRateGetter(gomock.NewController(t)),
+			rateHistory: mocks.NewMockRateHistory(gomock.NewController(t)),
+			outbox:      mocks.NewMockOutbox(gomock.NewController(t)),
+			email:       mocks.NewMockChannel(gomock.NewController(t)),
+			telegram:    mocks.NewMockChannel(gomock.NewController(t)),
+			webhook:     mocks.NewMockChannel(gomock.NewController(t)),
+			slack:       mocks.NewMockChannel(gomock.NewController(t)),
+			discord:     mocks.NewMockChannel(gomock.NewController(t)),
+			log:         slog.Default(),
+		}
+	}
+	cast := func(t *testing.T, f *fields) mocked {
+		t.Helper()
+		var (
+			m  mocked
+			ok = make([]bool, 9)
+		)
+		m.subGetter, ok[0] = f.subGetter.(*mocks.MockSubscriberGetter)
+		m.rateGetter, ok[1] = f.rateGetter.(*mocks.MockRateGetter)
+		m.rateHistory, ok[2] = f.rateHistory.(*mocks.MockRateHistory)
+		m.outbox, ok[3] = f.outbox.(*mocks.MockOutbox)
+		m.email, ok[4] = f.email.(*mocks.MockChannel)
+		m.telegram, ok[5] = f.telegram.(*mocks.MockChannel)
+		m.webhook, ok[6] = f.webhook.(*mocks.MockChannel)
+		m.slack, ok[7] = f.slack.(*mocks.MockChannel)
+		m.discord, ok[8] = f.discord.(*mocks.MockChannel)
+		for i := range ok {
+			if !ok[i] {
+				t.Fatal("failed to cast dependencies to mocks")
+			}
+		}
+		return m
+	}
+	// recipients matches saved notifications by their recipients and
+	// subjects, since the rest of the fields depend on the current time.
+	recipients := func(want map[string]string) gomock.Matcher {
+		return gomock.Cond(func(x any) bool {
+			n, ok := x.([]outbox.Notification)
+			if !ok || len(n) != len(want) {
+				return false
+			}
+			for i := range n {
+				if want[n[i].Email] != n[i].Subject || n[i].RunDate.IsZero() {
+					return false
+				}
+			}
+			return true
+		})
+	}
+
+	// notifications matches saved notifications exactly,
+	// except time of the next attempt, which is the current time.
+	notifications := func(want []outbox.Notification) gomock.Matcher {
+		return gomock.Cond(func(x any) bool {
+			n, ok := x.([]outbox.Notification)
+			if !ok {
+				return false
+			}
+			got := make([]outbox.Notification, 0, len(n))
+			for _, c := range n {
+				if c.NextAttemptAt.IsZero() {
+					return false
+				}
+				c.NextAttemptAt = time.Time{}
+				got = append(got, c)
+			}
+			return reflect.DeepEqual(got, want)
+		})
+	}
+
+	at := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	var (
+		rateValue float32 = 10.
+		quote             = rate.Rate{Rate: rateValue, Provider: "exchangerate-api"}
+		fmtMsg            = "fmtTestMsg"
+		fmtText           = "fmtTestText"
+		digestMsg         = "fmtDigestMsg"
+		daily             = []rate.Daily{{Day: at.AddDate(0, 0, -1), Rate: 9.5}}
+		trend             = formatter.Trend{Rate: rateValue, History: daily}
+		dailyMsg          = channel.Message{Subject: subject, Body: fmtMsg, Text: fmtText}
+		dailySubs         = []subscriber.Subscriber{
+			{ID: 1, Email: "test@test.com", Frequency: subscriber.FrequencyDaily},
+			{ID: 2, Email: "test2@test.com", Frequency: subscriber.FrequencyDaily},
+		}
+	)
+	// saved returns quote, which is saved to the history on the day.
+	saved := func(day time.Time) rate.Rate {
+		r := quote
+		r.RunDate = day
+		return r
+	}
+	// content matches content passed to the channel. Fetch time
+	// depends on the current time, so it's only checked to be set.
+	content := func(want channel.Content) gomock.Matcher {
+		return gomock.Cond(func(x any) bool {
+			c, ok := x.(channel.Content)
+			if !ok || c.FetchedAt.IsZero() {
+				return false
+			}
+			c.FetchedAt = time.Time{}
+			return reflect.DeepEqual(c, want)
+		})
+	}
+	dailyContent := func(l subscriber.Locale) gomock.Matcher {
+		return content(channel.Content{
+			Frequency: subscriber.FrequencyDaily,
+			Locale:    l,
+			Quote:     quote,
+			Trend:     trend,
+			At:        at,
+		})
+	}
+
 	tests := []struct {
 		name    string
 		fields  fields
 		args    args
+		want    int
 		wantErr bool
 		setup   func(t *testing.T, f *fields)
 	}{
 		{
-			name: "Should not return error when everything is correct",
+			name: "Should enqueue notifications for the local day of the scheduled run when it's caught up",
 			args: args{
 				ctx: context.Background(),
+				at:  time.Date(2024, 5, 1, 1, 0, 0, 0, time.FixedZone("UTC+3", 3*60*60)),
 			},
-			fields: fields{
-				mailer:     mocks.NewMockMailer(gomock.NewController(t)),
-				subGetter:  mocks.NewMockSubscriberGetter(gomock.NewController(t)),
-				formatter:  mocks.NewMockRateMessageFormatter(gomock.NewController(t)),
-				rateGetter: mocks.NewMockRateGetter(gomock.NewController(t)),
-				log:        slog.Default(),
+			fields: newFields(t),
+			setup: func(t *testing.T, f *fields) {
+				t.Helper()
+				m := cast(t, f)
+				scheduled := time.Date(2024, 4, 30, 22, 0, 0, 0, time.UTC)
+				m.rateGetter.EXPECT().GetQuote(gomock.Any()).Times(1).Return(quote, nil)
+				m.rateHistory.EXPECT().
+					Save(gomock.Any(), saved(time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC))).
+					Times(1).
+					Return(int64(1), nil)
+				m.subGetter.EXPECT().
+					GetDue(gomock.Any(), time.Date(2024, 5, 1, 1, 0, 0, 0, time.FixedZone("UTC+3", 3*60*60)), int64(0), enqueueBatchSize).
+					Times(1).
+					Return(dailySubs[:1], nil)
+				m.rateHistory.EXPECT().
+					GetDaily(gomock.Any(), time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC), scheduled).
+					Times(1).
+					Return(daily, nil)
+				m.email.EXPECT().Format(content(channel.Content{
+					Frequency: subscriber.FrequencyDaily,
+					Quote:     quote,
+					Trend:     trend,
+					At:        scheduled,
+				})).Times(1).Return(dailyMsg, nil)
+				m.outbox.EXPECT().
+					Save(gomock.Any(), gomock.Cond(func(x any) bool {
+						n, ok := x.([]outbox.Notification)
+						return ok && len(n) == 1 &&
+							n[0].Email == dailySubs[0].Email &&
+							n[0].Channel == subscriber.ChannelEmail &&
+							n[0].Body == fmtMsg &&
+							n[0].Text == fmtText &&
+							n[0].RunDate.Equal(time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC))
+					})).
+					Times(1).
+					Return(1, nil)
+			},
+			want: 1,
+		},
+		{
+			name: "Should enqueue notification per subscriber when everything is correct",
+			args: args{
+				ctx: context.Background(),
+				at:  at,
 			},
+			fields: newFields(t),
 			setup: func(t *testing.T, f *fields) {
 				t.Helper()
-				var (
-					rate   float32 = 10.
-					fmtMsg         = "fmtTestMsg"
-					subs           = []subscriber.Subscriber{
-						{ID: 1, Email: "test@test.com"},
-						{ID: 2, Email: "test2@test.com"},
-					}
-				)
-
-				m, ok := f.mailer.(*mocks.MockMailer)
-				if !ok {
-					t.Fatal("failed to cast mailer to mock mailer")
-				}
-
-				m.EXPECT().
-					Send(gomock.Any(), fmtMsg, subject, "test@test.com", "test2@test.com").
+				m := cast(t, f)
+				m.rateGetter.EXPECT().GetQuote(gomock.Any()).Times(1).Return(quote, nil)
+				m.rateHistory.EXPECT().
+					Save(gomock.Any(), saved(runDate(at))).
 					Times(1).
-					Return(nil)
-
-				rg, ok := f.rateGetter.(*mocks.MockRateGetter)
-				if !ok {
-					t.Fatal("failed to cast rate getter to mock rate getter")
-				}
-				rg.EXPECT().GetRate(gomock.Any()).Times(1).Return(rate, nil)
-
-				fmter, ok := f.formatter.(*mocks.MockRateMessageFormatter)
-				if !ok {
-					t.Fatal("failed to cast formatter to mock formatter")
-				}
-				fmter.EXPECT().Format(rate).Times(1).Return(fmtMsg)
-
-				sg, ok := f.subGetter.(*mocks.MockSubscriberGetter)
-				if !ok {
-					t.Fatal("failed to cast subscribe getter to mock subscribe getter")
-				}
-				sg.EXPECT().Get(gomock.Any()).Times(1).Return(subs, nil)
+					Return(int64(1), nil)
+				m.subGetter.EXPECT().GetDue(gomock.Any(), gomock.Any(), int64(0), enqueueBatchSize).Times(1).Return(dailySubs, nil)
+				m.rateHistory.EXPECT().GetDaily(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(daily, nil)
+				m.email.EXPECT().Format(dailyContent("")).Times(1).Return(dailyMsg, nil)
+				m.outbox.EXPECT().
+					Save(gomock.Any(), recipients(map[string]string{
+						"test@test.com":  subject,
+						"test2@test.com": subject,
+					})).
+					Times(1).
+					Return(2, nil)
 			},
+			want:    2,
 			wantErr: false,
 		},
 		{
-			name: "Should return error when subs getter returned err",
+			name: "Should enqueue notifications when rate of the run date is already saved",
 			args: args{
 				ctx: context.Background(),
+				at:  at,
+			},
+			fields: newFields(t),
+			setup: func(t *testing.T, f *fields) {
+				t.Helper()
+				m := cast(t, f)
+				m.rateGetter.EXPECT().GetQuote(gomock.Any()).Times(1).Return(quote, nil)
+				m.rateHistory.EXPECT().
+					Save(gomock.Any(), saved(runDate(at))).
+					Times(1).
+					Return(int64(0), rate.ErrAlreadyExists)
+				m.subGetter.EXPECT().GetDue(gomock.Any(), gomock.Any(), int64(0), enqueueBatchSize).Times(1).Return(dailySubs, nil)
+				m.rateHistory.EXPECT().GetDaily(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(daily, nil)
+				m.email.EXPECT().Format(dailyContent("")).Times(1).Return(dailyMsg, nil)
+				m.outbox.EXPECT().Save(gomock.Any(), gomock.Any()).Times(1).Return(2, nil)
 			},
-			fields: fields{
-				mailer:     mocks.NewMockMailer(gomock.NewController(t)),
-				subGetter:  mocks.NewMockSubscriberGetter(gomock.NewController(t)),
-				formatter:  mocks.NewMockRateMessageFormatter(gomock.NewController(t)),
-				rateGetter: mocks.NewMockRateGetter(gomock.NewController(t)),
-				log:        slog.Default(),
+			want:    2,
+			wantErr: false,
+		},
+		{
+			name: "Should fan out notification to each channel address of the subscriber",
+			args: args{
+				ctx: context.Background(),
+				at:  at,
 			},
+			fields: newFields(t),
 			setup: func(t *testing.T, f *fields) {
 				t.Helper()
-				var (
-					rate   float32 = 10.
-					fmtMsg         = "fmtTestMsg"
-					subs           = []subscriber.Subscriber{
-						{ID: 1, Email: "test@test.com"},
-						{ID: 2, Email: "test2@test.com"},
-					}
-				)
-
-				m, ok := f.mailer.(*mocks.MockMailer)
-				if !ok {
-					t.Fatal("failed to cast mailer to mock mailer")
-				}
-
-				m.EXPECT().
-					Send(gomock.Any(), fmtMsg, subject, "test@test.com", "test2@test.com").
-					Times(0).
-					Return(nil)
-
-				rg, ok := f.rateGetter.(*mocks.MockRateGetter)
-				if !ok {
-					t.Fatal("failed to cast rate getter to mock rate getter")
-				}
-				rg.EXPECT().GetRate(gomock.Any()).Times(0).Return(rate, nil)
-
-				fmter, ok := f.formatter.(*mocks.MockRateMessageFormatter)
-				if !ok {
-					t.Fatal("failed to cast formatter to mock formatter")
-				}
-				fmter.EXPECT().Format(rate).Times(0).Return(fmtMsg)
-
-				sg, ok := f.subGetter.(*mocks.MockSubscriberGetter)
-				if !ok {
-					t.Fatal("failed to cast subscribe getter to mock subscribe getter")
-				}
-				sg.EXPECT().
-					Get(gomock.Any()).
+				m := cast(t, f)
+				subs := []subscriber.Subscriber{{
+					ID:        1,
+					Channel:   subscriber.ChannelEmail,
+					Email:     "test@test.com",
+					Frequency: subscriber.FrequencyDaily,
+					Addresses: []subscriber.Address{
+						{SubscriberID: 1, Channel: subscriber.ChannelSlack, Address: "https://slack.test/1"},
+						{SubscriberID: 1, Channel: subscriber.ChannelTelegram, Address: "42"},
+					},
+				}}
+				m.rateGetter.EXPECT().GetQuote(gomock.Any()).Times(1).Return(quote, nil)
+				m.rateHistory.EXPECT().Save(gomock.Any(), saved(runDate(at))).Times(1).Return(int64(1), nil)
+				m.subGetter.EXPECT().GetDue(gomock.Any(), gomock.Any(), int64(0), enqueueBatchSize).Times(1).Return(subs, nil)
+				m.rateHistory.EXPECT().GetDaily(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(daily, nil)
+				m.email.EXPECT().Format(dailyContent("")).Times(1).Return(dailyMsg, nil)
+				m.slack.EXPECT().Format(dailyContent("")).Times(1).Return(channel.Message{Body: `{"blocks":[]}`}, nil)
+				m.telegram.EXPECT().Format(dailyContent("")).Times(1).Return(channel.Message{Text: fmtText}, nil)
+				m.outbox.EXPECT().
+					Save(gomock.Any(), notifications([]outbox.Notification{
+						{
+							SubscriberID: 1,
+							Channel:      subscriber.ChannelEmail,
+							Email:        "test@test.com",
+							Subject:      subject,
+							Body:         fmtMsg,
+							Text:         fmtText,
+							Rate:         rateValue,
+							RunDate:      runDate(at),
+						},
+						{
+							SubscriberID: 1,
+							Channel:      subscriber.ChannelSlack,
+							Email:        "https://slack.test/1",
+							Body:         `{"blocks":[]}`,
+							Rate:         rateValue,
+							RunDate:      runDate(at),
+						},
+						{
+							SubscriberID: 1,
+							Channel:      subscriber.ChannelTelegram,
+							Email:        "42",
+							Text:         fmtText,
+							Rate:         rateValue,
+							RunDate:      runDate(at),
+						},
+					})).
 					Times(1).
-					Return(subs, errors.New("failed to get subs"))
+					Return(3, nil)
 			},
-			wantErr: true,
+			want:    3,
+			wantErr: false,
 		},
 		{
-			name: "Should return error when subs are empty",
+			name: "Should enqueue notifications without message when channel isn't supported",
 			args: args{
 				ctx: context.Background(),
+				at:  at,
 			},
-			fields: fields{
-				mailer:     mocks.NewMockMailer(gomock.NewController(t)),
-				subGetter:  mocks.NewMockSubscriberGetter(gomock.NewController(t)),
-				formatter:  mocks.NewMockRateMessageFormatter(gomock.NewController(t)),
-				rateGetter: mocks.NewMockRateGetter(gomock.NewController(t)),
-				log:        slog.Default(),
+			fields: newFields(t),
+			setup: func(t *testing.T, f *fields) {
+				t.Helper()
+				m := cast(t, f)
+				subs := []subscriber.Subscriber{{ID: 5, Channel: "pigeon", Email: "roof", Frequency: subscriber.FrequencyDaily}}
+				m.rateGetter.EXPECT().GetQuote(gomock.Any()).Times(1).Return(quote, nil)
+				m.rateHistory.EXPECT().Save(gomock.Any(), saved(runDate(at))).Times(1).Return(int64(1), nil)
+				m.subGetter.EXPECT().GetDue(gomock.Any(), gomock.Any(), int64(0), enqueueBatchSize).Times(1).Return(subs, nil)
+				m.rateHistory.EXPECT().GetDaily(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+				m.outbox.EXPECT().
+					Save(gomock.Any(), notifications([]outbox.Notification{{
+						SubscriberID: 5,
+						Channel:      "pigeon",
+						Email:        "roof",
+						Rate:         rateValue,
+						RunDate:      runDate(at),
+					}})).
+					Times(1).
+					Return(1, nil)
 			},
+			want:    1,
+			wantErr: false,
+		},
+		{
+			name: "Should format message once per channel and locale",
+			args: args{
+				ctx: context.Background(),
+				at:  at,
+			},
+			fields: newFields(t),
 			setup: func(t *testing.T, f *fields) {
 				t.Helper()
-				var (
-					rate   float32 = 10.
-					fmtMsg         = "fmtTestMsg"
-				)
-
-				m, ok := f.mailer.(*mocks.MockMailer)
-				if !ok {
-					t.Fatal("failed to cast mailer to mock mailer")
+				m := cast(t, f)
+				subs := []subscriber.Subscriber{
+					{ID: 1, Email: "en@test.com", Frequency: subscriber.FrequencyDaily, Locale: subscriber.LocaleEnglish},
+					{ID: 2, Email: "uk@test.com", Frequency: subscriber.FrequencyDaily, Locale: subscriber.LocaleUkrainian},
+					{ID: 3, Email: "uk2@test.com", Frequency: subscriber.FrequencyDaily, Locale: subscriber.LocaleUkrainian},
+					{ID: 4, Channel: subscriber.ChannelSlack, Email: "https://slack.test/4", Locale: subscriber.LocaleUkrainian},
+					{ID: 5, Channel: subscriber.ChannelSlack, Email: "https://slack.test/5", Locale: subscriber.LocaleUkrainian},
+					{ID: 6, Channel: subscriber.ChannelDiscord, Email: "https://discord.test/6", Locale: subscriber.LocaleUkrainian},
+					{ID: 7, Channel: subscriber.ChannelWebhook, Email: "https://test.com/hook"},
 				}
-
-				m.EXPECT().
-					Send(gomock.Any(), fmtMsg, subject, "test@test.com", "test2@test.com").
-					Times(0).
-					Return(nil)
-
-				rg, ok := f.rateGetter.(*mocks.MockRateGetter)
-				if !ok {
-					t.Fatal("failed to cast rate getter to mock rate getter")
-				}
-				rg.EXPECT().GetRate(gomock.Any()).Times(0).Return(rate, nil)
-
-				fmter, ok := f.formatter.(*mocks.MockRateMessageFormatter)
-				if !ok {
-					t.Fatal("failed to cast formatter to mock formatter")
-				}
-				fmter.EXPECT().Format(rate).Times(0).Return(fmtMsg)
-
-				sg, ok := f.subGetter.(*mocks.MockSubscriberGetter)
-				if !ok {
-					t.Fatal("failed to cast subscribe getter to mock subscribe getter")
-				}
-				sg.EXPECT().Get(gomock.Any()).Times(1).Return(nil, nil)
+				m.rateGetter.EXPECT().GetQuote(gomock.Any()).Times(1).Return(quote, nil)
+				m.rateHistory.EXPECT().Save(gomock.Any(), gomock.Any()).Times(1).Return(int64(1), nil)
+				m.subGetter.EXPECT().GetDue(gomock.Any(), gomock.Any(), int64(0), enqueueBatchSize).Times(1).Return(subs, nil)
+				m.rateHistory.EXPECT().GetDaily(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(daily, nil)
+				m.email.EXPECT().
+					Format(dailyContent(subscriber.LocaleEnglish)).
+					Times(1).
+					Return(dailyMsg, nil)
+				m.email.EXPECT().
+					Format(dailyContent(subscriber.LocaleUkrainian)).
+					Times(1).
+					Return(channel.Message{Subject: ukSubject, Body: fmtMsg, Text: fmtText}, nil)
+				m.slack.EXPECT().Format(dailyContent(subscriber.LocaleUkrainian)).Times(1).Return(channel.Message{}, nil)
+				m.discord.EXPECT().Format(dailyContent(subscriber.LocaleUkrainian)).Times(1).Return(channel.Message{}, nil)
+				m.webhook.EXPECT().Format(dailyContent("")).Times(1).Return(channel.Message{Body: "{}"}, nil)
+				m.outbox.EXPECT().
+					Save(gomock.Any(), recipients(map[string]string{
+						"en@test.com":            subject,
+						"uk@test.com":            ukSubject,
+						"uk2@test.com":           ukSubject,
+						"https://slack.test/4":   "",
+						"https://slack.test/5":   "",
+						"https://discord.test/6": "",
+						"https://test.com/hook":  "",
+					})).
+					Times(1).
+					Return(7, nil)
+			},
+			want:    7,
+			wantErr: false,
+		},
+		{
+			name: "Should return error when daily rates couldn't be read",
+			args: args{
+				ctx: context.Background(),
+				at:  at,
 			},
+			fields: newFields(t),
+			setup: func(t *testing.T, f *fields) {
+				t.Helper()
+				m := cast(t, f)
+				m.rateGetter.EXPECT().GetQuote(gomock.Any()).Times(1).Return(quote, nil)
+				m.rateHistory.EXPECT().Save(gomock.Any(), gomock.Any()).Times(1).Return(int64(1), nil)
+				m.subGetter.EXPECT().GetDue(gomock.Any(), gomock.Any(), int64(0), enqueueBatchSize).Times(1).Return(dailySubs, nil)
+				m.rateHistory.EXPECT().
+					GetDaily(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil, errors.New("failed to read rates"))
+				m.email.EXPECT().Format(gomock.Any()).Times(0)
+				m.outbox.EXPECT().Save(gomock.Any(), gomock.Any()).Times(0)
+			},
+			want:    0,
 			wantErr: true,
 		},
 		{
-			name: "Should return error when rate getter returned err",
+			name: "Should return error when message couldn't be formatted",
 			args: args{
 				ctx: context.Background(),
+				at:  at,
+			},
+			fields: newFields(t),
+			setup: func(t *testing.T, f *fields) {
+				t.Helper()
+				m := cast(t, f)
+				m.rateGetter.EXPECT().GetQuote(gomock.Any()).Times(1).Return(quote, nil)
+				m.rateHistory.EXPECT().Save(gomock.Any(), gomock.Any()).Times(1).Return(int64(1), nil)
+				m.subGetter.EXPECT().GetDue(gomock.Any(), gomock.Any(), int64(0), enqueueBatchSize).Times(1).Return(dailySubs, nil)
+				m.rateHistory.EXPECT().GetDaily(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(daily, nil)
+				m.email.EXPECT().
+					Format(gomock.Any()).
+					Times(1).
+					Return(channel.Message{}, errors.New("failed to execute template"))
+				m.outbox.EXPECT().Save(gomock.Any(), gomock.Any()).Times(0)
 			},
-			fields: fields{
-				mailer:     mocks.NewMockMailer(gomock.NewController(t)),
-				subGetter:  mocks.NewMockSubscriberGetter(gomock.NewController(t)),
-				formatter:  mocks.NewMockRateMessageFormatter(gomock.NewController(t)),
-				rateGetter: mocks.NewMockRateGetter(gomock.NewController(t)),
-				log:        slog.Default(),
+			want:    0,
+			wantErr: true,
+		},
+		{
+			name: "Should enqueue digests with rate stats for weekly and monthly subscribers",
+			args: args{
+				ctx: context.Background(),
+				at:  at,
 			},
+			fields: newFields(t),
 			setup: func(t *testing.T, f *fields) {
 				t.Helper()
-				var (
-					rate   float32 = 10.
-					fmtMsg         = "fmtTestMsg"
-					subs           = []subscriber.Subscriber{
-						{ID: 1, Email: "test@test.com"},
-						{ID: 2, Email: "test2@test.com"},
-					}
-				)
-
-				m, ok := f.mailer.(*mocks.MockMailer)
-				if !ok {
-					t.Fatal("failed to cast mailer to mock mailer")
-				}
-
-				m.EXPECT().
-					Send(gomock.Any(), fmtMsg, subject, "test@test.com", "test2@test.com").
-					Times(0).
-					Return(nil)
-
-				rg, ok := f.rateGetter.(*mocks.MockRateGetter)
-				if !ok {
-					t.Fatal("failed to cast rate getter to mock rate getter")
+				m := cast(t, f)
+				stats := rate.Stats{Min: 9, Max: 11, Avg: 10, Count: 7}
+				subs := []subscriber.Subscriber{
+					{ID: 1, Email: "weekly@test.com", Frequency: subscriber.FrequencyWeekly},
+					{ID: 2, Email: "monthly@test.com", Frequency: subscriber.FrequencyMonthly},
 				}
-				rg.EXPECT().
-					GetRate(gomock.Any()).
+				m.rateGetter.EXPECT().GetQuote(gomock.Any()).Times(1).Return(quote, nil)
+				m.rateHistory.EXPECT().Save(gomock.Any(), gomock.Any()).Times(1).Return(int64(1), nil)
+				m.rateHistory.EXPECT().GetDaily(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(daily, nil)
+				m.rateHistory.EXPECT().
+					GetStats(gomock.Any(), at.AddDate(0, 0, -7), at).
 					Times(1).
-					Return(rate, errors.New("failed to get rate"))
-
-				fmter, ok := f.formatter.(*mocks.MockRateMessageFormatter)
-				if !ok {
-					t.Fatal("failed to cast formatter to mock formatter")
-				}
-				fmter.EXPECT().Format(rate).Times(0).Return(fmtMsg)
-
-				sg, ok := f.subGetter.(*mocks.MockSubscriberGetter)
-				if !ok {
-					t.Fatal("failed to cast subscribe getter to mock subscribe getter")
+					Return(stats, nil)
+				m.rateHistory.EXPECT().
+					GetStats(gomock.Any(), at.AddDate(0, -1, 0), at).
+					Times(1).
+					Return(stats, nil)
+				m.subGetter.EXPECT().GetDue(gomock.Any(), gomock.Any(), int64(0), enqueueBatchSize).Times(1).Return(subs, nil)
+				m.email.EXPECT().
+					Format(content(channel.Content{
+						Frequency: subscriber.FrequencyWeekly,
+						Quote:     quote,
+						Trend:     trend,
+						Stats:     stats,
+						At:        at,
+					})).
+					Times(1).
+					Return(channel.Message{Subject: weeklySubject, Body: digestMsg}, nil)
+				m.email.EXPECT().
+					Format(content(channel.Content{
+						Frequency: subscriber.FrequencyMonthly,
+						Quote:     quote,
+						Trend:     trend,
+						Stats:     stats,
+						At:        at,
+					})).
+					Times(1).
+					Return(channel.Message{Subject: monthlySubject, Body: digestMsg}, nil)
+				m.outbox.EXPECT().
+					Save(gomock.Any(), recipients(map[string]string{
+						"weekly@test.com":  weeklySubject,
+						"monthly@test.com": monthlySubject,
+					})).
+					Times(1).
+					Return(2, nil)
+			},
+			want:    2,
+			wantErr: false,
+		},
+		{
+			name: "Should fallback to the latest rate when history is empty",
+			args: args{
+				ctx: context.Background(),
+				at:  at,
+			},
+			fields: newFields(t),
+			setup: func(t *testing.T, f *fields) {
+				t.Helper()
+				m := cast(t, f)
+				subs := []subscriber.Subscriber{
+					{ID: 1, Email: "weekly@test.com", Frequency: subscriber.FrequencyWeekly},
 				}
-				sg.EXPECT().
-					Get(gomock.Any()).
+				m.rateGetter.EXPECT().GetQuote(gomock.Any()).Times(1).Return(quote, nil)
+				m.rateHistory.EXPECT().Save(gomock.Any(), gomock.Any()).Times(1).Return(int64(1), nil)
+				m.rateHistory.EXPECT().GetDaily(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(nil, nil)
+				m.rateHistory.EXPECT().
+					GetStats(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(rate.Stats{}, nil)
+				m.subGetter.EXPECT().GetDue(gomock.Any(), gomock.Any(), int64(0), enqueueBatchSize).Times(1).Return(subs, nil)
+				m.email.EXPECT().
+					Format(content(channel.Content{
+						Frequency: subscriber.FrequencyWeekly,
+						Quote:     quote,
+						Trend:     formatter.Trend{Rate: rateValue},
+						Stats:     rate.Stats{Min: rateValue, Max: rateValue, Avg: rateValue, Count: 1},
+						At:        at,
+					})).
 					Times(1).
-					Return(subs, nil)
+					Return(channel.Message{Subject: weeklySubject, Body: digestMsg}, nil)
+				m.outbox.EXPECT().Save(gomock.Any(), gomock.Any()).Times(1).Return(1, nil)
+			},
+			want:    1,
+			wantErr: false,
+		},
+		{
+			name: "Should return error when subs getter returned err",
+			args: args{
+				ctx: context.Background(),
+				at:  at,
+			},
+			fields: newFields(t),
+			setup: func(t *testing.T, f *fields) {
+				t.Helper()
+				m := cast(t, f)
+				m.rateGetter.EXPECT().GetQuote(gomock.Any()).Times(1).Return(quote, nil)
+				m.rateHistory.EXPECT().Save(gomock.Any(), gomock.Any()).Times(1).Return(int64(1), nil)
+				m.subGetter.EXPECT().
+					GetDue(gomock.Any(), gomock.Any(), int64(0), enqueueBatchSize).
+					Times(1).
+					Return(dailySubs, errors.New("failed to get subs"))
+				m.email.EXPECT().Format(gomock.Any()).Times(0)
+				m.outbox.EXPECT().Save(gomock.Any(), gomock.Any()).Times(0)
 			},
 			wantErr: true,
 		},
 		{
-			name: "Should return error when mailer returned err",
+			name: "Should not return error when there're no subs due",
 			args: args{
 				ctx: context.Background(),
+				at:  at,
 			},
-			fields: fields{
-				mailer:     mocks.NewMockMailer(gomock.NewController(t)),
-				subGetter:  mocks.NewMockSubscriberGetter(gomock.NewController(t)),
-				formatter:  mocks.NewMockRateMessageFormatter(gomock.NewController(t)),
-				rateGetter: mocks.NewMockRateGetter(gomock.NewController(t)),
-				log:        slog.Default(),
+			fields: newFields(t),
+			setup: func(t *testing.T, f *fields) {
+				t.Helper()
+				m := cast(t, f)
+				m.rateGetter.EXPECT().GetQuote(gomock.Any()).Times(1).Return(quote, nil)
+				m.rateHistory.EXPECT().Save(gomock.Any(), gomock.Any()).Times(1).Return(int64(1), nil)
+				m.subGetter.EXPECT().GetDue(gomock.Any(), gomock.Any(), int64(0), enqueueBatchSize).Times(1).Return(nil, nil)
+				m.rateHistory.EXPECT().GetDaily(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+				m.email.EXPECT().Format(gomock.Any()).Times(0)
+				m.outbox.EXPECT().Save(gomock.Any(), gomock.Any()).Times(0)
+			},
+			want:    0,
+			wantErr: false,
+		},
+		{
+			name: "Should return error when rate getter returned err",
+			args: args{
+				ctx: context.Background(),
+				at:  at,
 			},
+			fields: newFields(t),
 			setup: func(t *testing.T, f *fields) {
 				t.Helper()
-				var (
-					rate   float32 = 10.
-					fmtMsg         = "fmtTestMsg"
-					subs           = []subscriber.Subscriber{
-						{ID: 1, Email: "test@test.com"},
-						{ID: 2, Email: "test2@test.com"},
-					}
-				)
-
-				m, ok := f.mailer.(*mocks.MockMailer)
-				if !ok {
-					t.Fatal("failed to cast mailer to mock mailer")
-				}
-
-				m.EXPECT().
-					Send(gomock.Any(), fmtMsg, subject, "test@test.com", "test2@test.com").
+				m := cast(t, f)
+				m.rateGetter.EXPECT().
+					GetQuote(gomock.Any()).
 					Times(1).
-					Return(errors.New("failed to send msg"))
-
-				rg, ok := f.rateGetter.(*mocks.MockRateGetter)
-				if !ok {
-					t.Fatal("failed to cast rate getter to mock rate getter")
-				}
-				rg.EXPECT().
-					GetRate(gomock.Any()).
+					Return(rate.Rate{}, errors.New("failed to get rate"))
+				m.rateHistory.EXPECT().Save(gomock.Any(), gomock.Any()).Times(0)
+				m.subGetter.EXPECT().GetDue(gomock.Any(), gomock.Any(), int64(0), enqueueBatchSize).Times(0)
+				m.outbox.EXPECT().Save(gomock.Any(), gomock.Any()).Times(0)
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should return error when rate history returned err",
+			args: args{
+				ctx: context.Background(),
+				at:  at,
+			},
+			fields: newFields(t),
+			setup: func(t *testing.T, f *fields) {
+				t.Helper()
+				m := cast(t, f)
+				m.rateGetter.EXPECT().GetQuote(gomock.Any()).Times(1).Return(quote, nil)
+				m.rateHistory.EXPECT().
+					Save(gomock.Any(), gomock.Any()).
 					Times(1).
-					Return(rate, nil)
-
-				fmter, ok := f.formatter.(*mocks.MockRateMessageFormatter)
-				if !ok {
-					t.Fatal("failed to cast formatter to mock formatter")
+					Return(int64(0), errors.New("failed to save rate"))
+				m.subGetter.EXPECT().GetDue(gomock.Any(), gomock.Any(), int64(0), enqueueBatchSize).Times(0)
+				m.outbox.EXPECT().Save(gomock.Any(), gomock.Any()).Times(0)
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should not count notifications, which were already enqueued today",
+			args: args{
+				ctx: context.Background(),
+				at:  at,
+			},
+			fields: newFields(t),
+			setup: func(t *testing.T, f *fields) {
+				t.Helper()
+				m := cast(t, f)
+				m.rateGetter.EXPECT().GetQuote(gomock.Any()).Times(1).Return(quote, nil)
+				m.rateHistory.EXPECT().Save(gomock.Any(), gomock.Any()).Times(1).Return(int64(1), nil)
+				m.subGetter.EXPECT().GetDue(gomock.Any(), gomock.Any(), int64(0), enqueueBatchSize).Times(1).Return(dailySubs, nil)
+				m.rateHistory.EXPECT().GetDaily(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(daily, nil)
+				m.email.EXPECT().Format(dailyContent("")).Times(1).Return(dailyMsg, nil)
+				m.outbox.EXPECT().Save(gomock.Any(), gomock.Any()).Times(1).Return(0, nil)
+			},
+			want:    0,
+			wantErr: false,
+		},
+		{
+			name: "Should enqueue subscribers batch by batch and format message once",
+			args: args{
+				ctx: context.Background(),
+				at:  at,
+			},
+			fields: newFields(t),
+			setup: func(t *testing.T, f *fields) {
+				t.Helper()
+				m := cast(t, f)
+				first := make([]subscriber.Subscriber, enqueueBatchSize)
+				for i := range first {
+					first[i] = subscriber.Subscriber{ID: int64(i + 1), Frequency: subscriber.FrequencyDaily}
 				}
-				fmter.EXPECT().Format(rate).Times(1).Return(fmtMsg)
+				last := []subscriber.Subscriber{{ID: enqueueBatchSize + 1, Frequency: subscriber.FrequencyDaily}}
 
-				sg, ok := f.subGetter.(*mocks.MockSubscriberGetter)
-				if !ok {
-					t.Fatal("failed to cast subscribe getter to mock subscribe getter")
-				}
-				sg.EXPECT().
-					Get(gomock.Any()).
+				m.rateGetter.EXPECT().GetQuote(gomock.Any()).Times(1).Return(quote, nil)
+				m.rateHistory.EXPECT().Save(gomock.Any(), gomock.Any()).Times(1).Return(int64(1), nil)
+				gomock.InOrder(
+					m.subGetter.EXPECT().
+						GetDue(gomock.Any(), at, int64(0), enqueueBatchSize).
+						Times(1).
+						Return(first, nil),
+					m.outbox.EXPECT().Save(gomock.Any(), gomock.Len(enqueueBatchSize)).Times(1).Return(enqueueBatchSize, nil),
+					m.subGetter.EXPECT().
+						GetDue(gomock.Any(), at, int64(enqueueBatchSize), enqueueBatchSize).
+						Times(1).
+						Return(last, nil),
+					m.outbox.EXPECT().Save(gomock.Any(), gomock.Len(1)).Times(1).Return(1, nil),
+				)
+				m.rateHistory.EXPECT().GetDaily(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(daily, nil)
+				m.email.EXPECT().Format(dailyContent("")).Times(1).Return(dailyMsg, nil)
+			},
+			want:    enqueueBatchSize + 1,
+			wantErr: false,
+		},
+		{
+			name: "Should return error when outbox returned err",
+			args: args{
+				ctx: context.Background(),
+				at:  at,
+			},
+			fields: newFields(t),
+			setup: func(t *testing.T, f *fields) {
+				t.Helper()
+				m := cast(t, f)
+				m.rateGetter.EXPECT().GetQuote(gomock.Any()).Times(1).Return(quote, nil)
+				m.rateHistory.EXPECT().Save(gomock.Any(), gomock.Any()).Times(1).Return(int64(1), nil)
+				m.subGetter.EXPECT().GetDue(gomock.Any(), gomock.Any(), int64(0), enqueueBatchSize).Times(1).Return(dailySubs, nil)
+				m.rateHistory.EXPECT().GetDaily(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(daily, nil)
+				m.email.EXPECT().Format(dailyContent("")).Times(1).Return(dailyMsg, nil)
+				m.outbox.EXPECT().
+					Save(gomock.Any(), gomock.Any()).
 					Times(1).
-					Return(subs, nil)
+					Return(0, errors.New("failed to save notifications"))
 			},
 			wantErr: true,
 		},
@@ -395,67 +800,824 @@ func TestServiceSend(t *testing.T) {
 			t.Parallel()
 			tt.setup(t, &tt.fields)
 			w := &Service{
-				mailer:     tt.fields.mailer,
-				formatter:  tt.fields.formatter,
-				subGetter:  tt.fields.subGetter,
-				rateGetter: tt.fields.rateGetter,
-				log:        tt.fields.log,
+				subGetter:   tt.fields.subGetter,
+				rateGetter:  tt.fields.rateGetter,
+				rateHistory: tt.fields.rateHistory,
+				outbox:      tt.fields.outbox,
+				channels: map[subscriber.Channel]Channel{
+					subscriber.ChannelEmail:    tt.fields.email,
+					subscriber.ChannelTelegram: tt.fields.telegram,
+					subscriber.ChannelWebhook:  tt.fields.webhook,
+					subscriber.ChannelSlack:    tt.fields.slack,
+					subscriber.ChannelDiscord:  tt.fields.discord,
+				},
+				log: tt.fields.log,
+			}
+			got, err := w.Enqueue(tt.args.ctx, tt.args.at)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Service.Enqueue() error = %v, wantErr %v", err, tt.wantErr)
+				return
 			}
-			if err := w.Send(tt.args.ctx); (err != nil) != tt.wantErr {
-				t.Errorf("Service.Send() error = %v, wantErr %v", err, tt.wantErr)
+
+			if got != tt.want {
+				t.Errorf("Service.Enqueue() = %v, want %v", got, tt.want)
 			}
 		})
 	}
 }
 
-func TestMapSubsToMails(t *testing.T) {
+func TestServiceDeliver(t *testing.T) {
 	t.Parallel()
+	type fields struct {
+		outbox       Outbox
+		deliveryLog  DeliveryLog
+		suppressions SuppressionList
+		email        Channel
+		telegram     Channel
+		webhook      Channel
+		slack        Channel
+		discord      Channel
+		log          *slog.Logger
+	}
 	type args struct {
-		s []subscriber.Subscriber
+		ctx context.Context
+	}
+	type mocked struct {
+		outbox       *mocks.MockOutbox
+		deliveryLog  *mocks.MockDeliveryLog
+		suppressions *mocks.MockSuppressionList
+		email        *mocks.MockChannel
+		telegram     *mocks.MockChannel
+		webhook      *mocks.MockChannel
+		slack        *mocks.MockChannel
+		discord      *mocks.MockChannel
+	}
+	newFields := func(t *testing.T) fields {
+		t.Helper()
+		return fields{
+			outbox:       mocks.NewMockOutbox(gomock.NewController(t)),
+			deliveryLog:  mocks.NewMockDeliveryLog(gomock.NewController(t)),
+			suppressions: mocks.NewMockSuppressionList(gomock.NewController(t)),
+			email:        mocks.NewMockChannel(gomock.NewController(t)),
+			telegram:     mocks.NewMockChannel(gomock.NewController(t)),
+			webhook:      mocks.NewMockChannel(gomock.NewController(t)),
+			slack:        mocks.NewMockChannel(gomock.NewController(t)),
+			discord:      mocks.NewMockChannel(gomock.NewController(t)),
+			log:          slog.Default(),
+		}
+	}
+	cast := func(t *testing.T, f *fields) mocked {
+		t.Helper()
+		var (
+			m  mocked
+			ok = make([]bool, 8)
+		)
+		m.outbox, ok[0] = f.outbox.(*mocks.MockOutbox)
+		m.deliveryLog, ok[1] = f.deliveryLog.(*mocks.MockDeliveryLog)
+		m.suppressions, ok[2] = f.suppressions.(*mocks.MockSuppressionList)
+		m.email, ok[3] = f.email.(*mocks.MockChannel)
+		m.telegram, ok[4] = f.telegram.(*mocks.MockChannel)
+		m.webhook, ok[5] = f.webhook.(*mocks.MockChannel)
+		m.slack, ok[6] = f.slack.(*mocks.MockChannel)
+		m.discord, ok[7] = f.discord.(*mocks.MockChannel)
+		for i := range ok {
+			if !ok[i] {
+				t.Fatal("failed to cast dependencies to mocks")
+			}
+		}
+		return m
+	}
+	// to matches notification by its recipient.
+	to := func(recipient string) gomock.Matcher {
+		return gomock.Cond(func(x any) bool {
+			n, ok := x.(outbox.Notification)
+			return ok && n.Email == recipient
+		})
+	}
+
+	pending := []outbox.Notification{
+		{ID: 1, SubscriberID: 1, Email: "test@test.com", Subject: subject, Body: "msg", Text: "text", Rate: 10},
+		{
+			ID:           2,
+			SubscriberID: 2,
+			Email:        "test2@test.com",
+			Subject:      subject,
+			Body:         "msg",
+			Text:         "text",
+			Attempts:     maxAttempts - 2,
+		},
+		{
+			ID:           3,
+			SubscriberID: 3,
+			Email:        "test3@test.com",
+			Subject:      subject,
+			Body:         "msg",
+			Text:         "text",
+			Attempts:     maxAttempts - 1,
+		},
 	}
+
 	tests := []struct {
-		name string
-		args args
-		want []string
+		name       string
+		fields     fields
+		args       args
+		wantErr    bool
+		wantSent   int
+		wantFailed int
+		setup      func(t *testing.T, f *fields)
 	}{
 		{
-			name: "Should map subscribers to email correctly",
+			name: "Should mark notifications as sent when channel succeeded",
 			args: args{
-				s: []subscriber.Subscriber{
-					{Email: "test@test.com"},
-					{Email: "test2@test.com"},
-					{Email: "test3@test.com"},
-					{Email: "test4@test.com"},
-				},
+				ctx: context.Background(),
 			},
-			want: []string{
-				"test@test.com",
-				"test2@test.com",
-				"test3@test.com",
-				"test4@test.com",
+			fields: newFields(t),
+			setup: func(t *testing.T, f *fields) {
+				t.Helper()
+				m := cast(t, f)
+				m.outbox.EXPECT().
+					Claim(gomock.Any(), gomock.Any(), gomock.Any(), deliverBatchSize).
+					Times(1).
+					Return(pending[:1], nil)
+				m.suppressions.EXPECT().
+					GetSuppressed(gomock.Any(), []string{"test@test.com"}).
+					Times(1).
+					Return(nil, nil)
+				m.email.EXPECT().
+					Send(gomock.Any(), to("test@test.com")).
+					Times(1).
+					Return("id", nil)
+				m.deliveryLog.EXPECT().
+					Save(gomock.Any(), delivery.Delivery{
+						NotificationID: 1,
+						SubscriberID:   1,
+						Email:          "test@test.com",
+						Rate:           10,
+						MessageID:      "id",
+						Status:         delivery.StatusSent,
+					}).
+					Times(1).
+					Return(int64(1), nil)
+				m.outbox.EXPECT().MarkSent(gomock.Any(), int64(1)).Times(1).Return(nil)
+			},
+			wantErr:  false,
+			wantSent: 1,
+		},
+		{
+			name: "Should reschedule or move notifications to dead letters when channel failed",
+			args: args{
+				ctx: context.Background(),
+			},
+			fields: newFields(t),
+			setup: func(t *testing.T, f *fields) {
+				t.Helper()
+				m := cast(t, f)
+				sendErr := errors.New("failed to send")
+				m.outbox.EXPECT().
+					Claim(gomock.Any(), gomock.Any(), gomock.Any(), deliverBatchSize).
+					Times(1).
+					Return(pending, nil)
+				m.suppressions.EXPECT().
+					GetSuppressed(gomock.Any(), gomock.Len(len(pending))).
+					Times(1).
+					Return(nil, nil)
+				m.email.EXPECT().
+					Send(gomock.Any(), to("test@test.com")).
+					Times(1).
+					Return("id", nil)
+				m.email.EXPECT().
+					Send(gomock.Any(), to("test2@test.com")).
+					Times(1).
+					Return("", sendErr)
+				m.email.EXPECT().
+					Send(gomock.Any(), to("test3@test.com")).
+					Times(1).
+					Return("", sendErr)
+				m.deliveryLog.EXPECT().
+					Save(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(int64(1), nil)
+				m.deliveryLog.EXPECT().
+					Save(gomock.Any(), delivery.Delivery{
+						NotificationID: 2,
+						SubscriberID:   2,
+						Email:          "test2@test.com",
+						Status:         delivery.StatusFailed,
+						Error:          sendErr.Error(),
+					}).
+					Times(1).
+					Return(int64(2), nil)
+				m.deliveryLog.EXPECT().
+					Save(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(int64(3), nil)
+				m.outbox.EXPECT().MarkSent(gomock.Any(), int64(1)).Times(1).Return(nil)
+				m.outbox.EXPECT().
+					Reschedule(gomock.Any(), int64(2), gomock.Any(), sendErr).
+					Times(1).
+					Return(nil)
+				m.outbox.EXPECT().MarkDead(gomock.Any(), int64(3), sendErr).Times(1).Return(nil)
+			},
+			wantErr:    false,
+			wantSent:   1,
+			wantFailed: 2,
+		},
+		{
+			name: "Should not return error when there're no pending notifications",
+			args: args{
+				ctx: context.Background(),
+			},
+			fields: newFields(t),
+			setup: func(t *testing.T, f *fields) {
+				t.Helper()
+				m := cast(t, f)
+				m.outbox.EXPECT().
+					Claim(gomock.Any(), gomock.Any(), gomock.Any(), deliverBatchSize).
+					Times(1).
+					Return(nil, nil)
+				m.suppressions.EXPECT().GetSuppressed(gomock.Any(), gomock.Any()).Times(0)
+				m.email.EXPECT().Send(gomock.Any(), gomock.Any()).Times(0)
+			},
+			wantErr: false,
+		},
+		{
+			name: "Should move notifications to dead letters without sending when recipient is suppressed",
+			args: args{
+				ctx: context.Background(),
+			},
+			fields: newFields(t),
+			setup: func(t *testing.T, f *fields) {
+				t.Helper()
+				m := cast(t, f)
+				m.outbox.EXPECT().
+					Claim(gomock.Any(), gomock.Any(), gomock.Any(), deliverBatchSize).
+					Times(1).
+					Return(pending[:2], nil)
+				m.suppressions.EXPECT().
+					GetSuppressed(gomock.Any(), []string{"test@test.com", "test2@test.com"}).
+					Times(1).
+					Return([]suppression.Suppression{
+						{Email: "test2@test.com", Reason: suppression.ReasonHardBounce},
+					}, nil)
+				m.email.EXPECT().
+					Send(gomock.Any(), to("test@test.com")).
+					Times(1).
+					Return("id", nil)
+				m.email.EXPECT().
+					Send(gomock.Any(), to("test2@test.com")).
+					Times(0)
+				m.deliveryLog.EXPECT().
+					Save(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(int64(1), nil)
+				m.deliveryLog.EXPECT().
+					Save(gomock.Any(), delivery.Delivery{
+						NotificationID: 2,
+						SubscriberID:   2,
+						Email:          "test2@test.com",
+						Status:         delivery.StatusFailed,
+						Error:          "recipient is suppressed: hard_bounce",
+					}).
+					Times(1).
+					Return(int64(2), nil)
+				m.outbox.EXPECT().MarkSent(gomock.Any(), int64(1)).Times(1).Return(nil)
+				m.outbox.EXPECT().Reschedule(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+				m.outbox.EXPECT().
+					MarkDead(gomock.Any(), int64(2), gomock.Cond(func(x any) bool {
+						err, ok := x.(error)
+						return ok && errors.Is(err, ErrSuppressed)
+					})).
+					Times(1).
+					Return(nil)
+			},
+			wantErr:    false,
+			wantSent:   1,
+			wantFailed: 1,
+		},
+		{
+			name: "Should send telegram notifications through telegram channel",
+			args: args{
+				ctx: context.Background(),
+			},
+			fields: newFields(t),
+			setup: func(t *testing.T, f *fields) {
+				t.Helper()
+				m := cast(t, f)
+				m.outbox.EXPECT().
+					Claim(gomock.Any(), gomock.Any(), gomock.Any(), deliverBatchSize).
+					Times(1).
+					Return([]outbox.Notification{{
+						ID:           4,
+						SubscriberID: 4,
+						Channel:      subscriber.ChannelTelegram,
+						Email:        "42",
+						Subject:      subject,
+						Body:         "msg",
+						Text:         "text",
+					}}, nil)
+				m.suppressions.EXPECT().GetSuppressed(gomock.Any(), gomock.Any()).Times(0)
+				m.email.EXPECT().Send(gomock.Any(), gomock.Any()).Times(0)
+				m.telegram.EXPECT().Send(gomock.Any(), to("42")).Times(1).Return("7", nil)
+				m.deliveryLog.EXPECT().
+					Save(gomock.Any(), delivery.Delivery{
+						NotificationID: 4,
+						SubscriberID:   4,
+						Email:          "42",
+						MessageID:      "7",
+						Status:         delivery.StatusSent,
+					}).
+					Times(1).
+					Return(int64(1), nil)
+				m.outbox.EXPECT().MarkSent(gomock.Any(), int64(4)).Times(1).Return(nil)
+			},
+			wantErr:  false,
+			wantSent: 1,
+		},
+		{
+			name: "Should post webhook notifications through webhook channel",
+			args: args{
+				ctx: context.Background(),
 			},
+			fields: newFields(t),
+			setup: func(t *testing.T, f *fields) {
+				t.Helper()
+				m := cast(t, f)
+				n := outbox.Notification{
+					ID:           6,
+					SubscriberID: 6,
+					Channel:      subscriber.ChannelWebhook,
+					Email:        "https://example.com/hook",
+					Body:         `{"rate":10}`,
+				}
+				m.outbox.EXPECT().
+					Claim(gomock.Any(), gomock.Any(), gomock.Any(), deliverBatchSize).
+					Times(1).
+					Return([]outbox.Notification{n}, nil)
+				m.suppressions.EXPECT().GetSuppressed(gomock.Any(), gomock.Any()).Times(0)
+				m.email.EXPECT().Send(gomock.Any(), gomock.Any()).Times(0)
+				m.webhook.EXPECT().Send(gomock.Any(), n).Times(1).Return("6", nil)
+				m.deliveryLog.EXPECT().
+					Save(gomock.Any(), delivery.Delivery{
+						NotificationID: 6,
+						SubscriberID:   6,
+						Email:          "https://example.com/hook",
+						MessageID:      "6",
+						Status:         delivery.StatusSent,
+					}).
+					Times(1).
+					Return(int64(1), nil)
+				m.outbox.EXPECT().MarkSent(gomock.Any(), int64(6)).Times(1).Return(nil)
+			},
+			wantErr:  false,
+			wantSent: 1,
 		},
 		{
-			name: "Should map empty subscribers to email correctly",
+			name: "Should reschedule webhook notifications when endpoint failed",
 			args: args{
-				s: []subscriber.Subscriber{},
+				ctx: context.Background(),
+			},
+			fields: newFields(t),
+			setup: func(t *testing.T, f *fields) {
+				t.Helper()
+				m := cast(t, f)
+				m.outbox.EXPECT().
+					Claim(gomock.Any(), gomock.Any(), gomock.Any(), deliverBatchSize).
+					Times(1).
+					Return([]outbox.Notification{{
+						ID:           7,
+						SubscriberID: 7,
+						Channel:      subscriber.ChannelWebhook,
+						Email:        "https://example.com/hook",
+					}}, nil)
+				m.webhook.EXPECT().
+					Send(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return("", errors.New("unexpected status 500"))
+				m.deliveryLog.EXPECT().
+					Save(gomock.Any(), gomock.Cond(func(x any) bool {
+						d, ok := x.(delivery.Delivery)
+						return ok && d.Status == delivery.StatusFailed && d.Email == "https://example.com/hook"
+					})).
+					Times(1).
+					Return(int64(1), nil)
+				m.outbox.EXPECT().Reschedule(gomock.Any(), int64(7), gomock.Any(), gomock.Any()).Times(1).Return(nil)
 			},
-			want: []string{},
+			wantErr:    false,
+			wantFailed: 1,
 		},
 		{
-			name: "Should map nil subscribers to email correctly",
+			name: "Should post slack and discord notifications to their chats",
 			args: args{
-				s: nil,
+				ctx: context.Background(),
 			},
-			want: []string{},
+			fields: newFields(t),
+			setup: func(t *testing.T, f *fields) {
+				t.Helper()
+				m := cast(t, f)
+				m.outbox.EXPECT().
+					Claim(gomock.Any(), gomock.Any(), gomock.Any(), deliverBatchSize).
+					Times(1).
+					Return([]outbox.Notification{
+						{ID: 8, SubscriberID: 8, Channel: subscriber.ChannelSlack, Email: "https://slack.test", Body: "slack"},
+						{ID: 9, SubscriberID: 9, Channel: subscriber.ChannelDiscord, Email: "https://discord.test", Body: "discord"},
+					}, nil)
+				m.suppressions.EXPECT().GetSuppressed(gomock.Any(), gomock.Any()).Times(0)
+				m.slack.EXPECT().Send(gomock.Any(), to("https://slack.test")).Times(1).Return("", nil)
+				m.discord.EXPECT().Send(gomock.Any(), to("https://discord.test")).Times(1).Return("42", nil)
+				m.deliveryLog.EXPECT().Save(gomock.Any(), gomock.Any()).Times(2).Return(int64(1), nil)
+				m.outbox.EXPECT().MarkSent(gomock.Any(), int64(8)).Times(1).Return(nil)
+				m.outbox.EXPECT().MarkSent(gomock.Any(), int64(9)).Times(1).Return(nil)
+			},
+			wantErr:  false,
+			wantSent: 2,
+		},
+		{
+			name: "Should move notifications to dead letters when channel isn't supported",
+			args: args{
+				ctx: context.Background(),
+			},
+			fields: newFields(t),
+			setup: func(t *testing.T, f *fields) {
+				t.Helper()
+				m := cast(t, f)
+				m.outbox.EXPECT().
+					Claim(gomock.Any(), gomock.Any(), gomock.Any(), deliverBatchSize).
+					Times(1).
+					Return([]outbox.Notification{{ID: 5, SubscriberID: 5, Channel: "pigeon", Email: "roof"}}, nil)
+				m.deliveryLog.EXPECT().Save(gomock.Any(), gomock.Any()).Times(1).Return(int64(1), nil)
+				m.outbox.EXPECT().Reschedule(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+				m.outbox.EXPECT().
+					MarkDead(gomock.Any(), int64(5), gomock.Cond(func(x any) bool {
+						err, ok := x.(error)
+						return ok && errors.Is(err, ErrUnsupportedChannel)
+					})).
+					Times(1).
+					Return(nil)
+			},
+			wantErr:    false,
+			wantFailed: 1,
+		},
+		{
+			name: "Should return error when suppression list couldn't be read",
+			args: args{
+				ctx: context.Background(),
+			},
+			fields: newFields(t),
+			setup: func(t *testing.T, f *fields) {
+				t.Helper()
+				m := cast(t, f)
+				m.outbox.EXPECT().
+					Claim(gomock.Any(), gomock.Any(), gomock.Any(), deliverBatchSize).
+					Times(1).
+					Return(pending, nil)
+				m.suppressions.EXPECT().
+					GetSuppressed(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil, errors.New("failed to get suppressions"))
+				m.email.EXPECT().Send(gomock.Any(), gomock.Any()).Times(0)
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should return error when outbox couldn't be read",
+			args: args{
+				ctx: context.Background(),
+			},
+			fields: newFields(t),
+			setup: func(t *testing.T, f *fields) {
+				t.Helper()
+				m := cast(t, f)
+				m.outbox.EXPECT().
+					Claim(gomock.Any(), gomock.Any(), gomock.Any(), deliverBatchSize).
+					Times(1).
+					Return(nil, errors.New("failed to get pending"))
+				m.email.EXPECT().Send(gomock.Any(), gomock.Any()).Times(0)
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should return error when outbox couldn't be updated",
+			args: args{
+				ctx: context.Background(),
+			},
+			fields: newFields(t),
+			setup: func(t *testing.T, f *fields) {
+				t.Helper()
+				m := cast(t, f)
+				m.outbox.EXPECT().
+					Claim(gomock.Any(), gomock.Any(), gomock.Any(), deliverBatchSize).
+					Times(1).
+					Return(pending[:1], nil)
+				m.suppressions.EXPECT().
+					GetSuppressed(gomock.Any(), []string{"test@test.com"}).
+					Times(1).
+					Return(nil, nil)
+				m.email.EXPECT().
+					Send(gomock.Any(), to("test@test.com")).
+					Times(1).
+					Return("id", nil)
+				m.deliveryLog.EXPECT().Save(gomock.Any(), gomock.Any()).Times(1).Return(int64(1), nil)
+				m.outbox.EXPECT().
+					MarkSent(gomock.Any(), int64(1)).
+					Times(1).
+					Return(errors.New("failed to mark sent"))
+			},
+			wantErr:  true,
+			wantSent: 1,
+		},
+		{
+			name: "Should return error when delivery couldn't be recorded",
+			args: args{
+				ctx: context.Background(),
+			},
+			fields: newFields(t),
+			setup: func(t *testing.T, f *fields) {
+				t.Helper()
+				m := cast(t, f)
+				m.outbox.EXPECT().
+					Claim(gomock.Any(), gomock.Any(), gomock.Any(), deliverBatchSize).
+					Times(1).
+					Return(pending[:1], nil)
+				m.suppressions.EXPECT().
+					GetSuppressed(gomock.Any(), []string{"test@test.com"}).
+					Times(1).
+					Return(nil, nil)
+				m.email.EXPECT().
+					Send(gomock.Any(), to("test@test.com")).
+					Times(1).
+					Return("id", nil)
+				m.deliveryLog.EXPECT().
+					Save(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(int64(0), errors.New("failed to save delivery"))
+				m.outbox.EXPECT().MarkSent(gomock.Any(), int64(1)).Times(1).Return(nil)
+			},
+			wantErr:  true,
+			wantSent: 1,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
-			if got := mapSubsToMails(tt.args.s); !reflect.DeepEqual(got, tt.want) {
-				t.Errorf("mapSubsToMails() = %v, want %v", got, tt.want)
+			tt.setup(t, &tt.fields)
+			w := &Service{
+				outbox:       tt.fields.outbox,
+				deliveryLog:  tt.fields.deliveryLog,
+				suppressions: tt.fields.suppressions,
+				channels: map[subscriber.Channel]Channel{
+					subscriber.ChannelEmail:    tt.fields.email,
+					subscriber.ChannelTelegram: tt.fields.telegram,
+					subscriber.ChannelWebhook:  tt.fields.webhook,
+					subscriber.ChannelSlack:    tt.fields.slack,
+					subscriber.ChannelDiscord:  tt.fields.discord,
+				},
+				log: tt.fields.log,
+			}
+			got, err := w.Deliver(tt.args.ctx)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Service.Deliver() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if got.Sent() != tt.wantSent || len(got.Failed()) != tt.wantFailed {
+				t.Errorf(
+					"Service.Deliver() sent = %v, failed = %v, want sent %v, failed %v",
+					got.Sent(),
+					len(got.Failed()),
+					tt.wantSent,
+					tt.wantFailed,
+				)
+			}
+		})
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		attempts int
+		want     time.Duration
+	}{
+		{
+			name:     "Should return base backoff after first attempt",
+			attempts: 1,
+			want:     baseBackoff,
+		},
+		{
+			name:     "Should double backoff after each attempt",
+			attempts: 3,
+			want:     baseBackoff * 4,
+		},
+		{
+			name:     "Should cap backoff with maximum",
+			attempts: 10,
+			want:     maxBackoff,
+		},
+		{
+			name:     "Should cap backoff with maximum when shift overflows",
+			attempts: 100,
+			want:     maxBackoff,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := backoff(tt.attempts); got != tt.want {
+				t.Errorf("backoff() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFrequencyOf(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name string
+		s    subscriber.Subscriber
+		want subscriber.Frequency
+	}{
+		{
+			name: "Should return frequency of the subscriber",
+			s:    subscriber.Subscriber{Frequency: subscriber.FrequencyWeekly},
+			want: subscriber.FrequencyWeekly,
+		},
+		{
+			name: "Should treat subscribers without frequency as daily",
+			s:    subscriber.Subscriber{},
+			want: subscriber.FrequencyDaily,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := frequencyOf(tt.s); got != tt.want {
+				t.Errorf("frequencyOf() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestServiceDeliverConcurrently(t *testing.T) {
+	t.Parallel()
+	n := make([]outbox.Notification, 0, sendConcurrency*3)
+	for i := range sendConcurrency * 3 {
+		n = append(n, outbox.Notification{
+			ID:      int64(i),
+			Email:   fmt.Sprintf("test%d@test.com", i),
+			Subject: subject,
+			Body:    "msg",
+		})
+	}
+
+	var (
+		mu       sync.Mutex
+		inFlight int
+		maxSeen  int
+	)
+
+	m := mocks.NewMockChannel(gomock.NewController(t))
+	m.EXPECT().
+		Send(gomock.Any(), gomock.Any()).
+		Times(len(n)).
+		DoAndReturn(func(_ context.Context, n outbox.Notification) (string, error) {
+			mu.Lock()
+			inFlight++
+			maxSeen = max(maxSeen, inFlight)
+			mu.Unlock()
+
+			time.Sleep(time.Millisecond)
+
+			mu.Lock()
+			inFlight--
+			mu.Unlock()
+
+			if n.Email == "test0@test.com" {
+				return "", errors.New("failed to send")
+			}
+			return "id", nil
+		})
+
+	w := &Service{channels: map[subscriber.Channel]Channel{subscriber.ChannelEmail: m}, log: slog.Default()}
+	got := w.deliver(context.Background(), n, nil)
+
+	if len(got) != len(n) {
+		t.Fatalf("Service.deliver() returned %v results, want %v", len(got), len(n))
+	}
+
+	for i := range got {
+		if got[i].Email != n[i].Email || got[i].Channel != subscriber.ChannelEmail {
+			t.Errorf("Service.deliver() result %v = %v, want email %v", i, got[i], n[i].Email)
+		}
+		if wantErr := i == 0; (got[i].Err != nil) != wantErr {
+			t.Errorf("Service.deliver() result %v err = %v, wantErr %v", i, got[i].Err, wantErr)
+		}
+	}
+
+	if maxSeen > sendConcurrency {
+		t.Errorf("Service.deliver() sent %v notifications simultaneously, want at most %v", maxSeen, sendConcurrency)
+	}
+}
+
+func TestRunDate(t *testing.T) {
+	t.Parallel()
+	kyiv := time.FixedZone("Kyiv", 3*60*60)
+	tests := []struct {
+		name string
+		t    time.Time
+		want time.Time
+	}{
+		{
+			name: "Should truncate time to the start of the day",
+			t:    time.Date(2024, 5, 1, 12, 30, 0, 0, time.UTC),
+			want: time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "Should use day in the location of the point of time",
+			t:    time.Date(2024, 5, 2, 1, 0, 0, 0, kyiv),
+			want: time.Date(2024, 5, 2, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := runDate(tt.t); !got.Equal(tt.want) {
+				t.Errorf("runDate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestServiceDeliverInterrupted(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		sendErr  error
+		wantSent int
+	}{
+		{
+			name:     "Should save outcome of the sent notification when delivery is cancelled",
+			sendErr:  nil,
+			wantSent: 1,
+		},
+		{
+			name:     "Should leave notification claimed when its send is cancelled",
+			sendErr:  context.Canceled,
+			wantSent: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			ctrl := gomock.NewController(t)
+			ob := mocks.NewMockOutbox(ctrl)
+			dl := mocks.NewMockDeliveryLog(ctrl)
+			sl := mocks.NewMockSuppressionList(ctrl)
+			ch := mocks.NewMockChannel(ctrl)
+			notCancelled := gomock.Cond(func(ctx any) bool {
+				return ctx.(context.Context).Err() == nil
+			})
+
+			ob.EXPECT().
+				Claim(gomock.Any(), gomock.Any(), gomock.Any(), deliverBatchSize).
+				Times(1).
+				Return([]outbox.Notification{{ID: 1, Email: "test@test.com"}}, nil)
+			sl.EXPECT().GetSuppressed(gomock.Any(), gomock.Any()).Times(1).Return(nil, nil)
+			ch.EXPECT().
+				Send(gomock.Any(), gomock.Any()).
+				Times(1).
+				DoAndReturn(func(context.Context, outbox.Notification) (string, error) {
+					cancel()
+					if tt.sendErr != nil {
+						return "", tt.sendErr
+					}
+					return "id", nil
+				})
+			dl.EXPECT().Save(notCancelled, gomock.Any()).Times(tt.wantSent).Return(int64(1), nil)
+			ob.EXPECT().MarkSent(notCancelled, int64(1)).Times(tt.wantSent).Return(nil)
+
+			w := &Service{
+				outbox:       ob,
+				deliveryLog:  dl,
+				suppressions: sl,
+				channels:     map[subscriber.Channel]Channel{subscriber.ChannelEmail: ch},
+				log:          slog.Default(),
+			}
+
+			rep, err := w.Deliver(ctx)
+			if err != nil {
+				t.Fatalf("Service.Deliver() error = %v, want nil", err)
+			}
+			if len(rep.Results) != tt.wantSent {
+				t.Errorf("Service.Deliver() reported %v results, want %v", len(rep.Results), tt.wantSent)
 			}
 		})
 	}
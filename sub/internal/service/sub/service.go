@@ -5,18 +5,24 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/hrvadl/converter/sub/internal/service/validator"
 	"github.com/hrvadl/converter/sub/internal/storage/subscriber"
 )
 
 const operation = "ratesender service"
 
+// ErrInvalidSubscriber is returned when subscriber's email
+// or frequency preferences are not valid.
+var ErrInvalidSubscriber = errors.New("invalid subscriber")
+
 // NewService constructs new Service with provided arguments.
 // NOTE: neither of arguments can't be nil, or service will panic in
 // the future.
-func NewService(rr RecipientSaver, vv Validator) *Service {
+func NewService(rr RecipientSaver, vv Validator, nn Normalizer) *Service {
 	return &Service{
-		repo:      rr,
-		validator: vv,
+		repo:       rr,
+		validator:  vv,
+		normalizer: nn,
 	}
 }
 
@@ -27,29 +33,85 @@ type RecipientSaver interface {
 
 //go:generate mockgen -destination=./mocks/mock_validator.go -package=mocks . Validator
 type Validator interface {
-	Validate(mail string) bool
+	Validate(ctx context.Context, mail string) error
+}
+
+//go:generate mockgen -destination=./mocks/mock_normalizer.go -package=mocks . Normalizer
+type Normalizer interface {
+	Normalize(email string) (string, error)
 }
 
 // Service is a main structure, responsible for doing checks
 // and calling underlying saver to save subscriber if everything is correct.
 type Service struct {
-	repo      RecipientSaver
-	validator Validator
+	repo       RecipientSaver
+	validator  Validator
+	normalizer Normalizer
 }
 
-// Subscribe method accepts context and subscriber's mail.
-// First of all, it validates subscriber's email.
+// Subscribe method accepts context and subscriber with preferred frequency.
+// First of all, it validates subscriber's email and frequency preferences.
+// Canonical email is used to detect subscribers with the same mailbox.
+// Subscriber without frequency is subscribed to the daily mails,
+// and subscriber without locale receives mails in English.
 // Then it call underlying repo to save subscriber:
 // If OK returns ID of saved subscriber, if not - returns an error.
-func (s *Service) Subscribe(ctx context.Context, mail string) (int64, error) {
-	if !s.validator.Validate(mail) {
-		return 0, errors.New("invalid email")
+func (s *Service) Subscribe(ctx context.Context, sub subscriber.Subscriber) (int64, error) {
+	if err := s.validator.Validate(ctx, sub.Email); err != nil {
+		var r *validator.Rejection
+		if errors.As(err, &r) {
+			return 0, fmt.Errorf("%w: %w", ErrInvalidSubscriber, err)
+		}
+		return 0, fmt.Errorf("%s: failed to validate email: %w", operation, err)
 	}
 
-	resp, err := s.repo.Save(ctx, subscriber.Subscriber{Email: mail})
+	canonical, err := s.normalizer.Normalize(sub.Email)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %w", ErrInvalidSubscriber, err)
+	}
+	sub.CanonicalEmail = canonical
+
+	if sub.Frequency == "" {
+		sub.Frequency = subscriber.FrequencyDaily
+	}
+
+	if err := validateFrequency(sub); err != nil {
+		return 0, err
+	}
+
+	if sub.Locale == "" {
+		sub.Locale = subscriber.LocaleEnglish
+	}
+
+	if sub.Locale != subscriber.LocaleEnglish && sub.Locale != subscriber.LocaleUkrainian {
+		return 0, fmt.Errorf("%w: invalid locale", ErrInvalidSubscriber)
+	}
+
+	resp, err := s.repo.Save(ctx, sub)
 	if err != nil {
 		return 0, fmt.Errorf("%s: failed to save recipient: %w", operation, err)
 	}
 
 	return resp, nil
 }
+
+// validateFrequency checks whether frequency is known and
+// the day, chosen for the weekly/monthly digest, exists.
+func validateFrequency(sub subscriber.Subscriber) error {
+	switch sub.Frequency {
+	case subscriber.FrequencyDaily:
+		return nil
+	case subscriber.FrequencyWeekly:
+		if sub.Weekday < 0 || sub.Weekday > 6 {
+			return fmt.Errorf("%w: invalid weekday", ErrInvalidSubscriber)
+		}
+		return nil
+	case subscriber.FrequencyMonthly:
+		if sub.MonthDay < 1 || sub.MonthDay > 31 {
+			return fmt.Errorf("%w: invalid day of month", ErrInvalidSubscriber)
+		}
+		return nil
+	default:
+		return fmt.Errorf("%w: invalid frequency", ErrInvalidSubscriber)
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"go.uber.org/mock/gomock"
 
 	"github.com/hrvadl/converter/sub/internal/service/sub/mocks"
+	emailvalidator "github.com/hrvadl/converter/sub/internal/service/validator"
 	"github.com/hrvadl/converter/sub/internal/storage/subscriber"
 )
 
@@ -17,6 +18,7 @@ func TestNewService(t *testing.T) {
 	type args struct {
 		rr RecipientSaver
 		vv Validator
+		nn Normalizer
 	}
 	tests := []struct {
 		name string
@@ -28,10 +30,12 @@ func TestNewService(t *testing.T) {
 			args: args{
 				rr: mocks.NewMockRecipientSaver(gomock.NewController(t)),
 				vv: mocks.NewMockValidator(gomock.NewController(t)),
+				nn: mocks.NewMockNormalizer(gomock.NewController(t)),
 			},
 			want: &Service{
-				repo:      mocks.NewMockRecipientSaver(gomock.NewController(t)),
-				validator: mocks.NewMockValidator(gomock.NewController(t)),
+				repo:       mocks.NewMockRecipientSaver(gomock.NewController(t)),
+				validator:  mocks.NewMockValidator(gomock.NewController(t)),
+				normalizer: mocks.NewMockNormalizer(gomock.NewController(t)),
 			},
 		},
 		{
@@ -39,6 +43,7 @@ func TestNewService(t *testing.T) {
 			args: args{
 				rr: nil,
 				vv: nil,
+				nn: nil,
 			},
 			want: &Service{
 				repo: nil,
@@ -49,7 +54,7 @@ func TestNewService(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
-			if got := NewService(tt.args.rr, tt.args.vv); !reflect.DeepEqual(got, tt.want) {
+			if got := NewService(tt.args.rr, tt.args.vv, tt.args.nn); !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("NewService() = %v, want %v", got, tt.want)
 			}
 		})
@@ -59,12 +64,13 @@ func TestNewService(t *testing.T) {
 func TestServiceSubscribe(t *testing.T) {
 	t.Parallel()
 	type fields struct {
-		repo      RecipientSaver
-		validator Validator
+		repo       RecipientSaver
+		validator  Validator
+		normalizer Normalizer
 	}
 	type args struct {
-		ctx  context.Context
-		mail string
+		ctx context.Context
+		sub subscriber.Subscriber
 	}
 	tests := []struct {
 		name    string
@@ -72,19 +78,20 @@ func TestServiceSubscribe(t *testing.T) {
 		args    args
 		want    int64
 		wantErr bool
-		setup   func(t *testing.T, saver RecipientSaver, validator Validator)
+		setup   func(t *testing.T, saver RecipientSaver, validator Validator, normalizer Normalizer)
 	}{
 		{
 			name: "Should not return err when everything is correct",
 			fields: fields{
-				repo:      mocks.NewMockRecipientSaver(gomock.NewController(t)),
-				validator: mocks.NewMockValidator(gomock.NewController(t)),
+				repo:       mocks.NewMockRecipientSaver(gomock.NewController(t)),
+				validator:  mocks.NewMockValidator(gomock.NewController(t)),
+				normalizer: mocks.NewMockNormalizer(gomock.NewController(t)),
 			},
 			args: args{
-				ctx:  context.Background(),
-				mail: "mail@gmail.com",
+				ctx: context.Background(),
+				sub: subscriber.Subscriber{Email: "mail@gmail.com"},
 			},
-			setup: func(t *testing.T, saver RecipientSaver, validator Validator) {
+			setup: func(t *testing.T, saver RecipientSaver, validator Validator, normalizer Normalizer) {
 				t.Helper()
 				rs, ok := saver.(*mocks.MockRecipientSaver)
 				if !ok {
@@ -96,9 +103,20 @@ func TestServiceSubscribe(t *testing.T) {
 					t.Fatalf("Failed to cast validator to mock saver")
 				}
 
-				v.EXPECT().Validate("mail@gmail.com").Times(1).Return(true)
+				n, ok := normalizer.(*mocks.MockNormalizer)
+				if !ok {
+					t.Fatalf("Failed to cast normalizer to mock normalizer")
+				}
+
+				v.EXPECT().Validate(gomock.Any(), "mail@gmail.com").Times(1).Return(nil)
+				n.EXPECT().Normalize("mail@gmail.com").Times(1).Return("mail@gmail.com", nil)
 				rs.EXPECT().
-					Save(gomock.Any(), subscriber.Subscriber{Email: "mail@gmail.com"}).
+					Save(gomock.Any(), subscriber.Subscriber{
+						Email:          "mail@gmail.com",
+						CanonicalEmail: "mail@gmail.com",
+						Frequency:      subscriber.FrequencyDaily,
+						Locale:         subscriber.LocaleEnglish,
+					}).
 					Times(1).
 					Return(int64(1), nil)
 			},
@@ -108,14 +126,15 @@ func TestServiceSubscribe(t *testing.T) {
 		{
 			name: "Should return err when saver returned err",
 			fields: fields{
-				repo:      mocks.NewMockRecipientSaver(gomock.NewController(t)),
-				validator: mocks.NewMockValidator(gomock.NewController(t)),
+				repo:       mocks.NewMockRecipientSaver(gomock.NewController(t)),
+				validator:  mocks.NewMockValidator(gomock.NewController(t)),
+				normalizer: mocks.NewMockNormalizer(gomock.NewController(t)),
 			},
 			args: args{
-				ctx:  context.Background(),
-				mail: "mail@gmail.com",
+				ctx: context.Background(),
+				sub: subscriber.Subscriber{Email: "mail@gmail.com"},
 			},
-			setup: func(t *testing.T, saver RecipientSaver, validator Validator) {
+			setup: func(t *testing.T, saver RecipientSaver, validator Validator, normalizer Normalizer) {
 				t.Helper()
 				rs, ok := saver.(*mocks.MockRecipientSaver)
 				if !ok {
@@ -127,9 +146,20 @@ func TestServiceSubscribe(t *testing.T) {
 					t.Fatalf("Failed to cast validator to mock saver")
 				}
 
-				v.EXPECT().Validate("mail@gmail.com").Times(1).Return(true)
+				n, ok := normalizer.(*mocks.MockNormalizer)
+				if !ok {
+					t.Fatalf("Failed to cast normalizer to mock normalizer")
+				}
+
+				v.EXPECT().Validate(gomock.Any(), "mail@gmail.com").Times(1).Return(nil)
+				n.EXPECT().Normalize("mail@gmail.com").Times(1).Return("mail@gmail.com", nil)
 				rs.EXPECT().
-					Save(gomock.Any(), subscriber.Subscriber{Email: "mail@gmail.com"}).
+					Save(gomock.Any(), subscriber.Subscriber{
+						Email:          "mail@gmail.com",
+						CanonicalEmail: "mail@gmail.com",
+						Frequency:      subscriber.FrequencyDaily,
+						Locale:         subscriber.LocaleEnglish,
+					}).
 					Times(1).
 					Return(int64(0), errors.New("failed to save subscriber"))
 			},
@@ -137,16 +167,17 @@ func TestServiceSubscribe(t *testing.T) {
 			wantErr: true,
 		},
 		{
-			name: "Should return err when validator returned false",
+			name: "Should return err when validator rejected email",
 			fields: fields{
-				repo:      mocks.NewMockRecipientSaver(gomock.NewController(t)),
-				validator: mocks.NewMockValidator(gomock.NewController(t)),
+				repo:       mocks.NewMockRecipientSaver(gomock.NewController(t)),
+				validator:  mocks.NewMockValidator(gomock.NewController(t)),
+				normalizer: mocks.NewMockNormalizer(gomock.NewController(t)),
 			},
 			args: args{
-				ctx:  context.Background(),
-				mail: "",
+				ctx: context.Background(),
+				sub: subscriber.Subscriber{Email: ""},
 			},
-			setup: func(t *testing.T, saver RecipientSaver, validator Validator) {
+			setup: func(t *testing.T, saver RecipientSaver, validator Validator, normalizer Normalizer) {
 				t.Helper()
 				rs, ok := saver.(*mocks.MockRecipientSaver)
 				if !ok {
@@ -158,7 +189,10 @@ func TestServiceSubscribe(t *testing.T) {
 					t.Fatalf("Failed to cast validator to mock saver")
 				}
 
-				v.EXPECT().Validate("").Times(1).Return(false)
+				v.EXPECT().
+					Validate(gomock.Any(), "").
+					Times(1).
+					Return(&emailvalidator.Rejection{Reason: emailvalidator.ReasonInvalidSyntax})
 				rs.EXPECT().
 					Save(gomock.Any(), subscriber.Subscriber{Email: "mail@gmail.com"}).
 					Times(0).
@@ -167,13 +201,326 @@ func TestServiceSubscribe(t *testing.T) {
 			want:    0,
 			wantErr: true,
 		},
+		{
+			name: "Should save weekly subscriber when weekday is correct",
+			fields: fields{
+				repo:       mocks.NewMockRecipientSaver(gomock.NewController(t)),
+				validator:  mocks.NewMockValidator(gomock.NewController(t)),
+				normalizer: mocks.NewMockNormalizer(gomock.NewController(t)),
+			},
+			args: args{
+				ctx: context.Background(),
+				sub: subscriber.Subscriber{
+					Email:     "mail@gmail.com",
+					Frequency: subscriber.FrequencyWeekly,
+					Weekday:   1,
+				},
+			},
+			setup: func(t *testing.T, saver RecipientSaver, validator Validator, normalizer Normalizer) {
+				t.Helper()
+				rs, ok := saver.(*mocks.MockRecipientSaver)
+				if !ok {
+					t.Fatalf("Failed to cast saver to mock saver")
+				}
+
+				v, ok := validator.(*mocks.MockValidator)
+				if !ok {
+					t.Fatalf("Failed to cast validator to mock saver")
+				}
+
+				n, ok := normalizer.(*mocks.MockNormalizer)
+				if !ok {
+					t.Fatalf("Failed to cast normalizer to mock normalizer")
+				}
+
+				v.EXPECT().Validate(gomock.Any(), "mail@gmail.com").Times(1).Return(nil)
+				n.EXPECT().Normalize("mail@gmail.com").Times(1).Return("mail@gmail.com", nil)
+				rs.EXPECT().
+					Save(gomock.Any(), subscriber.Subscriber{
+						Email:          "mail@gmail.com",
+						CanonicalEmail: "mail@gmail.com",
+						Frequency:      subscriber.FrequencyWeekly,
+						Weekday:        1,
+						Locale:         subscriber.LocaleEnglish,
+					}).
+					Times(1).
+					Return(int64(2), nil)
+			},
+			want:    2,
+			wantErr: false,
+		},
+		{
+			name: "Should return err when weekday is out of range",
+			fields: fields{
+				repo:       mocks.NewMockRecipientSaver(gomock.NewController(t)),
+				validator:  mocks.NewMockValidator(gomock.NewController(t)),
+				normalizer: mocks.NewMockNormalizer(gomock.NewController(t)),
+			},
+			args: args{
+				ctx: context.Background(),
+				sub: subscriber.Subscriber{
+					Email:     "mail@gmail.com",
+					Frequency: subscriber.FrequencyWeekly,
+					Weekday:   7,
+				},
+			},
+			setup: func(t *testing.T, saver RecipientSaver, validator Validator, normalizer Normalizer) {
+				t.Helper()
+				rs, ok := saver.(*mocks.MockRecipientSaver)
+				if !ok {
+					t.Fatalf("Failed to cast saver to mock saver")
+				}
+
+				v, ok := validator.(*mocks.MockValidator)
+				if !ok {
+					t.Fatalf("Failed to cast validator to mock saver")
+				}
+
+				n, ok := normalizer.(*mocks.MockNormalizer)
+				if !ok {
+					t.Fatalf("Failed to cast normalizer to mock normalizer")
+				}
+
+				v.EXPECT().Validate(gomock.Any(), "mail@gmail.com").Times(1).Return(nil)
+				n.EXPECT().Normalize("mail@gmail.com").Times(1).Return("mail@gmail.com", nil)
+				rs.EXPECT().Save(gomock.Any(), gomock.Any()).Times(0)
+			},
+			want:    0,
+			wantErr: true,
+		},
+		{
+			name: "Should return err when day of month is out of range",
+			fields: fields{
+				repo:       mocks.NewMockRecipientSaver(gomock.NewController(t)),
+				validator:  mocks.NewMockValidator(gomock.NewController(t)),
+				normalizer: mocks.NewMockNormalizer(gomock.NewController(t)),
+			},
+			args: args{
+				ctx: context.Background(),
+				sub: subscriber.Subscriber{
+					Email:     "mail@gmail.com",
+					Frequency: subscriber.FrequencyMonthly,
+					MonthDay:  32,
+				},
+			},
+			setup: func(t *testing.T, saver RecipientSaver, validator Validator, normalizer Normalizer) {
+				t.Helper()
+				rs, ok := saver.(*mocks.MockRecipientSaver)
+				if !ok {
+					t.Fatalf("Failed to cast saver to mock saver")
+				}
+
+				v, ok := validator.(*mocks.MockValidator)
+				if !ok {
+					t.Fatalf("Failed to cast validator to mock saver")
+				}
+
+				n, ok := normalizer.(*mocks.MockNormalizer)
+				if !ok {
+					t.Fatalf("Failed to cast normalizer to mock normalizer")
+				}
+
+				v.EXPECT().Validate(gomock.Any(), "mail@gmail.com").Times(1).Return(nil)
+				n.EXPECT().Normalize("mail@gmail.com").Times(1).Return("mail@gmail.com", nil)
+				rs.EXPECT().Save(gomock.Any(), gomock.Any()).Times(0)
+			},
+			want:    0,
+			wantErr: true,
+		},
+		{
+			name: "Should return err when validator failed",
+			fields: fields{
+				repo:       mocks.NewMockRecipientSaver(gomock.NewController(t)),
+				validator:  mocks.NewMockValidator(gomock.NewController(t)),
+				normalizer: mocks.NewMockNormalizer(gomock.NewController(t)),
+			},
+			args: args{
+				ctx: context.Background(),
+				sub: subscriber.Subscriber{Email: "mail@gmail.com"},
+			},
+			setup: func(t *testing.T, saver RecipientSaver, validator Validator, _ Normalizer) {
+				t.Helper()
+				rs, ok := saver.(*mocks.MockRecipientSaver)
+				if !ok {
+					t.Fatalf("Failed to cast saver to mock saver")
+				}
+
+				v, ok := validator.(*mocks.MockValidator)
+				if !ok {
+					t.Fatalf("Failed to cast validator to mock saver")
+				}
+
+				v.EXPECT().
+					Validate(gomock.Any(), "mail@gmail.com").
+					Times(1).
+					Return(errors.New("failed to lookup mx"))
+				rs.EXPECT().Save(gomock.Any(), gomock.Any()).Times(0)
+			},
+			want:    0,
+			wantErr: true,
+		},
+		{
+			name: "Should return err when email couldn't be normalised",
+			fields: fields{
+				repo:       mocks.NewMockRecipientSaver(gomock.NewController(t)),
+				validator:  mocks.NewMockValidator(gomock.NewController(t)),
+				normalizer: mocks.NewMockNormalizer(gomock.NewController(t)),
+			},
+			args: args{
+				ctx: context.Background(),
+				sub: subscriber.Subscriber{Email: "mail@gmail.com"},
+			},
+			setup: func(t *testing.T, saver RecipientSaver, validator Validator, normalizer Normalizer) {
+				t.Helper()
+				rs, ok := saver.(*mocks.MockRecipientSaver)
+				if !ok {
+					t.Fatalf("Failed to cast saver to mock saver")
+				}
+
+				v, ok := validator.(*mocks.MockValidator)
+				if !ok {
+					t.Fatalf("Failed to cast validator to mock saver")
+				}
+
+				n, ok := normalizer.(*mocks.MockNormalizer)
+				if !ok {
+					t.Fatalf("Failed to cast normalizer to mock normalizer")
+				}
+
+				v.EXPECT().Validate(gomock.Any(), "mail@gmail.com").Times(1).Return(nil)
+				n.EXPECT().Normalize("mail@gmail.com").Times(1).Return("", errors.New("invalid domain"))
+				rs.EXPECT().Save(gomock.Any(), gomock.Any()).Times(0)
+			},
+			want:    0,
+			wantErr: true,
+		},
+		{
+			name: "Should keep ukrainian locale when subscribing",
+			fields: fields{
+				repo:       mocks.NewMockRecipientSaver(gomock.NewController(t)),
+				validator:  mocks.NewMockValidator(gomock.NewController(t)),
+				normalizer: mocks.NewMockNormalizer(gomock.NewController(t)),
+			},
+			args: args{
+				ctx: context.Background(),
+				sub: subscriber.Subscriber{Email: "mail@gmail.com", Locale: subscriber.LocaleUkrainian},
+			},
+			setup: func(t *testing.T, saver RecipientSaver, validator Validator, normalizer Normalizer) {
+				t.Helper()
+				rs, ok := saver.(*mocks.MockRecipientSaver)
+				if !ok {
+					t.Fatalf("Failed to cast saver to mock saver")
+				}
+
+				v, ok := validator.(*mocks.MockValidator)
+				if !ok {
+					t.Fatalf("Failed to cast validator to mock saver")
+				}
+
+				n, ok := normalizer.(*mocks.MockNormalizer)
+				if !ok {
+					t.Fatalf("Failed to cast normalizer to mock normalizer")
+				}
+
+				v.EXPECT().Validate(gomock.Any(), "mail@gmail.com").Times(1).Return(nil)
+				n.EXPECT().Normalize("mail@gmail.com").Times(1).Return("mail@gmail.com", nil)
+				rs.EXPECT().
+					Save(gomock.Any(), subscriber.Subscriber{
+						Email:          "mail@gmail.com",
+						CanonicalEmail: "mail@gmail.com",
+						Frequency:      subscriber.FrequencyDaily,
+						Locale:         subscriber.LocaleUkrainian,
+					}).
+					Times(1).
+					Return(int64(3), nil)
+			},
+			want:    3,
+			wantErr: false,
+		},
+		{
+			name: "Should return err when locale is unknown",
+			fields: fields{
+				repo:       mocks.NewMockRecipientSaver(gomock.NewController(t)),
+				validator:  mocks.NewMockValidator(gomock.NewController(t)),
+				normalizer: mocks.NewMockNormalizer(gomock.NewController(t)),
+			},
+			args: args{
+				ctx: context.Background(),
+				sub: subscriber.Subscriber{Email: "mail@gmail.com", Locale: subscriber.Locale("fr")},
+			},
+			setup: func(t *testing.T, saver RecipientSaver, validator Validator, normalizer Normalizer) {
+				t.Helper()
+				rs, ok := saver.(*mocks.MockRecipientSaver)
+				if !ok {
+					t.Fatalf("Failed to cast saver to mock saver")
+				}
+
+				v, ok := validator.(*mocks.MockValidator)
+				if !ok {
+					t.Fatalf("Failed to cast validator to mock saver")
+				}
+
+				n, ok := normalizer.(*mocks.MockNormalizer)
+				if !ok {
+					t.Fatalf("Failed to cast normalizer to mock normalizer")
+				}
+
+				v.EXPECT().Validate(gomock.Any(), "mail@gmail.com").Times(1).Return(nil)
+				n.EXPECT().Normalize("mail@gmail.com").Times(1).Return("mail@gmail.com", nil)
+				rs.EXPECT().Save(gomock.Any(), gomock.Any()).Times(0)
+			},
+			want:    0,
+			wantErr: true,
+		},
+		{
+			name: "Should return err when frequency is unknown",
+			fields: fields{
+				repo:       mocks.NewMockRecipientSaver(gomock.NewController(t)),
+				validator:  mocks.NewMockValidator(gomock.NewController(t)),
+				normalizer: mocks.NewMockNormalizer(gomock.NewController(t)),
+			},
+			args: args{
+				ctx: context.Background(),
+				sub: subscriber.Subscriber{
+					Email:     "mail@gmail.com",
+					Frequency: subscriber.Frequency("hourly"),
+				},
+			},
+			setup: func(t *testing.T, saver RecipientSaver, validator Validator, normalizer Normalizer) {
+				t.Helper()
+				rs, ok := saver.(*mocks.MockRecipientSaver)
+				if !ok {
+					t.Fatalf("Failed to cast saver to mock saver")
+				}
+
+				v, ok := validator.(*mocks.MockValidator)
+				if !ok {
+					t.Fatalf("Failed to cast validator to mock saver")
+				}
+
+				n, ok := normalizer.(*mocks.MockNormalizer)
+				if !ok {
+					t.Fatalf("Failed to cast normalizer to mock normalizer")
+				}
+
+				v.EXPECT().Validate(gomock.Any(), "mail@gmail.com").Times(1).Return(nil)
+				n.EXPECT().Normalize("mail@gmail.com").Times(1).Return("mail@gmail.com", nil)
+				rs.EXPECT().Save(gomock.Any(), gomock.Any()).Times(0)
+			},
+			want:    0,
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			tt.setup(t, tt.fields.repo, tt.fields.validator)
-			s := &Service{repo: tt.fields.repo, validator: tt.fields.validator}
-			got, err := s.Subscribe(tt.args.ctx, tt.args.mail)
+			tt.setup(t, tt.fields.repo, tt.fields.validator, tt.fields.normalizer)
+			s := &Service{
+				repo:       tt.fields.repo,
+				validator:  tt.fields.validator,
+				normalizer: tt.fields.normalizer,
+			}
+			got, err := s.Subscribe(tt.args.ctx, tt.args.sub)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("Service.Subscribe() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -0,0 +1,100 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/hrvadl/converter/sub/internal/service/suppression (interfaces: Repo)
+//
+// Generated by this command:
+//
+//	mockgen -destination=./mocks/mock_repo.go -package=mocks . Repo
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	suppression "github.com/hrvadl/converter/sub/internal/storage/suppression"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRepo is a mock of Repo interface.
+type MockRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepoMockRecorder
+}
+
+// MockRepoMockRecorder is the mock recorder for MockRepo.
+type MockRepoMockRecorder struct {
+	mock *MockRepo
+}
+
+// NewMockRepo creates a new mock instance.
+func NewMockRepo(ctrl *gomock.Controller) *MockRepo {
+	mock := &MockRepo{ctrl: ctrl}
+	mock.recorder = &MockRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepo) EXPECT() *MockRepoMockRecorder {
+	return m.recorder
+}
+
+// CountEvents mocks base method.
+func (m *MockRepo) CountEvents(arg0 context.Context, arg1 string, arg2 suppression.EventType, arg3 time.Time) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountEvents", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountEvents indicates an expected call of CountEvents.
+func (mr *MockRepoMockRecorder) CountEvents(arg0, arg1, arg2, arg3 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountEvents", reflect.TypeOf((*MockRepo)(nil).CountEvents), arg0, arg1, arg2, arg3)
+}
+
+// Lift mocks base method.
+func (m *MockRepo) Lift(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Lift", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Lift indicates an expected call of Lift.
+func (mr *MockRepoMockRecorder) Lift(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Lift", reflect.TypeOf((*MockRepo)(nil).Lift), arg0, arg1)
+}
+
+// SaveEvent mocks base method.
+func (m *MockRepo) SaveEvent(arg0 context.Context, arg1 suppression.Event) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveEvent", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SaveEvent indicates an expected call of SaveEvent.
+func (mr *MockRepoMockRecorder) SaveEvent(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveEvent", reflect.TypeOf((*MockRepo)(nil).SaveEvent), arg0, arg1)
+}
+
+// Suppress mocks base method.
+func (m *MockRepo) Suppress(arg0 context.Context, arg1 suppression.Suppression) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Suppress", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Suppress indicates an expected call of Suppress.
+func (mr *MockRepoMockRecorder) Suppress(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Suppress", reflect.TypeOf((*MockRepo)(nil).Suppress), arg0, arg1)
+}
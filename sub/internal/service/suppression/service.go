@@ -0,0 +1,106 @@
+package suppression
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hrvadl/converter/sub/internal/storage/suppression"
+)
+
+const operation = "suppression service"
+
+// softBounceWindow is a period, during which soft bounces are
+// counted. Older soft bounces don't lead to the suppression.
+const softBounceWindow = time.Hour * 24 * 30
+
+// ErrUnsupportedEvent is returned when the event can't be recorded.
+var ErrUnsupportedEvent = errors.New("event type is not supported")
+
+// NewService constructs new Service with provided arguments.
+// Email is suppressed after softBounceLimit soft bounces.
+// NOTE: neither of arguments can't be nil, or service will panic in
+// the future.
+func NewService(r Repo, softBounceLimit int) *Service {
+	return &Service{
+		repo:            r,
+		softBounceLimit: softBounceLimit,
+	}
+}
+
+//go:generate mockgen -destination=./mocks/mock_repo.go -package=mocks . Repo
+type Repo interface {
+	SaveEvent(ctx context.Context, e suppression.Event) (int64, error)
+	CountEvents(ctx context.Context, email string, t suppression.EventType, since time.Time) (int, error)
+	Suppress(ctx context.Context, s suppression.Suppression) error
+	Lift(ctx context.Context, email string) error
+}
+
+// Service is a main structure, responsible for handling bounces
+// and complaints reported by the mail provider, so emails, which
+// can't or shouldn't receive mails, aren't mailed anymore.
+type Service struct {
+	repo            Repo
+	softBounceLimit int
+}
+
+// Record method records mail event and suppresses the email when it's
+// needed. Email is suppressed right away after hard bounce or complaint,
+// and after softBounceLimit soft bounces during softBounceWindow.
+// Redelivered events are skipped. Returns ErrUnsupportedEvent if event
+// isn't a bounce or complaint.
+func (s *Service) Record(ctx context.Context, e suppression.Event) error {
+	var reason suppression.Reason
+	switch e.Type {
+	case suppression.EventHardBounce:
+		reason = suppression.ReasonHardBounce
+	case suppression.EventComplaint:
+		reason = suppression.ReasonComplaint
+	case suppression.EventSoftBounce:
+		reason = suppression.ReasonSoftBounce
+	default:
+		return fmt.Errorf("%s: %w: %q", operation, ErrUnsupportedEvent, e.Type)
+	}
+
+	_, err := s.repo.SaveEvent(ctx, e)
+	if errors.Is(err, suppression.ErrDuplicate) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("%s: failed to save event: %w", operation, err)
+	}
+
+	if e.Type == suppression.EventSoftBounce {
+		n, err := s.repo.CountEvents(ctx, e.Email, e.Type, time.Now().Add(-softBounceWindow))
+		if err != nil {
+			return fmt.Errorf("%s: failed to count soft bounces: %w", operation, err)
+		}
+
+		if n < s.softBounceLimit {
+			return nil
+		}
+	}
+
+	err = s.repo.Suppress(ctx, suppression.Suppression{
+		Email:       e.Email,
+		Reason:      reason,
+		Description: e.Description,
+	})
+	if err != nil {
+		return fmt.Errorf("%s: failed to suppress email: %w", operation, err)
+	}
+
+	return nil
+}
+
+// Lift method removes email from the suppression list, so it's mailed
+// again. Bounces recorded before aren't counted anymore. Returns
+// suppression.ErrNotFound if email isn't suppressed.
+func (s *Service) Lift(ctx context.Context, email string) error {
+	if err := s.repo.Lift(ctx, email); err != nil {
+		return fmt.Errorf("%s: failed to lift suppression: %w", operation, err)
+	}
+
+	return nil
+}
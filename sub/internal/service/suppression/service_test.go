@@ -0,0 +1,304 @@
+package suppression
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"go.uber.org/mock/gomock"
+
+	"github.com/hrvadl/converter/sub/internal/service/suppression/mocks"
+	"github.com/hrvadl/converter/sub/internal/storage/suppression"
+)
+
+func TestNewService(t *testing.T) {
+	t.Parallel()
+	type args struct {
+		r               Repo
+		softBounceLimit int
+	}
+	tests := []struct {
+		name string
+		args args
+		want *Service
+	}{
+		{
+			name: "Should create new service correctly when correct arguments are provided",
+			args: args{
+				r:               mocks.NewMockRepo(gomock.NewController(t)),
+				softBounceLimit: 3,
+			},
+			want: &Service{
+				repo:            mocks.NewMockRepo(gomock.NewController(t)),
+				softBounceLimit: 3,
+			},
+		},
+		{
+			name: "Should create new service correctly when allowed arguments are provided",
+			args: args{
+				r: nil,
+			},
+			want: &Service{
+				repo: nil,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := NewService(tt.args.r, tt.args.softBounceLimit); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewService() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestServiceRecord(t *testing.T) {
+	t.Parallel()
+	type fields struct {
+		repo Repo
+	}
+	type args struct {
+		ctx context.Context
+		e   suppression.Event
+	}
+	cast := func(t *testing.T, r Repo) *mocks.MockRepo {
+		t.Helper()
+		rr, ok := r.(*mocks.MockRepo)
+		if !ok {
+			t.Fatal("failed to cast repo to mock")
+		}
+		return rr
+	}
+	event := func(t suppression.EventType) suppression.Event {
+		return suppression.Event{
+			EventID:     "msg_1",
+			Email:       "test@test.com",
+			Type:        t,
+			Description: "rejected",
+		}
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		setup   func(t *testing.T, r Repo)
+		wantErr error
+	}{
+		{
+			name: "Should suppress email right away when it has bounced permanently",
+			fields: fields{
+				repo: mocks.NewMockRepo(gomock.NewController(t)),
+			},
+			args: args{
+				ctx: context.Background(),
+				e:   event(suppression.EventHardBounce),
+			},
+			setup: func(t *testing.T, r Repo) {
+				t.Helper()
+				rr := cast(t, r)
+				rr.EXPECT().SaveEvent(gomock.Any(), event(suppression.EventHardBounce)).Times(1).Return(int64(1), nil)
+				rr.EXPECT().CountEvents(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+				rr.EXPECT().
+					Suppress(gomock.Any(), suppression.Suppression{
+						Email:       "test@test.com",
+						Reason:      suppression.ReasonHardBounce,
+						Description: "rejected",
+					}).
+					Times(1).
+					Return(nil)
+			},
+		},
+		{
+			name: "Should suppress email right away when recipient has complained",
+			fields: fields{
+				repo: mocks.NewMockRepo(gomock.NewController(t)),
+			},
+			args: args{
+				ctx: context.Background(),
+				e:   event(suppression.EventComplaint),
+			},
+			setup: func(t *testing.T, r Repo) {
+				t.Helper()
+				rr := cast(t, r)
+				rr.EXPECT().SaveEvent(gomock.Any(), gomock.Any()).Times(1).Return(int64(1), nil)
+				rr.EXPECT().
+					Suppress(gomock.Any(), suppression.Suppression{
+						Email:       "test@test.com",
+						Reason:      suppression.ReasonComplaint,
+						Description: "rejected",
+					}).
+					Times(1).
+					Return(nil)
+			},
+		},
+		{
+			name: "Should not suppress email when soft bounce limit isn't reached",
+			fields: fields{
+				repo: mocks.NewMockRepo(gomock.NewController(t)),
+			},
+			args: args{
+				ctx: context.Background(),
+				e:   event(suppression.EventSoftBounce),
+			},
+			setup: func(t *testing.T, r Repo) {
+				t.Helper()
+				rr := cast(t, r)
+				rr.EXPECT().SaveEvent(gomock.Any(), gomock.Any()).Times(1).Return(int64(1), nil)
+				rr.EXPECT().
+					CountEvents(gomock.Any(), "test@test.com", suppression.EventSoftBounce, gomock.Any()).
+					Times(1).
+					Return(2, nil)
+				rr.EXPECT().Suppress(gomock.Any(), gomock.Any()).Times(0)
+			},
+		},
+		{
+			name: "Should suppress email when soft bounce limit is reached",
+			fields: fields{
+				repo: mocks.NewMockRepo(gomock.NewController(t)),
+			},
+			args: args{
+				ctx: context.Background(),
+				e:   event(suppression.EventSoftBounce),
+			},
+			setup: func(t *testing.T, r Repo) {
+				t.Helper()
+				rr := cast(t, r)
+				rr.EXPECT().SaveEvent(gomock.Any(), gomock.Any()).Times(1).Return(int64(1), nil)
+				rr.EXPECT().
+					CountEvents(gomock.Any(), "test@test.com", suppression.EventSoftBounce, gomock.Any()).
+					Times(1).
+					Return(3, nil)
+				rr.EXPECT().
+					Suppress(gomock.Any(), suppression.Suppression{
+						Email:       "test@test.com",
+						Reason:      suppression.ReasonSoftBounce,
+						Description: "rejected",
+					}).
+					Times(1).
+					Return(nil)
+			},
+		},
+		{
+			name: "Should skip event when it has already been recorded",
+			fields: fields{
+				repo: mocks.NewMockRepo(gomock.NewController(t)),
+			},
+			args: args{
+				ctx: context.Background(),
+				e:   event(suppression.EventHardBounce),
+			},
+			setup: func(t *testing.T, r Repo) {
+				t.Helper()
+				rr := cast(t, r)
+				rr.EXPECT().
+					SaveEvent(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(int64(0), suppression.ErrDuplicate)
+				rr.EXPECT().Suppress(gomock.Any(), gomock.Any()).Times(0)
+			},
+		},
+		{
+			name: "Should return error when event is not supported",
+			fields: fields{
+				repo: mocks.NewMockRepo(gomock.NewController(t)),
+			},
+			args: args{
+				ctx: context.Background(),
+				e:   event(suppression.EventLifted),
+			},
+			setup: func(t *testing.T, r Repo) {
+				t.Helper()
+				rr := cast(t, r)
+				rr.EXPECT().SaveEvent(gomock.Any(), gomock.Any()).Times(0)
+			},
+			wantErr: ErrUnsupportedEvent,
+		},
+		{
+			name: "Should return error when soft bounces couldn't be counted",
+			fields: fields{
+				repo: mocks.NewMockRepo(gomock.NewController(t)),
+			},
+			args: args{
+				ctx: context.Background(),
+				e:   event(suppression.EventSoftBounce),
+			},
+			setup: func(t *testing.T, r Repo) {
+				t.Helper()
+				rr := cast(t, r)
+				rr.EXPECT().SaveEvent(gomock.Any(), gomock.Any()).Times(1).Return(int64(1), nil)
+				rr.EXPECT().
+					CountEvents(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(0, errTest)
+				rr.EXPECT().Suppress(gomock.Any(), gomock.Any()).Times(0)
+			},
+			wantErr: errTest,
+		},
+		{
+			name: "Should return error when email couldn't be suppressed",
+			fields: fields{
+				repo: mocks.NewMockRepo(gomock.NewController(t)),
+			},
+			args: args{
+				ctx: context.Background(),
+				e:   event(suppression.EventComplaint),
+			},
+			setup: func(t *testing.T, r Repo) {
+				t.Helper()
+				rr := cast(t, r)
+				rr.EXPECT().SaveEvent(gomock.Any(), gomock.Any()).Times(1).Return(int64(1), nil)
+				rr.EXPECT().Suppress(gomock.Any(), gomock.Any()).Times(1).Return(errTest)
+			},
+			wantErr: errTest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			tt.setup(t, tt.fields.repo)
+			s := &Service{
+				repo:            tt.fields.repo,
+				softBounceLimit: 3,
+			}
+			if err := s.Record(tt.args.ctx, tt.args.e); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Service.Record() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestServiceLift(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		repoErr error
+		wantErr error
+	}{
+		{
+			name: "Should lift suppression when repo succeeded",
+		},
+		{
+			name:    "Should return not found error when email isn't suppressed",
+			repoErr: suppression.ErrNotFound,
+			wantErr: suppression.ErrNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			r := mocks.NewMockRepo(gomock.NewController(t))
+			r.EXPECT().Lift(gomock.Any(), "test@test.com").Times(1).Return(tt.repoErr)
+			s := &Service{repo: r}
+			if err := s.Lift(context.Background(), "test@test.com"); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Service.Lift() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+var errTest = errors.New("test error")
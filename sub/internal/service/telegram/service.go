@@ -0,0 +1,94 @@
+// Package telegram manages subscribers, who receive
+// notifications from the Telegram bot.
+package telegram
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/hrvadl/converter/sub/internal/storage/subscriber"
+)
+
+const operation = "telegram service"
+
+var (
+	// ErrAlreadySubscribed is returned when chat is already subscribed.
+	ErrAlreadySubscribed = errors.New("chat is already subscribed")
+	// ErrNotSubscribed is returned when chat isn't subscribed.
+	ErrNotSubscribed = errors.New("chat is not subscribed")
+)
+
+// NewService constructs service with the provided subscriber repo.
+// NOTE: repo can't be nil, or service will panic.
+func NewService(r Repo) *Service {
+	return &Service{repo: r}
+}
+
+//go:generate mockgen -destination=./mocks/mock_repo.go -package=mocks . Repo
+type Repo interface {
+	Save(ctx context.Context, s subscriber.Subscriber) (int64, error)
+	GetByCanonicalEmail(ctx context.Context, canonical string) (subscriber.Subscriber, error)
+	Unsubscribe(ctx context.Context, id int64) error
+}
+
+// Service is a main structure, responsible for
+// subscribing and unsubscribing Telegram chats.
+type Service struct {
+	repo Repo
+}
+
+// Subscribe method saves chat as the daily subscriber of the Telegram
+// channel. Chat, which has unsubscribed before, is subscribed back.
+// Subscriber without locale receives messages in English.
+// Returns ErrAlreadySubscribed if chat is already subscribed.
+func (s *Service) Subscribe(ctx context.Context, chatID int64, l subscriber.Locale) error {
+	if l == "" {
+		l = subscriber.LocaleEnglish
+	}
+
+	_, err := s.repo.Save(ctx, subscriber.Subscriber{
+		Channel:        subscriber.ChannelTelegram,
+		Email:          strconv.FormatInt(chatID, 10),
+		CanonicalEmail: canonical(chatID),
+		Frequency:      subscriber.FrequencyDaily,
+		Locale:         l,
+	})
+	if errors.Is(err, subscriber.ErrAlreadyExists) {
+		return ErrAlreadySubscribed
+	}
+	if err != nil {
+		return fmt.Errorf("%s: failed to save subscriber: %w", operation, err)
+	}
+
+	return nil
+}
+
+// Unsubscribe method stops notifications to the chat.
+// Returns ErrNotSubscribed if chat isn't subscribed.
+func (s *Service) Unsubscribe(ctx context.Context, chatID int64) error {
+	sub, err := s.repo.GetByCanonicalEmail(ctx, canonical(chatID))
+	if errors.Is(err, subscriber.ErrNotFound) {
+		return ErrNotSubscribed
+	}
+	if err != nil {
+		return fmt.Errorf("%s: failed to get subscriber: %w", operation, err)
+	}
+
+	if sub.Status == subscriber.StatusUnsubscribed {
+		return ErrNotSubscribed
+	}
+
+	if err := s.repo.Unsubscribe(ctx, sub.ID); err != nil {
+		return fmt.Errorf("%s: failed to unsubscribe: %w", operation, err)
+	}
+
+	return nil
+}
+
+// canonical returns canonical email of the chat, which keeps chats
+// unique and doesn't collide with the email subscribers.
+func canonical(chatID int64) string {
+	return string(subscriber.ChannelTelegram) + ":" + strconv.FormatInt(chatID, 10)
+}
@@ -0,0 +1,182 @@
+package telegram
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"go.uber.org/mock/gomock"
+
+	"github.com/hrvadl/converter/sub/internal/service/telegram/mocks"
+	"github.com/hrvadl/converter/sub/internal/storage/subscriber"
+)
+
+func TestNewService(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name string
+		r    Repo
+		want *Service
+	}{
+		{
+			name: "Should create new service correctly when correct arguments are provided",
+			r:    mocks.NewMockRepo(gomock.NewController(t)),
+			want: &Service{repo: mocks.NewMockRepo(gomock.NewController(t))},
+		},
+		{
+			name: "Should create new service correctly when allowed arguments are provided",
+			r:    nil,
+			want: &Service{repo: nil},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := NewService(tt.r); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewService() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestServiceSubscribe(t *testing.T) {
+	t.Parallel()
+	chat := func(l subscriber.Locale) subscriber.Subscriber {
+		return subscriber.Subscriber{
+			Channel:        subscriber.ChannelTelegram,
+			Email:          "42",
+			CanonicalEmail: "telegram:42",
+			Frequency:      subscriber.FrequencyDaily,
+			Locale:         l,
+		}
+	}
+	tests := []struct {
+		name    string
+		locale  subscriber.Locale
+		setup   func(r *mocks.MockRepo)
+		wantErr error
+		wantAny bool
+	}{
+		{
+			name:   "Should save chat as daily telegram subscriber",
+			locale: subscriber.LocaleUkrainian,
+			setup: func(r *mocks.MockRepo) {
+				r.EXPECT().Save(gomock.Any(), chat(subscriber.LocaleUkrainian)).Times(1).Return(int64(1), nil)
+			},
+		},
+		{
+			name:   "Should save chat in English when locale is missing",
+			locale: "",
+			setup: func(r *mocks.MockRepo) {
+				r.EXPECT().Save(gomock.Any(), chat(subscriber.LocaleEnglish)).Times(1).Return(int64(1), nil)
+			},
+		},
+		{
+			name:   "Should return ErrAlreadySubscribed when chat is subscribed",
+			locale: subscriber.LocaleEnglish,
+			setup: func(r *mocks.MockRepo) {
+				r.EXPECT().Save(gomock.Any(), gomock.Any()).Times(1).Return(int64(0), subscriber.ErrAlreadyExists)
+			},
+			wantErr: ErrAlreadySubscribed,
+		},
+		{
+			name:   "Should return error when repo failed",
+			locale: subscriber.LocaleEnglish,
+			setup: func(r *mocks.MockRepo) {
+				r.EXPECT().Save(gomock.Any(), gomock.Any()).Times(1).Return(int64(0), errors.New("db is down"))
+			},
+			wantAny: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			r := mocks.NewMockRepo(gomock.NewController(t))
+			tt.setup(r)
+			err := NewService(r).Subscribe(context.Background(), 42, tt.locale)
+			if tt.wantAny {
+				if err == nil {
+					t.Error("Service.Subscribe() error = nil, want error")
+				}
+				return
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Service.Subscribe() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestServiceUnsubscribe(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		setup   func(r *mocks.MockRepo)
+		wantErr error
+		wantAny bool
+	}{
+		{
+			name: "Should unsubscribe active chat",
+			setup: func(r *mocks.MockRepo) {
+				r.EXPECT().
+					GetByCanonicalEmail(gomock.Any(), "telegram:42").
+					Times(1).
+					Return(subscriber.Subscriber{ID: 7, Status: subscriber.StatusActive}, nil)
+				r.EXPECT().Unsubscribe(gomock.Any(), int64(7)).Times(1).Return(nil)
+			},
+		},
+		{
+			name: "Should return ErrNotSubscribed when chat is unknown",
+			setup: func(r *mocks.MockRepo) {
+				r.EXPECT().
+					GetByCanonicalEmail(gomock.Any(), "telegram:42").
+					Times(1).
+					Return(subscriber.Subscriber{}, subscriber.ErrNotFound)
+			},
+			wantErr: ErrNotSubscribed,
+		},
+		{
+			name: "Should return ErrNotSubscribed when chat has already unsubscribed",
+			setup: func(r *mocks.MockRepo) {
+				r.EXPECT().
+					GetByCanonicalEmail(gomock.Any(), "telegram:42").
+					Times(1).
+					Return(subscriber.Subscriber{ID: 7, Status: subscriber.StatusUnsubscribed}, nil)
+				r.EXPECT().Unsubscribe(gomock.Any(), gomock.Any()).Times(0)
+			},
+			wantErr: ErrNotSubscribed,
+		},
+		{
+			name: "Should return error when repo failed",
+			setup: func(r *mocks.MockRepo) {
+				r.EXPECT().
+					GetByCanonicalEmail(gomock.Any(), "telegram:42").
+					Times(1).
+					Return(subscriber.Subscriber{ID: 7, Status: subscriber.StatusActive}, nil)
+				r.EXPECT().Unsubscribe(gomock.Any(), int64(7)).Times(1).Return(errors.New("db is down"))
+			},
+			wantAny: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			r := mocks.NewMockRepo(gomock.NewController(t))
+			tt.setup(r)
+			err := NewService(r).Unsubscribe(context.Background(), 42)
+			if tt.wantAny {
+				if err == nil {
+					t.Error("Service.Unsubscribe() error = nil, want error")
+				}
+				return
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Service.Unsubscribe() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
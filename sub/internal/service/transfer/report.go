@@ -0,0 +1,36 @@
+package transfer
+
+// RowError describes the row of the imported CSV,
+// which wasn't imported. Line starts from 1, header
+// is the first line.
+type RowError struct {
+	Line   int
+	Email  string
+	Reason string
+}
+
+// Report is an outcome of the import. Failed and Duplicates
+// contain at most maxReportedRows rows each, while counters
+// contain the total number of rows.
+type Report struct {
+	Imported       int
+	Skipped        int
+	FailedCount    int
+	DuplicateCount int
+	Failed         []RowError
+	Duplicates     []RowError
+}
+
+func (r *Report) fail(e RowError) {
+	r.FailedCount++
+	if len(r.Failed) < maxReportedRows {
+		r.Failed = append(r.Failed, e)
+	}
+}
+
+func (r *Report) duplicate(e RowError) {
+	r.DuplicateCount++
+	if len(r.Duplicates) < maxReportedRows {
+		r.Duplicates = append(r.Duplicates, e)
+	}
+}
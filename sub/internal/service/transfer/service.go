@@ -0,0 +1,268 @@
+package transfer
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hrvadl/converter/sub/internal/service/sub"
+	"github.com/hrvadl/converter/sub/internal/storage/subscriber"
+)
+
+const operation = "transfer service"
+
+const (
+	// exportBatchSize is a number of subscribers read
+	// from the DB and written to the CSV at once.
+	exportBatchSize = 1000
+	// maxReportedRows is a maximum number of failed and duplicate
+	// rows listed in the report, so report of the broken file stays
+	// small. Counters are not limited.
+	maxReportedRows = 1000
+)
+
+// header is a header of the exported CSV. Import accepts any
+// subset of it in any order, but email column is required.
+var header = []string{"id", "email", "frequency", "weekday", "month_day", "status", "created_at"}
+
+// NewService constructs new Service with provided arguments.
+// NOTE: neither of arguments can't be nil, or service will panic in
+// the future.
+func NewService(sb Subscriber, sg SubscriberGetter) *Service {
+	return &Service{
+		subscriber: sb,
+		getter:     sg,
+	}
+}
+
+//go:generate mockgen -destination=./mocks/mock_subscriber.go -package=mocks . Subscriber
+type Subscriber interface {
+	Subscribe(ctx context.Context, s subscriber.Subscriber) (int64, error)
+}
+
+//go:generate mockgen -destination=./mocks/mock_getter.go -package=mocks . SubscriberGetter
+type SubscriberGetter interface {
+	Get(ctx context.Context, f subscriber.Filter) ([]subscriber.Subscriber, error)
+}
+
+// Service is a main structure, responsible for moving
+// subscribers between environments with CSV files.
+type Service struct {
+	subscriber Subscriber
+	getter     SubscriberGetter
+}
+
+// Import method reads subscribers from the CSV and subscribes them one by one,
+// so every row is validated the same way as a regular subscription. First row
+// should be a header, unknown columns are ignored, so exported file could be
+// imported as is. Rows with the unsubscribed status are skipped, so people who
+// have left aren't subscribed again. Invalid and duplicate rows are reported and
+// don't stop the import. Returns an error only if the CSV couldn't be read or
+// subscriber couldn't be saved, report contains rows imported up to that point.
+func (s *Service) Import(ctx context.Context, r io.Reader) (Report, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	cr.TrimLeadingSpace = true
+
+	head, err := cr.Read()
+	if err != nil {
+		return Report{}, fmt.Errorf("%s: failed to read header: %w", operation, err)
+	}
+
+	cols, err := newColumns(head)
+	if err != nil {
+		return Report{}, fmt.Errorf("%s: %w", operation, err)
+	}
+
+	var rep Report
+	for {
+		record, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			return rep, nil
+		}
+
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			rep.fail(RowError{Line: parseErr.Line, Reason: parseErr.Err.Error()})
+			continue
+		}
+		if err != nil {
+			return rep, fmt.Errorf("%s: failed to read row: %w", operation, err)
+		}
+
+		line, _ := cr.FieldPos(0)
+		sub, err := cols.parse(record)
+		if err != nil {
+			rep.fail(RowError{Line: line, Email: sub.Email, Reason: err.Error()})
+			continue
+		}
+
+		if sub.Status == subscriber.StatusUnsubscribed {
+			rep.Skipped++
+			continue
+		}
+
+		if err := s.subscribe(ctx, sub, line, &rep); err != nil {
+			return rep, fmt.Errorf("%s: failed to import line %d: %w", operation, line, err)
+		}
+	}
+}
+
+// subscribe method subscribes single row and records the outcome to the
+// report. Returns an error only if it's not caused by the row itself.
+func (s *Service) subscribe(ctx context.Context, row subscriber.Subscriber, line int, rep *Report) error {
+	row.Status = ""
+	_, err := s.subscriber.Subscribe(ctx, row)
+	switch {
+	case err == nil:
+		rep.Imported++
+		return nil
+	case errors.Is(err, subscriber.ErrAlreadyExists):
+		rep.duplicate(RowError{Line: line, Email: row.Email, Reason: subscriber.ErrAlreadyExists.Error()})
+		return nil
+	case errors.Is(err, sub.ErrInvalidSubscriber):
+		rep.fail(RowError{Line: line, Email: row.Email, Reason: err.Error()})
+		return nil
+	default:
+		return err
+	}
+}
+
+// Export method writes email subscribers matching the filter to the CSV
+// with a header, ordered by ID. Subscribers of other channels aren't
+// exported, since their addresses are secrets and import accepts emails
+// only. Subscribers are read in batches and each batch is flushed to the
+// writer, so only a single batch is held in memory. AfterID and Limit of
+// the filter are ignored. Returns number of exported subscribers.
+func (s *Service) Export(ctx context.Context, f subscriber.Filter, w io.Writer) (int, error) {
+	f.Channel = subscriber.ChannelEmail
+	cw := csv.NewWriter(w)
+	if err := cw.Write(header); err != nil {
+		return 0, fmt.Errorf("%s: failed to write header: %w", operation, err)
+	}
+
+	it := subscriber.NewIterator(
+		func(ctx context.Context, afterID int64, limit int) ([]subscriber.Subscriber, error) {
+			f.AfterID, f.Limit = afterID, limit
+			return s.getter.Get(ctx, f)
+		},
+		exportBatchSize,
+	)
+
+	var exported int
+	for {
+		subs, err := it.Next(ctx)
+		if err != nil {
+			return exported, fmt.Errorf("%s: failed to get subscribers: %w", operation, err)
+		}
+
+		if len(subs) == 0 {
+			break
+		}
+
+		for i := range subs {
+			if err := cw.Write(format(subs[i])); err != nil {
+				return exported, fmt.Errorf("%s: failed to write subscriber: %w", operation, err)
+			}
+		}
+
+		cw.Flush()
+		if err := cw.Error(); err != nil {
+			return exported, fmt.Errorf("%s: failed to flush subscribers: %w", operation, err)
+		}
+		exported += len(subs)
+	}
+
+	cw.Flush()
+	if err := cw.Error(); err != nil {
+		return exported, fmt.Errorf("%s: failed to flush: %w", operation, err)
+	}
+
+	return exported, nil
+}
+
+func format(s subscriber.Subscriber) []string {
+	return []string{
+		strconv.FormatInt(s.ID, 10),
+		s.Email,
+		string(s.Frequency),
+		strconv.Itoa(s.Weekday),
+		strconv.Itoa(s.MonthDay),
+		string(s.Status),
+		s.CreatedAt.UTC().Format(time.RFC3339),
+	}
+}
+
+// columns holds positions of the known columns in
+// the imported CSV. Missing columns are -1.
+type columns struct {
+	email     int
+	frequency int
+	weekday   int
+	monthDay  int
+	status    int
+}
+
+func newColumns(head []string) (columns, error) {
+	c := columns{email: -1, frequency: -1, weekday: -1, monthDay: -1, status: -1}
+	for i, name := range head {
+		switch strings.ToLower(strings.TrimSpace(name)) {
+		case "email":
+			c.email = i
+		case "frequency":
+			c.frequency = i
+		case "weekday":
+			c.weekday = i
+		case "month_day":
+			c.monthDay = i
+		case "status":
+			c.status = i
+		}
+	}
+
+	if c.email == -1 {
+		return columns{}, errors.New("header should contain email column")
+	}
+
+	return c, nil
+}
+
+// parse method maps CSV record to the subscriber. Empty optional
+// columns are left zero, so subscription defaults are applied.
+func (c columns) parse(record []string) (subscriber.Subscriber, error) {
+	field := func(i int) string {
+		if i < 0 || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+
+	s := subscriber.Subscriber{
+		Email:     field(c.email),
+		Frequency: subscriber.Frequency(strings.ToLower(field(c.frequency))),
+		Status:    subscriber.Status(strings.ToLower(field(c.status))),
+	}
+
+	var err error
+	if s.Weekday, err = atoi(field(c.weekday)); err != nil {
+		return s, fmt.Errorf("invalid weekday: %w", err)
+	}
+
+	if s.MonthDay, err = atoi(field(c.monthDay)); err != nil {
+		return s, fmt.Errorf("invalid day of month: %w", err)
+	}
+
+	return s, nil
+}
+
+func atoi(s string) (int, error) {
+	if s == "" {
+		return 0, nil
+	}
+	return strconv.Atoi(s)
+}
@@ -0,0 +1,383 @@
+package transfer
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"go.uber.org/mock/gomock"
+
+	"github.com/hrvadl/converter/sub/internal/service/sub"
+	"github.com/hrvadl/converter/sub/internal/service/transfer/mocks"
+	"github.com/hrvadl/converter/sub/internal/storage/subscriber"
+)
+
+func TestNewService(t *testing.T) {
+	t.Parallel()
+	type args struct {
+		sb Subscriber
+		sg SubscriberGetter
+	}
+	tests := []struct {
+		name string
+		args args
+		want *Service
+	}{
+		{
+			name: "Should create new service correctly when correct arguments are provided",
+			args: args{
+				sb: mocks.NewMockSubscriber(gomock.NewController(t)),
+				sg: mocks.NewMockSubscriberGetter(gomock.NewController(t)),
+			},
+			want: &Service{
+				subscriber: mocks.NewMockSubscriber(gomock.NewController(t)),
+				getter:     mocks.NewMockSubscriberGetter(gomock.NewController(t)),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := NewService(tt.args.sb, tt.args.sg); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewService() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestServiceImport(t *testing.T) {
+	t.Parallel()
+	type fields struct {
+		subscriber Subscriber
+	}
+	type args struct {
+		ctx context.Context
+		csv string
+	}
+	cast := func(t *testing.T, f fields) *mocks.MockSubscriber {
+		t.Helper()
+		m, ok := f.subscriber.(*mocks.MockSubscriber)
+		if !ok {
+			t.Fatal("failed to cast subscriber to mock")
+		}
+		return m
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		setup   func(t *testing.T, f fields)
+		want    Report
+		wantErr bool
+	}{
+		{
+			name: "Should import valid rows and report invalid and duplicate ones",
+			fields: fields{
+				subscriber: mocks.NewMockSubscriber(gomock.NewController(t)),
+			},
+			args: args{
+				ctx: context.Background(),
+				csv: "email,frequency,weekday,month_day\n" +
+					"first@test.com,,,\n" +
+					"second@test.com,weekly,3,\n" +
+					"invalid,daily,,\n" +
+					"first@test.com,daily,,\n" +
+					"third@test.com,monthly,,x\n",
+			},
+			setup: func(t *testing.T, f fields) {
+				t.Helper()
+				m := cast(t, f)
+				gomock.InOrder(
+					m.EXPECT().
+						Subscribe(gomock.Any(), subscriber.Subscriber{Email: "first@test.com"}).
+						Times(1).
+						Return(int64(1), nil),
+					m.EXPECT().
+						Subscribe(gomock.Any(), subscriber.Subscriber{
+							Email:     "second@test.com",
+							Frequency: subscriber.FrequencyWeekly,
+							Weekday:   3,
+						}).
+						Times(1).
+						Return(int64(2), nil),
+					m.EXPECT().
+						Subscribe(gomock.Any(), subscriber.Subscriber{Email: "invalid", Frequency: subscriber.FrequencyDaily}).
+						Times(1).
+						Return(int64(0), fmt.Errorf("%w: invalid email", sub.ErrInvalidSubscriber)),
+					m.EXPECT().
+						Subscribe(gomock.Any(), subscriber.Subscriber{Email: "first@test.com", Frequency: subscriber.FrequencyDaily}).
+						Times(1).
+						Return(int64(0), fmt.Errorf("failed to save: %w", subscriber.ErrAlreadyExists)),
+				)
+			},
+			want: Report{
+				Imported:       2,
+				FailedCount:    2,
+				DuplicateCount: 1,
+				Failed: []RowError{
+					{Line: 4, Email: "invalid", Reason: "invalid subscriber: invalid email"},
+					{Line: 6, Email: "third@test.com", Reason: `invalid day of month: strconv.Atoi: parsing "x": invalid syntax`},
+				},
+				Duplicates: []RowError{
+					{Line: 5, Email: "first@test.com", Reason: subscriber.ErrAlreadyExists.Error()},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Should import exported file and skip unsubscribed rows",
+			fields: fields{
+				subscriber: mocks.NewMockSubscriber(gomock.NewController(t)),
+			},
+			args: args{
+				ctx: context.Background(),
+				csv: "id,email,frequency,weekday,month_day,status,created_at\n" +
+					"1,first@test.com,monthly,0,15,active,2024-05-01T00:00:00Z\n" +
+					"2,left@test.com,daily,0,1,unsubscribed,2024-05-01T00:00:00Z\n",
+			},
+			setup: func(t *testing.T, f fields) {
+				t.Helper()
+				m := cast(t, f)
+				m.EXPECT().
+					Subscribe(gomock.Any(), subscriber.Subscriber{
+						Email:     "first@test.com",
+						Frequency: subscriber.FrequencyMonthly,
+						MonthDay:  15,
+					}).
+					Times(1).
+					Return(int64(1), nil)
+			},
+			want:    Report{Imported: 1, Skipped: 1},
+			wantErr: false,
+		},
+		{
+			name: "Should report malformed row and continue",
+			fields: fields{
+				subscriber: mocks.NewMockSubscriber(gomock.NewController(t)),
+			},
+			args: args{
+				ctx: context.Background(),
+				csv: "email\n" +
+					"bad\"quote@test.com\n" +
+					"good@test.com\n",
+			},
+			setup: func(t *testing.T, f fields) {
+				t.Helper()
+				m := cast(t, f)
+				m.EXPECT().
+					Subscribe(gomock.Any(), subscriber.Subscriber{Email: "good@test.com"}).
+					Times(1).
+					Return(int64(1), nil)
+			},
+			want: Report{
+				Imported:    1,
+				FailedCount: 1,
+				Failed:      []RowError{{Line: 2, Reason: `bare " in non-quoted-field`}},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Should return error when header doesn't contain email",
+			fields: fields{
+				subscriber: mocks.NewMockSubscriber(gomock.NewController(t)),
+			},
+			args: args{
+				ctx: context.Background(),
+				csv: "mail,frequency\ntest@test.com,daily\n",
+			},
+			setup: func(t *testing.T, f fields) {
+				t.Helper()
+				cast(t, f).EXPECT().Subscribe(gomock.Any(), gomock.Any()).Times(0)
+			},
+			want:    Report{},
+			wantErr: true,
+		},
+		{
+			name: "Should return error when file is empty",
+			fields: fields{
+				subscriber: mocks.NewMockSubscriber(gomock.NewController(t)),
+			},
+			args: args{
+				ctx: context.Background(),
+				csv: "",
+			},
+			setup: func(t *testing.T, f fields) {
+				t.Helper()
+				cast(t, f).EXPECT().Subscribe(gomock.Any(), gomock.Any()).Times(0)
+			},
+			want:    Report{},
+			wantErr: true,
+		},
+		{
+			name: "Should stop import when subscriber couldn't be saved",
+			fields: fields{
+				subscriber: mocks.NewMockSubscriber(gomock.NewController(t)),
+			},
+			args: args{
+				ctx: context.Background(),
+				csv: "email\nfirst@test.com\nsecond@test.com\nthird@test.com\n",
+			},
+			setup: func(t *testing.T, f fields) {
+				t.Helper()
+				m := cast(t, f)
+				gomock.InOrder(
+					m.EXPECT().Subscribe(gomock.Any(), gomock.Any()).Times(1).Return(int64(1), nil),
+					m.EXPECT().Subscribe(gomock.Any(), gomock.Any()).Times(1).Return(int64(0), errors.New("failed to connect")),
+				)
+			},
+			want:    Report{Imported: 1},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			tt.setup(t, tt.fields)
+			s := &Service{
+				subscriber: tt.fields.subscriber,
+			}
+			got, err := s.Import(tt.args.ctx, strings.NewReader(tt.args.csv))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Service.Import() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Service.Import() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestServiceExport(t *testing.T) {
+	t.Parallel()
+	type fields struct {
+		getter SubscriberGetter
+	}
+	type args struct {
+		ctx context.Context
+		f   subscriber.Filter
+	}
+	created := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	cast := func(t *testing.T, f fields) *mocks.MockSubscriberGetter {
+		t.Helper()
+		m, ok := f.getter.(*mocks.MockSubscriberGetter)
+		if !ok {
+			t.Fatal("failed to cast getter to mock")
+		}
+		return m
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		setup   func(t *testing.T, f fields)
+		want    int
+		wantCSV string
+		wantErr bool
+	}{
+		{
+			name: "Should export subscribers matching the filter with header",
+			fields: fields{
+				getter: mocks.NewMockSubscriberGetter(gomock.NewController(t)),
+			},
+			args: args{
+				ctx: context.Background(),
+				f:   subscriber.Filter{Status: subscriber.StatusActive, AfterID: 100, Limit: 1},
+			},
+			setup: func(t *testing.T, f fields) {
+				t.Helper()
+				cast(t, f).EXPECT().
+					Get(gomock.Any(), subscriber.Filter{Channel: subscriber.ChannelEmail, Status: subscriber.StatusActive, Limit: exportBatchSize}).
+					Times(1).
+					Return([]subscriber.Subscriber{
+						{
+							ID:        1,
+							Email:     "first@test.com",
+							Frequency: subscriber.FrequencyDaily,
+							MonthDay:  1,
+							Status:    subscriber.StatusActive,
+							CreatedAt: created,
+						},
+						{
+							ID:        2,
+							Email:     "second@test.com",
+							Frequency: subscriber.FrequencyWeekly,
+							Weekday:   5,
+							Status:    subscriber.StatusActive,
+							CreatedAt: created,
+						},
+					}, nil)
+			},
+			want: 2,
+			wantCSV: "id,email,frequency,weekday,month_day,status,created_at\n" +
+				"1,first@test.com,daily,0,1,active,2024-05-01T12:00:00Z\n" +
+				"2,second@test.com,weekly,5,0,active,2024-05-01T12:00:00Z\n",
+			wantErr: false,
+		},
+		{
+			name: "Should export only header when there're no subscribers",
+			fields: fields{
+				getter: mocks.NewMockSubscriberGetter(gomock.NewController(t)),
+			},
+			args: args{
+				ctx: context.Background(),
+				f:   subscriber.Filter{},
+			},
+			setup: func(t *testing.T, f fields) {
+				t.Helper()
+				cast(t, f).EXPECT().Get(gomock.Any(), gomock.Any()).Times(1).Return(nil, nil)
+			},
+			want:    0,
+			wantCSV: "id,email,frequency,weekday,month_day,status,created_at\n",
+			wantErr: false,
+		},
+		{
+			name: "Should return error when subscribers couldn't be got",
+			fields: fields{
+				getter: mocks.NewMockSubscriberGetter(gomock.NewController(t)),
+			},
+			args: args{
+				ctx: context.Background(),
+				f:   subscriber.Filter{},
+			},
+			setup: func(t *testing.T, f fields) {
+				t.Helper()
+				cast(t, f).EXPECT().
+					Get(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil, errors.New("failed to connect"))
+			},
+			want:    0,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			tt.setup(t, tt.fields)
+			s := &Service{
+				getter: tt.fields.getter,
+			}
+			var buf bytes.Buffer
+			got, err := s.Export(tt.args.ctx, tt.args.f, &buf)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Service.Export() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("Service.Export() = %v, want %v", got, tt.want)
+			}
+			if !tt.wantErr && buf.String() != tt.wantCSV {
+				t.Errorf("Service.Export() csv = %q, want %q", buf.String(), tt.wantCSV)
+			}
+		})
+	}
+}
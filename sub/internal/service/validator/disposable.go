@@ -0,0 +1,89 @@
+package validator
+
+import (
+	"bufio"
+	"context"
+	_ "embed"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+//go:embed disposable_domains.txt
+var defaultDisposable string
+
+// DefaultDisposable constructs new Disposable check with
+// the built-in blocklist of the popular disposable mail providers.
+func DefaultDisposable() *Disposable {
+	domains, _ := readDomains(strings.NewReader(defaultDisposable))
+	return NewDisposable(domains...)
+}
+
+// NewDisposable constructs new Disposable check with
+// provided blocklist of the domains.
+func NewDisposable(domains ...string) *Disposable {
+	set := make(map[string]struct{}, len(domains))
+	for _, d := range domains {
+		set[strings.TrimSuffix(strings.ToLower(d), ".")] = struct{}{}
+	}
+
+	return &Disposable{
+		domains: set,
+	}
+}
+
+// LoadDisposable reads blocklist from the file with one domain per
+// line. Empty lines and lines starting with # are ignored.
+func LoadDisposable(path string) (*Disposable, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open blocklist: %w", err)
+	}
+	defer f.Close()
+
+	domains, err := readDomains(f)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read blocklist: %w", err)
+	}
+
+	return NewDisposable(domains...), nil
+}
+
+func readDomains(r io.Reader) ([]string, error) {
+	var domains []string
+	s := bufio.NewScanner(r)
+	for s.Scan() {
+		line := strings.TrimSpace(s.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		domains = append(domains, line)
+	}
+
+	return domains, s.Err()
+}
+
+// Disposable is a check, which rejects emails of the disposable
+// mail providers. Subdomains of the blocked domain are blocked too.
+type Disposable struct {
+	domains map[string]struct{}
+}
+
+// Check method returns *Rejection if domain of the
+// email or any of its parents is blocked.
+func (d *Disposable) Check(_ context.Context, addr Address) error {
+	for domain := addr.Domain; domain != ""; {
+		if _, ok := d.domains[domain]; ok {
+			return reject(ReasonDisposableDomain, "%s is a disposable mail provider", addr.Domain)
+		}
+
+		_, parent, ok := strings.Cut(domain, ".")
+		if !ok {
+			break
+		}
+		domain = parent
+	}
+
+	return nil
+}
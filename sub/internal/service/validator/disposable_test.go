@@ -0,0 +1,84 @@
+package validator
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDisposableCheck(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "disposable.txt")
+	content := "# disposable mail providers\nmailinator.com\n\n  Guerrillamail.com  \n"
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("Failed to write blocklist: %v", err)
+	}
+
+	d, err := LoadDisposable(path)
+	if err != nil {
+		t.Fatalf("LoadDisposable() error = %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		domain  string
+		wantErr bool
+	}{
+		{
+			name:    "Should reject domain when it's blocked",
+			domain:  "mailinator.com",
+			wantErr: true,
+		},
+		{
+			name:    "Should reject domain when it's blocked in the other case",
+			domain:  "guerrillamail.com",
+			wantErr: true,
+		},
+		{
+			name:    "Should reject subdomain of the blocked domain",
+			domain:  "eu.mailinator.com",
+			wantErr: true,
+		},
+		{
+			name:   "Should accept domain when it's not blocked",
+			domain: "gmail.com",
+		},
+		{
+			name:   "Should accept domain when it only ends with the blocked one",
+			domain: "notmailinator.com",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := d.Check(context.Background(), Address{Local: "test", Domain: tt.domain})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Check() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			var r *Rejection
+			if tt.wantErr && (!errors.As(err, &r) || r.Reason != ReasonDisposableDomain) {
+				t.Errorf("Check() error = %v, want %v rejection", err, ReasonDisposableDomain)
+			}
+		})
+	}
+}
+
+func TestLoadDisposableMissingFile(t *testing.T) {
+	t.Parallel()
+	if _, err := LoadDisposable(filepath.Join(t.TempDir(), "missing.txt")); err == nil {
+		t.Error("LoadDisposable() error = nil, want error")
+	}
+}
+
+func TestDefaultDisposable(t *testing.T) {
+	t.Parallel()
+	err := DefaultDisposable().Check(context.Background(), Address{Local: "test", Domain: "mailinator.com"})
+	var r *Rejection
+	if !errors.As(err, &r) || r.Reason != ReasonDisposableDomain {
+		t.Errorf("Check() error = %v, want %v rejection", err, ReasonDisposableDomain)
+	}
+}
@@ -0,0 +1,63 @@
+package validator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+)
+
+// NewMailServer constructs new MailServer check with
+// provided resolver, i.e. net.DefaultResolver.
+// NOTE: resolver can't be nil, or check will panic.
+func NewMailServer(r Resolver) *MailServer {
+	return &MailServer{
+		resolver: r,
+	}
+}
+
+// Resolver is a subset of the net.Resolver methods, so
+// DNS lookups could be faked in tests.
+type Resolver interface {
+	LookupMX(ctx context.Context, name string) ([]*net.MX, error)
+	LookupHost(ctx context.Context, host string) ([]string, error)
+}
+
+// MailServer is a check, which rejects emails of the domains
+// without mail server. Domain accepts mail, if it has MX record,
+// or A/AAAA record as the implicit MX.
+type MailServer struct {
+	resolver Resolver
+}
+
+// Check method returns *Rejection if domain doesn't exist, has
+// null MX record (RFC 7505) or has neither MX nor A/AAAA records.
+// Temporary DNS failures are returned as a plain error, so
+// subscriber could retry later.
+func (m *MailServer) Check(ctx context.Context, addr Address) error {
+	mx, err := m.resolver.LookupMX(ctx, addr.Domain)
+	if err == nil && len(mx) > 0 {
+		if len(mx) == 1 && (mx[0].Host == "." || mx[0].Host == "") {
+			return reject(ReasonNoMailServer, "%s doesn't accept mail", addr.Domain)
+		}
+		return nil
+	}
+	if err != nil && !isNotFound(err) {
+		return fmt.Errorf("failed to lookup mx of %s: %w", addr.Domain, err)
+	}
+
+	hosts, err := m.resolver.LookupHost(ctx, addr.Domain)
+	if err == nil && len(hosts) > 0 {
+		return nil
+	}
+	if err != nil && !isNotFound(err) {
+		return fmt.Errorf("failed to lookup host of %s: %w", addr.Domain, err)
+	}
+
+	return reject(ReasonNoMailServer, "%s doesn't have mail server", addr.Domain)
+}
+
+func isNotFound(err error) bool {
+	var dnsErr *net.DNSError
+	return errors.As(err, &dnsErr) && dnsErr.IsNotFound
+}
@@ -0,0 +1,108 @@
+package validator
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+)
+
+type fakeResolver struct {
+	mx    map[string][]*net.MX
+	hosts map[string][]string
+	err   error
+}
+
+func (f fakeResolver) LookupMX(_ context.Context, name string) ([]*net.MX, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	if mx, ok := f.mx[name]; ok {
+		return mx, nil
+	}
+	return nil, &net.DNSError{Err: "no such host", Name: name, IsNotFound: true}
+}
+
+func (f fakeResolver) LookupHost(_ context.Context, host string) ([]string, error) {
+	if hosts, ok := f.hosts[host]; ok {
+		return hosts, nil
+	}
+	return nil, &net.DNSError{Err: "no such host", Name: host, IsNotFound: true}
+}
+
+func TestMailServerCheck(t *testing.T) {
+	t.Parallel()
+	resolver := fakeResolver{
+		mx: map[string][]*net.MX{
+			"gmail.com":   {{Host: "gmail-smtp-in.l.google.com.", Pref: 5}},
+			"nomail.com":  {{Host: ".", Pref: 0}},
+			"example.org": {},
+		},
+		hosts: map[string][]string{
+			"example.com": {"93.184.216.34"},
+		},
+	}
+	tests := []struct {
+		name       string
+		resolver   Resolver
+		domain     string
+		wantReason Reason
+		wantErr    bool
+	}{
+		{
+			name:     "Should accept domain when it has MX record",
+			resolver: resolver,
+			domain:   "gmail.com",
+		},
+		{
+			name:     "Should accept domain when it has only A record",
+			resolver: resolver,
+			domain:   "example.com",
+		},
+		{
+			name:       "Should reject domain when it has null MX record",
+			resolver:   resolver,
+			domain:     "nomail.com",
+			wantReason: ReasonNoMailServer,
+			wantErr:    true,
+		},
+		{
+			name:       "Should reject domain when it has neither MX nor A records",
+			resolver:   resolver,
+			domain:     "example.org",
+			wantReason: ReasonNoMailServer,
+			wantErr:    true,
+		},
+		{
+			name:       "Should reject domain when it doesn't exist",
+			resolver:   resolver,
+			domain:     "gmial.com",
+			wantReason: ReasonNoMailServer,
+			wantErr:    true,
+		},
+		{
+			name:     "Should return plain error when lookup failed temporarily",
+			resolver: fakeResolver{err: &net.DNSError{Err: "timeout", IsTimeout: true}},
+			domain:   "gmail.com",
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := NewMailServer(tt.resolver).Check(context.Background(), Address{Local: "test", Domain: tt.domain})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Check() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			var r *Rejection
+			if errors.As(err, &r) != (tt.wantReason != "") {
+				t.Fatalf("Check() error = %v, want rejection %v", err, tt.wantReason)
+			}
+			if r != nil && r.Reason != tt.wantReason {
+				t.Errorf("Check() reason = %v, want %v", r.Reason, tt.wantReason)
+			}
+		})
+	}
+}
@@ -0,0 +1,73 @@
+package validator
+
+import (
+	"context"
+	"net/mail"
+	"strings"
+)
+
+// Address is a parsed email, which is passed to the checks.
+// Both parts are lowercased.
+type Address struct {
+	Local  string
+	Domain string
+}
+
+// Check is a single step of the validation pipeline. It returns
+// *Rejection if email doesn't pass the check, or any other error if
+// check couldn't be done.
+type Check interface {
+	Check(ctx context.Context, addr Address) error
+}
+
+// CheckFunc is an adapter to use ordinary function as the Check.
+type CheckFunc func(ctx context.Context, addr Address) error
+
+// Check method calls f(ctx, addr).
+func (f CheckFunc) Check(ctx context.Context, addr Address) error {
+	return f(ctx, addr)
+}
+
+// NewPipeline constructs new Pipeline with provided checks. Checks
+// are run in the given order, so cheap ones should go first.
+// NOTE: without checks only syntax of the email is validated.
+func NewPipeline(checks ...Check) *Pipeline {
+	return &Pipeline{
+		syntax: NewStdlib(),
+		checks: checks,
+	}
+}
+
+// Pipeline validates syntax of the email and then runs
+// the checks until the first rejection.
+type Pipeline struct {
+	syntax *Stdlib
+	checks []Check
+}
+
+// Validate method returns *Rejection with the reason, if email
+// doesn't pass any of the checks.
+func (p *Pipeline) Validate(ctx context.Context, email string) error {
+	if !p.syntax.Validate(email) {
+		return reject(ReasonInvalidSyntax, "email %q is not valid", email)
+	}
+
+	m, err := mail.ParseAddress(email)
+	if err != nil {
+		return reject(ReasonInvalidSyntax, "email %q is not valid", email)
+	}
+
+	at := strings.LastIndexByte(m.Address, '@')
+	addr := Address{
+		Local:  strings.ToLower(m.Address[:at]),
+		Domain: strings.TrimSuffix(strings.ToLower(m.Address[at+1:]), "."),
+	}
+
+	for _, c := range p.checks {
+		if err := c.Check(ctx, addr); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
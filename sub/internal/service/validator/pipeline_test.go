@@ -0,0 +1,82 @@
+package validator
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestPipelineValidate(t *testing.T) {
+	t.Parallel()
+	errLookup := errors.New("failed to lookup")
+	tests := []struct {
+		name       string
+		checks     []Check
+		email      string
+		wantReason Reason
+		wantErr    error
+	}{
+		{
+			name:   "Should accept email when all checks passed",
+			checks: []Check{NewRoleAccount(DefaultRoles...), NewDisposable("mailinator.com")},
+			email:  "Alice@Gmail.com",
+		},
+		{
+			name:       "Should reject email when syntax is invalid",
+			checks:     []Check{NewRoleAccount(DefaultRoles...)},
+			email:      "alice.gmail.com",
+			wantReason: ReasonInvalidSyntax,
+		},
+		{
+			name:       "Should reject role account ignoring case and tag",
+			checks:     []Check{NewRoleAccount(DefaultRoles...)},
+			email:      "NoReply+news@example.com",
+			wantReason: ReasonRoleAccount,
+		},
+		{
+			name:       "Should reject email on the first failed check",
+			checks:     []Check{NewRoleAccount(DefaultRoles...), NewDisposable("mailinator.com")},
+			email:      "postmaster@mailinator.com",
+			wantReason: ReasonRoleAccount,
+		},
+		{
+			name: "Should pass lowercased address to the checks",
+			checks: []Check{CheckFunc(func(_ context.Context, addr Address) error {
+				if addr != (Address{Local: "alice", Domain: "gmail.com"}) {
+					return errors.New("unexpected address")
+				}
+				return nil
+			})},
+			email: "Alice@GMAIL.com",
+		},
+		{
+			name: "Should return error when check failed",
+			checks: []Check{CheckFunc(func(_ context.Context, _ Address) error {
+				return errLookup
+			})},
+			email:   "alice@gmail.com",
+			wantErr: errLookup,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := NewPipeline(tt.checks...).Validate(context.Background(), tt.email)
+
+			var r *Rejection
+			switch {
+			case tt.wantReason != "":
+				if !errors.As(err, &r) || r.Reason != tt.wantReason {
+					t.Errorf("Validate() error = %v, want %v rejection", err, tt.wantReason)
+				}
+			case tt.wantErr != nil:
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("Validate() error = %v, want %v", err, tt.wantErr)
+				}
+			case err != nil:
+				t.Errorf("Validate() error = %v, want nil", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,37 @@
+package validator
+
+import "fmt"
+
+// Reason is a machine-readable reason of the email rejection.
+type Reason string
+
+const (
+	// ReasonInvalidSyntax means email isn't a valid address.
+	ReasonInvalidSyntax Reason = "INVALID_SYNTAX"
+	// ReasonNoMailServer means email domain doesn't accept mail.
+	ReasonNoMailServer Reason = "NO_MAIL_SERVER"
+	// ReasonDisposableDomain means email belongs to the
+	// disposable (temporary) mail provider.
+	ReasonDisposableDomain Reason = "DISPOSABLE_DOMAIN"
+	// ReasonRoleAccount means email belongs to the role,
+	// not to the person, i.e. noreply@ or postmaster@.
+	ReasonRoleAccount Reason = "ROLE_ACCOUNT"
+)
+
+// Rejection is returned, when email doesn't pass one of the checks.
+type Rejection struct {
+	Reason Reason
+	Msg    string
+}
+
+// Error method returns human-readable message of the rejection.
+func (r *Rejection) Error() string {
+	return r.Msg
+}
+
+func reject(reason Reason, format string, args ...any) *Rejection {
+	return &Rejection{
+		Reason: reason,
+		Msg:    fmt.Sprintf(format, args...),
+	}
+}
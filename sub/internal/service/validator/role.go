@@ -0,0 +1,50 @@
+package validator
+
+import (
+	"context"
+	"strings"
+)
+
+// DefaultRoles are local parts of the common role accounts,
+// which are shared mailboxes or don't receive mail at all.
+var DefaultRoles = []string{
+	"abuse",
+	"do-not-reply",
+	"donotreply",
+	"hostmaster",
+	"mailer-daemon",
+	"no-reply",
+	"noreply",
+	"postmaster",
+	"webmaster",
+}
+
+// NewRoleAccount constructs new RoleAccount check, which rejects
+// emails with the given local parts.
+func NewRoleAccount(roles ...string) *RoleAccount {
+	set := make(map[string]struct{}, len(roles))
+	for _, r := range roles {
+		set[strings.ToLower(r)] = struct{}{}
+	}
+
+	return &RoleAccount{
+		roles: set,
+	}
+}
+
+// RoleAccount is a check, which rejects role accounts. Plus tag
+// is ignored, so noreply+news@ is a role account as well.
+type RoleAccount struct {
+	roles map[string]struct{}
+}
+
+// Check method returns *Rejection if local part of the
+// email is one of the roles.
+func (r *RoleAccount) Check(_ context.Context, addr Address) error {
+	local, _, _ := strings.Cut(addr.Local, "+")
+	if _, ok := r.roles[local]; ok {
+		return reject(ReasonRoleAccount, "%s@ is a role account, use personal email", local)
+	}
+
+	return nil
+}
@@ -0,0 +1,54 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/hrvadl/converter/sub/internal/service/webhook (interfaces: Poster)
+//
+// Generated by this command:
+//
+//	mockgen -destination=./mocks/mock_poster.go -package=mocks . Poster
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockPoster is a mock of Poster interface.
+type MockPoster struct {
+	ctrl     *gomock.Controller
+	recorder *MockPosterMockRecorder
+}
+
+// MockPosterMockRecorder is the mock recorder for MockPoster.
+type MockPosterMockRecorder struct {
+	mock *MockPoster
+}
+
+// NewMockPoster creates a new mock instance.
+func NewMockPoster(ctrl *gomock.Controller) *MockPoster {
+	mock := &MockPoster{ctrl: ctrl}
+	mock.recorder = &MockPosterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPoster) EXPECT() *MockPosterMockRecorder {
+	return m.recorder
+}
+
+// Post mocks base method.
+func (m *MockPoster) Post(arg0 context.Context, arg1, arg2, arg3 string, arg4 []byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Post", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Post indicates an expected call of Post.
+func (mr *MockPosterMockRecorder) Post(arg0, arg1, arg2, arg3, arg4 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Post", reflect.TypeOf((*MockPoster)(nil).Post), arg0, arg1, arg2, arg3, arg4)
+}
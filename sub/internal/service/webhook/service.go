@@ -0,0 +1,168 @@
+// Package webhook manages URLs, which receive the rates as signed
+// JSON payloads or chat messages, and delivers payloads to them.
+package webhook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/hrvadl/converter/sub/internal/storage/outbox"
+	"github.com/hrvadl/converter/sub/internal/storage/subscriber"
+	"github.com/hrvadl/converter/sub/internal/storage/webhook"
+)
+
+const operation = "webhook service"
+
+const (
+	// minSecretLength is a minimal length of the secret, so
+	// signatures couldn't be brute-forced.
+	minSecretLength = 16
+	// maxSecretLength is a length of the secret column.
+	maxSecretLength = 255
+	// maxURLLength is a length of the canonical email
+	// column without the channel prefix.
+	maxURLLength = 254 - len(subscriber.ChannelWebhook) - 1
+)
+
+var (
+	// ErrInvalidURL is returned when URL isn't an absolute HTTPS one.
+	ErrInvalidURL = errors.New("webhook url must be an absolute https url")
+	// ErrInvalidChannel is returned when channel isn't a webhook or chat one.
+	ErrInvalidChannel = errors.New("webhook channel must be webhook, slack or discord")
+	// ErrInvalidSecret is returned when secret is too short or too long.
+	ErrInvalidSecret = errors.New("webhook secret must be 16-255 characters long")
+	// ErrAlreadyRegistered is returned when URL is already registered.
+	ErrAlreadyRegistered = errors.New("webhook is already registered")
+	// ErrNotRegistered is returned when URL isn't registered.
+	ErrNotRegistered = errors.New("webhook is not registered")
+)
+
+// NewService constructs service with the provided webhook repo
+// and client, which posts the payloads.
+// NOTE: neither of arguments can't be nil, or service will panic.
+func NewService(r Repo, p Poster) *Service {
+	return &Service{
+		repo:   r,
+		poster: p,
+	}
+}
+
+//go:generate mockgen -destination=./mocks/mock_repo.go -package=mocks . Repo
+type Repo interface {
+	Save(ctx context.Context, w webhook.Webhook) (int64, error)
+	GetSecret(ctx context.Context, subscriberID int64) (string, error)
+	Delete(ctx context.Context, canonicalURL string) error
+}
+
+//go:generate mockgen -destination=./mocks/mock_poster.go -package=mocks . Poster
+type Poster interface {
+	Post(ctx context.Context, url, secret, id string, body []byte) error
+}
+
+// Service is a main structure, responsible for registering
+// webhooks and posting payloads to them.
+type Service struct {
+	repo   Repo
+	poster Poster
+}
+
+// Register method validates URL and secret, and saves webhook as the
+// daily subscriber of the given channel. Webhook without channel receives
+// signed JSON payloads. Slack and Discord webhooks receive chat messages,
+// their URL is a secret itself, so the secret is ignored. Webhook, which
+// has been unregistered before, is registered back with the new channel
+// and secret. Returns ID of the subscriber.
+// Returns ErrInvalidURL, ErrInvalidChannel or ErrInvalidSecret if arguments
+// are invalid, and ErrAlreadyRegistered if URL is already registered.
+func (s *Service) Register(ctx context.Context, rawURL, secret string, c subscriber.Channel) (int64, error) {
+	u, canonical, err := parse(rawURL)
+	if err != nil {
+		return 0, err
+	}
+
+	switch c {
+	case "", subscriber.ChannelWebhook:
+		c = subscriber.ChannelWebhook
+		if len(secret) < minSecretLength || len(secret) > maxSecretLength {
+			return 0, ErrInvalidSecret
+		}
+	case subscriber.ChannelSlack, subscriber.ChannelDiscord:
+		secret = ""
+	default:
+		return 0, ErrInvalidChannel
+	}
+
+	id, err := s.repo.Save(ctx, webhook.Webhook{Channel: c, URL: u, CanonicalURL: canonical, Secret: secret})
+	if errors.Is(err, webhook.ErrAlreadyExists) {
+		return 0, ErrAlreadyRegistered
+	}
+	if err != nil {
+		return 0, fmt.Errorf("%s: failed to save webhook: %w", operation, err)
+	}
+
+	return id, nil
+}
+
+// Unregister method stops posting payloads or messages to the URL
+// and forgets its secret. Returns ErrNotRegistered if URL isn't registered.
+func (s *Service) Unregister(ctx context.Context, rawURL string) error {
+	_, canonical, err := parse(rawURL)
+	if err != nil {
+		return err
+	}
+
+	err = s.repo.Delete(ctx, canonical)
+	if errors.Is(err, webhook.ErrNotFound) {
+		return ErrNotRegistered
+	}
+	if err != nil {
+		return fmt.Errorf("%s: failed to delete webhook: %w", operation, err)
+	}
+
+	return nil
+}
+
+// Send method posts payload of the notification to the webhook,
+// signed with its secret. ID of the notification identifies the payload,
+// so receiver could drop the retried ones, and it's returned as the
+// message ID. Returns ErrNotRegistered if webhook was unregistered
+// after the notification was enqueued.
+func (s *Service) Send(ctx context.Context, n outbox.Notification) (string, error) {
+	secret, err := s.repo.GetSecret(ctx, n.SubscriberID)
+	if errors.Is(err, webhook.ErrNotFound) {
+		return "", fmt.Errorf("%s: %w", operation, ErrNotRegistered)
+	}
+	if err != nil {
+		return "", fmt.Errorf("%s: failed to get secret: %w", operation, err)
+	}
+
+	id := strconv.FormatInt(n.ID, 10)
+	if err := s.poster.Post(ctx, n.Email, secret, id, []byte(n.Body)); err != nil {
+		return "", fmt.Errorf("%s: failed to post payload: %w", operation, err)
+	}
+
+	return id, nil
+}
+
+// parse validates webhook URL and returns it alongside its canonical
+// form, which is unique across subscribers. Scheme and host are
+// case-insensitive, and fragment is never sent, so they're normalised.
+func parse(rawURL string) (string, string, error) {
+	rawURL = strings.TrimSpace(rawURL)
+	u, err := url.Parse(rawURL)
+	if err != nil || !strings.EqualFold(u.Scheme, "https") || u.Host == "" || u.User != nil {
+		return "", "", ErrInvalidURL
+	}
+
+	u.Scheme, u.Host, u.Fragment = "https", strings.ToLower(u.Host), ""
+	normalized := u.String()
+	if len(normalized) > maxURLLength {
+		return "", "", ErrInvalidURL
+	}
+
+	return normalized, string(subscriber.ChannelWebhook) + ":" + normalized, nil
+}
@@ -0,0 +1,17 @@
+package dedup
+
+// Result is a summary of the canonicalisation of the subscribers.
+type Result struct {
+	// UpToDate means canonical emails were already
+	// computed with the same rules, so nothing was done.
+	UpToDate bool
+	// Updated is a number of the subscribers,
+	// which canonical email has changed.
+	Updated int64
+	// Merged is a number of the duplicates,
+	// which were merged into other subscribers.
+	Merged int64
+	// Invalid is a number of the subscribers, which emails couldn't be
+	// normalised, so their canonical emails were kept as is.
+	Invalid int64
+}
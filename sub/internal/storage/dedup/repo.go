@@ -0,0 +1,244 @@
+package dedup
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/hrvadl/converter/sub/internal/storage/platform/db"
+	"github.com/hrvadl/converter/sub/internal/storage/subscriber"
+)
+
+// Repo is a thin abstraction to not do sqlx queries directly
+// in the services. It recomputes canonical emails of the
+// subscribers and merges subscribers, which became duplicates.
+type Repo struct {
+	db *sqlx.DB
+}
+
+// NewRepo constructs repo with provided sqlx DB connection.
+// NOTE: db connection could be MySQL, PostgreSQL or SQLite one.
+func NewRepo(db *sqlx.DB) *Repo {
+	return &Repo{
+		db: db,
+	}
+}
+
+// row is a subscriber of the email channel with its recomputed canonical email.
+type row struct {
+	ID             int64             `db:"id"`
+	Email          string            `db:"email"`
+	CanonicalEmail string            `db:"canonical_email"`
+	Status         subscriber.Status `db:"status"`
+	canonical      string
+}
+
+// Canonicalize method recomputes canonical emails of the email subscribers
+// with normalize, unless they were already computed with the same rules.
+// Subscribers with the same canonical email are merged into the active
+// one, or into the oldest one if all of them are unsubscribed: history
+// and additional addresses of the duplicates are moved to it, pending
+// notifications of the duplicates are dropped, so the mailbox doesn't
+// receive the same mail twice. Emails, which couldn't be normalised, keep
+// their canonical email. Everything is done in a single transaction, and
+// the rules row is locked, so replicas starting at the same time don't
+// canonicalize subscribers twice.
+func (r *Repo) Canonicalize(
+	ctx context.Context,
+	rules string,
+	normalize func(email string) (string, error),
+) (Result, error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return Result{}, fmt.Errorf("failed to begin tx: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	var current string
+	err = tx.GetContext(
+		ctx,
+		&current,
+		"SELECT rules FROM canonical_rules WHERE id = 1"+db.DialectOf(tx).ForUpdate(),
+	)
+	if err != nil {
+		return Result{}, fmt.Errorf("failed to get canonical rules: %w", err)
+	}
+	if current == rules {
+		return Result{UpToDate: true}, nil
+	}
+
+	var rows []row
+	if err := tx.SelectContext(
+		ctx,
+		&rows,
+		tx.Rebind("SELECT id, email, canonical_email, status FROM subscribers WHERE channel = ? ORDER BY id"),
+		subscriber.ChannelEmail,
+	); err != nil {
+		return Result{}, fmt.Errorf("failed to get subscribers: %w", err)
+	}
+
+	var res Result
+	groups := make(map[string][]*row, len(rows))
+	order := make([]string, 0, len(rows))
+	for i := range rows {
+		s := &rows[i]
+		if s.canonical, err = normalize(s.Email); err != nil {
+			s.canonical = s.CanonicalEmail
+			res.Invalid++
+		}
+		if _, ok := groups[s.canonical]; !ok {
+			order = append(order, s.canonical)
+		}
+		groups[s.canonical] = append(groups[s.canonical], s)
+	}
+
+	var changed []*row
+	for _, canonical := range order {
+		survivor, duplicates := split(groups[canonical])
+		for _, d := range duplicates {
+			if err := merge(ctx, tx, d.ID, survivor.ID); err != nil {
+				return Result{}, fmt.Errorf("failed to merge subscriber %d into %d: %w", d.ID, survivor.ID, err)
+			}
+			res.Merged++
+		}
+		if survivor.canonical != survivor.CanonicalEmail {
+			changed = append(changed, survivor)
+		}
+	}
+
+	// Canonical emails are unique, so changed ones are moved aside
+	// first, otherwise new email of one subscriber could clash with
+	// the old email of another one, which isn't updated yet.
+	for _, s := range changed {
+		if _, err := exec(
+			ctx, tx, "UPDATE subscribers SET canonical_email = ? WHERE id = ?", fmt.Sprintf("canonicalizing:%d", s.ID), s.ID,
+		); err != nil {
+			return Result{}, fmt.Errorf("failed to reset canonical email: %w", err)
+		}
+	}
+	for _, s := range changed {
+		if _, err := exec(
+			ctx, tx, "UPDATE subscribers SET canonical_email = ? WHERE id = ?", s.canonical, s.ID,
+		); err != nil {
+			return Result{}, fmt.Errorf("failed to update canonical email: %w", err)
+		}
+		res.Updated++
+	}
+
+	if _, err := exec(
+		ctx, tx, "UPDATE canonical_rules SET rules = ?, updated_at = ? WHERE id = 1", rules, time.Now().UTC(),
+	); err != nil {
+		return Result{}, fmt.Errorf("failed to save canonical rules: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return Result{}, fmt.Errorf("failed to commit tx: %w", err)
+	}
+
+	return res, nil
+}
+
+// split returns subscriber, which the rest of the group is merged into:
+// the first active one, or the oldest one if all of them are unsubscribed.
+// Group is ordered by ID.
+func split(group []*row) (*row, []*row) {
+	survivor := group[0]
+	for _, s := range group {
+		if s.Status == subscriber.StatusActive {
+			survivor = s
+			break
+		}
+	}
+
+	duplicates := make([]*row, 0, len(group)-1)
+	for _, s := range group {
+		if s != survivor {
+			duplicates = append(duplicates, s)
+		}
+	}
+
+	return survivor, duplicates
+}
+
+// merge moves history and additional addresses of the duplicate to the
+// survivor and deletes the duplicate. Pending notifications of the
+// duplicate are dropped. Sent notifications of the days, which survivor
+// has its own notification of, and addresses of the channels, which
+// survivor has its own address of, are dropped too, since they'd clash
+// with the survivor's ones. Delivery history is kept entirely.
+func merge(ctx context.Context, tx *sqlx.Tx, duplicate, survivor int64) error {
+	if _, err := exec(
+		ctx, tx, "DELETE FROM outbox WHERE subscriber_id = ? AND status = 'pending'", duplicate,
+	); err != nil {
+		return fmt.Errorf("failed to delete pending notifications: %w", err)
+	}
+
+	var clashes []int64
+	if err := tx.SelectContext(
+		ctx,
+		&clashes,
+		tx.Rebind(`SELECT d.id FROM outbox d
+		JOIN outbox s ON s.subscriber_id = ? AND s.channel = d.channel AND s.run_date = d.run_date
+		WHERE d.subscriber_id = ?`),
+		survivor,
+		duplicate,
+	); err != nil {
+		return fmt.Errorf("failed to get clashing notifications: %w", err)
+	}
+	for _, id := range clashes {
+		if _, err := exec(ctx, tx, "DELETE FROM outbox WHERE id = ?", id); err != nil {
+			return fmt.Errorf("failed to delete clashing notification: %w", err)
+		}
+	}
+
+	if _, err := exec(
+		ctx, tx, "UPDATE outbox SET subscriber_id = ? WHERE subscriber_id = ?", survivor, duplicate,
+	); err != nil {
+		return fmt.Errorf("failed to move notifications: %w", err)
+	}
+
+	if _, err := exec(
+		ctx, tx, "UPDATE deliveries SET subscriber_id = ? WHERE subscriber_id = ?", survivor, duplicate,
+	); err != nil {
+		return fmt.Errorf("failed to move deliveries: %w", err)
+	}
+
+	var channels []subscriber.Channel
+	if err := tx.SelectContext(
+		ctx,
+		&channels,
+		tx.Rebind("SELECT channel FROM subscriber_addresses WHERE subscriber_id = ?"),
+		survivor,
+	); err != nil {
+		return fmt.Errorf("failed to get addresses: %w", err)
+	}
+	for _, ch := range channels {
+		if _, err := exec(
+			ctx, tx, "DELETE FROM subscriber_addresses WHERE subscriber_id = ? AND channel = ?", duplicate, ch,
+		); err != nil {
+			return fmt.Errorf("failed to delete clashing address: %w", err)
+		}
+	}
+
+	if _, err := exec(
+		ctx, tx, "UPDATE subscriber_addresses SET subscriber_id = ? WHERE subscriber_id = ?", survivor, duplicate,
+	); err != nil {
+		return fmt.Errorf("failed to move addresses: %w", err)
+	}
+
+	if _, err := exec(ctx, tx, "DELETE FROM subscribers WHERE id = ?", duplicate); err != nil {
+		return fmt.Errorf("failed to delete duplicate: %w", err)
+	}
+
+	return nil
+}
+
+func exec(ctx context.Context, tx *sqlx.Tx, query string, args ...any) (int64, error) {
+	res, err := tx.ExecContext(ctx, tx.Rebind(query), args...)
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
@@ -0,0 +1,191 @@
+package dedup
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/hrvadl/converter/sub/internal/service/canonical"
+	"github.com/hrvadl/converter/sub/internal/storage/platform/dbtest"
+)
+
+func TestNewRepo(t *testing.T) {
+	t.Parallel()
+	type args struct {
+		db *sqlx.DB
+	}
+	tests := []struct {
+		name string
+		args args
+	}{
+		{
+			name: "Should create repo with correct db conn",
+			args: args{
+				db: &sqlx.DB{},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := NewRepo(tt.args.db); got == nil {
+				t.Errorf("NewRepo() = %v, want not nil", got)
+			}
+		})
+	}
+}
+
+func TestRepoCanonicalize(t *testing.T) {
+	t.Parallel()
+	type subscriber struct {
+		ID             int64  `db:"id"`
+		CanonicalEmail string `db:"canonical_email"`
+	}
+	type notification struct {
+		ID           int64 `db:"id"`
+		SubscriberID int64 `db:"subscriber_id"`
+	}
+	tests := []struct {
+		name              string
+		normalizer        *canonical.Normalizer
+		want              Result
+		wantSubscribers   []subscriber
+		wantNotifications []notification
+		wantDeliveries    []int64
+		wantAddresses     []int64
+	}{
+		{
+			name:       "Should merge old gmail and international domain duplicates",
+			normalizer: canonical.New(canonical.Gmail),
+			want:       Result{Updated: 1, Merged: 2, Invalid: 1},
+			wantSubscribers: []subscriber{
+				{ID: 1, CanonicalEmail: "johndoe@gmail.com"},
+				{ID: 4, CanonicalEmail: "alice@xn--80aikifvh.xn--j1amh"},
+				{ID: 5, CanonicalEmail: "broken"},
+				{ID: 6, CanonicalEmail: "telegram:42"},
+			},
+			wantNotifications: []notification{{ID: 1, SubscriberID: 1}, {ID: 2, SubscriberID: 1}},
+			wantDeliveries:    []int64{1, 1},
+			wantAddresses:     []int64{1},
+		},
+		{
+			name:       "Should merge only international domain duplicates without provider rules",
+			normalizer: canonical.New(),
+			want:       Result{Merged: 1, Invalid: 1},
+			wantSubscribers: []subscriber{
+				{ID: 1, CanonicalEmail: "john.doe@gmail.com"},
+				{ID: 2, CanonicalEmail: "johndoe@gmail.com"},
+				{ID: 4, CanonicalEmail: "alice@xn--80aikifvh.xn--j1amh"},
+				{ID: 5, CanonicalEmail: "broken"},
+				{ID: 6, CanonicalEmail: "telegram:42"},
+			},
+			wantNotifications: []notification{
+				{ID: 1, SubscriberID: 1},
+				{ID: 2, SubscriberID: 1},
+				{ID: 3, SubscriberID: 2},
+			},
+			wantDeliveries: []int64{1, 2},
+			wantAddresses:  []int64{1, 2},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			dbtest.Run(t, func(t *testing.T, conn *sqlx.DB) {
+				exec := func(query string, args ...any) {
+					t.Helper()
+					if _, err := conn.Exec(conn.Rebind(query), args...); err != nil {
+						t.Fatalf("Failed to prepare data: %v", err)
+					}
+				}
+				for _, s := range []struct {
+					channel   string
+					email     string
+					canonical string
+					status    string
+				}{
+					{"email", "John.Doe@gmail.com", "john.doe@gmail.com", "active"},
+					{"email", "johndoe@gmail.com", "johndoe@gmail.com", "active"},
+					{"email", "alice@Приклад.укр", "alice@приклад.укр", "unsubscribed"},
+					{"email", "alice@xn--80aikifvh.xn--j1amh", "alice@xn--80aikifvh.xn--j1amh", "active"},
+					{"email", "broken", "broken", "active"},
+					{"telegram", "42", "telegram:42", "active"},
+				} {
+					exec(
+						"INSERT INTO subscribers (channel, email, canonical_email, status) VALUES (?, ?, ?, ?)",
+						s.channel, s.email, s.canonical, s.status,
+					)
+				}
+				for _, n := range []struct {
+					subscriberID int64
+					status       string
+					runDate      string
+				}{{1, "sent", "2024-05-01"}, {1, "pending", "2024-05-02"}, {2, "sent", "2024-05-01"}, {3, "pending", "2024-05-02"}} {
+					exec(
+						`INSERT INTO outbox (subscriber_id, email, subject, body, text, status, run_date)
+						VALUES (?, '', 'Rate', '', '', ?, ?)`,
+						n.subscriberID, n.status, n.runDate,
+					)
+				}
+				exec("INSERT INTO deliveries (notification_id, subscriber_id, email, status) VALUES (1, 1, '', 'sent')")
+				exec("INSERT INTO deliveries (notification_id, subscriber_id, email, status) VALUES (3, 2, '', 'sent')")
+				exec("INSERT INTO subscriber_addresses (subscriber_id, channel, address) VALUES (1, 'telegram', '42')")
+				exec("INSERT INTO subscriber_addresses (subscriber_id, channel, address) VALUES (2, 'telegram', '43')")
+
+				r := NewRepo(conn)
+				got, err := r.Canonicalize(context.Background(), tt.normalizer.Rules(), tt.normalizer.Normalize)
+				if err != nil {
+					t.Fatalf("Canonicalize() error = %v", err)
+				}
+				if got != tt.want {
+					t.Errorf("Canonicalize() = %+v, want %+v", got, tt.want)
+				}
+
+				var subscribers []subscriber
+				if err := conn.Select(&subscribers, "SELECT id, canonical_email FROM subscribers ORDER BY id"); err != nil {
+					t.Fatalf("Failed to get subscribers: %v", err)
+				}
+				if !reflect.DeepEqual(subscribers, tt.wantSubscribers) {
+					t.Errorf("Canonicalize() subscribers = %+v, want %+v", subscribers, tt.wantSubscribers)
+				}
+
+				var notifications []notification
+				if err := conn.Select(&notifications, "SELECT id, subscriber_id FROM outbox ORDER BY id"); err != nil {
+					t.Fatalf("Failed to get notifications: %v", err)
+				}
+				if !reflect.DeepEqual(notifications, tt.wantNotifications) {
+					t.Errorf("Canonicalize() notifications = %+v, want %+v", notifications, tt.wantNotifications)
+				}
+
+				var deliveries, addresses []int64
+				if err := conn.Select(&deliveries, "SELECT subscriber_id FROM deliveries ORDER BY id"); err != nil {
+					t.Fatalf("Failed to get deliveries: %v", err)
+				}
+				if !reflect.DeepEqual(deliveries, tt.wantDeliveries) {
+					t.Errorf("Canonicalize() deliveries = %v, want %v", deliveries, tt.wantDeliveries)
+				}
+				if err := conn.Select(&addresses, "SELECT subscriber_id FROM subscriber_addresses ORDER BY subscriber_id"); err != nil {
+					t.Fatalf("Failed to get addresses: %v", err)
+				}
+				if !reflect.DeepEqual(addresses, tt.wantAddresses) {
+					t.Errorf("Canonicalize() addresses = %v, want %v", addresses, tt.wantAddresses)
+				}
+
+				again, err := r.Canonicalize(context.Background(), tt.normalizer.Rules(), func(string) (string, error) {
+					return "", errors.New("should not be called")
+				})
+				if err != nil {
+					t.Fatalf("Canonicalize() error = %v", err)
+				}
+				if !again.UpToDate {
+					t.Errorf("Canonicalize() = %+v, want up to date with the same rules", again)
+				}
+			})
+		})
+	}
+}
@@ -0,0 +1,57 @@
+package delivery
+
+import "time"
+
+// Status represents the outcome of the delivery attempt.
+type Status string
+
+const (
+	// StatusSent means mail was accepted by the provider.
+	StatusSent Status = "sent"
+	// StatusFailed means mail couldn't be sent.
+	StatusFailed Status = "failed"
+)
+
+// Delivery is a model, which represents single attempt
+// to send the notification to the subscriber.
+type Delivery struct {
+	ID             int64     `db:"id"`
+	NotificationID int64     `db:"notification_id"`
+	SubscriberID   int64     `db:"subscriber_id"`
+	Email          string    `db:"email"`
+	ScheduledAt    time.Time `db:"scheduled_at"`
+	Rate           float32   `db:"rate"`
+	MessageID      string    `db:"message_id"`
+	Status         Status    `db:"status"`
+	Error          string    `db:"error"`
+	CreatedAt      time.Time `db:"created_at"`
+}
+
+// Filter represents criteria of the delivery history lookup.
+// Zero values are ignored. Deliveries are returned newest first,
+// BeforeID is an ID of the last delivery from the previous page.
+type Filter struct {
+	Email        string
+	SubscriberID int64
+	Owner        Owner
+	Status       Status
+	From         time.Time
+	To           time.Time
+	BeforeID     int64
+	Limit        int
+}
+
+// Owner is a person, whose deliveries are matched either by the email or
+// by the subscriber ID, so deliveries to the other addresses of the
+// subscriber and to the email before it was changed are matched too.
+type Owner struct {
+	Email        string
+	SubscriberID int64
+}
+
+// Page represents single page of the delivery history.
+// NextBeforeID is zero when there're no more deliveries.
+type Page struct {
+	Deliveries   []Delivery
+	NextBeforeID int64
+}
@@ -0,0 +1,114 @@
+package delivery
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/hrvadl/converter/sub/internal/storage/platform/db"
+)
+
+// maxErrorLength is a length of the error column.
+const maxErrorLength = 1024
+
+const columns = `id, notification_id, subscriber_id, email, scheduled_at,
+rate, message_id, status, error, created_at`
+
+// Repo is a thin abstraction to not do sqlx queries
+// directly in the services. It records every delivery attempt,
+// so support could investigate whether subscriber got the mail.
+type Repo struct {
+	db *sqlx.DB
+}
+
+// NewRepo constructs repo with provided sqlx DB connection.
+// NOTE: db connection could be MySQL, PostgreSQL or SQLite one.
+func NewRepo(db *sqlx.DB) *Repo {
+	return &Repo{
+		db: db,
+	}
+}
+
+// Save method records delivery attempt and returns its ID.
+func (r *Repo) Save(ctx context.Context, d Delivery) (int64, error) {
+	d.Error = db.Truncate(d.Error, maxErrorLength)
+
+	return db.Insert(
+		ctx,
+		r.db,
+		`INSERT INTO deliveries
+		(notification_id, subscriber_id, email, scheduled_at, rate, message_id, status, error)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+		d.NotificationID,
+		d.SubscriberID,
+		d.Email,
+		d.ScheduledAt.UTC(),
+		d.Rate,
+		d.MessageID,
+		d.Status,
+		d.Error,
+	)
+}
+
+// Get method returns at most limit deliveries matching the filter,
+// newest first.
+func (r *Repo) Get(ctx context.Context, f Filter) ([]Delivery, error) {
+	where, args := newFilter(f)
+	d := []Delivery{}
+	err := r.db.SelectContext(
+		ctx,
+		&d,
+		r.db.Rebind("SELECT "+columns+" FROM deliveries"+where+" ORDER BY id DESC LIMIT ?"),
+		append(args, f.Limit)...,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return d, nil
+}
+
+// newFilter builds WHERE clause with its arguments from
+// the non-zero fields of the filter.
+func newFilter(f Filter) (string, []any) {
+	var (
+		conds []string
+		args  []any
+	)
+
+	add := func(cond string, arg any) {
+		conds = append(conds, cond)
+		args = append(args, arg)
+	}
+
+	if f.Email != "" {
+		add("email = ?", f.Email)
+	}
+	if f.SubscriberID != 0 {
+		add("subscriber_id = ?", f.SubscriberID)
+	}
+	if f.Owner != (Owner{}) {
+		conds = append(conds, "(email = ? OR subscriber_id = ?)")
+		args = append(args, f.Owner.Email, f.Owner.SubscriberID)
+	}
+	if f.Status != "" {
+		add("status = ?", f.Status)
+	}
+	if !f.From.IsZero() {
+		add("created_at >= ?", f.From.UTC())
+	}
+	if !f.To.IsZero() {
+		add("created_at < ?", f.To.UTC())
+	}
+	if f.BeforeID != 0 {
+		add("id < ?", f.BeforeID)
+	}
+
+	if len(conds) == 0 {
+		return "", nil
+	}
+
+	return fmt.Sprintf(" WHERE %s", strings.Join(conds, " AND ")), args
+}
@@ -0,0 +1,160 @@
+package delivery
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/hrvadl/converter/sub/internal/storage/platform/dbtest"
+)
+
+func TestNewRepo(t *testing.T) {
+	t.Parallel()
+	type args struct {
+		db *sqlx.DB
+	}
+	tests := []struct {
+		name string
+		args args
+	}{
+		{
+			name: "Should create repo with correct db conn",
+			args: args{
+				db: &sqlx.DB{},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := NewRepo(tt.args.db); got == nil {
+				t.Errorf("NewRepo() = %v, want not nil", got)
+			}
+		})
+	}
+}
+
+func TestNewFilter(t *testing.T) {
+	t.Parallel()
+	from := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 5, 2, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name      string
+		f         Filter
+		wantWhere string
+		wantArgs  []any
+	}{
+		{
+			name:      "Should return empty clause when filter is empty",
+			f:         Filter{Limit: 10},
+			wantWhere: "",
+			wantArgs:  nil,
+		},
+		{
+			name:      "Should filter by email only",
+			f:         Filter{Email: "test@test.com"},
+			wantWhere: " WHERE email = ?",
+			wantArgs:  []any{"test@test.com"},
+		},
+		{
+			name:      "Should filter by email or subscriber of the owner",
+			f:         Filter{Owner: Owner{Email: "test@test.com", SubscriberID: 1}},
+			wantWhere: " WHERE (email = ? OR subscriber_id = ?)",
+			wantArgs:  []any{"test@test.com", int64(1)},
+		},
+		{
+			name: "Should join all conditions when all fields are set",
+			f: Filter{
+				Email:        "test@test.com",
+				SubscriberID: 1,
+				Status:       StatusFailed,
+				From:         from,
+				To:           to,
+				BeforeID:     100,
+			},
+			wantWhere: " WHERE email = ? AND subscriber_id = ? AND status = ?" +
+				" AND created_at >= ? AND created_at < ? AND id < ?",
+			wantArgs: []any{"test@test.com", int64(1), StatusFailed, from, to, int64(100)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			where, args := newFilter(tt.f)
+			if where != tt.wantWhere {
+				t.Errorf("newFilter() where = %v, want %v", where, tt.wantWhere)
+			}
+			if !reflect.DeepEqual(args, tt.wantArgs) {
+				t.Errorf("newFilter() args = %v, want %v", args, tt.wantArgs)
+			}
+		})
+	}
+}
+
+func TestRepoGet(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		f       Filter
+		wantIDs []int64
+	}{
+		{
+			name:    "Should return deliveries newest first",
+			f:       Filter{Limit: 10},
+			wantIDs: []int64{4, 3, 2, 1},
+		},
+		{
+			name:    "Should return deliveries of the subscriber with the given status",
+			f:       Filter{SubscriberID: 1, Status: StatusFailed, Limit: 10},
+			wantIDs: []int64{2},
+		},
+		{
+			name:    "Should return the next page of deliveries to the email",
+			f:       Filter{Email: "test@test.com", BeforeID: 3, Limit: 1},
+			wantIDs: []int64{2},
+		},
+		{
+			name:    "Should return deliveries to the email and to the other addresses of the subscriber",
+			f:       Filter{Owner: Owner{Email: "other@test.com", SubscriberID: 1}, Status: StatusFailed, Limit: 10},
+			wantIDs: []int64{4, 2},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			dbtest.Run(t, func(t *testing.T, conn *sqlx.DB) {
+				r := NewRepo(conn)
+				for _, d := range []Delivery{
+					{NotificationID: 1, SubscriberID: 1, Email: "test@test.com", Status: StatusSent},
+					{NotificationID: 2, SubscriberID: 1, Email: "test@test.com", Status: StatusFailed},
+					{NotificationID: 3, SubscriberID: 1, Email: "test@test.com", Status: StatusSent},
+					{NotificationID: 4, SubscriberID: 2, Email: "other@test.com", Status: StatusFailed},
+				} {
+					d.ScheduledAt = time.Now()
+					if _, err := r.Save(context.Background(), d); err != nil {
+						t.Fatalf("Save() error = %v", err)
+					}
+				}
+
+				got, err := r.Get(context.Background(), tt.f)
+				if err != nil {
+					t.Fatalf("Get() error = %v", err)
+				}
+
+				ids := make([]int64, 0, len(got))
+				for _, d := range got {
+					ids = append(ids, d.ID)
+				}
+				if !reflect.DeepEqual(ids, tt.wantIDs) {
+					t.Errorf("Get() returned ids %v, want %v", ids, tt.wantIDs)
+				}
+			})
+		})
+	}
+}
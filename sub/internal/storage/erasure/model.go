@@ -0,0 +1,14 @@
+package erasure
+
+import "time"
+
+// Audit is a model, which represents anonymised record of the
+// erasure. It doesn't contain any personal data of the erased
+// person, only the number of erased records.
+type Audit struct {
+	ID                   int64     `db:"id"`
+	SubscriberDeleted    bool      `db:"subscriber_deleted"`
+	NotificationsDeleted int64     `db:"notifications_deleted"`
+	DeliveriesDeleted    int64     `db:"deliveries_deleted"`
+	CreatedAt            time.Time `db:"created_at"`
+}
@@ -0,0 +1,109 @@
+package erasure
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/hrvadl/converter/sub/internal/storage/platform/db"
+)
+
+// Repo is a thin abstraction to not do sqlx queries
+// directly in the services. It erases all personal data
+// of the person and keeps anonymised audit of it.
+type Repo struct {
+	db *sqlx.DB
+}
+
+// NewRepo constructs repo with provided sqlx DB connection.
+// NOTE: db connection could be MySQL, PostgreSQL or SQLite one.
+func NewRepo(db *sqlx.DB) *Repo {
+	return &Repo{
+		db: db,
+	}
+}
+
+// Erase method deletes subscriber with the given canonical email, its
+// notifications from the outbox, delivery history, webhook secret and
+// additional channel addresses in a single transaction, and records
+// anonymised audit entry. Subscriber is matched by the canonical email,
+// so it's erased regardless of the spelling it was subscribed with.
+// History is matched both by email and subscriber ID, so history of the
+// email, which was changed, is erased as well.
+func (r *Repo) Erase(ctx context.Context, email, canonical string) (Audit, error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return Audit{}, fmt.Errorf("failed to begin tx: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	var id int64
+	err = tx.GetContext(
+		ctx,
+		&id,
+		tx.Rebind("SELECT id FROM subscribers WHERE canonical_email = ?"+db.DialectOf(tx).ForUpdate()),
+		canonical,
+	)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return Audit{}, fmt.Errorf("failed to get subscriber: %w", err)
+	}
+
+	audit := Audit{CreatedAt: time.Now().UTC()}
+	if audit.DeliveriesDeleted, err = exec(
+		ctx, tx, "DELETE FROM deliveries WHERE email = ? OR subscriber_id = ?", email, id,
+	); err != nil {
+		return Audit{}, fmt.Errorf("failed to delete deliveries: %w", err)
+	}
+
+	if audit.NotificationsDeleted, err = exec(
+		ctx, tx, "DELETE FROM outbox WHERE email = ? OR subscriber_id = ?", email, id,
+	); err != nil {
+		return Audit{}, fmt.Errorf("failed to delete notifications: %w", err)
+	}
+
+	if _, err = exec(ctx, tx, "DELETE FROM webhooks WHERE subscriber_id = ?", id); err != nil {
+		return Audit{}, fmt.Errorf("failed to delete webhook secret: %w", err)
+	}
+
+	if _, err = exec(ctx, tx, "DELETE FROM subscriber_addresses WHERE subscriber_id = ?", id); err != nil {
+		return Audit{}, fmt.Errorf("failed to delete subscriber addresses: %w", err)
+	}
+
+	deleted, err := exec(ctx, tx, "DELETE FROM subscribers WHERE canonical_email = ?", canonical)
+	if err != nil {
+		return Audit{}, fmt.Errorf("failed to delete subscriber: %w", err)
+	}
+	audit.SubscriberDeleted = deleted != 0
+
+	audit.ID, err = db.Insert(
+		ctx,
+		tx,
+		`INSERT INTO erasures (subscriber_deleted, notifications_deleted, deliveries_deleted, created_at)
+		VALUES (?, ?, ?, ?)`,
+		audit.SubscriberDeleted,
+		audit.NotificationsDeleted,
+		audit.DeliveriesDeleted,
+		audit.CreatedAt,
+	)
+	if err != nil {
+		return Audit{}, fmt.Errorf("failed to save audit: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return Audit{}, fmt.Errorf("failed to commit tx: %w", err)
+	}
+
+	return audit, nil
+}
+
+func exec(ctx context.Context, tx *sqlx.Tx, query string, args ...any) (int64, error) {
+	res, err := tx.ExecContext(ctx, tx.Rebind(query), args...)
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
@@ -0,0 +1,139 @@
+package erasure
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/hrvadl/converter/sub/internal/storage/platform/dbtest"
+)
+
+func TestNewRepo(t *testing.T) {
+	t.Parallel()
+	type args struct {
+		db *sqlx.DB
+	}
+	tests := []struct {
+		name string
+		args args
+	}{
+		{
+			name: "Should create repo with correct db conn",
+			args: args{
+				db: &sqlx.DB{},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := NewRepo(tt.args.db); got == nil {
+				t.Errorf("NewRepo() = %v, want not nil", got)
+			}
+		})
+	}
+}
+
+func TestRepoErase(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		email     string
+		canonical string
+		want      Audit
+		left      int
+		addresses int
+	}{
+		{
+			name:      "Should erase subscriber with its notifications and deliveries",
+			email:     "test@test.com",
+			canonical: "test@test.com",
+			want:      Audit{SubscriberDeleted: true, NotificationsDeleted: 2, DeliveriesDeleted: 1},
+			left:      2,
+		},
+		{
+			name:      "Should erase subscriber when email is spelled differently",
+			email:     "Test@Test.com",
+			canonical: "test@test.com",
+			want:      Audit{SubscriberDeleted: true, NotificationsDeleted: 2, DeliveriesDeleted: 1},
+			left:      2,
+		},
+		{
+			name:      "Should erase history of the email without subscriber",
+			email:     "old@test.com",
+			canonical: "old@test.com",
+			want:      Audit{NotificationsDeleted: 1},
+			left:      3,
+			addresses: 1,
+		},
+		{
+			name:      "Should record audit when there's nothing to erase",
+			email:     "unknown@test.com",
+			canonical: "unknown@test.com",
+			want:      Audit{},
+			left:      4,
+			addresses: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			dbtest.Run(t, func(t *testing.T, conn *sqlx.DB) {
+				exec := func(query string, args ...any) {
+					t.Helper()
+					if _, err := conn.Exec(conn.Rebind(query), args...); err != nil {
+						t.Fatalf("Failed to prepare data: %v", err)
+					}
+				}
+				exec("INSERT INTO subscribers (email, canonical_email) VALUES (?, ?)", "test@test.com", "test@test.com")
+				exec("INSERT INTO subscribers (email, canonical_email) VALUES (?, ?)", "other@test.com", "other@test.com")
+				for _, n := range []struct {
+					subscriberID int64
+					email        string
+				}{{1, "test@test.com"}, {1, "renamed@test.com"}, {2, "other@test.com"}, {3, "old@test.com"}} {
+					exec(
+						`INSERT INTO outbox (subscriber_id, email, subject, body, text)
+						VALUES (?, ?, 'Rate', '', '')`,
+						n.subscriberID,
+						n.email,
+					)
+				}
+				exec(
+					`INSERT INTO deliveries (notification_id, subscriber_id, email, status)
+					VALUES (1, 1, 'test@test.com', 'sent')`,
+				)
+				exec("INSERT INTO subscriber_addresses (subscriber_id, channel, address) VALUES (1, 'telegram', '42')")
+
+				got, err := NewRepo(conn).Erase(context.Background(), tt.email, tt.canonical)
+				if err != nil {
+					t.Fatalf("Erase() error = %v", err)
+				}
+				if got.ID == 0 || got.CreatedAt.IsZero() {
+					t.Errorf("Erase() = %v, want saved audit", got)
+				}
+				got.ID, got.CreatedAt = 0, tt.want.CreatedAt
+				if got != tt.want {
+					t.Errorf("Erase() = %v, want %v", got, tt.want)
+				}
+
+				var left int
+				if err := conn.Get(&left, "SELECT COUNT(*) FROM outbox"); err != nil {
+					t.Fatalf("Failed to count notifications: %v", err)
+				}
+				if left != tt.left {
+					t.Errorf("Erase() left %d notifications, want %d", left, tt.left)
+				}
+
+				if err := conn.Get(&left, "SELECT COUNT(*) FROM subscriber_addresses"); err != nil {
+					t.Fatalf("Failed to count addresses: %v", err)
+				}
+				if left != tt.addresses {
+					t.Errorf("Erase() left %d addresses, want %d", left, tt.addresses)
+				}
+			})
+		})
+	}
+}
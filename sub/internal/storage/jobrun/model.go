@@ -0,0 +1,42 @@
+package jobrun
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	// ErrNotFound is returned when there're no matching runs.
+	ErrNotFound = errors.New("run is not found")
+	// ErrDone is returned when the run has already been finished.
+	ErrDone = errors.New("run is already done")
+	// ErrHeld is returned when the run is leased by another owner.
+	ErrHeld = errors.New("run is held by another owner")
+	// ErrLeaseLost is returned when the lease has expired and
+	// run was taken over by another owner.
+	ErrLeaseLost = errors.New("lease is lost")
+)
+
+// Status represents the state of the job run.
+type Status string
+
+const (
+	// StatusRunning means run is leased by the owner and isn't finished yet.
+	StatusRunning Status = "running"
+	// StatusDone means run was successfully finished.
+	StatusDone Status = "done"
+)
+
+// Run is a model, which represents single daily run of the job.
+// Token is a fencing token, which is incremented every time the
+// lease is taken over, so stale owner can't finish the run.
+type Run struct {
+	Name       string    `db:"name"`
+	RunDate    time.Time `db:"run_date"`
+	Status     Status    `db:"status"`
+	Owner      string    `db:"owner"`
+	Token      int64     `db:"token"`
+	LeaseUntil time.Time `db:"lease_until"`
+	CreatedAt  time.Time `db:"created_at"`
+	UpdatedAt  time.Time `db:"updated_at"`
+}
@@ -0,0 +1,199 @@
+package jobrun
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/hrvadl/converter/sub/internal/storage/platform/db"
+)
+
+// Repo is a thin abstraction to not do sqlx queries
+// directly in the services. It coordinates job runs between
+// replicas, so each daily run is performed only once.
+type Repo struct {
+	db *sqlx.DB
+}
+
+// NewRepo constructs repo with provided sqlx DB connection.
+// NOTE: db connection could be MySQL, PostgreSQL or SQLite one.
+func NewRepo(db *sqlx.DB) *Repo {
+	return &Repo{
+		db: db,
+	}
+}
+
+// Acquire method leases the run of the job for the given day until ttl
+// expires and returns the fencing token. The run is taken over when the
+// previous lease has expired or it belongs to the same owner. Returns
+// ErrDone if the run has already been finished and ErrHeld if it's leased
+// by another owner.
+func (r *Repo) Acquire(
+	ctx context.Context,
+	name string,
+	day time.Time,
+	owner string,
+	ttl time.Duration,
+) (int64, error) {
+	now := time.Now().UTC()
+	day = truncateDay(day)
+
+	_, err := r.db.ExecContext(
+		ctx,
+		r.db.Rebind(`INSERT INTO job_runs (name, run_date, status, owner, token, lease_until, updated_at)
+		VALUES (?, ?, ?, ?, 1, ?, ?)`),
+		name,
+		day,
+		StatusRunning,
+		owner,
+		now.Add(ttl),
+		now,
+	)
+	if err == nil {
+		return 1, nil
+	}
+
+	if !db.DialectOf(r.db).IsUniqueViolation(err) {
+		return 0, fmt.Errorf("failed to insert run: %w", err)
+	}
+
+	res, err := r.db.ExecContext(
+		ctx,
+		r.db.Rebind(`UPDATE job_runs SET owner = ?, token = token + 1, lease_until = ?, updated_at = ?
+		WHERE name = ? AND run_date = ? AND status = ? AND (lease_until < ? OR owner = ?)`),
+		owner,
+		now.Add(ttl),
+		now,
+		name,
+		day,
+		StatusRunning,
+		now,
+		owner,
+	)
+	if err != nil {
+		return 0, fmt.Errorf("failed to take over run: %w", err)
+	}
+
+	run, err := r.Get(ctx, name, day)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get run: %w", err)
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get taken over runs: %w", err)
+	}
+
+	if n == 0 {
+		if run.Status == StatusDone {
+			return 0, ErrDone
+		}
+		return 0, ErrHeld
+	}
+
+	return run.Token, nil
+}
+
+// Complete method marks the run as done. Returns ErrLeaseLost if the
+// run was taken over by another owner, i.e the token is stale.
+func (r *Repo) Complete(ctx context.Context, name string, day time.Time, token int64) error {
+	res, err := r.db.ExecContext(
+		ctx,
+		r.db.Rebind(`UPDATE job_runs SET status = ?, updated_at = ?
+		WHERE name = ? AND run_date = ? AND status = ? AND token = ?`),
+		StatusDone,
+		time.Now().UTC(),
+		name,
+		truncateDay(day),
+		StatusRunning,
+		token,
+	)
+	if err != nil {
+		return err
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if n == 0 {
+		return ErrLeaseLost
+	}
+
+	return nil
+}
+
+// Renew method extends the lease of the run until ttl expires, so it isn't
+// taken over while the job is still running. Returns ErrLeaseLost if the
+// run was taken over by another owner or is already done, i.e the token is
+// stale.
+func (r *Repo) Renew(ctx context.Context, name string, day time.Time, token int64, ttl time.Duration) error {
+	now := time.Now().UTC()
+	res, err := r.db.ExecContext(
+		ctx,
+		r.db.Rebind(`UPDATE job_runs SET lease_until = ?, updated_at = ?
+		WHERE name = ? AND run_date = ? AND status = ? AND token = ?`),
+		now.Add(ttl),
+		now,
+		name,
+		truncateDay(day),
+		StatusRunning,
+		token,
+	)
+	if err != nil {
+		return err
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if n == 0 {
+		return ErrLeaseLost
+	}
+
+	return nil
+}
+
+// Get method returns the run of the job for the given day.
+func (r *Repo) Get(ctx context.Context, name string, day time.Time) (Run, error) {
+	var run Run
+	err := r.db.GetContext(
+		ctx,
+		&run,
+		r.db.Rebind(`SELECT name, run_date, status, owner, token, lease_until, created_at, updated_at
+		FROM job_runs WHERE name = ? AND run_date = ?`),
+		name,
+		truncateDay(day),
+	)
+	return run, err
+}
+
+// GetLastDone method returns the latest successfully finished
+// run of the job. Returns ErrNotFound if job has never been done.
+func (r *Repo) GetLastDone(ctx context.Context, name string) (Run, error) {
+	var run Run
+	err := r.db.GetContext(
+		ctx,
+		&run,
+		r.db.Rebind(`SELECT name, run_date, status, owner, token, lease_until, created_at, updated_at
+		FROM job_runs WHERE name = ? AND status = ? ORDER BY run_date DESC LIMIT 1`),
+		name,
+		StatusDone,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return Run{}, ErrNotFound
+	}
+	return run, err
+}
+
+func truncateDay(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,227 @@
+package jobrun
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/hrvadl/converter/sub/internal/storage/platform/dbtest"
+)
+
+func TestNewRepo(t *testing.T) {
+	t.Parallel()
+	type args struct {
+		db *sqlx.DB
+	}
+	tests := []struct {
+		name string
+		args args
+	}{
+		{
+			name: "Should create repo with correct db conn",
+			args: args{
+				db: &sqlx.DB{},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := NewRepo(tt.args.db); got == nil {
+				t.Errorf("NewRepo() = %v, want not nil", got)
+			}
+		})
+	}
+}
+
+func TestTruncateDay(t *testing.T) {
+	t.Parallel()
+	kyiv := time.FixedZone("Kyiv", 3*60*60)
+	tests := []struct {
+		name string
+		t    time.Time
+		want time.Time
+	}{
+		{
+			name: "Should truncate time to the start of the day",
+			t:    time.Date(2024, 5, 1, 12, 30, 15, 10, time.UTC),
+			want: time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "Should convert time to UTC before truncating",
+			t:    time.Date(2024, 5, 2, 1, 0, 0, 0, kyiv),
+			want: time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := truncateDay(tt.t); !got.Equal(tt.want) {
+				t.Errorf("truncateDay() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRepoAcquire(t *testing.T) {
+	t.Parallel()
+	const name = "daily"
+	day := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name      string
+		setup     func(t *testing.T, r *Repo)
+		owner     string
+		want      int64
+		wantErr   error
+		wantOwner string
+	}{
+		{
+			name:      "Should lease the run, which wasn't started",
+			owner:     "first",
+			want:      1,
+			wantOwner: "first",
+		},
+		{
+			name: "Should extend the lease of the same owner",
+			setup: func(t *testing.T, r *Repo) {
+				acquire(t, r, name, day, "first", time.Minute)
+			},
+			owner:     "first",
+			want:      2,
+			wantOwner: "first",
+		},
+		{
+			name: "Should not lease the run held by another owner",
+			setup: func(t *testing.T, r *Repo) {
+				acquire(t, r, name, day, "first", time.Minute)
+			},
+			owner:     "second",
+			wantErr:   ErrHeld,
+			wantOwner: "first",
+		},
+		{
+			name: "Should take over the run, which lease has expired",
+			setup: func(t *testing.T, r *Repo) {
+				acquire(t, r, name, day, "first", -time.Minute)
+			},
+			owner:     "second",
+			want:      2,
+			wantOwner: "second",
+		},
+		{
+			name: "Should not lease the run, which is done",
+			setup: func(t *testing.T, r *Repo) {
+				token := acquire(t, r, name, day, "first", -time.Minute)
+				if err := r.Complete(context.Background(), name, day, token); err != nil {
+					t.Fatalf("Failed to complete run: %v", err)
+				}
+			},
+			owner:     "second",
+			wantErr:   ErrDone,
+			wantOwner: "first",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			dbtest.Run(t, func(t *testing.T, conn *sqlx.DB) {
+				r := NewRepo(conn)
+				if tt.setup != nil {
+					tt.setup(t, r)
+				}
+
+				got, err := r.Acquire(context.Background(), name, day, tt.owner, time.Minute)
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("Acquire() error = %v, wantErr %v", err, tt.wantErr)
+				}
+				if got != tt.want {
+					t.Errorf("Acquire() = %v, want %v", got, tt.want)
+				}
+
+				run, err := r.Get(context.Background(), name, day)
+				if err != nil {
+					t.Fatalf("Get() error = %v", err)
+				}
+				if run.Owner != tt.wantOwner || !run.RunDate.Equal(truncateDay(day)) {
+					t.Errorf("Get() = %v, want run of %s on %v", run, tt.wantOwner, truncateDay(day))
+				}
+			})
+		})
+	}
+}
+
+func TestRepoComplete(t *testing.T) {
+	t.Parallel()
+	const name = "daily"
+	day := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	dbtest.Run(t, func(t *testing.T, conn *sqlx.DB) {
+		r := NewRepo(conn)
+		if _, err := r.GetLastDone(context.Background(), name); !errors.Is(err, ErrNotFound) {
+			t.Fatalf("GetLastDone() error = %v, want %v", err, ErrNotFound)
+		}
+
+		stale := acquire(t, r, name, day, "first", -time.Minute)
+		token := acquire(t, r, name, day, "second", time.Minute)
+		if err := r.Complete(context.Background(), name, day, stale); !errors.Is(err, ErrLeaseLost) {
+			t.Fatalf("Complete() with stale token error = %v, want %v", err, ErrLeaseLost)
+		}
+
+		if err := r.Complete(context.Background(), name, day, token); err != nil {
+			t.Fatalf("Complete() error = %v", err)
+		}
+
+		run, err := r.GetLastDone(context.Background(), name)
+		if err != nil {
+			t.Fatalf("GetLastDone() error = %v", err)
+		}
+		if run.Status != StatusDone || run.Owner != "second" || !run.RunDate.Equal(day) {
+			t.Errorf("GetLastDone() = %v, want run of second on %v", run, day)
+		}
+	})
+}
+
+func TestRepoRenew(t *testing.T) {
+	t.Parallel()
+	const name = "daily"
+	day := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	dbtest.Run(t, func(t *testing.T, conn *sqlx.DB) {
+		r := NewRepo(conn)
+		token := acquire(t, r, name, day, "first", time.Millisecond)
+		if err := r.Renew(context.Background(), name, day, token, time.Hour); err != nil {
+			t.Fatalf("Renew() error = %v", err)
+		}
+
+		time.Sleep(time.Millisecond * 5)
+		if _, err := r.Acquire(context.Background(), name, day, "second", time.Minute); !errors.Is(err, ErrHeld) {
+			t.Fatalf("Acquire() of the renewed run error = %v, want %v", err, ErrHeld)
+		}
+
+		stale := acquire(t, r, name, day, "first", -time.Minute)
+		token = acquire(t, r, name, day, "second", time.Minute)
+		if err := r.Renew(context.Background(), name, day, stale, time.Hour); !errors.Is(err, ErrLeaseLost) {
+			t.Fatalf("Renew() with stale token error = %v, want %v", err, ErrLeaseLost)
+		}
+
+		if err := r.Complete(context.Background(), name, day, token); err != nil {
+			t.Fatalf("Complete() error = %v", err)
+		}
+		if err := r.Renew(context.Background(), name, day, token, time.Hour); !errors.Is(err, ErrLeaseLost) {
+			t.Fatalf("Renew() of the done run error = %v, want %v", err, ErrLeaseLost)
+		}
+	})
+}
+
+func acquire(t *testing.T, r *Repo, name string, day time.Time, owner string, ttl time.Duration) int64 {
+	t.Helper()
+	token, err := r.Acquire(context.Background(), name, day, owner, ttl)
+	if err != nil {
+		t.Fatalf("Failed to acquire run: %v", err)
+	}
+	return token
+}
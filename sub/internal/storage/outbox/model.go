@@ -0,0 +1,42 @@
+package outbox
+
+import (
+	"time"
+
+	"github.com/hrvadl/converter/sub/internal/storage/subscriber"
+)
+
+// Status represents the state of the notification in the outbox.
+type Status string
+
+const (
+	// StatusPending means notification is waiting to be delivered.
+	StatusPending Status = "pending"
+	// StatusSent means notification was successfully delivered.
+	StatusSent Status = "sent"
+	// StatusDead means notification exceeded maximum number of attempts
+	// and won't be delivered until it's requeued.
+	StatusDead Status = "dead"
+)
+
+// Notification is a model, which represents mail
+// persisted to the outbox before it's sent to the subscriber.
+// Subscriber gets at most one notification per RunDate.
+// Email is an address in the Channel, i.e. chat ID for Telegram.
+type Notification struct {
+	ID            int64              `db:"id"`
+	SubscriberID  int64              `db:"subscriber_id"`
+	Channel       subscriber.Channel `db:"channel"`
+	Email         string             `db:"email"`
+	Subject       string             `db:"subject"`
+	Body          string             `db:"body"`
+	Text          string             `db:"text"`
+	Rate          float32            `db:"rate"`
+	Status        Status             `db:"status"`
+	Attempts      int                `db:"attempts"`
+	LastError     string             `db:"last_error"`
+	RunDate       time.Time          `db:"run_date"`
+	NextAttemptAt time.Time          `db:"next_attempt_at"`
+	CreatedAt     time.Time          `db:"created_at"`
+	UpdatedAt     time.Time          `db:"updated_at"`
+}
@@ -0,0 +1,271 @@
+package outbox
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/hrvadl/converter/sub/internal/storage/platform/db"
+	"github.com/hrvadl/converter/sub/internal/storage/subscriber"
+)
+
+// maxErrorLength is a length of the last_error column.
+const maxErrorLength = 1024
+
+// columns doesn't include run_date, since it's used only to
+// deduplicate notifications and it's empty for the old ones.
+const columns = `id, subscriber_id, channel, email, subject, body, text, rate, status,
+attempts, last_error, next_attempt_at, created_at, updated_at`
+
+// Repo is a thin abstraction to not do sqlx queries
+// directly in the services. It persists notifications before
+// they're sent, so they're not lost if sending has failed.
+type Repo struct {
+	db *sqlx.DB
+}
+
+// NewRepo constructs repo with provided sqlx DB connection.
+// NOTE: db connection could be MySQL, PostgreSQL or SQLite one.
+func NewRepo(db *sqlx.DB) *Repo {
+	return &Repo{
+		db: db,
+	}
+}
+
+// Save method persists all notifications in a single transaction,
+// so either all of them are saved or none. Notifications, which were
+// already saved for the same subscriber, channel and run date, are
+// skipped, so the run could be safely repeated. Notifications without
+// channel are saved as the email ones. Returns number of saved
+// notifications.
+func (r *Repo) Save(ctx context.Context, n []Notification) (int, error) {
+	if len(n) == 0 {
+		return 0, nil
+	}
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin tx: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	stmt, err := tx.PreparexContext(
+		ctx,
+		tx.Rebind(`INSERT INTO outbox (subscriber_id, channel, email, subject, body, text, rate, run_date, next_attempt_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`+onDuplicateSkip(db.DialectOf(tx))),
+	)
+	if err != nil {
+		return 0, fmt.Errorf("failed to prepare statement: %w", err)
+	}
+	defer stmt.Close()
+
+	var saved int
+	for i := range n {
+		res, err := stmt.ExecContext(
+			ctx,
+			n[i].SubscriberID,
+			channel(n[i].Channel),
+			n[i].Email,
+			n[i].Subject,
+			n[i].Body,
+			n[i].Text,
+			n[i].Rate,
+			n[i].RunDate.UTC(),
+			n[i].NextAttemptAt.UTC(),
+		)
+		if err != nil {
+			return 0, fmt.Errorf("failed to save notification: %w", err)
+		}
+
+		affected, err := res.RowsAffected()
+		if err != nil {
+			return 0, fmt.Errorf("failed to get affected rows: %w", err)
+		}
+		saved += int(affected)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit tx: %w", err)
+	}
+
+	return saved, nil
+}
+
+// GetPending method gets at most limit pending notifications,
+// which should be attempted at the given point of time or earlier.
+func (r *Repo) GetPending(ctx context.Context, at time.Time, limit int) ([]Notification, error) {
+	n := []Notification{}
+	err := r.db.SelectContext(
+		ctx,
+		&n,
+		r.db.Rebind("SELECT "+columns+` FROM outbox
+		WHERE status = ? AND next_attempt_at <= ? ORDER BY id LIMIT ?`),
+		StatusPending,
+		at.UTC(),
+		limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return n, nil
+}
+
+// Claim method gets at most limit pending notifications, which should be
+// attempted at the given point of time or earlier, and postpones their next
+// attempt until the given point of time. This way notification is sent by
+// a single replica, and if that replica crashes, it's picked up again after
+// the claim expires. Notifications claimed by another replica in between
+// are skipped.
+func (r *Repo) Claim(ctx context.Context, at, until time.Time, limit int) ([]Notification, error) {
+	pending, err := r.GetPending(ctx, at, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pending notifications: %w", err)
+	}
+
+	claimed := pending[:0]
+	for i := range pending {
+		res, err := r.db.ExecContext(
+			ctx,
+			r.db.Rebind(`UPDATE outbox SET next_attempt_at = ?, updated_at = ?
+			WHERE id = ? AND status = ? AND next_attempt_at <= ?`),
+			until.UTC(),
+			time.Now().UTC(),
+			pending[i].ID,
+			StatusPending,
+			at.UTC(),
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to claim notification: %w", err)
+		}
+
+		if n, err := res.RowsAffected(); err == nil && n == 1 {
+			claimed = append(claimed, pending[i])
+		}
+	}
+
+	return claimed, nil
+}
+
+// MarkSent method marks notification as successfully delivered.
+func (r *Repo) MarkSent(ctx context.Context, id int64) error {
+	_, err := r.db.ExecContext(
+		ctx,
+		r.db.Rebind("UPDATE outbox SET status = ?, attempts = attempts + 1, last_error = '', updated_at = ? WHERE id = ?"),
+		StatusSent,
+		time.Now().UTC(),
+		id,
+	)
+	return err
+}
+
+// Reschedule method records failed attempt and schedules the
+// next one at the given point of time.
+func (r *Repo) Reschedule(ctx context.Context, id int64, next time.Time, cause error) error {
+	_, err := r.db.ExecContext(
+		ctx,
+		r.db.Rebind(`UPDATE outbox SET attempts = attempts + 1, last_error = ?, next_attempt_at = ?, updated_at = ?
+		WHERE id = ?`),
+		db.Truncate(cause.Error(), maxErrorLength),
+		next.UTC(),
+		time.Now().UTC(),
+		id,
+	)
+	return err
+}
+
+// MarkDead method records failed attempt and moves notification
+// to the dead-letter state, so it won't be attempted anymore.
+func (r *Repo) MarkDead(ctx context.Context, id int64, cause error) error {
+	_, err := r.db.ExecContext(
+		ctx,
+		r.db.Rebind("UPDATE outbox SET status = ?, attempts = attempts + 1, last_error = ?, updated_at = ? WHERE id = ?"),
+		StatusDead,
+		db.Truncate(cause.Error(), maxErrorLength),
+		time.Now().UTC(),
+		id,
+	)
+	return err
+}
+
+// GetDead method gets at most limit dead-lettered notifications
+// with ID greater than afterID, ordered by ID.
+func (r *Repo) GetDead(ctx context.Context, afterID int64, limit int) ([]Notification, error) {
+	n := []Notification{}
+	err := r.db.SelectContext(
+		ctx,
+		&n,
+		r.db.Rebind("SELECT "+columns+" FROM outbox WHERE status = ? AND id > ? ORDER BY id LIMIT ?"),
+		StatusDead,
+		afterID,
+		limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return n, nil
+}
+
+// GetByOwner method gets all notifications to the given email or to the
+// subscriber with the given ID, i.e. to its other addresses, ordered by ID.
+func (r *Repo) GetByOwner(ctx context.Context, email string, subscriberID int64) ([]Notification, error) {
+	n := []Notification{}
+	err := r.db.SelectContext(
+		ctx,
+		&n,
+		r.db.Rebind("SELECT "+columns+" FROM outbox WHERE email = ? OR subscriber_id = ? ORDER BY id"),
+		email,
+		subscriberID,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return n, nil
+}
+
+// Requeue method moves dead-lettered notifications with given IDs
+// back to the pending state and resets their attempts. If there're no
+// IDs, all dead-lettered notifications are requeued. Returns number of
+// requeued notifications.
+func (r *Repo) Requeue(ctx context.Context, ids ...int64) (int64, error) {
+	now := time.Now().UTC()
+	query, args := `UPDATE outbox SET status = ?, attempts = 0, next_attempt_at = ?, updated_at = ?
+	WHERE status = ?`, []any{StatusPending, now, now, StatusDead}
+
+	if len(ids) != 0 {
+		var err error
+		query, args, err = sqlx.In(query+" AND id IN (?)", append(args, ids)...)
+		if err != nil {
+			return 0, fmt.Errorf("failed to build query: %w", err)
+		}
+	}
+
+	res, err := r.db.ExecContext(ctx, r.db.Rebind(query), args...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+}
+
+// onDuplicateSkip returns clause, which skips notification already saved
+// for the same subscriber, channel and run date, so it's not counted as affected.
+func onDuplicateSkip(d db.Dialect) string {
+	if d == db.MySQL {
+		return " ON DUPLICATE KEY UPDATE id = id"
+	}
+	return " ON CONFLICT (subscriber_id, channel, run_date) DO NOTHING"
+}
+
+// channel returns channel of the notification,
+// falling back to the email one when it's empty.
+func channel(c subscriber.Channel) subscriber.Channel {
+	if c == "" {
+		return subscriber.ChannelEmail
+	}
+	return c
+}
@@ -0,0 +1,270 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/hrvadl/converter/sub/internal/storage/platform/dbtest"
+	"github.com/hrvadl/converter/sub/internal/storage/subscriber"
+)
+
+func TestNewRepo(t *testing.T) {
+	t.Parallel()
+	type args struct {
+		db *sqlx.DB
+	}
+	tests := []struct {
+		name string
+		args args
+	}{
+		{
+			name: "Should create repo with correct db conn",
+			args: args{
+				db: &sqlx.DB{},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := NewRepo(tt.args.db); got == nil {
+				t.Errorf("NewRepo() = %v, want not nil", got)
+			}
+		})
+	}
+}
+
+func TestRepoSave(t *testing.T) {
+	t.Parallel()
+	day := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name  string
+		saved []Notification
+		n     []Notification
+		want  int
+	}{
+		{
+			name: "Should save all notifications",
+			n:    []Notification{newNotification(1, day), newNotification(2, day)},
+			want: 2,
+		},
+		{
+			name:  "Should skip notifications saved for the same subscriber and run date",
+			saved: []Notification{newNotification(1, day)},
+			n: []Notification{
+				newNotification(1, day),
+				newNotification(1, day.AddDate(0, 0, 1)),
+				newNotification(2, day),
+			},
+			want: 2,
+		},
+		{
+			name:  "Should save notifications to other channels of the same subscriber",
+			saved: []Notification{newNotification(1, day)},
+			n: []Notification{
+				newNotification(1, day),
+				func() Notification {
+					n := newNotification(1, day)
+					n.Channel, n.Email = subscriber.ChannelTelegram, "42"
+					return n
+				}(),
+			},
+			want: 1,
+		},
+		{
+			name: "Should save nothing when there're no notifications",
+			want: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			dbtest.Run(t, func(t *testing.T, conn *sqlx.DB) {
+				r := NewRepo(conn)
+				if _, err := r.Save(context.Background(), tt.saved); err != nil {
+					t.Fatalf("Failed to save notifications: %v", err)
+				}
+
+				got, err := r.Save(context.Background(), tt.n)
+				if err != nil {
+					t.Fatalf("Save() error = %v", err)
+				}
+				if got != tt.want {
+					t.Errorf("Save() = %v, want %v", got, tt.want)
+				}
+			})
+		})
+	}
+}
+
+func TestRepoGetByOwner(t *testing.T) {
+	t.Parallel()
+	day := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name         string
+		email        string
+		subscriberID int64
+		wantIDs      []int64
+	}{
+		{
+			name:         "Should get notifications to the email and to the other addresses of the subscriber",
+			email:        "test@test.com",
+			subscriberID: 2,
+			wantIDs:      []int64{1, 3, 4},
+		},
+		{
+			name:    "Should get notifications to the email without subscriber",
+			email:   "test@test.com",
+			wantIDs: []int64{1},
+		},
+		{
+			name:    "Should get nothing when there're no notifications",
+			email:   "unknown@test.com",
+			wantIDs: []int64{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			dbtest.Run(t, func(t *testing.T, conn *sqlx.DB) {
+				r := NewRepo(conn)
+				other := newNotification(3, day)
+				other.Email = "other@test.com"
+				renamed := newNotification(2, day)
+				renamed.Email = "renamed@test.com"
+				chat := newNotification(2, day)
+				chat.Channel, chat.Email = subscriber.ChannelTelegram, "42"
+				if _, err := r.Save(context.Background(), []Notification{newNotification(1, day), other, renamed, chat}); err != nil {
+					t.Fatalf("Failed to save notifications: %v", err)
+				}
+
+				got, err := r.GetByOwner(context.Background(), tt.email, tt.subscriberID)
+				if err != nil {
+					t.Fatalf("GetByOwner() error = %v", err)
+				}
+
+				ids := make([]int64, 0, len(got))
+				for _, n := range got {
+					ids = append(ids, n.ID)
+				}
+				if !reflect.DeepEqual(ids, tt.wantIDs) {
+					t.Errorf("GetByOwner() returned ids %v, want %v", ids, tt.wantIDs)
+				}
+			})
+		})
+	}
+}
+
+func TestRepoClaim(t *testing.T) {
+	t.Parallel()
+	day := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	dbtest.Run(t, func(t *testing.T, conn *sqlx.DB) {
+		r := NewRepo(conn)
+		due, later := newNotification(1, day), newNotification(2, day)
+		later.NextAttemptAt = day.Add(time.Hour)
+		later.Channel = subscriber.ChannelTelegram
+		if _, err := r.Save(context.Background(), []Notification{due, later}); err != nil {
+			t.Fatalf("Failed to save notifications: %v", err)
+		}
+
+		claimed, err := r.Claim(context.Background(), day, day.Add(time.Minute), 10)
+		if err != nil {
+			t.Fatalf("Claim() error = %v", err)
+		}
+		if len(claimed) != 1 || claimed[0].SubscriberID != due.SubscriberID {
+			t.Fatalf("Claim() = %v, want notification of subscriber %d", claimed, due.SubscriberID)
+		}
+		if claimed[0].Channel != subscriber.ChannelEmail {
+			t.Errorf("Claim() channel = %v, want %v", claimed[0].Channel, subscriber.ChannelEmail)
+		}
+
+		again, err := r.Claim(context.Background(), day, day.Add(time.Minute), 10)
+		if err != nil {
+			t.Fatalf("Claim() error = %v", err)
+		}
+		if len(again) != 0 {
+			t.Errorf("Claim() = %v, want claimed notification to be skipped", again)
+		}
+
+		if err := r.MarkSent(context.Background(), claimed[0].ID); err != nil {
+			t.Fatalf("MarkSent() error = %v", err)
+		}
+
+		pending, err := r.GetPending(context.Background(), day.Add(time.Hour), 10)
+		if err != nil {
+			t.Fatalf("GetPending() error = %v", err)
+		}
+		if len(pending) != 1 || pending[0].SubscriberID != later.SubscriberID {
+			t.Fatalf("GetPending() = %v, want notification of subscriber %d", pending, later.SubscriberID)
+		}
+		if pending[0].Channel != subscriber.ChannelTelegram {
+			t.Errorf("GetPending() channel = %v, want %v", pending[0].Channel, subscriber.ChannelTelegram)
+		}
+	})
+}
+
+func TestRepoRequeue(t *testing.T) {
+	t.Parallel()
+	day := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	dbtest.Run(t, func(t *testing.T, conn *sqlx.DB) {
+		r := NewRepo(conn)
+		n := []Notification{newNotification(1, day), newNotification(2, day)}
+		if _, err := r.Save(context.Background(), n); err != nil {
+			t.Fatalf("Failed to save notifications: %v", err)
+		}
+
+		pending, err := r.GetPending(context.Background(), day, 10)
+		if err != nil {
+			t.Fatalf("GetPending() error = %v", err)
+		}
+		for _, p := range pending {
+			if err := r.MarkDead(context.Background(), p.ID, errors.New("rejected")); err != nil {
+				t.Fatalf("MarkDead() error = %v", err)
+			}
+		}
+
+		dead, err := r.GetDead(context.Background(), 0, 10)
+		if err != nil {
+			t.Fatalf("GetDead() error = %v", err)
+		}
+		if len(dead) != 2 || dead[0].LastError != "rejected" || dead[0].Attempts != 1 {
+			t.Fatalf("GetDead() = %v, want 2 dead notifications", dead)
+		}
+
+		requeued, err := r.Requeue(context.Background(), dead[0].ID)
+		if err != nil {
+			t.Fatalf("Requeue() error = %v", err)
+		}
+		if requeued != 1 {
+			t.Errorf("Requeue() = %v, want 1", requeued)
+		}
+
+		if requeued, err = r.Requeue(context.Background()); err != nil {
+			t.Fatalf("Requeue() error = %v", err)
+		}
+		if requeued != 1 {
+			t.Errorf("Requeue() of all = %v, want 1", requeued)
+		}
+	})
+}
+
+func newNotification(subscriberID int64, runDate time.Time) Notification {
+	return Notification{
+		SubscriberID:  subscriberID,
+		Email:         "test@test.com",
+		Subject:       "Rate",
+		Body:          "<p>41.5</p>",
+		Text:          "41.5",
+		Rate:          41.5,
+		RunDate:       runDate,
+		NextAttemptAt: runDate,
+	}
+}
@@ -1,3 +0,0 @@
-package db
-
-const AlreadyExistsErrCode = 1062
@@ -0,0 +1,77 @@
+package db
+
+import (
+	"context"
+
+	"github.com/jmoiron/sqlx"
+)
+
+func Must(db *sqlx.DB, err error) *sqlx.DB {
+	if err != nil {
+		panic(err)
+	}
+	return db
+}
+
+// NewConn connects to the database. Dialect of the database is
+// detected from the DSN, see ParseDSN.
+func NewConn(dsn string) (*sqlx.DB, error) {
+	d, dsn, err := ParseDSN(dsn)
+	if err != nil {
+		return nil, err
+	}
+
+	return connect(d, dsn)
+}
+
+// NewMigrationConn opens connection, which allows multiple
+// statements in a single query, since migration scripts
+// contain several statements.
+func NewMigrationConn(dsn string) (*sqlx.DB, error) {
+	d, dsn, err := ParseDSN(dsn)
+	if err != nil {
+		return nil, err
+	}
+
+	if d == MySQL {
+		if dsn, err = withMultiStatements(dsn); err != nil {
+			return nil, err
+		}
+	}
+
+	return connect(d, dsn)
+}
+
+func connect(d Dialect, dsn string) (*sqlx.DB, error) {
+	db, err := sqlx.Connect(string(d), dsn)
+	if err != nil {
+		return nil, err
+	}
+
+	// SQLite allows a single writer at a time, so concurrent
+	// writes are queued by the pool instead of failing as busy.
+	if d == SQLite {
+		db.SetMaxOpenConns(1)
+	}
+
+	return db, nil
+}
+
+// Insert executes INSERT query with ? placeholders and returns
+// ID of the inserted row. PostgreSQL doesn't report last insert ID,
+// so the ID is returned by the query itself there.
+func Insert(ctx context.Context, e sqlx.ExtContext, query string, args ...any) (int64, error) {
+	query = e.Rebind(query)
+	if DialectOf(e) == Postgres {
+		var id int64
+		err := sqlx.GetContext(ctx, e, &id, query+" RETURNING id", args...)
+		return id, err
+	}
+
+	res, err := e.ExecContext(ctx, query, args...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.LastInsertId()
+}
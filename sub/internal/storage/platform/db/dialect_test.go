@@ -0,0 +1,134 @@
+package db
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/lib/pq"
+)
+
+func TestParseDSN(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name        string
+		dsn         string
+		wantDialect Dialect
+		wantDSN     string
+		wantErr     bool
+	}{
+		{
+			name:        "Should treat DSN without scheme as MySQL one",
+			dsn:         "root:pass@(db:3306)/converter?parseTime=true",
+			wantDialect: MySQL,
+			wantDSN:     "root:pass@(db:3306)/converter?parseTime=true",
+		},
+		{
+			name:        "Should strip mysql scheme",
+			dsn:         "mysql://root:pass@(db:3306)/converter",
+			wantDialect: MySQL,
+			wantDSN:     "root:pass@(db:3306)/converter",
+		},
+		{
+			name:        "Should detect PostgreSQL and set UTC time zone",
+			dsn:         "postgres://user:pass@db:5432/converter?sslmode=disable",
+			wantDialect: Postgres,
+			wantDSN:     "postgres://user:pass@db:5432/converter?sslmode=disable&timezone=UTC",
+		},
+		{
+			name:        "Should keep time zone of PostgreSQL set explicitly",
+			dsn:         "postgresql://db/converter?timezone=Europe%2FKyiv",
+			wantDialect: Postgres,
+			wantDSN:     "postgresql://db/converter?timezone=Europe%2FKyiv",
+		},
+		{
+			name:        "Should detect SQLite and configure connection",
+			dsn:         "sqlite:///var/lib/sub/sub.db",
+			wantDialect: SQLite,
+			wantDSN:     "/var/lib/sub/sub.db?_pragma=busy_timeout%285000%29&_time_format=sqlite&_txlock=immediate",
+		},
+		{
+			name:        "Should keep SQLite parameters set explicitly",
+			dsn:         "sqlite://sub.db?_txlock=deferred&_pragma=busy_timeout(100)",
+			wantDialect: SQLite,
+			wantDSN:     "sub.db?_pragma=busy_timeout%28100%29&_time_format=sqlite&_txlock=deferred",
+		},
+		{
+			name:    "Should return error when DSN is empty",
+			dsn:     "",
+			wantErr: true,
+		},
+		{
+			name:    "Should return error when SQLite path is empty",
+			dsn:     "sqlite://",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			d, dsn, err := ParseDSN(tt.dsn)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseDSN() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if d != tt.wantDialect {
+				t.Errorf("ParseDSN() dialect = %v, want %v", d, tt.wantDialect)
+			}
+			if dsn != tt.wantDSN {
+				t.Errorf("ParseDSN() dsn = %v, want %v", dsn, tt.wantDSN)
+			}
+		})
+	}
+}
+
+func TestDialectIsUniqueViolation(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		dialect Dialect
+		err     error
+		want    bool
+	}{
+		{
+			name:    "Should detect MySQL duplicate entry",
+			dialect: MySQL,
+			err:     fmt.Errorf("failed: %w", &mysql.MySQLError{Number: 1062}),
+			want:    true,
+		},
+		{
+			name:    "Should not detect other MySQL errors",
+			dialect: MySQL,
+			err:     &mysql.MySQLError{Number: 1064},
+			want:    false,
+		},
+		{
+			name:    "Should detect PostgreSQL unique violation",
+			dialect: Postgres,
+			err:     fmt.Errorf("failed: %w", &pq.Error{Code: "23505"}),
+			want:    true,
+		},
+		{
+			name:    "Should not detect errors of another dialect",
+			dialect: Postgres,
+			err:     &mysql.MySQLError{Number: 1062},
+			want:    false,
+		},
+		{
+			name:    "Should not detect plain errors",
+			dialect: SQLite,
+			err:     errors.New("UNIQUE constraint failed"),
+			want:    false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := tt.dialect.IsUniqueViolation(tt.err); got != tt.want {
+				t.Errorf("IsUniqueViolation() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
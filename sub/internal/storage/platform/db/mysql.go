@@ -1,19 +1,26 @@
 package db
 
-//nolint:revive
 import (
-	_ "github.com/go-sql-driver/mysql"
-	"github.com/jmoiron/sqlx"
+	"errors"
+
+	"github.com/go-sql-driver/mysql"
 )
 
-func Must(db *sqlx.DB, err error) *sqlx.DB {
+// mysqlDuplicateEntry is an error code of the duplicate
+// entry for the unique index or primary key.
+const mysqlDuplicateEntry = 1062
+
+func isMySQLUniqueViolation(err error) bool {
+	var mySQLErr *mysql.MySQLError
+	return errors.As(err, &mySQLErr) && mySQLErr.Number == mysqlDuplicateEntry
+}
+
+func withMultiStatements(dsn string) (string, error) {
+	cfg, err := mysql.ParseDSN(dsn)
 	if err != nil {
-		panic(err)
+		return "", err
 	}
-	return db
-}
 
-func NewConn(dsn string) (*sqlx.DB, error) {
-	db, err := sqlx.Connect("mysql", dsn)
-	return db, err
+	cfg.MultiStatements = true
+	return cfg.FormatDSN(), nil
 }
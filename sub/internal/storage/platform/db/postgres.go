@@ -0,0 +1,35 @@
+package db
+
+import (
+	"errors"
+	"net/url"
+
+	"github.com/lib/pq"
+)
+
+// postgresUniqueViolation is an SQLSTATE code of
+// the violated unique index or primary key.
+const postgresUniqueViolation = "23505"
+
+func isPostgresUniqueViolation(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == postgresUniqueViolation
+}
+
+// postgresDSN sets session time zone to UTC, unless it's set explicitly,
+// so dates are computed the same way as in MySQL and times are read in UTC.
+// Unparsable URLs are returned as is, so the driver reports them.
+func postgresDSN(dsn string) string {
+	u, err := url.Parse(dsn)
+	if err != nil {
+		return dsn
+	}
+
+	q := u.Query()
+	if q.Get("timezone") == "" {
+		q.Set("timezone", "UTC")
+	}
+	u.RawQuery = q.Encode()
+
+	return u.String()
+}
@@ -0,0 +1,48 @@
+package db
+
+import (
+	"errors"
+	"net/url"
+	"strings"
+
+	"modernc.org/sqlite"
+	sqlite3 "modernc.org/sqlite/lib"
+)
+
+func isSQLiteUniqueViolation(err error) bool {
+	var sqliteErr *sqlite.Error
+	if !errors.As(err, &sqliteErr) {
+		return false
+	}
+
+	code := sqliteErr.Code()
+	return code == sqlite3.SQLITE_CONSTRAINT_UNIQUE || code == sqlite3.SQLITE_CONSTRAINT_PRIMARYKEY
+}
+
+// sqliteDSN configures connection to the database file at the given path:
+//   - times are written in the format understood by SQLite date functions,
+//     so they're compared and grouped by day correctly;
+//   - writers wait for the lock held by another process instead of failing;
+//   - transactions take the write lock upfront, so they don't deadlock
+//     upgrading the read lock.
+//
+// Parameters, which are set explicitly, aren't overridden.
+func sqliteDSN(path string) string {
+	path, rawQuery, _ := strings.Cut(path, "?")
+	q, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		return path + "?" + rawQuery
+	}
+
+	if q.Get("_time_format") == "" {
+		q.Set("_time_format", "sqlite")
+	}
+	if q.Get("_txlock") == "" {
+		q.Set("_txlock", "immediate")
+	}
+	if !strings.Contains(rawQuery, "busy_timeout") {
+		q.Add("_pragma", "busy_timeout(5000)")
+	}
+
+	return path + "?" + q.Encode()
+}
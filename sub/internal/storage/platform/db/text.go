@@ -0,0 +1,18 @@
+package db
+
+import "unicode/utf8"
+
+// Truncate returns s cut to at most n bytes, so it fits the text column.
+// It's cut on the rune boundary, so multibyte character isn't split into
+// invalid UTF-8, which is rejected by PostgreSQL and strict MySQL.
+func Truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+
+	return s[:n]
+}
@@ -0,0 +1,47 @@
+package db
+
+import "testing"
+
+func TestTruncate(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name string
+		s    string
+		n    int
+		want string
+	}{
+		{
+			name: "Should return short string as is",
+			s:    "timeout",
+			n:    10,
+			want: "timeout",
+		},
+		{
+			name: "Should cut long string to the limit",
+			s:    "connection refused",
+			n:    10,
+			want: "connection",
+		},
+		{
+			name: "Should not split multibyte character",
+			s:    "помилка",
+			n:    5,
+			want: "по",
+		},
+		{
+			name: "Should keep multibyte character ending at the limit",
+			s:    "помилка",
+			n:    4,
+			want: "по",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := Truncate(tt.s, tt.n); got != tt.want {
+				t.Errorf("Truncate() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
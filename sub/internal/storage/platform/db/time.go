@@ -0,0 +1,45 @@
+package db
+
+import (
+	"fmt"
+	"time"
+)
+
+// timeLayouts are layouts of the times returned as text.
+var timeLayouts = []string{
+	time.DateOnly,
+	time.DateTime,
+	"2006-01-02 15:04:05.999999999-07:00",
+}
+
+// Time is a time.Time, which could be scanned from the text as well.
+// SQLite returns times computed by the expressions, i.e DATE(created_at),
+// as text, since they don't have a declared type.
+type Time struct {
+	time.Time
+}
+
+// Scan implements sql.Scanner interface.
+func (t *Time) Scan(src any) error {
+	switch v := src.(type) {
+	case time.Time:
+		t.Time = v
+		return nil
+	case string:
+		return t.parse(v)
+	case []byte:
+		return t.parse(string(v))
+	default:
+		return fmt.Errorf("failed to scan %T into time", src)
+	}
+}
+
+func (t *Time) parse(s string) error {
+	for _, layout := range timeLayouts {
+		if parsed, err := time.Parse(layout, s); err == nil {
+			t.Time = parsed
+			return nil
+		}
+	}
+	return fmt.Errorf("failed to parse time %q", s)
+}
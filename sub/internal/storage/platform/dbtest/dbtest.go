@@ -0,0 +1,171 @@
+// Package dbtest runs tests of the repos against every supported database.
+// SQLite and MySQL don't need Docker or network: SQLite database is a file,
+// and MySQL compatible server is started in memory of the test process,
+// unless real server is set by the SUB_TEST_MYSQL_DSN env var. PostgreSQL is
+// used, when its server is set by the SUB_TEST_POSTGRES_DSN env var. The user
+// should be allowed to create databases, since every test gets its own one.
+package dbtest
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"math/rand/v2"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/hrvadl/converter/sub/internal/storage/platform/db"
+	"github.com/hrvadl/converter/sub/internal/storage/platform/migrate"
+	"github.com/hrvadl/converter/sub/migrations"
+)
+
+const (
+	postgresEnvKey = "SUB_TEST_POSTGRES_DSN"
+	mysqlEnvKey    = "SUB_TEST_MYSQL_DSN"
+)
+
+// Run runs test against the fresh migrated database of every backend
+// in the parallel subtest named after the dialect.
+func Run(t *testing.T, test func(t *testing.T, conn *sqlx.DB)) {
+	t.Helper()
+	for _, d := range []db.Dialect{db.SQLite, db.Postgres, db.MySQL} {
+		t.Run(string(d), func(t *testing.T) {
+			t.Parallel()
+			test(t, Open(t, d))
+		})
+	}
+}
+
+// Open returns connection to the fresh database of the given dialect
+// migrated to the latest version. Test is skipped, if server of the
+// dialect isn't configured. Database is dropped, when test is finished.
+func Open(t *testing.T, d db.Dialect) *sqlx.DB {
+	t.Helper()
+	dsn := newDatabase(t, d)
+	migrateUp(t, dsn)
+
+	conn, err := db.NewConn(dsn)
+	if err != nil {
+		t.Fatalf("Failed to connect to %s: %v", d, err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+
+	return conn
+}
+
+// Migrator returns migrator of the database behind the given DSN.
+func Migrator(t *testing.T, dsn string) *migrate.Migrator {
+	t.Helper()
+	conn, err := db.NewMigrationConn(dsn)
+	if err != nil {
+		t.Fatalf("Failed to connect to migrate: %v", err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+
+	dialect := db.DialectOf(conn)
+	fsys, err := migrations.For(string(dialect))
+	if err != nil {
+		t.Fatalf("Failed to get migrations: %v", err)
+	}
+
+	mm, err := migrate.Load(fsys)
+	if err != nil {
+		t.Fatalf("Failed to load migrations: %v", err)
+	}
+	if dialect == db.MySQL && os.Getenv(mysqlEnvKey) == "" {
+		mm = withoutTemporaryTables(mm)
+	}
+
+	return migrate.NewMigrator(conn, mm, slog.New(slog.NewTextHandler(io.Discard, nil)))
+}
+
+func migrateUp(t *testing.T, dsn string) {
+	t.Helper()
+	if _, err := Migrator(t, dsn).Up(context.Background(), 0); err != nil {
+		t.Fatalf("Failed to migrate: %v", err)
+	}
+}
+
+// newDatabase creates empty database of the given dialect and returns its DSN.
+func newDatabase(t *testing.T, d db.Dialect) string {
+	t.Helper()
+	if d == db.SQLite {
+		return "sqlite://" + filepath.Join(t.TempDir(), "sub.db")
+	}
+
+	server := os.Getenv(map[db.Dialect]string{db.Postgres: postgresEnvKey, db.MySQL: mysqlEnvKey}[d])
+	if server == "" && d == db.MySQL {
+		var err error
+		if server, err = inProcessMySQL(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if server == "" {
+		t.Skipf("%s server isn't configured", d)
+	}
+
+	admin, err := db.NewConn(server)
+	if err != nil {
+		t.Fatalf("Failed to connect to %s server: %v", d, err)
+	}
+	defer admin.Close()
+
+	name := fmt.Sprintf("sub_test_%d", rand.Uint32())
+	if _, err := admin.Exec("CREATE DATABASE " + name); err != nil {
+		t.Fatalf("Failed to create database: %v", err)
+	}
+
+	// Cleanups run in the reverse order, so connections of the test
+	// are closed by the time database is dropped.
+	t.Cleanup(func() {
+		admin, err := db.NewConn(server)
+		if err != nil {
+			t.Errorf("Failed to connect to %s server: %v", d, err)
+			return
+		}
+		defer admin.Close()
+
+		if _, err := admin.Exec("DROP DATABASE " + name); err != nil {
+			t.Errorf("Failed to drop database: %v", err)
+		}
+	})
+
+	dsn, err := withDatabase(d, server, name)
+	if err != nil {
+		t.Fatalf("Failed to build dsn: %v", err)
+	}
+
+	return dsn
+}
+
+// withDatabase returns server DSN pointing to the given database.
+func withDatabase(d db.Dialect, server, name string) (string, error) {
+	if d == db.Postgres {
+		u, err := url.Parse(server)
+		if err != nil {
+			return "", err
+		}
+		u.Path = "/" + name
+		return u.String(), nil
+	}
+
+	_, server, err := db.ParseDSN(server)
+	if err != nil {
+		return "", err
+	}
+
+	cfg, err := mysql.ParseDSN(server)
+	if err != nil {
+		return "", err
+	}
+	cfg.DBName = name
+	cfg.ParseTime = true
+
+	return cfg.FormatDSN(), nil
+}
@@ -0,0 +1,48 @@
+package dbtest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hrvadl/converter/sub/internal/storage/platform/db"
+	"github.com/hrvadl/converter/sub/internal/storage/platform/migrate"
+)
+
+func TestMigrations(t *testing.T) {
+	t.Parallel()
+	for _, d := range []db.Dialect{db.SQLite, db.Postgres, db.MySQL} {
+		t.Run("Should apply, revert and apply again all migrations of "+string(d), func(t *testing.T) {
+			t.Parallel()
+			m := Migrator(t, newDatabase(t, d))
+			ctx := context.Background()
+
+			applied, err := m.Up(ctx, 0)
+			if err != nil {
+				t.Fatalf("Up() error = %v", err)
+			}
+			if applied == 0 {
+				t.Fatal("Up() applied no migrations")
+			}
+
+			reverted, err := m.Down(ctx, 0)
+			if err != nil {
+				t.Fatalf("Down() error = %v", err)
+			}
+			if reverted != applied {
+				t.Errorf("Down() reverted %d migrations, want %d", reverted, applied)
+			}
+
+			v, err := m.Version(ctx)
+			if err != nil {
+				t.Fatalf("Version() error = %v", err)
+			}
+			if v != (migrate.Version{Version: migrate.NilVersion}) {
+				t.Errorf("Version() = %v, want nil version", v)
+			}
+
+			if _, err := m.Up(ctx, 0); err != nil {
+				t.Fatalf("Up() after Down() error = %v", err)
+			}
+		})
+	}
+}
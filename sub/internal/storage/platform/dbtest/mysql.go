@@ -0,0 +1,52 @@
+package dbtest
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	sqle "github.com/dolthub/go-mysql-server"
+	"github.com/dolthub/go-mysql-server/memory"
+	"github.com/dolthub/go-mysql-server/server"
+	"github.com/sirupsen/logrus"
+
+	"github.com/hrvadl/converter/sub/internal/storage/platform/migrate"
+)
+
+// inProcessMySQL starts MySQL compatible server in memory of the test
+// process once and returns its DSN. It's used, when MySQL server isn't
+// configured, so MySQL dialect is tested without Docker or network.
+var inProcessMySQL = sync.OnceValues(func() (string, error) {
+	// Server logs every connection and failed query, which
+	// buries the output of the failed test.
+	logrus.SetLevel(logrus.ErrorLevel)
+
+	provider := memory.NewDBProvider()
+	srv, err := server.NewServer(
+		server.Config{Protocol: "tcp", Address: "127.0.0.1:0"},
+		sqle.NewDefault(provider),
+		memory.NewSessionBuilder(provider),
+		nil,
+	)
+	if err != nil {
+		return "", fmt.Errorf("failed to start in-process mysql: %w", err)
+	}
+
+	go func() { _ = srv.Start() }()
+
+	return fmt.Sprintf("root@tcp(%s)/?parseTime=true", srv.Listener.Addr()), nil
+})
+
+// withoutTemporaryTables returns migrations, which create regular tables
+// instead of temporary ones, since in-process server doesn't support
+// them. Migrations drop such tables themselves, so the schema is the
+// same as on the real server.
+func withoutTemporaryTables(mm []migrate.Migration) []migrate.Migration {
+	stubbed := make([]migrate.Migration, len(mm))
+	for i, m := range mm {
+		m.Up = strings.ReplaceAll(m.Up, "TEMPORARY TABLE", "TABLE")
+		m.Down = strings.ReplaceAll(m.Down, "TEMPORARY TABLE", "TABLE")
+		stubbed[i] = m
+	}
+	return stubbed
+}
@@ -0,0 +1,129 @@
+package migrate
+
+import (
+	"fmt"
+	"io/fs"
+	"regexp"
+	"sort"
+	"strconv"
+)
+
+// fileName matches {version}_{title}.{up|down}.sql migration files.
+var fileName = regexp.MustCompile(`^(\d+)_(.+)\.(up|down)\.sql$`)
+
+// Migration is a pair of SQL scripts, which apply and
+// revert a single change of the schema.
+type Migration struct {
+	Version int64
+	Name    string
+	Up      string
+	Down    string
+}
+
+// Load reads migrations from the root of fsys and returns them ordered by
+// version. Files, which don't match {version}_{title}.{up|down}.sql, are
+// ignored. Returns an error if any version is duplicated or misses up or
+// down script.
+func Load(fsys fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read migrations: %w", err)
+	}
+
+	byVersion := make(map[int64]*Migration)
+	for _, e := range entries {
+		match := fileName.FindStringSubmatch(e.Name())
+		if e.IsDir() || match == nil {
+			continue
+		}
+
+		version, err := strconv.ParseInt(match[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid version of %s: %w", e.Name(), err)
+		}
+
+		m, ok := byVersion[version]
+		if !ok {
+			m = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = m
+		}
+		if m.Name != match[2] {
+			return nil, fmt.Errorf("version %d is used by both %s and %s", version, m.Name, match[2])
+		}
+
+		body, err := fs.ReadFile(fsys, e.Name())
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", e.Name(), err)
+		}
+
+		if match[3] == "up" {
+			m.Up = string(body)
+		} else {
+			m.Down = string(body)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if m.Up == "" || m.Down == "" {
+			return nil, fmt.Errorf("migration %d_%s should have both up and down scripts", m.Version, m.Name)
+		}
+		migrations = append(migrations, *m)
+	}
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+
+	return migrations, nil
+}
+
+// pending returns at most n migrations, which are newer than the current
+// version, oldest first. All of them are returned, when n isn't positive.
+func pending(migrations []Migration, current int64, n int) []Migration {
+	i := sort.Search(len(migrations), func(i int) bool {
+		return migrations[i].Version > current
+	})
+	p := migrations[i:]
+	if n > 0 && n < len(p) {
+		p = p[:n]
+	}
+	return p
+}
+
+// applied returns at most n migrations up to the current version, newest
+// first. All of them are returned, when n isn't positive.
+func applied(migrations []Migration, current int64, n int) []Migration {
+	var a []Migration
+	for i := len(migrations) - 1; i >= 0 && (n <= 0 || len(a) < n); i-- {
+		if migrations[i].Version <= current {
+			a = append(a, migrations[i])
+		}
+	}
+	return a
+}
+
+// previous returns version of the migration preceding
+// the given one, or NilVersion if it's the first.
+func previous(migrations []Migration, version int64) int64 {
+	prev := NilVersion
+	for _, m := range migrations {
+		if m.Version >= version {
+			break
+		}
+		prev = m.Version
+	}
+	return prev
+}
+
+// known reports whether version is one of the migrations or NilVersion.
+func known(migrations []Migration, version int64) bool {
+	if version == NilVersion {
+		return true
+	}
+	for _, m := range migrations {
+		if m.Version == version {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,156 @@
+package migrate
+
+import (
+	"reflect"
+	"testing"
+	"testing/fstest"
+
+	"github.com/hrvadl/converter/sub/migrations"
+)
+
+func TestLoad(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		fsys    fstest.MapFS
+		want    []Migration
+		wantErr bool
+	}{
+		{
+			name: "Should load migrations ordered by version",
+			fsys: fstest.MapFS{
+				"000002_outbox.up.sql":        {Data: []byte("CREATE TABLE outbox;")},
+				"000002_outbox.down.sql":      {Data: []byte("DROP TABLE outbox;")},
+				"000001_init_schema.up.sql":   {Data: []byte("CREATE TABLE subscribers;")},
+				"000001_init_schema.down.sql": {Data: []byte("DROP TABLE subscribers;")},
+				"migrations.go":               {Data: []byte("package migrations")},
+			},
+			want: []Migration{
+				{Version: 1, Name: "init_schema", Up: "CREATE TABLE subscribers;", Down: "DROP TABLE subscribers;"},
+				{Version: 2, Name: "outbox", Up: "CREATE TABLE outbox;", Down: "DROP TABLE outbox;"},
+			},
+		},
+		{
+			name: "Should return error when down script is missing",
+			fsys: fstest.MapFS{
+				"000001_init_schema.up.sql": {Data: []byte("CREATE TABLE subscribers;")},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should return error when version is duplicated",
+			fsys: fstest.MapFS{
+				"000001_init_schema.up.sql": {Data: []byte("CREATE TABLE subscribers;")},
+				"000001_outbox.up.sql":      {Data: []byte("CREATE TABLE outbox;")},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := Load(tt.fsys)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Load() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Load() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadEmbedded(t *testing.T) {
+	t.Parallel()
+	load := func(t *testing.T, dialect string) []Migration {
+		t.Helper()
+		fsys, err := migrations.For(dialect)
+		if err != nil {
+			t.Fatalf("For() error = %v", err)
+		}
+		got, err := Load(fsys)
+		if err != nil {
+			t.Fatalf("Load() error = %v", err)
+		}
+		if len(got) == 0 {
+			t.Fatalf("Load() returned no migrations of %s", dialect)
+		}
+		return got
+	}
+
+	mysql := load(t, "mysql")
+	for i, m := range mysql {
+		if m.Version != int64(i+1) {
+			t.Errorf("Migration %s has version %d, want %d", m.Name, m.Version, i+1)
+		}
+	}
+
+	latest := mysql[len(mysql)-1].Version
+	for _, dialect := range []string{"postgres", "sqlite"} {
+		t.Run("Should migrate "+dialect+" to the same version as mysql", func(t *testing.T) {
+			t.Parallel()
+			got := load(t, dialect)
+			if v := got[len(got)-1].Version; v != latest {
+				t.Errorf("Latest %s version = %d, want %d", dialect, v, latest)
+			}
+		})
+	}
+}
+
+func TestPlan(t *testing.T) {
+	t.Parallel()
+	mm := []Migration{{Version: 1}, {Version: 2}, {Version: 3}}
+	versions := func(mm []Migration) []int64 {
+		v := make([]int64, 0, len(mm))
+		for _, m := range mm {
+			v = append(v, m.Version)
+		}
+		return v
+	}
+	tests := []struct {
+		name string
+		got  []int64
+		want []int64
+	}{
+		{
+			name: "Should return all pending migrations when n isn't positive",
+			got:  versions(pending(mm, NilVersion, 0)),
+			want: []int64{1, 2, 3},
+		},
+		{
+			name: "Should return at most n pending migrations",
+			got:  versions(pending(mm, 1, 1)),
+			want: []int64{2},
+		},
+		{
+			name: "Should return no pending migrations when schema is up to date",
+			got:  versions(pending(mm, 3, 0)),
+			want: []int64{},
+		},
+		{
+			name: "Should return applied migrations newest first",
+			got:  versions(applied(mm, 2, 0)),
+			want: []int64{2, 1},
+		},
+		{
+			name: "Should return at most n applied migrations",
+			got:  versions(applied(mm, 3, 1)),
+			want: []int64{3},
+		},
+		{
+			name: "Should return previous version",
+			got:  []int64{previous(mm, 3), previous(mm, 1)},
+			want: []int64{2, NilVersion},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if !reflect.DeepEqual(tt.got, tt.want) {
+				t.Errorf("got = %v, want %v", tt.got, tt.want)
+			}
+		})
+	}
+}
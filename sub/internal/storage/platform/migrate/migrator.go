@@ -0,0 +1,361 @@
+package migrate
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/hrvadl/converter/sub/internal/storage/platform/db"
+)
+
+const operation = "migrator"
+
+// NilVersion is a version of the schema,
+// when none of the migrations is applied.
+const NilVersion int64 = 0
+
+const (
+	// lockName is a name of the MySQL or PostgreSQL advisory lock, which
+	// guards migrations, so only one replica migrates the schema at a time.
+	lockName = "sub_schema_migrations"
+	// lockTimeout is a period, during which migrator waits for
+	// the lock held by another replica.
+	lockTimeout = time.Minute
+	// lockRetryInterval is a period between attempts to take
+	// PostgreSQL lock, which doesn't support waiting with timeout.
+	lockRetryInterval = time.Second
+)
+
+var (
+	// ErrDirty means the last migration has failed halfway, so schema should
+	// be fixed manually and its version should be forced.
+	ErrDirty = errors.New("schema is dirty")
+	// ErrLocked means another migrator held the lock longer than lockTimeout.
+	ErrLocked = errors.New("schema is locked by another migrator")
+	// ErrUnknownVersion means schema is migrated to the version,
+	// which isn't known to this binary.
+	ErrUnknownVersion = errors.New("unknown schema version")
+)
+
+// NewMigrator constructs migrator, which applies the given migrations
+// through the provided db connection. State of the schema is kept in
+// the schema_migrations table, which is compatible with golang-migrate,
+// so schema migrated by it could be migrated further.
+// Locking of the schema depends on the dialect of the connection.
+// NOTE: db connection should allow multiple statements in a single
+// query, since migration scripts contain several statements.
+// NOTE: neither of arguments can't be nil, or migrator will panic.
+func NewMigrator(db *sqlx.DB, migrations []Migration, log *slog.Logger) *Migrator {
+	return &Migrator{
+		db:         db,
+		migrations: migrations,
+		log:        log,
+	}
+}
+
+// Migrator applies and reverts migrations of the schema. Every
+// operation holds the lock, so concurrent migrators wait for each other.
+type Migrator struct {
+	db         *sqlx.DB
+	migrations []Migration
+	log        *slog.Logger
+}
+
+// Version is a version of the schema. Dirty version means
+// its migration has failed halfway.
+type Version struct {
+	Version int64 `db:"version"`
+	Dirty   bool  `db:"dirty"`
+}
+
+// Status represents migration and whether it's applied to the schema.
+type Status struct {
+	Migration
+	Applied bool
+}
+
+// Up method applies at most n pending migrations, oldest first.
+// All pending migrations are applied, when n isn't positive.
+// Returns number of applied migrations. Returns ErrDirty if the
+// previous migration has failed, or ErrUnknownVersion if schema is
+// newer than the migrations of this binary.
+func (m *Migrator) Up(ctx context.Context, n int) (int, error) {
+	var done int
+	err := m.withLock(ctx, func(conn *sqlx.Conn) error {
+		v, err := m.current(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		for _, mig := range pending(m.migrations, v.Version, n) {
+			m.log.Info("Applying migration", "version", mig.Version, "name", mig.Name)
+			if err := m.run(ctx, conn, mig.Version, mig.Up, mig.Version); err != nil {
+				return fmt.Errorf("failed to apply %d_%s: %w", mig.Version, mig.Name, err)
+			}
+			done++
+		}
+		return nil
+	})
+	if err != nil {
+		return done, fmt.Errorf("%s: %w", operation, err)
+	}
+
+	return done, nil
+}
+
+// Down method reverts at most n applied migrations, newest first.
+// All applied migrations are reverted, when n isn't positive.
+// Returns number of reverted migrations. Returns the same errors as Up.
+func (m *Migrator) Down(ctx context.Context, n int) (int, error) {
+	var done int
+	err := m.withLock(ctx, func(conn *sqlx.Conn) error {
+		v, err := m.current(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		for _, mig := range applied(m.migrations, v.Version, n) {
+			m.log.Info("Reverting migration", "version", mig.Version, "name", mig.Name)
+			prev := previous(m.migrations, mig.Version)
+			if err := m.run(ctx, conn, mig.Version, mig.Down, prev); err != nil {
+				return fmt.Errorf("failed to revert %d_%s: %w", mig.Version, mig.Name, err)
+			}
+			done++
+		}
+		return nil
+	})
+	if err != nil {
+		return done, fmt.Errorf("%s: %w", operation, err)
+	}
+
+	return done, nil
+}
+
+// Version method returns current version of the schema.
+func (m *Migrator) Version(ctx context.Context) (Version, error) {
+	var v Version
+	err := m.withLock(ctx, func(conn *sqlx.Conn) error {
+		var err error
+		v, err = m.version(ctx, conn)
+		return err
+	})
+	if err != nil {
+		return Version{}, fmt.Errorf("%s: %w", operation, err)
+	}
+
+	return v, nil
+}
+
+// Status method returns all migrations with their state, oldest first.
+func (m *Migrator) Status(ctx context.Context) ([]Status, error) {
+	v, err := m.Version(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	s := make([]Status, 0, len(m.migrations))
+	for _, mig := range m.migrations {
+		s = append(s, Status{Migration: mig, Applied: mig.Version <= v.Version})
+	}
+
+	return s, nil
+}
+
+// Force method sets version of the schema and clears the dirty flag
+// without running any migrations. It's used after dirty schema was fixed
+// manually. Returns ErrUnknownVersion, if there's no such migration.
+func (m *Migrator) Force(ctx context.Context, version int64) error {
+	if !known(m.migrations, version) {
+		return fmt.Errorf("%s: %w: %d", operation, ErrUnknownVersion, version)
+	}
+
+	err := m.withLock(ctx, func(conn *sqlx.Conn) error {
+		return m.setVersion(ctx, conn, Version{Version: version})
+	})
+	if err != nil {
+		return fmt.Errorf("%s: %w", operation, err)
+	}
+
+	return nil
+}
+
+// withLock runs fn on a dedicated connection, while the lock is held.
+// Locks belong to the session, so lock is acquired and released
+// on the same connection, which is used by fn.
+func (m *Migrator) withLock(ctx context.Context, fn func(conn *sqlx.Conn) error) error {
+	conn, err := m.db.Connx(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get connection: %w", err)
+	}
+	defer conn.Close()
+
+	unlock, err := m.lock(ctx, conn)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := unlock(context.WithoutCancel(ctx)); err != nil {
+			m.log.Error("Failed to release migration lock", "err", err)
+		}
+	}()
+
+	if _, err := conn.ExecContext(
+		ctx,
+		`CREATE TABLE IF NOT EXISTS schema_migrations (
+			version bigint NOT NULL PRIMARY KEY,
+			dirty boolean NOT NULL
+		)`,
+	); err != nil {
+		return fmt.Errorf("failed to create schema_migrations table: %w", err)
+	}
+
+	return fn(conn)
+}
+
+// lock acquires the lock of the dialect on the given connection and returns
+// function, which releases it. SQLite isn't locked, since its database file
+// is used by a single replica and writers are serialized by the file lock.
+func (m *Migrator) lock(ctx context.Context, conn *sqlx.Conn) (func(ctx context.Context) error, error) {
+	switch db.DialectOf(m.db) {
+	case db.Postgres:
+		return lockPostgres(ctx, conn)
+	case db.SQLite:
+		return func(context.Context) error { return nil }, nil
+	default:
+		return lockMySQL(ctx, conn)
+	}
+}
+
+func lockMySQL(ctx context.Context, conn *sqlx.Conn) (func(ctx context.Context) error, error) {
+	var acquired sql.NullInt64
+	err := conn.GetContext(ctx, &acquired, "SELECT GET_LOCK(?, ?)", lockName, int(lockTimeout.Seconds()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire lock: %w", err)
+	}
+	if acquired.Int64 != 1 {
+		return nil, ErrLocked
+	}
+
+	return func(ctx context.Context) error {
+		_, err := conn.ExecContext(ctx, "SELECT RELEASE_LOCK(?)", lockName)
+		return err
+	}, nil
+}
+
+// lockPostgres polls the advisory lock until lockTimeout expires, since
+// pg_advisory_lock waits for the lock infinitely.
+func lockPostgres(ctx context.Context, conn *sqlx.Conn) (func(ctx context.Context) error, error) {
+	ctx, cancel := context.WithTimeout(ctx, lockTimeout)
+	defer cancel()
+
+	ticker := time.NewTicker(lockRetryInterval)
+	defer ticker.Stop()
+
+	unlock := func(ctx context.Context) error {
+		_, err := conn.ExecContext(ctx, "SELECT pg_advisory_unlock(hashtext($1))", lockName)
+		return err
+	}
+
+	for {
+		var acquired bool
+		err := conn.GetContext(ctx, &acquired, "SELECT pg_try_advisory_lock(hashtext($1))", lockName)
+		switch {
+		case ctx.Err() != nil:
+			// Expired or cancelled context is reported below.
+		case err != nil:
+			return nil, fmt.Errorf("failed to acquire lock: %w", err)
+		case acquired:
+			return unlock, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				return nil, ErrLocked
+			}
+			return nil, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// current returns version of the schema, which could be migrated.
+func (m *Migrator) current(ctx context.Context, conn *sqlx.Conn) (Version, error) {
+	v, err := m.version(ctx, conn)
+	if err != nil {
+		return Version{}, err
+	}
+
+	if v.Dirty {
+		return Version{}, fmt.Errorf("%w at version %d, fix it and force the version", ErrDirty, v.Version)
+	}
+
+	if !known(m.migrations, v.Version) {
+		return Version{}, fmt.Errorf("%w: %d", ErrUnknownVersion, v.Version)
+	}
+
+	return v, nil
+}
+
+func (m *Migrator) version(ctx context.Context, conn *sqlx.Conn) (Version, error) {
+	var v Version
+	err := conn.GetContext(ctx, &v, "SELECT version, dirty FROM schema_migrations LIMIT 1")
+	if errors.Is(err, sql.ErrNoRows) {
+		return Version{Version: NilVersion}, nil
+	}
+	if err != nil {
+		return Version{}, fmt.Errorf("failed to get schema version: %w", err)
+	}
+
+	return v, nil
+}
+
+// run marks the schema dirty at the given version, executes the script
+// and then sets the target version. If script fails, schema stays dirty.
+func (m *Migrator) run(ctx context.Context, conn *sqlx.Conn, version int64, script string, target int64) error {
+	if err := m.setVersion(ctx, conn, Version{Version: version, Dirty: true}); err != nil {
+		return err
+	}
+
+	if _, err := conn.ExecContext(ctx, script); err != nil {
+		return err
+	}
+
+	return m.setVersion(ctx, conn, Version{Version: target})
+}
+
+// setVersion replaces the only row of the schema_migrations table.
+// Clean NilVersion is stored as an empty table.
+func (m *Migrator) setVersion(ctx context.Context, conn *sqlx.Conn, v Version) error {
+	tx, err := conn.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin tx: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if _, err := tx.ExecContext(ctx, "DELETE FROM schema_migrations"); err != nil {
+		return fmt.Errorf("failed to clear schema version: %w", err)
+	}
+
+	if v.Version != NilVersion || v.Dirty {
+		if _, err := tx.ExecContext(
+			ctx,
+			tx.Rebind("INSERT INTO schema_migrations (version, dirty) VALUES (?, ?)"),
+			v.Version,
+			v.Dirty,
+		); err != nil {
+			return fmt.Errorf("failed to set schema version: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit tx: %w", err)
+	}
+
+	return nil
+}
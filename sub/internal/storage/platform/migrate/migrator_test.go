@@ -0,0 +1,39 @@
+package migrate
+
+import (
+	"log/slog"
+	"testing"
+
+	"github.com/jmoiron/sqlx"
+)
+
+func TestNewMigrator(t *testing.T) {
+	t.Parallel()
+	type args struct {
+		db         *sqlx.DB
+		migrations []Migration
+		log        *slog.Logger
+	}
+	tests := []struct {
+		name string
+		args args
+	}{
+		{
+			name: "Should create migrator with correct db conn",
+			args: args{
+				db:         &sqlx.DB{},
+				migrations: []Migration{{Version: 1}},
+				log:        slog.Default(),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := NewMigrator(tt.args.db, tt.args.migrations, tt.args.log); got == nil {
+				t.Errorf("NewMigrator() = %v, want not nil", got)
+			}
+		})
+	}
+}
@@ -0,0 +1,7 @@
+package rate
+
+import "errors"
+
+// ErrAlreadyExists is returned when rate of
+// the run date is already saved.
+var ErrAlreadyExists = errors.New("rate of the run date already exists")
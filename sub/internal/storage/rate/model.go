@@ -0,0 +1,31 @@
+package rate
+
+import "time"
+
+// Rate is a model, which represents USD -> UAH
+// exchange rate fetched at the given point of time.
+// Provider is a name of the upstream, which returned the rate.
+// RunDate is a day of the daily run, which has fetched the rate.
+type Rate struct {
+	ID        int64     `db:"id"`
+	Rate      float32   `db:"rate"`
+	Provider  string    `db:"provider"`
+	RunDate   time.Time `db:"run_date"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+// Stats represents aggregated exchange rates over the
+// period of time. Count is a number of rates, which were
+// recorded during the period.
+type Stats struct {
+	Min   float32 `db:"min"`
+	Max   float32 `db:"max"`
+	Avg   float32 `db:"avg"`
+	Count int     `db:"count"`
+}
+
+// Daily represents average exchange rate of the day.
+type Daily struct {
+	Day  time.Time `db:"day"`
+	Rate float32   `db:"rate"`
+}
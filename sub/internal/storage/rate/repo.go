@@ -0,0 +1,100 @@
+package rate
+
+import (
+	"context"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/hrvadl/converter/sub/internal/storage/platform/db"
+)
+
+// Repo is a thin abstraction to not do sqlx queries
+// directly in the services. It keeps the history of the
+// fetched exchange rates, so digests could be built from it.
+type Repo struct {
+	db *sqlx.DB
+}
+
+// NewRepo constructs repo with provided sqlx DB connection.
+// NOTE: db connection could be MySQL, PostgreSQL or SQLite one.
+func NewRepo(db *sqlx.DB) *Repo {
+	return &Repo{
+		db: db,
+	}
+}
+
+// Save method saves rate to the history and then returns
+// newly created ID. Rate is saved once per run date, so retried
+// and caught up runs don't skew the history. Rates without run date
+// aren't deduplicated. Returns ErrAlreadyExists if rate of the run
+// date is already saved.
+func (r *Repo) Save(ctx context.Context, rate Rate) (int64, error) {
+	id, err := db.Insert(
+		ctx,
+		r.db,
+		"INSERT INTO rates (rate, provider, run_date) VALUES (?, ?, ?)",
+		rate.Rate,
+		rate.Provider,
+		runDate(rate.RunDate),
+	)
+	if db.DialectOf(r.db).IsUniqueViolation(err) {
+		return 0, ErrAlreadyExists
+	}
+
+	return id, err
+}
+
+// GetStats method aggregates rates recorded in the [from, to] period.
+// If there're no rates in the period, Count will be zero.
+func (r *Repo) GetStats(ctx context.Context, from, to time.Time) (Stats, error) {
+	var s Stats
+	err := r.db.GetContext(
+		ctx,
+		&s,
+		r.db.Rebind(`SELECT COALESCE(MIN(rate), 0) AS min, COALESCE(MAX(rate), 0) AS max,
+		COALESCE(AVG(rate), 0) AS avg, COUNT(*) AS count
+		FROM rates WHERE created_at BETWEEN ? AND ?`),
+		from.UTC(),
+		to.UTC(),
+	)
+	return s, err
+}
+
+// GetDaily method returns average rate of each day in the [from, to] period,
+// oldest first. Days without recorded rates are skipped.
+func (r *Repo) GetDaily(ctx context.Context, from, to time.Time) ([]Daily, error) {
+	var rows []struct {
+		Day  db.Time `db:"day"`
+		Rate float32 `db:"rate"`
+	}
+	err := r.db.SelectContext(
+		ctx,
+		&rows,
+		r.db.Rebind(`SELECT DATE(created_at) AS day, AVG(rate) AS rate
+		FROM rates WHERE created_at BETWEEN ? AND ?
+		GROUP BY DATE(created_at) ORDER BY day`),
+		from.UTC(),
+		to.UTC(),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	d := make([]Daily, 0, len(rows))
+	for _, row := range rows {
+		d = append(d, Daily{Day: row.Day.Time, Rate: row.Rate})
+	}
+
+	return d, nil
+}
+
+// runDate returns day of the given point of time in UTC,
+// or nil if it's zero, so it's saved as NULL.
+func runDate(t time.Time) any {
+	if t.IsZero() {
+		return nil
+	}
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
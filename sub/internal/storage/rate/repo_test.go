@@ -0,0 +1,169 @@
+package rate
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/hrvadl/converter/sub/internal/storage/platform/dbtest"
+)
+
+func TestNewRepo(t *testing.T) {
+	t.Parallel()
+	type args struct {
+		db *sqlx.DB
+	}
+	tests := []struct {
+		name string
+		args args
+	}{
+		{
+			name: "Should create repo with correct db conn",
+			args: args{
+				db: &sqlx.DB{},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := NewRepo(tt.args.db); got == nil {
+				t.Errorf("NewRepo() = %v, want not nil", got)
+			}
+		})
+	}
+}
+
+func TestRepoGetStats(t *testing.T) {
+	t.Parallel()
+	day := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name string
+		from time.Time
+		to   time.Time
+		want Stats
+	}{
+		{
+			name: "Should aggregate rates in the period",
+			from: day,
+			to:   day.Add(24 * time.Hour),
+			want: Stats{Min: 40, Max: 42, Avg: 41, Count: 3},
+		},
+		{
+			name: "Should return zero count when there're no rates in the period",
+			from: day.AddDate(0, 1, 0),
+			to:   day.AddDate(0, 2, 0),
+			want: Stats{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			dbtest.Run(t, func(t *testing.T, conn *sqlx.DB) {
+				saveRates(t, conn, map[time.Time]float32{
+					day.Add(1 * time.Hour):  40,
+					day.Add(2 * time.Hour):  41,
+					day.Add(3 * time.Hour):  42,
+					day.Add(48 * time.Hour): 50,
+				})
+
+				got, err := NewRepo(conn).GetStats(context.Background(), tt.from, tt.to)
+				if err != nil {
+					t.Fatalf("GetStats() error = %v", err)
+				}
+				if got != tt.want {
+					t.Errorf("GetStats() = %v, want %v", got, tt.want)
+				}
+			})
+		})
+	}
+}
+
+func TestRepoGetDaily(t *testing.T) {
+	t.Parallel()
+	day := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	dbtest.Run(t, func(t *testing.T, conn *sqlx.DB) {
+		saveRates(t, conn, map[time.Time]float32{
+			day.Add(1 * time.Hour):  40,
+			day.Add(23 * time.Hour): 42,
+			day.Add(25 * time.Hour): 43,
+			day.Add(72 * time.Hour): 44,
+			day.Add(-time.Hour):     39,
+		})
+
+		got, err := NewRepo(conn).GetDaily(context.Background(), day, day.Add(72*time.Hour))
+		if err != nil {
+			t.Fatalf("GetDaily() error = %v", err)
+		}
+
+		want := []Daily{
+			{Day: day, Rate: 41},
+			{Day: day.AddDate(0, 0, 1), Rate: 43},
+			{Day: day.AddDate(0, 0, 3), Rate: 44},
+		}
+		if len(got) != len(want) {
+			t.Fatalf("GetDaily() = %v, want %v", got, want)
+		}
+		for i := range want {
+			if !got[i].Day.Equal(want[i].Day) || got[i].Rate != want[i].Rate {
+				t.Errorf("GetDaily()[%d] = %v, want %v", i, got[i], want[i])
+			}
+		}
+	})
+}
+
+func TestRepoSave(t *testing.T) {
+	t.Parallel()
+	dbtest.Run(t, func(t *testing.T, conn *sqlx.DB) {
+		r := NewRepo(conn)
+		first, err := r.Save(context.Background(), Rate{Rate: 41})
+		if err != nil {
+			t.Fatalf("Save() error = %v", err)
+		}
+
+		second, err := r.Save(context.Background(), Rate{Rate: 42})
+		if err != nil {
+			t.Fatalf("Save() error = %v", err)
+		}
+
+		if first == 0 || second <= first {
+			t.Errorf("Save() returned ids %d and %d, want increasing ones", first, second)
+		}
+	})
+}
+
+func TestRepoSaveRunDate(t *testing.T) {
+	t.Parallel()
+	day := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	dbtest.Run(t, func(t *testing.T, conn *sqlx.DB) {
+		r := NewRepo(conn)
+		if _, err := r.Save(context.Background(), Rate{Rate: 41, RunDate: day}); err != nil {
+			t.Fatalf("Save() error = %v", err)
+		}
+
+		if _, err := r.Save(context.Background(), Rate{Rate: 41, RunDate: day.AddDate(0, 0, 1)}); err != nil {
+			t.Fatalf("Save() of the next day error = %v", err)
+		}
+
+		_, err := r.Save(context.Background(), Rate{Rate: 42, RunDate: day.Add(time.Hour)})
+		if !errors.Is(err, ErrAlreadyExists) {
+			t.Errorf("Save() of the same day error = %v, want %v", err, ErrAlreadyExists)
+		}
+	})
+}
+
+// saveRates saves rates recorded at the given points of time.
+func saveRates(t *testing.T, conn *sqlx.DB, rates map[time.Time]float32) {
+	t.Helper()
+	for at, rate := range rates {
+		_, err := conn.Exec(conn.Rebind("INSERT INTO rates (rate, created_at) VALUES (?, ?)"), rate, at)
+		if err != nil {
+			t.Fatalf("Failed to save rate: %v", err)
+		}
+	}
+}
@@ -2,4 +2,13 @@ package subscriber
 
 import "errors"
 
-var ErrAlreadyExists = errors.New("subscriber already exists")
+var (
+	ErrAlreadyExists = errors.New("subscriber already exists")
+	ErrNotFound      = errors.New("subscriber is not found")
+	// ErrAddressExists is returned when subscriber already
+	// has an address of the same channel.
+	ErrAddressExists = errors.New("subscriber address already exists")
+	// ErrAddressNotFound is returned when subscriber
+	// doesn't have an address of the channel.
+	ErrAddressNotFound = errors.New("subscriber address is not found")
+)
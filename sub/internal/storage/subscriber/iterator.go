@@ -0,0 +1,61 @@
+package subscriber
+
+import "context"
+
+// defaultBatchSize is a size of the single page, which is used
+// when it's not provided.
+const defaultBatchSize = 1000
+
+// PageFunc gets at most limit subscribers with ID greater than
+// afterID, ordered by ID.
+type PageFunc func(ctx context.Context, afterID int64, limit int) ([]Subscriber, error)
+
+// NewIterator constructs Iterator over the pages of the given size.
+// Size falls back to the default one when it's not positive.
+// NOTE: page func can't be nil, or iterator will panic in the future.
+func NewIterator(page PageFunc, size int) *Iterator {
+	if size <= 0 {
+		size = defaultBatchSize
+	}
+
+	return &Iterator{
+		page: page,
+		size: size,
+	}
+}
+
+// Iterator reads subscribers batch by batch with keyset pagination,
+// so only a single batch is held in memory at once and the batch could
+// be processed before the rest of subscribers are read. Unlike offset
+// pagination, each page is looked up by the primary key, so reading is
+// equally fast at the end of the large table.
+type Iterator struct {
+	page    PageFunc
+	size    int
+	afterID int64
+	done    bool
+}
+
+// Next method returns the next batch of subscribers. Returns an empty
+// batch when there're no more subscribers. Iterator could be resumed
+// after the error, in this case the same batch is requested again.
+func (it *Iterator) Next(ctx context.Context) ([]Subscriber, error) {
+	if it.done {
+		return nil, nil
+	}
+
+	batch, err := it.page(ctx, it.afterID, it.size)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(batch) < it.size {
+		it.done = true
+	}
+
+	if len(batch) != 0 {
+		it.afterID = batch[len(batch)-1].ID
+	}
+
+	return batch, nil
+}
@@ -0,0 +1,124 @@
+package subscriber
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestNewIterator(t *testing.T) {
+	t.Parallel()
+	type args struct {
+		size int
+	}
+	tests := []struct {
+		name     string
+		args     args
+		wantSize int
+	}{
+		{
+			name:     "Should use provided size when it's positive",
+			args:     args{size: 10},
+			wantSize: 10,
+		},
+		{
+			name:     "Should fallback to default size when it's not positive",
+			args:     args{size: 0},
+			wantSize: defaultBatchSize,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			page := func(context.Context, int64, int) ([]Subscriber, error) { return nil, nil }
+			if got := NewIterator(page, tt.args.size); got.size != tt.wantSize {
+				t.Errorf("NewIterator() size = %v, want %v", got.size, tt.wantSize)
+			}
+		})
+	}
+}
+
+func TestIteratorNext(t *testing.T) {
+	t.Parallel()
+	type call struct {
+		afterID int64
+		limit   int
+	}
+	subs := func(ids ...int64) []Subscriber {
+		s := make([]Subscriber, 0, len(ids))
+		for _, id := range ids {
+			s = append(s, Subscriber{ID: id})
+		}
+		return s
+	}
+	tests := []struct {
+		name        string
+		size        int
+		pages       [][]Subscriber
+		failAt      int
+		wantBatches [][]Subscriber
+		wantCalls   []call
+		wantErr     bool
+	}{
+		{
+			name:        "Should stop without extra query when the last page is not full",
+			size:        2,
+			pages:       [][]Subscriber{subs(1, 3), subs(4)},
+			failAt:      -1,
+			wantBatches: [][]Subscriber{subs(1, 3), subs(4), nil},
+			wantCalls:   []call{{0, 2}, {3, 2}},
+		},
+		{
+			name:        "Should query one more page when the last page is full",
+			size:        2,
+			pages:       [][]Subscriber{subs(1, 2), {}},
+			failAt:      -1,
+			wantBatches: [][]Subscriber{subs(1, 2), {}, nil},
+			wantCalls:   []call{{0, 2}, {2, 2}},
+		},
+		{
+			name:        "Should return error and retry the same page after it",
+			size:        2,
+			pages:       [][]Subscriber{subs(1, 2), subs(5)},
+			failAt:      1,
+			wantBatches: [][]Subscriber{subs(1, 2), nil, subs(5)},
+			wantCalls:   []call{{0, 2}, {2, 2}, {2, 2}},
+			wantErr:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var calls []call
+			page := func(_ context.Context, afterID int64, limit int) ([]Subscriber, error) {
+				calls = append(calls, call{afterID, limit})
+				if len(calls)-1 == tt.failAt {
+					return nil, errors.New("failed to get page")
+				}
+				p := tt.pages[0]
+				tt.pages = tt.pages[1:]
+				return p, nil
+			}
+
+			it := NewIterator(page, tt.size)
+			var gotErr bool
+			for i, want := range tt.wantBatches {
+				got, err := it.Next(context.Background())
+				gotErr = gotErr || err != nil
+				if !reflect.DeepEqual(got, want) {
+					t.Errorf("Iterator.Next() #%d = %v, want %v", i, got, want)
+				}
+			}
+
+			if gotErr != tt.wantErr {
+				t.Errorf("Iterator.Next() error = %v, wantErr %v", gotErr, tt.wantErr)
+			}
+			if !reflect.DeepEqual(calls, tt.wantCalls) {
+				t.Errorf("Iterator.Next() calls = %v, want %v", calls, tt.wantCalls)
+			}
+		})
+	}
+}
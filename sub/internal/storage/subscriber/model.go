@@ -2,11 +2,135 @@ package subscriber
 
 import "time"
 
+// Frequency represents how often subscriber wants
+// to receive mails about USD -> UAH rate exchanges.
+type Frequency string
+
+const (
+	// FrequencyDaily means subscriber receives the latest rate every day.
+	FrequencyDaily Frequency = "daily"
+	// FrequencyWeekly means subscriber receives a digest once a week
+	// on the chosen Weekday.
+	FrequencyWeekly Frequency = "weekly"
+	// FrequencyMonthly means subscriber receives a digest once a month
+	// on the chosen MonthDay.
+	FrequencyMonthly Frequency = "monthly"
+)
+
+// Status represents whether subscriber still receives mails.
+type Status string
+
+const (
+	// StatusActive means subscriber receives mails.
+	StatusActive Status = "active"
+	// StatusUnsubscribed means subscriber was deleted and doesn't
+	// receive mails anymore. It's activated again when subscribes back.
+	StatusUnsubscribed Status = "unsubscribed"
+)
+
+// Locale represents language of the mails.
+type Locale string
+
+const (
+	// LocaleEnglish is a default locale of the mails.
+	LocaleEnglish Locale = "en"
+	// LocaleUkrainian means subscriber receives mails in Ukrainian.
+	LocaleUkrainian Locale = "uk"
+)
+
+// Channel represents transport, through which
+// subscriber receives notifications.
+type Channel string
+
+const (
+	// ChannelEmail means subscriber receives mails. It's a default channel.
+	ChannelEmail Channel = "email"
+	// ChannelTelegram means subscriber receives messages from the
+	// Telegram bot. Email of such subscriber is an ID of the chat.
+	ChannelTelegram Channel = "telegram"
+	// ChannelWebhook means subscriber receives signed JSON payloads
+	// posted to the URL. Email of such subscriber is the URL.
+	ChannelWebhook Channel = "webhook"
+	// ChannelSlack means subscriber receives Block Kit messages posted
+	// to the Slack incoming webhook. Email of such subscriber is the URL.
+	ChannelSlack Channel = "slack"
+	// ChannelDiscord means subscriber receives embeds posted to the
+	// Discord webhook. Email of such subscriber is the URL.
+	ChannelDiscord Channel = "discord"
+)
+
 // Subscriber is a model, which represents
 // user, subscribed to daily receive mails about
 // USD -> UAH rate exchanges.
+// NOTE: Weekday is only meaningful for the weekly frequency (0 is Sunday),
+// and MonthDay is only meaningful for the monthly one (1-31).
 type Subscriber struct {
-	ID        int64     `db:"id"`
-	Email     string    `db:"email"`
-	CreatedAt time.Time `db:"created_at"`
+	ID      int64   `db:"id"`
+	Channel Channel `db:"channel"`
+	Email   string  `db:"email"`
+	// CanonicalEmail is a normalised email, which is unique
+	// across subscribers. Mails are sent to the Email.
+	// Subscribers of other channels have it prefixed with
+	// the channel, i.e. telegram:42.
+	CanonicalEmail string    `db:"canonical_email"`
+	Frequency      Frequency `db:"frequency"`
+	Weekday        int       `db:"weekday"`
+	MonthDay       int       `db:"month_day"`
+	Status         Status    `db:"status"`
+	Locale         Locale    `db:"locale"`
+	CreatedAt      time.Time `db:"created_at"`
+	// Addresses are the additional channels, through which
+	// subscriber receives the same notifications.
+	Addresses []Address `db:"-"`
+}
+
+// Address is an additional channel address of the subscriber,
+// i.e. ID of the Telegram chat of the email subscriber.
+// Subscriber has at most one address per channel.
+type Address struct {
+	SubscriberID int64     `db:"subscriber_id"`
+	Channel      Channel   `db:"channel"`
+	Address      string    `db:"address"`
+	CreatedAt    time.Time `db:"created_at"`
+}
+
+// Targets returns all channel addresses of the subscriber: the
+// primary one first, followed by the additional ones. Primary address
+// of the subscriber without channel is treated as the email one.
+func (s Subscriber) Targets() []Address {
+	primary := Address{SubscriberID: s.ID, Channel: s.Channel, Address: s.Email}
+	if primary.Channel == "" {
+		primary.Channel = ChannelEmail
+	}
+
+	targets := make([]Address, 0, len(s.Addresses)+1)
+	targets = append(targets, primary)
+	for i := range s.Addresses {
+		if s.Addresses[i].Channel != primary.Channel {
+			targets = append(targets, s.Addresses[i])
+		}
+	}
+
+	return targets
+}
+
+// Filter represents criteria of the subscribers lookup. Zero values
+// are ignored. Email matches subscribers, whose email contains it.
+// Subscribers are returned ordered by ID, AfterID is an ID of the last
+// subscriber from the previous page.
+type Filter struct {
+	Channel     Channel
+	Email       string
+	Status      Status
+	CreatedFrom time.Time
+	CreatedTo   time.Time
+	AfterID     int64
+	Limit       int
+}
+
+// Page represents single page of the subscribers.
+// NextAfterID is zero when there're no more subscribers.
+type Page struct {
+	Subscribers []Subscriber
+	NextAfterID int64
 }
@@ -0,0 +1,53 @@
+package subscriber
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSubscriberTargets(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name string
+		s    Subscriber
+		want []Address
+	}{
+		{
+			name: "Should return primary email address when channel is missing",
+			s:    Subscriber{ID: 1, Email: "test@test.com"},
+			want: []Address{{SubscriberID: 1, Channel: ChannelEmail, Address: "test@test.com"}},
+		},
+		{
+			name: "Should return primary address followed by the additional ones",
+			s: Subscriber{
+				ID:        1,
+				Channel:   ChannelEmail,
+				Email:     "test@test.com",
+				Addresses: []Address{{SubscriberID: 1, Channel: ChannelTelegram, Address: "42"}},
+			},
+			want: []Address{
+				{SubscriberID: 1, Channel: ChannelEmail, Address: "test@test.com"},
+				{SubscriberID: 1, Channel: ChannelTelegram, Address: "42"},
+			},
+		},
+		{
+			name: "Should skip additional address of the primary channel",
+			s: Subscriber{
+				ID:        1,
+				Channel:   ChannelTelegram,
+				Email:     "42",
+				Addresses: []Address{{SubscriberID: 1, Channel: ChannelTelegram, Address: "43"}},
+			},
+			want: []Address{{SubscriberID: 1, Channel: ChannelTelegram, Address: "42"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := tt.s.Targets(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Subscriber.Targets() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -2,9 +2,12 @@ package subscriber
 
 import (
 	"context"
+	"database/sql"
 	"errors"
+	"fmt"
+	"strings"
+	"time"
 
-	"github.com/go-sql-driver/mysql"
 	"github.com/jmoiron/sqlx"
 
 	"github.com/hrvadl/converter/sub/internal/storage/platform/db"
@@ -18,36 +21,387 @@ type Repo struct {
 }
 
 // NewRepo constructs repo with provided sqlx DB connection.
-// NOTE: it expectes db connection to be connection MySQL.
+// NOTE: db connection could be MySQL, PostgreSQL or SQLite one.
 func NewRepo(db *sqlx.DB) *Repo {
 	return &Repo{
 		db: db,
 	}
 }
 
+const columns = "id, channel, email, canonical_email, frequency, weekday, month_day, status, locale, created_at"
+
 // Save method saves subscriber to the repo and then returns
-// newly created ID. Could return an error if email is not valid, or such email
+// newly created ID. Subscribers are unique by the canonical email.
+// Unsubscribed subscriber with the same canonical email is activated
+// again with the new email and settings, and its ID is returned.
+// Subscriber without channel is saved as the email one.
+// Could return an error if email is not valid, or such email
 // already exists.
 func (r *Repo) Save(ctx context.Context, s Subscriber) (int64, error) {
-	res, err := r.db.ExecContext(ctx, "INSERT INTO subscribers (email) VALUES (?)", s.Email)
+	if s.Channel == "" {
+		s.Channel = ChannelEmail
+	}
+
+	id, err := db.Insert(
+		ctx,
+		r.db,
+		`INSERT INTO subscribers (channel, email, canonical_email, frequency, weekday, month_day, locale)
+		VALUES (?, ?, ?, ?, ?, ?, ?)`,
+		s.Channel,
+		s.Email,
+		s.CanonicalEmail,
+		s.Frequency,
+		s.Weekday,
+		s.MonthDay,
+		s.Locale,
+	)
 	if err == nil {
-		return res.LastInsertId()
+		return id, nil
 	}
 
-	var mySQLErr *mysql.MySQLError
-	if errors.As(err, &mySQLErr) && mySQLErr.Number == db.AlreadyExistsErrCode {
-		return 0, ErrAlreadyExists
+	if db.DialectOf(r.db).IsUniqueViolation(err) {
+		return r.resubscribe(ctx, s)
 	}
 
 	return 0, err
 }
 
-// Get method gets all subscribers from the DB.
-func (r *Repo) Get(ctx context.Context) ([]Subscriber, error) {
+// resubscribe method activates unsubscribed subscriber with the same
+// canonical email. Returns ErrAlreadyExists if subscriber with such
+// canonical email is already active.
+func (r *Repo) resubscribe(ctx context.Context, s Subscriber) (int64, error) {
+	res, err := r.db.ExecContext(
+		ctx,
+		r.db.Rebind(`UPDATE subscribers SET status = ?, email = ?, frequency = ?, weekday = ?, month_day = ?, locale = ?
+		WHERE canonical_email = ? AND status = ?`),
+		StatusActive,
+		s.Email,
+		s.Frequency,
+		s.Weekday,
+		s.MonthDay,
+		s.Locale,
+		s.CanonicalEmail,
+		StatusUnsubscribed,
+	)
+	if err != nil {
+		return 0, fmt.Errorf("failed to activate subscriber: %w", err)
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get activated subscribers: %w", err)
+	}
+
+	if n == 0 {
+		return 0, ErrAlreadyExists
+	}
+
+	var id int64
+	err = r.db.GetContext(ctx, &id, r.db.Rebind("SELECT id FROM subscribers WHERE canonical_email = ?"), s.CanonicalEmail)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get subscriber id: %w", err)
+	}
+
+	return id, nil
+}
+
+// Get method returns at most limit subscribers matching the
+// filter, ordered by ID.
+func (r *Repo) Get(ctx context.Context, f Filter) ([]Subscriber, error) {
+	where, args := newFilter(f)
 	subscribers := []Subscriber{}
-	if err := r.db.SelectContext(ctx, &subscribers, "SELECT * FROM subscribers"); err != nil {
+	err := r.db.SelectContext(
+		ctx,
+		&subscribers,
+		r.db.Rebind("SELECT "+columns+" FROM subscribers"+where+" ORDER BY id LIMIT ?"),
+		append(args, f.Limit)...,
+	)
+	if err != nil {
 		return nil, err
 	}
 
 	return subscribers, nil
 }
+
+// Count method returns number of subscribers matching
+// the filter. AfterID and Limit are ignored.
+func (r *Repo) Count(ctx context.Context, f Filter) (int64, error) {
+	f.AfterID = 0
+	where, args := newFilter(f)
+	var n int64
+	if err := r.db.GetContext(ctx, &n, r.db.Rebind("SELECT COUNT(*) FROM subscribers"+where), args...); err != nil {
+		return 0, err
+	}
+
+	return n, nil
+}
+
+// GetByID method returns subscriber with the given ID.
+// Returns ErrNotFound if there's no such subscriber.
+func (r *Repo) GetByID(ctx context.Context, id int64) (Subscriber, error) {
+	var s Subscriber
+	err := r.db.GetContext(ctx, &s, r.db.Rebind("SELECT "+columns+" FROM subscribers WHERE id = ?"), id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return Subscriber{}, ErrNotFound
+	}
+
+	return s, err
+}
+
+// GetByEmail method returns subscriber with the given email.
+// Returns ErrNotFound if there's no such subscriber.
+func (r *Repo) GetByEmail(ctx context.Context, email string) (Subscriber, error) {
+	var s Subscriber
+	err := r.db.GetContext(ctx, &s, r.db.Rebind("SELECT "+columns+" FROM subscribers WHERE email = ?"), email)
+	if errors.Is(err, sql.ErrNoRows) {
+		return Subscriber{}, ErrNotFound
+	}
+
+	return s, err
+}
+
+// GetByCanonicalEmail method returns subscriber with the given
+// canonical email. Returns ErrNotFound if there's no such subscriber.
+func (r *Repo) GetByCanonicalEmail(ctx context.Context, canonical string) (Subscriber, error) {
+	var s Subscriber
+	err := r.db.GetContext(
+		ctx,
+		&s,
+		r.db.Rebind("SELECT "+columns+" FROM subscribers WHERE canonical_email = ?"),
+		canonical,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return Subscriber{}, ErrNotFound
+	}
+
+	return s, err
+}
+
+// Unsubscribe method marks subscriber with the given ID as unsubscribed,
+// so no more mails are sent to it. Returns ErrNotFound if there's no
+// such subscriber.
+func (r *Repo) Unsubscribe(ctx context.Context, id int64) error {
+	res, err := r.db.ExecContext(
+		ctx,
+		r.db.Rebind("UPDATE subscribers SET status = ? WHERE id = ?"),
+		StatusUnsubscribed,
+		id,
+	)
+	if err != nil {
+		return err
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if n == 0 {
+		if _, err := r.GetByID(ctx, id); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// GetDue method gets at most limit active subscribers with ID greater
+// than afterID, which should receive a mail on the day of the given point
+// of time in its location: all daily subscribers, weekly subscribers with the matching weekday
+// and monthly subscribers with the matching day of month. Monthly subscribers,
+// whose day doesn't exist in the current month (i.e 31st in April), are picked
+// up on the last day of the month. Subscribers are ordered by ID, so all of
+// them could be read page by page with the Iterator.
+func (r *Repo) GetDue(ctx context.Context, at time.Time, afterID int64, limit int) ([]Subscriber, error) {
+	f := newDueFilter(at)
+	subscribers := []Subscriber{}
+	err := r.db.SelectContext(
+		ctx,
+		&subscribers,
+		r.db.Rebind("SELECT "+columns+` FROM subscribers
+		WHERE status = ? AND id > ? AND (frequency = ?
+		OR (frequency = ? AND weekday = ?)
+		OR (frequency = ? AND (month_day = ? OR (? AND month_day > ?))))
+		ORDER BY id LIMIT ?`),
+		StatusActive,
+		afterID,
+		FrequencyDaily,
+		FrequencyWeekly, f.weekday,
+		FrequencyMonthly, f.monthDay, f.lastDayOfMonth, f.monthDay,
+		limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.loadAddresses(ctx, subscribers); err != nil {
+		return nil, fmt.Errorf("failed to get addresses: %w", err)
+	}
+
+	return subscribers, nil
+}
+
+// SaveAddress method adds the channel address to the subscriber.
+// Returns ErrNotFound if there's no such subscriber and ErrAddressExists
+// if subscriber already has an address of the same channel.
+func (r *Repo) SaveAddress(ctx context.Context, a Address) error {
+	if _, err := r.GetByID(ctx, a.SubscriberID); err != nil {
+		return err
+	}
+
+	_, err := r.db.ExecContext(
+		ctx,
+		r.db.Rebind("INSERT INTO subscriber_addresses (subscriber_id, channel, address) VALUES (?, ?, ?)"),
+		a.SubscriberID,
+		a.Channel,
+		a.Address,
+	)
+	if db.DialectOf(r.db).IsUniqueViolation(err) {
+		return ErrAddressExists
+	}
+
+	return err
+}
+
+// DeleteAddress method removes the channel address of the subscriber.
+// Returns ErrAddressNotFound if subscriber doesn't have such address.
+func (r *Repo) DeleteAddress(ctx context.Context, subscriberID int64, c Channel) error {
+	res, err := r.db.ExecContext(
+		ctx,
+		r.db.Rebind("DELETE FROM subscriber_addresses WHERE subscriber_id = ? AND channel = ?"),
+		subscriberID,
+		c,
+	)
+	if err != nil {
+		return err
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if n == 0 {
+		return ErrAddressNotFound
+	}
+
+	return nil
+}
+
+// GetAddresses method returns additional channel
+// addresses of the subscriber ordered by the channel.
+func (r *Repo) GetAddresses(ctx context.Context, subscriberID int64) ([]Address, error) {
+	addresses := []Address{}
+	err := r.db.SelectContext(
+		ctx,
+		&addresses,
+		r.db.Rebind(`SELECT subscriber_id, channel, address, created_at FROM subscriber_addresses
+		WHERE subscriber_id = ? ORDER BY channel`),
+		subscriberID,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return addresses, nil
+}
+
+// loadAddresses fills additional channel addresses of the
+// subscribers with a single query.
+func (r *Repo) loadAddresses(ctx context.Context, subscribers []Subscriber) error {
+	if len(subscribers) == 0 {
+		return nil
+	}
+
+	ids := make([]int64, 0, len(subscribers))
+	byID := make(map[int64]int, len(subscribers))
+	for i := range subscribers {
+		ids = append(ids, subscribers[i].ID)
+		byID[subscribers[i].ID] = i
+	}
+
+	query, args, err := sqlx.In(
+		`SELECT subscriber_id, channel, address, created_at FROM subscriber_addresses
+		WHERE subscriber_id IN (?) ORDER BY subscriber_id, channel`,
+		ids,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to build query: %w", err)
+	}
+
+	addresses := []Address{}
+	if err := r.db.SelectContext(ctx, &addresses, r.db.Rebind(query), args...); err != nil {
+		return err
+	}
+
+	for _, a := range addresses {
+		i := byID[a.SubscriberID]
+		subscribers[i].Addresses = append(subscribers[i].Addresses, a)
+	}
+
+	return nil
+}
+
+// dueFilter contains the calendar facts about the
+// day, which are needed to decide whether subscriber is due.
+type dueFilter struct {
+	weekday        int
+	monthDay       int
+	lastDayOfMonth bool
+}
+
+func newDueFilter(at time.Time) dueFilter {
+	return dueFilter{
+		weekday:        int(at.Weekday()),
+		monthDay:       at.Day(),
+		lastDayOfMonth: at.AddDate(0, 0, 1).Month() != at.Month(),
+	}
+}
+
+// newFilter builds WHERE clause with its arguments from
+// the non-zero fields of the filter.
+func newFilter(f Filter) (string, []any) {
+	var (
+		conds []string
+		args  []any
+	)
+
+	add := func(cond string, arg any) {
+		conds = append(conds, cond)
+		args = append(args, arg)
+	}
+
+	if f.Channel != "" {
+		add("channel = ?", f.Channel)
+	}
+	if f.Email != "" {
+		add(`email LIKE ? ESCAPE '!'`, "%"+escapeLike(f.Email)+"%")
+	}
+	if f.Status != "" {
+		add("status = ?", f.Status)
+	}
+	if !f.CreatedFrom.IsZero() {
+		add("created_at >= ?", f.CreatedFrom.UTC())
+	}
+	if !f.CreatedTo.IsZero() {
+		add("created_at < ?", f.CreatedTo.UTC())
+	}
+	if f.AfterID != 0 {
+		add("id > ?", f.AfterID)
+	}
+
+	if len(conds) == 0 {
+		return "", nil
+	}
+
+	return fmt.Sprintf(" WHERE %s", strings.Join(conds, " AND ")), args
+}
+
+// likeEscaper escapes wildcards of the LIKE pattern, so they're matched
+// literally. Backslash isn't an escape character of SQLite, and it should
+// be escaped itself in MySQL string literals, so ! is used instead.
+var likeEscaper = strings.NewReplacer("!", "!!", "%", "!%", "_", "!_")
+
+func escapeLike(s string) string {
+	return likeEscaper.Replace(s)
+}
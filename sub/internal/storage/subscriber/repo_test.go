@@ -1,9 +1,15 @@
 package subscriber
 
 import (
+	"context"
+	"errors"
+	"reflect"
 	"testing"
+	"time"
 
 	"github.com/jmoiron/sqlx"
+
+	"github.com/hrvadl/converter/sub/internal/storage/platform/dbtest"
 )
 
 func TestNewRepo(t *testing.T) {
@@ -32,3 +38,547 @@ func TestNewRepo(t *testing.T) {
 		})
 	}
 }
+
+func TestNewDueFilter(t *testing.T) {
+	t.Parallel()
+	type args struct {
+		at time.Time
+	}
+	tests := []struct {
+		name string
+		args args
+		want dueFilter
+	}{
+		{
+			name: "Should build filter for the middle of the month",
+			args: args{
+				at: time.Date(2024, time.May, 15, 12, 0, 0, 0, time.UTC),
+			},
+			want: dueFilter{
+				weekday:        int(time.Wednesday),
+				monthDay:       15,
+				lastDayOfMonth: false,
+			},
+		},
+		{
+			name: "Should detect last day of the short month",
+			args: args{
+				at: time.Date(2024, time.April, 30, 12, 0, 0, 0, time.UTC),
+			},
+			want: dueFilter{
+				weekday:        int(time.Tuesday),
+				monthDay:       30,
+				lastDayOfMonth: true,
+			},
+		},
+		{
+			name: "Should detect last day of February in a leap year",
+			args: args{
+				at: time.Date(2024, time.February, 29, 12, 0, 0, 0, time.UTC),
+			},
+			want: dueFilter{
+				weekday:        int(time.Thursday),
+				monthDay:       29,
+				lastDayOfMonth: true,
+			},
+		},
+		{
+			name: "Should use day in the location of the point of time when it's another day in UTC",
+			args: args{
+				at: time.Date(2024, time.April, 30, 22, 0, 0, 0, time.FixedZone("EDT", -4*60*60)),
+			},
+			want: dueFilter{
+				weekday:        int(time.Tuesday),
+				monthDay:       30,
+				lastDayOfMonth: true,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := newDueFilter(tt.args.at); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("newDueFilter() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewFilter(t *testing.T) {
+	t.Parallel()
+	from := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 5, 2, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name      string
+		f         Filter
+		wantWhere string
+		wantArgs  []any
+	}{
+		{
+			name:      "Should return empty clause when filter is empty",
+			f:         Filter{Limit: 10},
+			wantWhere: "",
+			wantArgs:  nil,
+		},
+		{
+			name:      "Should match email substring with escaped wildcards",
+			f:         Filter{Email: `te_st%!\`},
+			wantWhere: " WHERE email LIKE ? ESCAPE '!'",
+			wantArgs:  []any{`%te!_st!%!!\%`},
+		},
+		{
+			name: "Should join all conditions when all fields are set",
+			f: Filter{
+				Email:       "test",
+				Status:      StatusUnsubscribed,
+				CreatedFrom: from,
+				CreatedTo:   to,
+				AfterID:     100,
+			},
+			wantWhere: " WHERE email LIKE ? ESCAPE '!' AND status = ? AND created_at >= ? AND created_at < ? AND id > ?",
+			wantArgs:  []any{"%test%", StatusUnsubscribed, from, to, int64(100)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			where, args := newFilter(tt.f)
+			if where != tt.wantWhere {
+				t.Errorf("newFilter() where = %v, want %v", where, tt.wantWhere)
+			}
+			if !reflect.DeepEqual(args, tt.wantArgs) {
+				t.Errorf("newFilter() args = %v, want %v", args, tt.wantArgs)
+			}
+		})
+	}
+}
+
+func TestRepoSave(t *testing.T) {
+	t.Parallel()
+	subscriber := Subscriber{
+		Email:          "Test@test.com",
+		CanonicalEmail: "test@test.com",
+		Frequency:      FrequencyDaily,
+		Locale:         LocaleEnglish,
+	}
+	tests := []struct {
+		name       string
+		setup      func(t *testing.T, r *Repo) int64
+		s          Subscriber
+		wantErr    error
+		wantSameID bool
+	}{
+		{
+			name: "Should save new subscriber",
+			s:    subscriber,
+		},
+		{
+			name: "Should return ErrAlreadyExists when canonical email is subscribed",
+			setup: func(t *testing.T, r *Repo) int64 {
+				return save(t, r, subscriber)
+			},
+			s:       Subscriber{Email: "test@test.com", CanonicalEmail: "test@test.com", Frequency: FrequencyDaily},
+			wantErr: ErrAlreadyExists,
+		},
+		{
+			name: "Should return ErrAlreadyExists when another email has the same canonical one",
+			setup: func(t *testing.T, r *Repo) int64 {
+				return save(t, r, subscriber)
+			},
+			s:       Subscriber{Email: "TEST@test.com", CanonicalEmail: "test@test.com", Frequency: FrequencyMonthly},
+			wantErr: ErrAlreadyExists,
+		},
+		{
+			name: "Should subscribe back unsubscribed subscriber with the new settings",
+			setup: func(t *testing.T, r *Repo) int64 {
+				id := save(t, r, subscriber)
+				if err := r.Unsubscribe(context.Background(), id); err != nil {
+					t.Fatalf("Failed to unsubscribe: %v", err)
+				}
+				return id
+			},
+			s: Subscriber{
+				Email:          "test@test.com",
+				CanonicalEmail: "test@test.com",
+				Frequency:      FrequencyWeekly,
+				Weekday:        1,
+				Locale:         LocaleUkrainian,
+			},
+			wantSameID: true,
+		},
+		{
+			name: "Should save telegram subscriber alongside email one",
+			setup: func(t *testing.T, r *Repo) int64 {
+				return save(t, r, subscriber)
+			},
+			s: Subscriber{
+				Channel:        ChannelTelegram,
+				Email:          "42",
+				CanonicalEmail: "telegram:42",
+				Frequency:      FrequencyDaily,
+				Locale:         LocaleEnglish,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			dbtest.Run(t, func(t *testing.T, conn *sqlx.DB) {
+				r := NewRepo(conn)
+				var existing int64
+				if tt.setup != nil {
+					existing = tt.setup(t, r)
+				}
+
+				id, err := r.Save(context.Background(), tt.s)
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("Save() error = %v, wantErr %v", err, tt.wantErr)
+				}
+				if tt.wantErr != nil {
+					return
+				}
+				if tt.wantSameID && id != existing {
+					t.Errorf("Save() = %v, want existing id %v", id, existing)
+				}
+
+				got, err := r.GetByID(context.Background(), id)
+				if err != nil {
+					t.Fatalf("GetByID() error = %v", err)
+				}
+				want := tt.s
+				want.ID, want.Status, want.CreatedAt = id, StatusActive, got.CreatedAt
+				if want.Channel == "" {
+					want.Channel = ChannelEmail
+				}
+				if !reflect.DeepEqual(got, want) {
+					t.Errorf("GetByID() = %v, want %v", got, want)
+				}
+			})
+		})
+	}
+}
+
+func save(t *testing.T, r *Repo, s Subscriber) int64 {
+	t.Helper()
+	id, err := r.Save(context.Background(), s)
+	if err != nil {
+		t.Fatalf("Failed to save subscriber: %v", err)
+	}
+	return id
+}
+
+func TestRepoGet(t *testing.T) {
+	t.Parallel()
+	day := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name      string
+		f         Filter
+		wantIDs   []int64
+		wantCount int64
+	}{
+		{
+			name:      "Should list all subscribers ordered by id",
+			f:         Filter{Limit: 10},
+			wantIDs:   []int64{1, 2, 3, 4, 5},
+			wantCount: 5,
+		},
+		{
+			name:      "Should list the next page of subscribers",
+			f:         Filter{AfterID: 1, Limit: 2},
+			wantIDs:   []int64{2, 3},
+			wantCount: 5,
+		},
+		{
+			name:      "Should list subscribers of the given channel",
+			f:         Filter{Channel: ChannelEmail, Limit: 10},
+			wantIDs:   []int64{1, 2, 3, 4},
+			wantCount: 4,
+		},
+		{
+			name:      "Should match wildcards of the email literally",
+			f:         Filter{Email: "a_b", Limit: 10},
+			wantIDs:   []int64{2},
+			wantCount: 1,
+		},
+		{
+			name:      "Should list subscribers with the given status",
+			f:         Filter{Status: StatusUnsubscribed, Limit: 10},
+			wantIDs:   []int64{3},
+			wantCount: 1,
+		},
+		{
+			name:      "Should list subscribers created in the period",
+			f:         Filter{CreatedFrom: day, CreatedTo: day.AddDate(0, 0, 1), Limit: 10},
+			wantIDs:   []int64{4},
+			wantCount: 1,
+		},
+		{
+			name:      "Should return empty list when nothing matches",
+			f:         Filter{Email: "unknown", Limit: 10},
+			wantIDs:   []int64{},
+			wantCount: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			dbtest.Run(t, func(t *testing.T, conn *sqlx.DB) {
+				r := NewRepo(conn)
+				for _, email := range []string{"axb@test.com", "a_b@test.com", "old@test.com", "new@test.com"} {
+					save(t, r, Subscriber{Email: email, CanonicalEmail: email, Frequency: FrequencyDaily})
+				}
+				save(t, r, Subscriber{
+					Channel:        ChannelTelegram,
+					Email:          "42",
+					CanonicalEmail: "telegram:42",
+					Frequency:      FrequencyDaily,
+				})
+				if err := r.Unsubscribe(context.Background(), 3); err != nil {
+					t.Fatalf("Failed to unsubscribe: %v", err)
+				}
+				_, err := conn.Exec(conn.Rebind("UPDATE subscribers SET created_at = ? WHERE id = ?"), day.Add(time.Hour), 4)
+				if err != nil {
+					t.Fatalf("Failed to set creation time: %v", err)
+				}
+
+				got, err := r.Get(context.Background(), tt.f)
+				if err != nil {
+					t.Fatalf("Get() error = %v", err)
+				}
+
+				ids := make([]int64, 0, len(got))
+				for _, s := range got {
+					ids = append(ids, s.ID)
+				}
+				if !reflect.DeepEqual(ids, tt.wantIDs) {
+					t.Errorf("Get() returned ids %v, want %v", ids, tt.wantIDs)
+				}
+
+				count, err := r.Count(context.Background(), tt.f)
+				if err != nil {
+					t.Fatalf("Count() error = %v", err)
+				}
+				if count != tt.wantCount {
+					t.Errorf("Count() = %v, want %v", count, tt.wantCount)
+				}
+			})
+		})
+	}
+}
+
+func TestRepoGetDue(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		at      time.Time
+		wantIDs []int64
+	}{
+		{
+			name:    "Should return daily and weekly subscribers of the weekday",
+			at:      time.Date(2024, 4, 1, 12, 0, 0, 0, time.UTC),
+			wantIDs: []int64{1, 2},
+		},
+		{
+			name:    "Should return monthly subscribers of the missing day on the last day of month",
+			at:      time.Date(2024, 4, 30, 12, 0, 0, 0, time.UTC),
+			wantIDs: []int64{1, 3},
+		},
+		{
+			name:    "Should return only daily subscribers on the other days",
+			at:      time.Date(2024, 4, 3, 12, 0, 0, 0, time.UTC),
+			wantIDs: []int64{1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			dbtest.Run(t, func(t *testing.T, conn *sqlx.DB) {
+				r := NewRepo(conn)
+				for _, s := range []Subscriber{
+					{Email: "daily@test.com", Frequency: FrequencyDaily},
+					{Email: "weekly@test.com", Frequency: FrequencyWeekly, Weekday: int(time.Monday)},
+					{Email: "monthly@test.com", Frequency: FrequencyMonthly, MonthDay: 31},
+					{Email: "unsubscribed@test.com", Frequency: FrequencyDaily},
+				} {
+					s.CanonicalEmail = s.Email
+					save(t, r, s)
+				}
+				if err := r.Unsubscribe(context.Background(), 4); err != nil {
+					t.Fatalf("Failed to unsubscribe: %v", err)
+				}
+
+				got, err := r.GetDue(context.Background(), tt.at, 0, 10)
+				if err != nil {
+					t.Fatalf("GetDue() error = %v", err)
+				}
+
+				ids := make([]int64, 0, len(got))
+				for _, s := range got {
+					ids = append(ids, s.ID)
+				}
+				if !reflect.DeepEqual(ids, tt.wantIDs) {
+					t.Errorf("GetDue() returned ids %v, want %v", ids, tt.wantIDs)
+				}
+			})
+		})
+	}
+}
+
+func TestRepoNotFound(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name string
+		call func(r *Repo) error
+	}{
+		{
+			name: "Should return ErrNotFound when there's no subscriber with such id",
+			call: func(r *Repo) error {
+				_, err := r.GetByID(context.Background(), 100)
+				return err
+			},
+		},
+		{
+			name: "Should return ErrNotFound when there's no subscriber with such email",
+			call: func(r *Repo) error {
+				_, err := r.GetByEmail(context.Background(), "unknown@test.com")
+				return err
+			},
+		},
+		{
+			name: "Should return ErrNotFound when there's no subscriber with such canonical email",
+			call: func(r *Repo) error {
+				_, err := r.GetByCanonicalEmail(context.Background(), "telegram:42")
+				return err
+			},
+		},
+		{
+			name: "Should return ErrNotFound when unsubscribing unknown subscriber",
+			call: func(r *Repo) error {
+				return r.Unsubscribe(context.Background(), 100)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			dbtest.Run(t, func(t *testing.T, conn *sqlx.DB) {
+				r := NewRepo(conn)
+				save(t, r, Subscriber{Email: "test@test.com", CanonicalEmail: "test@test.com", Frequency: FrequencyDaily})
+				if err := tt.call(r); !errors.Is(err, ErrNotFound) {
+					t.Errorf("error = %v, want %v", err, ErrNotFound)
+				}
+			})
+		})
+	}
+}
+
+func TestRepoUnsubscribe(t *testing.T) {
+	t.Parallel()
+	dbtest.Run(t, func(t *testing.T, conn *sqlx.DB) {
+		r := NewRepo(conn)
+		id := save(t, r, Subscriber{Email: "test@test.com", CanonicalEmail: "test@test.com", Frequency: FrequencyDaily})
+		for range 2 {
+			if err := r.Unsubscribe(context.Background(), id); err != nil {
+				t.Fatalf("Unsubscribe() error = %v", err)
+			}
+		}
+
+		got, err := r.GetByEmail(context.Background(), "test@test.com")
+		if err != nil {
+			t.Fatalf("GetByEmail() error = %v", err)
+		}
+		if got.Status != StatusUnsubscribed {
+			t.Errorf("GetByEmail() status = %v, want %v", got.Status, StatusUnsubscribed)
+		}
+	})
+}
+
+func TestRepoAddresses(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		call    func(r *Repo, id int64) error
+		want    []Channel
+		wantErr error
+	}{
+		{
+			name: "Should load addresses of the due subscriber",
+			call: func(r *Repo, id int64) error {
+				return r.SaveAddress(context.Background(), Address{SubscriberID: id, Channel: ChannelSlack, Address: "https://hooks.slack.com/a"})
+			},
+			want: []Channel{ChannelSlack, ChannelTelegram},
+		},
+		{
+			name: "Should return ErrAddressExists when channel already has an address",
+			call: func(r *Repo, id int64) error {
+				return r.SaveAddress(context.Background(), Address{SubscriberID: id, Channel: ChannelTelegram, Address: "43"})
+			},
+			want:    []Channel{ChannelTelegram},
+			wantErr: ErrAddressExists,
+		},
+		{
+			name: "Should return ErrNotFound when subscriber is unknown",
+			call: func(r *Repo, _ int64) error {
+				return r.SaveAddress(context.Background(), Address{SubscriberID: 100, Channel: ChannelSlack, Address: "https://hooks.slack.com/a"})
+			},
+			want:    []Channel{ChannelTelegram},
+			wantErr: ErrNotFound,
+		},
+		{
+			name: "Should delete address of the channel",
+			call: func(r *Repo, id int64) error {
+				return r.DeleteAddress(context.Background(), id, ChannelTelegram)
+			},
+			want: nil,
+		},
+		{
+			name: "Should return ErrAddressNotFound when channel doesn't have an address",
+			call: func(r *Repo, id int64) error {
+				return r.DeleteAddress(context.Background(), id, ChannelDiscord)
+			},
+			want:    []Channel{ChannelTelegram},
+			wantErr: ErrAddressNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			dbtest.Run(t, func(t *testing.T, conn *sqlx.DB) {
+				r := NewRepo(conn)
+				id := save(t, r, Subscriber{Email: "test@test.com", CanonicalEmail: "test@test.com", Frequency: FrequencyDaily})
+				save(t, r, Subscriber{Email: "other@test.com", CanonicalEmail: "other@test.com", Frequency: FrequencyDaily})
+				err := r.SaveAddress(context.Background(), Address{SubscriberID: id, Channel: ChannelTelegram, Address: "42"})
+				if err != nil {
+					t.Fatalf("Failed to save address: %v", err)
+				}
+
+				if err := tt.call(r, id); !errors.Is(err, tt.wantErr) {
+					t.Fatalf("error = %v, want %v", err, tt.wantErr)
+				}
+
+				due, err := r.GetDue(context.Background(), time.Date(2024, 4, 1, 12, 0, 0, 0, time.UTC), 0, 10)
+				if err != nil {
+					t.Fatalf("GetDue() error = %v", err)
+				}
+				if len(due) != 2 || len(due[1].Addresses) != 0 {
+					t.Fatalf("GetDue() = %v, want addresses only of the first subscriber", due)
+				}
+
+				var got []Channel
+				for _, a := range due[0].Addresses {
+					got = append(got, a.Channel)
+				}
+				if !reflect.DeepEqual(got, tt.want) {
+					t.Errorf("GetDue() address channels = %v, want %v", got, tt.want)
+				}
+			})
+		})
+	}
+}
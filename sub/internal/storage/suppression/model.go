@@ -0,0 +1,63 @@
+package suppression
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	// ErrNotFound is returned when the email isn't suppressed.
+	ErrNotFound = errors.New("suppression is not found")
+	// ErrDuplicate is returned when the event has already been recorded.
+	ErrDuplicate = errors.New("event is already recorded")
+)
+
+// EventType represents the kind of the mail event.
+type EventType string
+
+const (
+	// EventHardBounce means mail was rejected permanently,
+	// i.e mailbox doesn't exist.
+	EventHardBounce EventType = "hard_bounce"
+	// EventSoftBounce means mail was rejected temporarily,
+	// i.e mailbox is full.
+	EventSoftBounce EventType = "soft_bounce"
+	// EventComplaint means recipient has marked mail as spam.
+	EventComplaint EventType = "complaint"
+	// EventLifted means suppression was lifted by the operator.
+	EventLifted EventType = "lifted"
+)
+
+// Reason represents the cause of the suppression.
+type Reason string
+
+const (
+	// ReasonHardBounce means email has bounced permanently.
+	ReasonHardBounce Reason = "hard_bounce"
+	// ReasonSoftBounce means email has bounced temporarily too many times.
+	ReasonSoftBounce Reason = "soft_bounce"
+	// ReasonComplaint means recipient has complained about the mail.
+	ReasonComplaint Reason = "complaint"
+)
+
+// Event is a model, which represents single mail event reported by
+// the mail provider. EventID is an ID of the provider's event, which is
+// used to skip redelivered ones. It's empty for lifted suppressions.
+type Event struct {
+	ID          int64     `db:"id"`
+	EventID     string    `db:"event_id"`
+	Email       string    `db:"email"`
+	Type        EventType `db:"type"`
+	MessageID   string    `db:"message_id"`
+	Description string    `db:"description"`
+	CreatedAt   time.Time `db:"created_at"`
+}
+
+// Suppression is a model, which represents email
+// no mails should be sent to.
+type Suppression struct {
+	Email       string    `db:"email"`
+	Reason      Reason    `db:"reason"`
+	Description string    `db:"description"`
+	CreatedAt   time.Time `db:"created_at"`
+}
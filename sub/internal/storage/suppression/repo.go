@@ -0,0 +1,177 @@
+package suppression
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/hrvadl/converter/sub/internal/storage/platform/db"
+)
+
+// maxDescriptionLength is a length of the description column.
+const maxDescriptionLength = 1024
+
+// Repo is a thin abstraction to not do sqlx queries
+// directly in the services. It records mail events reported
+// by the provider and keeps the list of emails, which shouldn't
+// be mailed anymore. Emails are compared case-insensitively.
+type Repo struct {
+	db *sqlx.DB
+}
+
+// NewRepo constructs repo with provided sqlx DB connection.
+// NOTE: db connection could be MySQL, PostgreSQL or SQLite one.
+func NewRepo(db *sqlx.DB) *Repo {
+	return &Repo{
+		db: db,
+	}
+}
+
+// SaveEvent method records mail event and returns its ID.
+// Returns ErrDuplicate if the event with the same event ID
+// has already been recorded for the email.
+func (r *Repo) SaveEvent(ctx context.Context, e Event) (int64, error) {
+	id, err := db.Insert(
+		ctx,
+		r.db,
+		`INSERT INTO suppression_events (event_id, email, type, message_id, description, created_at)
+		VALUES (?, ?, ?, ?, ?, ?)`,
+		sql.NullString{String: e.EventID, Valid: e.EventID != ""},
+		normalize(e.Email),
+		e.Type,
+		e.MessageID,
+		db.Truncate(e.Description, maxDescriptionLength),
+		time.Now().UTC(),
+	)
+	if db.DialectOf(r.db).IsUniqueViolation(err) {
+		return 0, ErrDuplicate
+	}
+
+	return id, err
+}
+
+// CountEvents method returns number of the events of the given type
+// recorded for the email since the given point of time. Only events
+// recorded after the suppression was lifted last time are counted.
+func (r *Repo) CountEvents(ctx context.Context, email string, t EventType, since time.Time) (int, error) {
+	email = normalize(email)
+	var n int
+	err := r.db.GetContext(
+		ctx,
+		&n,
+		r.db.Rebind(`SELECT COUNT(*) FROM suppression_events
+		WHERE email = ? AND type = ? AND created_at >= ? AND id > COALESCE(
+			(SELECT MAX(id) FROM suppression_events WHERE email = ? AND type = ?), 0
+		)`),
+		email,
+		t,
+		since.UTC(),
+		email,
+		EventLifted,
+	)
+	if err != nil {
+		return 0, err
+	}
+
+	return n, nil
+}
+
+// Suppress method adds email to the suppression list. Email,
+// which is already suppressed, keeps its original reason.
+func (r *Repo) Suppress(ctx context.Context, s Suppression) error {
+	_, err := r.db.ExecContext(
+		ctx,
+		r.db.Rebind(`INSERT INTO suppressions (email, reason, description, created_at)
+		VALUES (?, ?, ?, ?)`),
+		normalize(s.Email),
+		s.Reason,
+		db.Truncate(s.Description, maxDescriptionLength),
+		time.Now().UTC(),
+	)
+	if err != nil && !db.DialectOf(r.db).IsUniqueViolation(err) {
+		return err
+	}
+
+	return nil
+}
+
+// Lift method removes email from the suppression list and records
+// lifted event in a single transaction, so previous bounces aren't
+// counted anymore. Returns ErrNotFound if email isn't suppressed.
+func (r *Repo) Lift(ctx context.Context, email string) error {
+	email = normalize(email)
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin tx: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	res, err := tx.ExecContext(ctx, tx.Rebind("DELETE FROM suppressions WHERE email = ?"), email)
+	if err != nil {
+		return fmt.Errorf("failed to delete suppression: %w", err)
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get deleted suppressions: %w", err)
+	}
+
+	if n == 0 {
+		return ErrNotFound
+	}
+
+	if _, err = db.Insert(
+		ctx,
+		tx,
+		`INSERT INTO suppression_events (email, type, created_at) VALUES (?, ?, ?)`,
+		email,
+		EventLifted,
+		time.Now().UTC(),
+	); err != nil {
+		return fmt.Errorf("failed to record lifted event: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit tx: %w", err)
+	}
+
+	return nil
+}
+
+// GetSuppressed method returns suppressions of the given emails.
+// Emails, which aren't suppressed, are omitted.
+func (r *Repo) GetSuppressed(ctx context.Context, emails []string) ([]Suppression, error) {
+	if len(emails) == 0 {
+		return nil, nil
+	}
+
+	normalized := make([]string, 0, len(emails))
+	for _, e := range emails {
+		normalized = append(normalized, normalize(e))
+	}
+
+	query, args, err := sqlx.In(
+		"SELECT email, reason, description, created_at FROM suppressions WHERE email IN (?)",
+		normalized,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build query: %w", err)
+	}
+
+	s := []Suppression{}
+	if err := r.db.SelectContext(ctx, &s, r.db.Rebind(query), args...); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// normalize returns email in the form, which is stored, so
+// provider's and subscriber's spelling of the email match.
+func normalize(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
@@ -0,0 +1,186 @@
+package suppression
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/hrvadl/converter/sub/internal/storage/platform/dbtest"
+)
+
+func TestNewRepo(t *testing.T) {
+	t.Parallel()
+	type args struct {
+		db *sqlx.DB
+	}
+	tests := []struct {
+		name string
+		args args
+	}{
+		{
+			name: "Should create repo with correct db conn",
+			args: args{
+				db: &sqlx.DB{},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := NewRepo(tt.args.db); got == nil {
+				t.Errorf("NewRepo() = %v, want not nil", got)
+			}
+		})
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name  string
+		email string
+		want  string
+	}{
+		{
+			name:  "Should keep normalized email",
+			email: "test@test.com",
+			want:  "test@test.com",
+		},
+		{
+			name:  "Should lower case and trim email",
+			email: " Test@Test.COM ",
+			want:  "test@test.com",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := normalize(tt.email); got != tt.want {
+				t.Errorf("normalize() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRepoSaveEvent(t *testing.T) {
+	t.Parallel()
+	dbtest.Run(t, func(t *testing.T, conn *sqlx.DB) {
+		r := NewRepo(conn)
+		e := Event{EventID: "msg_1", Email: "test@test.com", Type: EventHardBounce, MessageID: "id"}
+		if _, err := r.SaveEvent(context.Background(), e); err != nil {
+			t.Fatalf("SaveEvent() error = %v", err)
+		}
+
+		if _, err := r.SaveEvent(context.Background(), e); !errors.Is(err, ErrDuplicate) {
+			t.Errorf("SaveEvent() of the same event error = %v, want %v", err, ErrDuplicate)
+		}
+
+		e.Email = "test2@test.com"
+		if _, err := r.SaveEvent(context.Background(), e); err != nil {
+			t.Errorf("SaveEvent() of the same event to another email error = %v", err)
+		}
+	})
+}
+
+func TestRepoCountEvents(t *testing.T) {
+	t.Parallel()
+	dbtest.Run(t, func(t *testing.T, conn *sqlx.DB) {
+		r := NewRepo(conn)
+		ctx := context.Background()
+		since := time.Now().Add(-time.Hour)
+		for _, e := range []Event{
+			{EventID: "msg_1", Email: "test@test.com", Type: EventSoftBounce},
+			{EventID: "msg_2", Email: "Test@test.com", Type: EventSoftBounce},
+			{EventID: "msg_3", Email: "test@test.com", Type: EventComplaint},
+			{EventID: "msg_4", Email: "test2@test.com", Type: EventSoftBounce},
+		} {
+			if _, err := r.SaveEvent(ctx, e); err != nil {
+				t.Fatalf("Failed to save event: %v", err)
+			}
+		}
+
+		n, err := r.CountEvents(ctx, "test@test.com", EventSoftBounce, since)
+		if err != nil {
+			t.Fatalf("CountEvents() error = %v", err)
+		}
+		if n != 2 {
+			t.Errorf("CountEvents() = %v, want 2", n)
+		}
+
+		if n, err = r.CountEvents(ctx, "test@test.com", EventSoftBounce, time.Now().Add(time.Hour)); err != nil || n != 0 {
+			t.Errorf("CountEvents() in the future = %v, %v, want 0", n, err)
+		}
+
+		if err := r.Suppress(ctx, Suppression{Email: "test@test.com", Reason: ReasonSoftBounce}); err != nil {
+			t.Fatalf("Failed to suppress email: %v", err)
+		}
+		if err := r.Lift(ctx, "test@test.com"); err != nil {
+			t.Fatalf("Failed to lift suppression: %v", err)
+		}
+
+		if n, err = r.CountEvents(ctx, "test@test.com", EventSoftBounce, since); err != nil || n != 0 {
+			t.Errorf("CountEvents() after lift = %v, %v, want 0", n, err)
+		}
+	})
+}
+
+func TestRepoSuppress(t *testing.T) {
+	t.Parallel()
+	dbtest.Run(t, func(t *testing.T, conn *sqlx.DB) {
+		r := NewRepo(conn)
+		ctx := context.Background()
+		if err := r.Suppress(ctx, Suppression{Email: "Test@test.com", Reason: ReasonHardBounce}); err != nil {
+			t.Fatalf("Suppress() error = %v", err)
+		}
+		if err := r.Suppress(ctx, Suppression{Email: "test@test.com", Reason: ReasonComplaint}); err != nil {
+			t.Fatalf("Suppress() of suppressed email error = %v", err)
+		}
+
+		got, err := r.GetSuppressed(ctx, []string{"TEST@test.com", "test2@test.com"})
+		if err != nil {
+			t.Fatalf("GetSuppressed() error = %v", err)
+		}
+		if len(got) != 1 || got[0].Email != "test@test.com" || got[0].Reason != ReasonHardBounce {
+			t.Errorf("GetSuppressed() = %v, want test@test.com suppressed due to hard bounce", got)
+		}
+
+		if got, err = r.GetSuppressed(ctx, nil); err != nil || len(got) != 0 {
+			t.Errorf("GetSuppressed() of no emails = %v, %v, want none", got, err)
+		}
+	})
+}
+
+func TestRepoLift(t *testing.T) {
+	t.Parallel()
+	dbtest.Run(t, func(t *testing.T, conn *sqlx.DB) {
+		r := NewRepo(conn)
+		ctx := context.Background()
+		if err := r.Lift(ctx, "test@test.com"); !errors.Is(err, ErrNotFound) {
+			t.Errorf("Lift() of not suppressed email error = %v, want %v", err, ErrNotFound)
+		}
+
+		if err := r.Suppress(ctx, Suppression{Email: "test@test.com", Reason: ReasonComplaint}); err != nil {
+			t.Fatalf("Failed to suppress email: %v", err)
+		}
+		if err := r.Lift(ctx, "Test@test.com"); err != nil {
+			t.Fatalf("Lift() error = %v", err)
+		}
+
+		got, err := r.GetSuppressed(ctx, []string{"test@test.com"})
+		if err != nil || len(got) != 0 {
+			t.Errorf("GetSuppressed() after lift = %v, %v, want none", got, err)
+		}
+
+		if err := r.Suppress(ctx, Suppression{Email: "test@test.com", Reason: ReasonComplaint}); err != nil {
+			t.Fatalf("Failed to suppress email again: %v", err)
+		}
+		if err := r.Lift(ctx, "test@test.com"); err != nil {
+			t.Errorf("Lift() second time error = %v", err)
+		}
+	})
+}
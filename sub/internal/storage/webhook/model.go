@@ -0,0 +1,34 @@
+package webhook
+
+import (
+	"errors"
+
+	"github.com/hrvadl/converter/sub/internal/storage/subscriber"
+)
+
+var (
+	// ErrAlreadyExists is returned when the webhook with
+	// the same URL is already registered.
+	ErrAlreadyExists = errors.New("webhook already exists")
+	// ErrNotFound is returned when the webhook isn't registered.
+	ErrNotFound = errors.New("webhook is not found")
+)
+
+// Webhook is a model, which represents URL registered to receive
+// the rates. It's stored as the subscriber of the Channel, whose email
+// is the URL, and its secret is kept aside, so it never leaves the
+// service with the subscriber.
+type Webhook struct {
+	SubscriberID int64
+	// Channel is either webhook, Slack or Discord one.
+	// Webhook without channel is saved as the webhook one.
+	Channel subscriber.Channel
+	URL     string
+	// CanonicalURL is a normalised URL, which is unique across
+	// subscribers, i.e. webhook:https://example.com. It's the same
+	// for all channels, so URL couldn't be registered twice.
+	CanonicalURL string
+	// Secret is a key of the HMAC signature of the payloads.
+	// It's empty for chats, whose URL is a secret itself.
+	Secret string
+}
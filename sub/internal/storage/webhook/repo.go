@@ -0,0 +1,175 @@
+package webhook
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/hrvadl/converter/sub/internal/storage/platform/db"
+	"github.com/hrvadl/converter/sub/internal/storage/subscriber"
+)
+
+// Repo is a thin abstraction to not do sqlx queries
+// directly in the services. It keeps webhooks as the
+// subscribers of the webhook channel alongside their secrets.
+type Repo struct {
+	db *sqlx.DB
+}
+
+// NewRepo constructs repo with provided sqlx DB connection.
+// NOTE: db connection could be MySQL, PostgreSQL or SQLite one.
+func NewRepo(db *sqlx.DB) *Repo {
+	return &Repo{
+		db: db,
+	}
+}
+
+// Save method registers daily webhook subscriber with its secret
+// in a single transaction and returns ID of the subscriber.
+// Unregistered webhook with the same canonical URL is activated again
+// with the new channel and secret. Secret isn't saved when it's empty.
+// Returns ErrAlreadyExists if such webhook is already active.
+func (r *Repo) Save(ctx context.Context, w Webhook) (int64, error) {
+	if w.Channel == "" {
+		w.Channel = subscriber.ChannelWebhook
+	}
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin tx: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	var s subscriber.Subscriber
+	err = tx.GetContext(
+		ctx,
+		&s,
+		tx.Rebind("SELECT id, status FROM subscribers WHERE canonical_email = ?"+db.DialectOf(tx).ForUpdate()),
+		w.CanonicalURL,
+	)
+
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		s.ID, err = db.Insert(
+			ctx,
+			tx,
+			`INSERT INTO subscribers (channel, email, canonical_email, frequency, locale)
+			VALUES (?, ?, ?, ?, ?)`,
+			w.Channel,
+			w.URL,
+			w.CanonicalURL,
+			subscriber.FrequencyDaily,
+			subscriber.LocaleEnglish,
+		)
+		if db.DialectOf(tx).IsUniqueViolation(err) {
+			return 0, ErrAlreadyExists
+		}
+		if err != nil {
+			return 0, fmt.Errorf("failed to save subscriber: %w", err)
+		}
+	case err != nil:
+		return 0, fmt.Errorf("failed to get subscriber: %w", err)
+	case s.Status == subscriber.StatusActive:
+		return 0, ErrAlreadyExists
+	default:
+		if _, err = tx.ExecContext(
+			ctx,
+			tx.Rebind("UPDATE subscribers SET status = ?, channel = ?, email = ? WHERE id = ?"),
+			subscriber.StatusActive,
+			w.Channel,
+			w.URL,
+			s.ID,
+		); err != nil {
+			return 0, fmt.Errorf("failed to activate subscriber: %w", err)
+		}
+	}
+
+	if _, err = tx.ExecContext(ctx, tx.Rebind("DELETE FROM webhooks WHERE subscriber_id = ?"), s.ID); err != nil {
+		return 0, fmt.Errorf("failed to delete old secret: %w", err)
+	}
+
+	if w.Secret != "" {
+		if _, err = tx.ExecContext(
+			ctx,
+			tx.Rebind("INSERT INTO webhooks (subscriber_id, secret, created_at) VALUES (?, ?, ?)"),
+			s.ID,
+			w.Secret,
+			time.Now().UTC(),
+		); err != nil {
+			return 0, fmt.Errorf("failed to save secret: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit tx: %w", err)
+	}
+
+	return s.ID, nil
+}
+
+// GetSecret method returns secret of the webhook subscriber
+// with the given ID. Returns ErrNotFound if there's no such webhook.
+func (r *Repo) GetSecret(ctx context.Context, subscriberID int64) (string, error) {
+	var secret string
+	err := r.db.GetContext(
+		ctx,
+		&secret,
+		r.db.Rebind("SELECT secret FROM webhooks WHERE subscriber_id = ?"),
+		subscriberID,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", ErrNotFound
+	}
+
+	return secret, err
+}
+
+// Delete method unsubscribes active webhook of any channel with the given
+// canonical URL and deletes its secret in a single transaction, so no more
+// payloads are posted to it. Returns ErrNotFound if there's no such
+// active webhook.
+func (r *Repo) Delete(ctx context.Context, canonicalURL string) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin tx: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	var id int64
+	err = tx.GetContext(
+		ctx,
+		&id,
+		tx.Rebind("SELECT id FROM subscribers WHERE canonical_email = ? AND status = ?"+db.DialectOf(tx).ForUpdate()),
+		canonicalURL,
+		subscriber.StatusActive,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get subscriber: %w", err)
+	}
+
+	if _, err = tx.ExecContext(
+		ctx,
+		tx.Rebind("UPDATE subscribers SET status = ? WHERE id = ?"),
+		subscriber.StatusUnsubscribed,
+		id,
+	); err != nil {
+		return fmt.Errorf("failed to unsubscribe: %w", err)
+	}
+
+	if _, err = tx.ExecContext(ctx, tx.Rebind("DELETE FROM webhooks WHERE subscriber_id = ?"), id); err != nil {
+		return fmt.Errorf("failed to delete secret: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit tx: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,172 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/hrvadl/converter/sub/internal/storage/platform/dbtest"
+	"github.com/hrvadl/converter/sub/internal/storage/subscriber"
+)
+
+func TestNewRepo(t *testing.T) {
+	t.Parallel()
+	type args struct {
+		db *sqlx.DB
+	}
+	tests := []struct {
+		name string
+		args args
+	}{
+		{
+			name: "Should create repo with correct db conn",
+			args: args{
+				db: &sqlx.DB{},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := NewRepo(tt.args.db); got == nil {
+				t.Errorf("NewRepo() = %v, want not nil", got)
+			}
+		})
+	}
+}
+
+func TestRepoSave(t *testing.T) {
+	t.Parallel()
+	dbtest.Run(t, func(t *testing.T, conn *sqlx.DB) {
+		r := NewRepo(conn)
+		subs := subscriber.NewRepo(conn)
+		ctx := context.Background()
+		w := Webhook{
+			URL:          "https://example.com/hook",
+			CanonicalURL: "webhook:https://example.com/hook",
+			Secret:       "first-secret-value",
+		}
+
+		id, err := r.Save(ctx, w)
+		if err != nil {
+			t.Fatalf("Save() error = %v", err)
+		}
+
+		s, err := subs.GetByID(ctx, id)
+		if err != nil {
+			t.Fatalf("Failed to get subscriber: %v", err)
+		}
+		if s.Channel != subscriber.ChannelWebhook || s.Email != w.URL || s.Frequency != subscriber.FrequencyDaily {
+			t.Errorf("Save() saved subscriber = %+v, want daily webhook to %s", s, w.URL)
+		}
+
+		if secret, err := r.GetSecret(ctx, id); err != nil || secret != w.Secret {
+			t.Errorf("GetSecret() = %v, %v, want %v", secret, err, w.Secret)
+		}
+
+		if _, err := r.Save(ctx, w); !errors.Is(err, ErrAlreadyExists) {
+			t.Errorf("Save() of active webhook error = %v, want %v", err, ErrAlreadyExists)
+		}
+
+		if err := r.Delete(ctx, w.CanonicalURL); err != nil {
+			t.Fatalf("Failed to delete webhook: %v", err)
+		}
+
+		w.Secret = "second-secret-value"
+		again, err := r.Save(ctx, w)
+		if err != nil {
+			t.Fatalf("Save() of deleted webhook error = %v", err)
+		}
+		if again != id {
+			t.Errorf("Save() of deleted webhook = %v, want %v", again, id)
+		}
+
+		if secret, err := r.GetSecret(ctx, id); err != nil || secret != w.Secret {
+			t.Errorf("GetSecret() after save = %v, %v, want %v", secret, err, w.Secret)
+		}
+	})
+}
+
+func TestRepoDelete(t *testing.T) {
+	t.Parallel()
+	dbtest.Run(t, func(t *testing.T, conn *sqlx.DB) {
+		r := NewRepo(conn)
+		subs := subscriber.NewRepo(conn)
+		ctx := context.Background()
+		if err := r.Delete(ctx, "webhook:https://example.com/hook"); !errors.Is(err, ErrNotFound) {
+			t.Errorf("Delete() of not registered webhook error = %v, want %v", err, ErrNotFound)
+		}
+
+		id, err := r.Save(ctx, Webhook{
+			URL:          "https://example.com/hook",
+			CanonicalURL: "webhook:https://example.com/hook",
+			Secret:       "first-secret-value",
+		})
+		if err != nil {
+			t.Fatalf("Failed to save webhook: %v", err)
+		}
+
+		if err := r.Delete(ctx, "webhook:https://example.com/hook"); err != nil {
+			t.Fatalf("Delete() error = %v", err)
+		}
+
+		if s, err := subs.GetByID(ctx, id); err != nil || s.Status != subscriber.StatusUnsubscribed {
+			t.Errorf("Subscriber after delete = %+v, %v, want unsubscribed", s, err)
+		}
+
+		if _, err := r.GetSecret(ctx, id); !errors.Is(err, ErrNotFound) {
+			t.Errorf("GetSecret() after delete error = %v, want %v", err, ErrNotFound)
+		}
+
+		if err := r.Delete(ctx, "webhook:https://example.com/hook"); !errors.Is(err, ErrNotFound) {
+			t.Errorf("Delete() second time error = %v, want %v", err, ErrNotFound)
+		}
+	})
+}
+
+func TestRepoSaveChat(t *testing.T) {
+	t.Parallel()
+	dbtest.Run(t, func(t *testing.T, conn *sqlx.DB) {
+		r := NewRepo(conn)
+		subs := subscriber.NewRepo(conn)
+		ctx := context.Background()
+		w := Webhook{
+			Channel:      subscriber.ChannelSlack,
+			URL:          "https://hooks.slack.com/services/T/B/X",
+			CanonicalURL: "webhook:https://hooks.slack.com/services/T/B/X",
+		}
+
+		id, err := r.Save(ctx, w)
+		if err != nil {
+			t.Fatalf("Save() error = %v", err)
+		}
+
+		if s, err := subs.GetByID(ctx, id); err != nil || s.Channel != subscriber.ChannelSlack {
+			t.Errorf("Save() saved subscriber = %+v, %v, want slack one", s, err)
+		}
+
+		if _, err := r.GetSecret(ctx, id); !errors.Is(err, ErrNotFound) {
+			t.Errorf("GetSecret() of chat error = %v, want %v", err, ErrNotFound)
+		}
+
+		w.Channel = subscriber.ChannelWebhook
+		if _, err := r.Save(ctx, w); !errors.Is(err, ErrAlreadyExists) {
+			t.Errorf("Save() of the same URL in another channel error = %v, want %v", err, ErrAlreadyExists)
+		}
+
+		if err := r.Delete(ctx, w.CanonicalURL); err != nil {
+			t.Fatalf("Delete() of chat error = %v", err)
+		}
+
+		w.Channel, w.Secret = subscriber.ChannelWebhook, "first-secret-value"
+		if _, err := r.Save(ctx, w); err != nil {
+			t.Fatalf("Save() of deleted chat as webhook error = %v", err)
+		}
+		if s, err := subs.GetByID(ctx, id); err != nil || s.Channel != subscriber.ChannelWebhook {
+			t.Errorf("Subscriber after save = %+v, %v, want webhook one", s, err)
+		}
+	})
+}
@@ -0,0 +1,84 @@
+// Package chat implements the clients, which post messages
+// to the Slack and Discord incoming webhooks.
+package chat
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// requestTimeout is a maximum duration of the post
+// including reading of the response.
+const requestTimeout = time.Second * 10
+
+// maxResponseSize is a number of bytes of the
+// response, which are read.
+const maxResponseSize = 64 << 10
+
+// StatusError is returned when webhook has responded with
+// non-2xx status. Body is a reason of the failure reported by
+// the chat, i.e. "invalid_token" for Slack.
+type StatusError struct {
+	Code int
+	Body string
+}
+
+func (e *StatusError) Error() string {
+	if e.Body == "" {
+		return fmt.Sprintf("chat webhook responded with status %d", e.Code)
+	}
+	return fmt.Sprintf("chat webhook responded with status %d: %s", e.Code, e.Body)
+}
+
+// newHTTPClient returns copy of the given HTTP client,
+// which doesn't follow redirects, so messages are posted
+// only to the registered URLs.
+func newHTTPClient(hc *http.Client) *http.Client {
+	c := *hc
+	c.CheckRedirect = func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	}
+	return &c
+}
+
+// post posts JSON payload to the URL and returns body of the 2xx
+// response. URL of the incoming webhook contains its token, so it's
+// stripped from the transport errors to not leak to the logs.
+func post(ctx context.Context, hc *http.Client, rawURL, payload string) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, rawURL, strings.NewReader(payload))
+	if err != nil {
+		return nil, errors.New("failed to build chat webhook request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := hc.Do(req)
+	if err != nil {
+		var uerr *url.Error
+		if errors.As(err, &uerr) {
+			err = uerr.Err
+		}
+		return nil, fmt.Errorf("failed to post to chat webhook: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read chat webhook response: %w", err)
+	}
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return nil, &StatusError{Code: resp.StatusCode, Body: string(bytes.TrimSpace(body))}
+	}
+
+	return body, nil
+}
@@ -0,0 +1,163 @@
+package chat
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+const payload = `{"text":"1 USD = 41.50 UAH"}`
+
+// newServer starts TLS server, which checks posted payload
+// and responds with the given status and body.
+func newServer(t *testing.T, status int, body string) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got, _ := io.ReadAll(r.Body)
+		if r.Method != http.MethodPost || string(got) != payload ||
+			r.Header.Get("Content-Type") != "application/json" {
+			t.Errorf("Unexpected request %s %s %v", r.Method, got, r.Header)
+		}
+		if r.URL.Path == "/discord" && r.URL.Query().Get("wait") != "true" {
+			t.Errorf("Discord request %s doesn't wait for the message", r.URL)
+		}
+		w.WriteHeader(status)
+		_, _ = io.WriteString(w, body)
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestSlackSend(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		status   int
+		body     string
+		wantCode int
+		wantBody string
+	}{
+		{
+			name:   "Should post message",
+			status: http.StatusOK,
+			body:   "ok",
+		},
+		{
+			name:     "Should return StatusError with reason when slack rejected message",
+			status:   http.StatusForbidden,
+			body:     "invalid_token\n",
+			wantCode: http.StatusForbidden,
+			wantBody: "invalid_token",
+		},
+		{
+			name:     "Should return StatusError when slack rate limited webhook",
+			status:   http.StatusTooManyRequests,
+			wantCode: http.StatusTooManyRequests,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			srv := newServer(t, tt.status, tt.body)
+			_, err := NewSlack(srv.Client()).Send(context.Background(), srv.URL+"/services/T/B/X", payload)
+			if tt.wantCode == 0 {
+				if err != nil {
+					t.Fatalf("Slack.Send() error = %v", err)
+				}
+				return
+			}
+
+			var statusErr *StatusError
+			if !errors.As(err, &statusErr) || statusErr.Code != tt.wantCode || statusErr.Body != tt.wantBody {
+				t.Errorf("Slack.Send() error = %v, want status %d %q", err, tt.wantCode, tt.wantBody)
+			}
+		})
+	}
+}
+
+func TestDiscordSend(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		status   int
+		body     string
+		want     string
+		wantCode int
+	}{
+		{
+			name:   "Should post message and return its ID",
+			status: http.StatusOK,
+			body:   `{"id":"1234567890","type":0}`,
+			want:   "1234567890",
+		},
+		{
+			name:   "Should post message when discord didn't return it",
+			status: http.StatusNoContent,
+			want:   "",
+		},
+		{
+			name:     "Should return StatusError when webhook is unknown",
+			status:   http.StatusNotFound,
+			body:     `{"message": "Unknown Webhook", "code": 10015}`,
+			wantCode: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			srv := newServer(t, tt.status, tt.body)
+			got, err := NewDiscord(srv.Client()).Send(context.Background(), srv.URL+"/discord", payload)
+			if tt.wantCode != 0 {
+				var statusErr *StatusError
+				if !errors.As(err, &statusErr) || statusErr.Code != tt.wantCode {
+					t.Fatalf("Discord.Send() error = %v, want status %d", err, tt.wantCode)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Discord.Send() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Discord.Send() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSendDoesNotFollowRedirects(t *testing.T) {
+	t.Parallel()
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/elsewhere" {
+			t.Error("Client followed redirect")
+		}
+		http.Redirect(w, r, "/elsewhere", http.StatusTemporaryRedirect)
+	}))
+	t.Cleanup(srv.Close)
+
+	var statusErr *StatusError
+	_, err := NewSlack(srv.Client()).Send(context.Background(), srv.URL+"/hook", payload)
+	if !errors.As(err, &statusErr) || statusErr.Code != http.StatusTemporaryRedirect {
+		t.Errorf("Slack.Send() error = %v, want status %d", err, http.StatusTemporaryRedirect)
+	}
+}
+
+func TestSendStripsURL(t *testing.T) {
+	t.Parallel()
+	srv := httptest.NewTLSServer(http.NotFoundHandler())
+	srv.Close()
+
+	_, err := NewDiscord(srv.Client()).Send(context.Background(), srv.URL+"/api/webhooks/1/topsecret", payload)
+	if err == nil {
+		t.Fatal("Discord.Send() error = nil, want error")
+	}
+	if strings.Contains(err.Error(), "topsecret") {
+		t.Errorf("Discord.Send() error = %v, want error without URL", err)
+	}
+}
@@ -0,0 +1,56 @@
+package chat
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// NewDiscord constructs Discord webhook client on top of
+// the given HTTP client, i.e. the one of the test server.
+// NOTE: http client can't be nil, or client will panic.
+func NewDiscord(hc *http.Client) *Discord {
+	return &Discord{http: newHTTPClient(hc)}
+}
+
+// Discord posts embeds to the Discord webhooks.
+type Discord struct {
+	http *http.Client
+}
+
+type discordMessage struct {
+	ID string `json:"id"`
+}
+
+// Send method posts JSON encoded message to the webhook URL and returns
+// ID of the posted message. Webhook is asked to wait for the message to be
+// posted, so it's confirmed and its ID is returned. Returns StatusError with
+// the reason reported by Discord, if it has rejected the message.
+func (d *Discord) Send(ctx context.Context, rawURL, payload string) (string, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "", errors.New("failed to parse discord webhook url")
+	}
+	q := u.Query()
+	q.Set("wait", "true")
+	u.RawQuery = q.Encode()
+
+	body, err := post(ctx, d.http, u.String(), payload)
+	if err != nil {
+		return "", err
+	}
+
+	if len(body) == 0 {
+		return "", nil
+	}
+
+	var msg discordMessage
+	if err := json.Unmarshal(body, &msg); err != nil {
+		return "", fmt.Errorf("failed to decode discord message: %w", err)
+	}
+
+	return msg.ID, nil
+}
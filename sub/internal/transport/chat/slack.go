@@ -0,0 +1,29 @@
+package chat
+
+import (
+	"context"
+	"net/http"
+)
+
+// NewSlack constructs Slack incoming webhook client on top of
+// the given HTTP client, i.e. the one of the test server.
+// NOTE: http client can't be nil, or client will panic.
+func NewSlack(hc *http.Client) *Slack {
+	return &Slack{http: newHTTPClient(hc)}
+}
+
+// Slack posts Block Kit messages to the Slack incoming webhooks.
+type Slack struct {
+	http *http.Client
+}
+
+// Send method posts JSON encoded message to the incoming webhook URL.
+// Slack doesn't return ID of the posted message, so it's always empty.
+// Returns StatusError with the reason, i.e. "invalid_blocks", if Slack
+// has rejected the message.
+func (s *Slack) Send(ctx context.Context, url, payload string) (string, error) {
+	if _, err := post(ctx, s.http, url, payload); err != nil {
+		return "", err
+	}
+	return "", nil
+}
@@ -5,6 +5,7 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"strings"
 	"time"
 
 	"github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors/retry"
@@ -47,6 +48,7 @@ func NewClient(addr string, from string, log *slog.Logger) (*Client, error) {
 	}
 
 	return &Client{
+		cc:   cc,
 		api:  pb.NewMailerServiceClient(cc),
 		log:  log,
 		from: from,
@@ -59,16 +61,31 @@ func NewClient(addr string, from string, log *slog.Logger) (*Client, error) {
 // on structure creation.
 type Client struct {
 	log  *slog.Logger
+	cc   *grpc.ClientConn
 	api  pb.MailerServiceClient
 	from string
 }
 
-func (c *Client) Send(ctx context.Context, html, subject string, to ...string) error {
-	_, err := c.api.Send(ctx, &pb.Mail{
+// Send method sends mail to the provided recipients and returns
+// ID of the mail assigned by the provider. When mail was sent in several
+// chunks, IDs are joined with a comma. Text is an optional plain-text
+// alternative of the html.
+func (c *Client) Send(ctx context.Context, html, text, subject string, to ...string) (string, error) {
+	res, err := c.api.Send(ctx, &pb.Mail{
 		From:    c.from,
 		To:      to,
 		Subject: subject,
 		Html:    html,
+		Text:    text,
 	})
-	return err
+	if err != nil {
+		return "", err
+	}
+	return strings.Join(res.GetMessageIds(), ","), nil
+}
+
+// Close method closes connection to the mailer service.
+// Client can't be used after it's closed.
+func (c *Client) Close() error {
+	return c.cc.Close()
 }
@@ -22,6 +22,7 @@ func TestClientSend(t *testing.T) {
 	type args struct {
 		ctx     context.Context
 		html    string
+		text    string
 		subject string
 		to      []string
 	}
@@ -30,6 +31,7 @@ func TestClientSend(t *testing.T) {
 		fields  fields
 		args    args
 		setup   func(t *testing.T, mailer pb.MailerServiceClient)
+		want    string
 		wantErr bool
 	}{
 		{
@@ -42,6 +44,7 @@ func TestClientSend(t *testing.T) {
 			args: args{
 				ctx:     context.Background(),
 				html:    "test html",
+				text:    "test text",
 				subject: "test subject",
 				to:      []string{"to@to.com", "to1@to.com"},
 			},
@@ -56,8 +59,10 @@ func TestClientSend(t *testing.T) {
 					To:      []string{"to@to.com", "to1@to.com"},
 					Subject: "test subject",
 					Html:    "test html",
-				}).Times(1).Return(nil, nil)
+					Text:    "test text",
+				}).Times(1).Return(&pb.SendResponse{MessageIds: []string{"id1", "id2"}}, nil)
 			},
+			want:    "id1,id2",
 			wantErr: false,
 		},
 		{
@@ -70,6 +75,7 @@ func TestClientSend(t *testing.T) {
 			args: args{
 				ctx:     context.Background(),
 				html:    "test html",
+				text:    "test text",
 				subject: "test subject",
 				to:      []string{"to@to.com", "to1@to.com"},
 			},
@@ -84,6 +90,7 @@ func TestClientSend(t *testing.T) {
 					To:      []string{"to@to.com", "to1@to.com"},
 					Subject: "test subject",
 					Html:    "test html",
+					Text:    "test text",
 				}).Times(1).Return(nil, errors.New("failed to send"))
 			},
 			wantErr: true,
@@ -99,8 +106,13 @@ func TestClientSend(t *testing.T) {
 				api:  tt.fields.api,
 				from: tt.fields.from,
 			}
-			if err := c.Send(tt.args.ctx, tt.args.html, tt.args.subject, tt.args.to...); (err != nil) != tt.wantErr {
+			got, err := c.Send(tt.args.ctx, tt.args.html, tt.args.text, tt.args.subject, tt.args.to...)
+			if (err != nil) != tt.wantErr {
 				t.Errorf("Client.Send() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("Client.Send() = %v, want %v", got, tt.want)
 			}
 		})
 	}
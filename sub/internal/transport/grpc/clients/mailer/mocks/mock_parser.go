@@ -16,7 +16,6 @@ import (
 	mailer "github.com/hrvadl/converter/protos/gen/go/v1/mailer"
 	gomock "go.uber.org/mock/gomock"
 	grpc "google.golang.org/grpc"
-	emptypb "google.golang.org/protobuf/types/known/emptypb"
 )
 
 // MockMailerServiceClient is a mock of MailerServiceClient interface.
@@ -43,14 +42,14 @@ func (m *MockMailerServiceClient) EXPECT() *MockMailerServiceClientMockRecorder
 }
 
 // Send mocks base method.
-func (m *MockMailerServiceClient) Send(arg0 context.Context, arg1 *mailer.Mail, arg2 ...grpc.CallOption) (*emptypb.Empty, error) {
+func (m *MockMailerServiceClient) Send(arg0 context.Context, arg1 *mailer.Mail, arg2 ...grpc.CallOption) (*mailer.SendResponse, error) {
 	m.ctrl.T.Helper()
 	varargs := []any{arg0, arg1}
 	for _, a := range arg2 {
 		varargs = append(varargs, a)
 	}
 	ret := m.ctrl.Call(m, "Send", varargs...)
-	ret0, _ := ret[0].(*emptypb.Empty)
+	ret0, _ := ret[0].(*mailer.SendResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -14,6 +14,7 @@ import (
 	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/protobuf/types/known/emptypb"
 
+	"github.com/hrvadl/converter/sub/internal/storage/rate"
 	"github.com/hrvadl/converter/sub/pkg/logger"
 )
 
@@ -48,6 +49,7 @@ func NewClient(addr string, log *slog.Logger) (*Client, error) {
 	}
 
 	return &Client{
+		cc:  cc,
 		api: pb.NewRateWatcherServiceClient(cc),
 		log: log,
 	}, nil
@@ -57,6 +59,7 @@ func NewClient(addr string, log *slog.Logger) (*Client, error) {
 // is responsible for retrivieng latest exchange rate.
 type Client struct {
 	log *slog.Logger
+	cc  *grpc.ClientConn
 	api pb.RateWatcherServiceClient
 }
 
@@ -68,3 +71,20 @@ func (c *Client) GetRate(ctx context.Context) (float32, error) {
 
 	return resp.Rate, nil
 }
+
+// GetQuote method returns the latest rate alongside
+// the name of the provider, which returned it.
+func (c *Client) GetQuote(ctx context.Context) (rate.Rate, error) {
+	resp, err := c.api.GetRate(ctx, &emptypb.Empty{})
+	if err != nil {
+		return rate.Rate{}, err
+	}
+
+	return rate.Rate{Rate: resp.Rate, Provider: resp.Provider}, nil
+}
+
+// Close method closes connection to the rate watcher service.
+// Client can't be used after it's closed.
+func (c *Client) Close() error {
+	return c.cc.Close()
+}
@@ -10,6 +10,7 @@ import (
 	pb "github.com/hrvadl/converter/protos/gen/go/v1/ratewatcher"
 	"go.uber.org/mock/gomock"
 
+	"github.com/hrvadl/converter/sub/internal/storage/rate"
 	"github.com/hrvadl/converter/sub/internal/transport/grpc/clients/ratewatcher/mocks"
 )
 
@@ -95,3 +96,57 @@ func TestClientGetRate(t *testing.T) {
 		})
 	}
 }
+
+func TestClientGetQuote(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		setup   func(t *testing.T, rw *mocks.MockRateWatcherServiceClient)
+		want    rate.Rate
+		wantErr bool
+	}{
+		{
+			name: "Should return rate with provider when rate service succeeded",
+			setup: func(t *testing.T, rw *mocks.MockRateWatcherServiceClient) {
+				t.Helper()
+				rw.EXPECT().
+					GetRate(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(&pb.RateResponse{Rate: 41.5, Provider: "exchangerate-api"}, nil)
+			},
+			want:    rate.Rate{Rate: 41.5, Provider: "exchangerate-api"},
+			wantErr: false,
+		},
+		{
+			name: "Should return error when rate service failed",
+			setup: func(t *testing.T, rw *mocks.MockRateWatcherServiceClient) {
+				t.Helper()
+				rw.EXPECT().
+					GetRate(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil, errors.New("failed to get rate"))
+			},
+			want:    rate.Rate{},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			rw := mocks.NewMockRateWatcherServiceClient(gomock.NewController(t))
+			tt.setup(t, rw)
+			c := &Client{log: slog.Default(), api: rw}
+
+			got, err := c.GetQuote(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Client.GetQuote() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if got != tt.want {
+				t.Errorf("Client.GetQuote() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,105 @@
+package admin
+
+import (
+	"context"
+	"crypto/subtle"
+	"strings"
+
+	pb "github.com/hrvadl/converter/protos/gen/go/v1/sub"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	authorizationHeader = "authorization"
+	bearerPrefix        = "Bearer "
+)
+
+// guardedServices are names of the services, which expose subscribers,
+// their notifications and deliveries, so they're guarded with the admin
+// token.
+var guardedServices = []string{
+	pb.AdminService_ServiceDesc.ServiceName,
+	pb.OutboxService_ServiceDesc.ServiceName,
+	pb.DeliveryService_ServiceDesc.ServiceName,
+}
+
+// NewAuthInterceptor constructs interceptor, which guards admin
+// services with the token. Admin calls are allowed only if they carry
+// "authorization: Bearer <token>" metadata, calls to other services are
+// passed through. If token is empty, all admin calls are rejected.
+func NewAuthInterceptor(token string) grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req any,
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (any, error) {
+		if err := authorize(ctx, info.FullMethod, token); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// NewStreamAuthInterceptor constructs stream counterpart
+// of the interceptor from the NewAuthInterceptor.
+func NewStreamAuthInterceptor(token string) grpc.StreamServerInterceptor {
+	return func(
+		srv any,
+		ss grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+		if err := authorize(ss.Context(), info.FullMethod, token); err != nil {
+			return err
+		}
+		return handler(srv, ss)
+	}
+}
+
+// authorize checks whether call to the given method is allowed.
+// Returns GRPC status error if it's not.
+func authorize(ctx context.Context, method, token string) error {
+	if !guarded(method) {
+		return nil
+	}
+
+	if token == "" {
+		return status.Error(codes.PermissionDenied, "admin service is disabled")
+	}
+
+	if !authorized(ctx, token) {
+		return status.Error(codes.Unauthenticated, "invalid admin token")
+	}
+
+	return nil
+}
+
+// guarded reports whether method belongs to one of the guarded services.
+func guarded(method string) bool {
+	for _, name := range guardedServices {
+		if strings.HasPrefix(method, "/"+name+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+func authorized(ctx context.Context, token string) bool {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return false
+	}
+
+	for _, v := range md.Get(authorizationHeader) {
+		got, ok := strings.CutPrefix(v, bearerPrefix)
+		if ok && subtle.ConstantTimeCompare([]byte(got), []byte(token)) == 1 {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,173 @@
+package admin
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestNewAuthInterceptor(t *testing.T) {
+	t.Parallel()
+	const (
+		adminMethod = "/sub.v1.AdminService/ListSubscribers"
+		subMethod   = "/sub.v1.SubService/Subscribe"
+	)
+	withToken := func(token string) context.Context {
+		return metadata.NewIncomingContext(
+			context.Background(),
+			metadata.Pairs(authorizationHeader, bearerPrefix+token),
+		)
+	}
+	tests := []struct {
+		name       string
+		token      string
+		ctx        context.Context
+		method     string
+		wantCode   codes.Code
+		wantCalled bool
+	}{
+		{
+			name:       "Should pass admin call when token is correct",
+			token:      "secret",
+			ctx:        withToken("secret"),
+			method:     adminMethod,
+			wantCode:   codes.OK,
+			wantCalled: true,
+		},
+		{
+			name:       "Should reject admin call when token is wrong",
+			token:      "secret",
+			ctx:        withToken("wrong"),
+			method:     adminMethod,
+			wantCode:   codes.Unauthenticated,
+			wantCalled: false,
+		},
+		{
+			name:       "Should reject admin call when token is missing",
+			token:      "secret",
+			ctx:        context.Background(),
+			method:     adminMethod,
+			wantCode:   codes.Unauthenticated,
+			wantCalled: false,
+		},
+		{
+			name:       "Should reject admin call when scheme is not bearer",
+			token:      "secret",
+			ctx:        metadata.NewIncomingContext(context.Background(), metadata.Pairs(authorizationHeader, "secret")),
+			method:     adminMethod,
+			wantCode:   codes.Unauthenticated,
+			wantCalled: false,
+		},
+		{
+			name:       "Should reject admin call when admin service is disabled",
+			token:      "",
+			ctx:        withToken(""),
+			method:     adminMethod,
+			wantCode:   codes.PermissionDenied,
+			wantCalled: false,
+		},
+		{
+			name:       "Should reject dead letters requeue when token is missing",
+			token:      "secret",
+			ctx:        context.Background(),
+			method:     "/sub.v1.OutboxService/RequeueDeadLetters",
+			wantCode:   codes.Unauthenticated,
+			wantCalled: false,
+		},
+		{
+			name:       "Should reject delivery history call when token is missing",
+			token:      "secret",
+			ctx:        context.Background(),
+			method:     "/sub.v1.DeliveryService/GetDeliveryHistory",
+			wantCode:   codes.Unauthenticated,
+			wantCalled: false,
+		},
+		{
+			name:       "Should pass call to other service without token",
+			token:      "secret",
+			ctx:        context.Background(),
+			method:     subMethod,
+			wantCode:   codes.OK,
+			wantCalled: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			called := false
+			handler := func(_ context.Context, _ any) (any, error) {
+				called = true
+				return nil, nil
+			}
+
+			i := NewAuthInterceptor(tt.token)
+			_, err := i(tt.ctx, nil, &grpc.UnaryServerInfo{FullMethod: tt.method}, handler)
+			if code := status.Code(err); code != tt.wantCode {
+				t.Errorf("NewAuthInterceptor() code = %v, wantCode %v", code, tt.wantCode)
+			}
+			if called != tt.wantCalled {
+				t.Errorf("NewAuthInterceptor() called handler = %v, want %v", called, tt.wantCalled)
+			}
+		})
+	}
+}
+
+// authStream is a fake server stream with the given context.
+type authStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s authStream) Context() context.Context { return s.ctx }
+
+func TestNewStreamAuthInterceptor(t *testing.T) {
+	t.Parallel()
+	const method = "/sub.v1.AdminService/ExportSubscribers"
+	tests := []struct {
+		name       string
+		ctx        context.Context
+		wantCode   codes.Code
+		wantCalled bool
+	}{
+		{
+			name: "Should pass admin stream when token is correct",
+			ctx: metadata.NewIncomingContext(
+				context.Background(),
+				metadata.Pairs(authorizationHeader, bearerPrefix+"secret"),
+			),
+			wantCode:   codes.OK,
+			wantCalled: true,
+		},
+		{
+			name:       "Should reject admin stream when token is missing",
+			ctx:        context.Background(),
+			wantCode:   codes.Unauthenticated,
+			wantCalled: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			called := false
+			handler := func(_ any, _ grpc.ServerStream) error {
+				called = true
+				return nil
+			}
+
+			i := NewStreamAuthInterceptor("secret")
+			err := i(nil, authStream{ctx: tt.ctx}, &grpc.StreamServerInfo{FullMethod: method}, handler)
+			if code := status.Code(err); code != tt.wantCode {
+				t.Errorf("NewStreamAuthInterceptor() code = %v, wantCode %v", code, tt.wantCode)
+			}
+			if called != tt.wantCalled {
+				t.Errorf("NewStreamAuthInterceptor() called handler = %v, want %v", called, tt.wantCalled)
+			}
+		})
+	}
+}
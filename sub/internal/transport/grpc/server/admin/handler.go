@@ -0,0 +1,297 @@
+package admin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	pb "github.com/hrvadl/converter/protos/gen/go/v1/sub"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/hrvadl/converter/sub/internal/service/admin"
+	"github.com/hrvadl/converter/sub/internal/storage/subscriber"
+)
+
+const operation = "admin server"
+
+// Registers admin handler to the given GRPC server. Server should
+// be guarded with the interceptor from the NewAuthInterceptor.
+// NOTE: all parameters are required, the service will panic if
+// either of them is missing.
+func Register(
+	srv *grpc.Server,
+	svc Service,
+	ts Transfer,
+	sl Suppressions,
+	wh Webhooks,
+	log *slog.Logger,
+) {
+	pb.RegisterAdminServiceServer(srv, &Server{
+		log:          log,
+		svc:          svc,
+		transfer:     ts,
+		suppressions: sl,
+		webhooks:     wh,
+	})
+}
+
+//go:generate mockgen -destination=./mocks/mock_svc.go -package=mocks . Service
+type Service interface {
+	ListSubscribers(ctx context.Context, f subscriber.Filter) (subscriber.Page, error)
+	GetSubscriber(ctx context.Context, id int64) (subscriber.Subscriber, error)
+	DeleteSubscriber(ctx context.Context, id int64) error
+	CountSubscribers(ctx context.Context, f subscriber.Filter) (int64, error)
+	AddAddress(ctx context.Context, a subscriber.Address) error
+	RemoveAddress(ctx context.Context, subscriberID int64, c subscriber.Channel) error
+}
+
+// Server represents admin GRPC server
+// which will handle the incoming requests and delegate
+// all work to the underlying svc.
+type Server struct {
+	pb.UnimplementedAdminServiceServer
+	log          *slog.Logger
+	svc          Service
+	transfer     Transfer
+	suppressions Suppressions
+	webhooks     Webhooks
+}
+
+// ListSubscribers method maps request to the filter, calls underlying
+// service method and maps subscribers to the GRPC response. Returns an error,
+// in case there was a failure.
+func (s *Server) ListSubscribers(
+	ctx context.Context,
+	req *pb.ListSubscribersRequest,
+) (*pb.ListSubscribersResponse, error) {
+	f := mapFilter(req.GetFilter())
+	f.AfterID = req.GetAfterId()
+	f.Limit = int(req.GetLimit())
+
+	p, err := s.svc.ListSubscribers(ctx, f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to list subscribers: %w", operation, err)
+	}
+
+	res := &pb.ListSubscribersResponse{
+		Subscribers: make([]*pb.Subscriber, 0, len(p.Subscribers)),
+		NextAfterId: p.NextAfterID,
+	}
+	for i := range p.Subscribers {
+		res.Subscribers = append(res.Subscribers, mapSubscriber(p.Subscribers[i]))
+	}
+
+	return res, nil
+}
+
+// GetSubscriber method calls underlying service method and maps subscriber
+// to the GRPC response. Returns NotFound code if there's no such subscriber.
+func (s *Server) GetSubscriber(ctx context.Context, req *pb.GetSubscriberRequest) (*pb.Subscriber, error) {
+	sub, err := s.svc.GetSubscriber(ctx, req.GetId())
+	if errors.Is(err, subscriber.ErrNotFound) {
+		return nil, status.Errorf(codes.NotFound, "%s: subscriber %d is not found", operation, req.GetId())
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to get subscriber: %w", operation, err)
+	}
+
+	return mapSubscriber(sub), nil
+}
+
+// DeleteSubscriber method calls underlying service method. Returns NotFound
+// code if there's no such subscriber.
+func (s *Server) DeleteSubscriber(
+	ctx context.Context,
+	req *pb.DeleteSubscriberRequest,
+) (*emptypb.Empty, error) {
+	err := s.svc.DeleteSubscriber(ctx, req.GetId())
+	if errors.Is(err, subscriber.ErrNotFound) {
+		return nil, status.Errorf(codes.NotFound, "%s: subscriber %d is not found", operation, req.GetId())
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to delete subscriber: %w", operation, err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+// CountSubscribers method maps request to the filter and calls underlying
+// service method. Returns an error, in case there was a failure.
+func (s *Server) CountSubscribers(
+	ctx context.Context,
+	req *pb.CountSubscribersRequest,
+) (*pb.CountSubscribersResponse, error) {
+	n, err := s.svc.CountSubscribers(ctx, mapFilter(req.GetFilter()))
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to count subscribers: %w", operation, err)
+	}
+
+	return &pb.CountSubscribersResponse{Count: n}, nil
+}
+
+// AddSubscriberAddress method maps request to the address and calls
+// underlying service method, so notifications are sent through its channel
+// as well. Returns InvalidArgument code if channel or address is invalid,
+// NotFound code if there's no such subscriber and AlreadyExists code if
+// subscriber already has an address of the channel.
+func (s *Server) AddSubscriberAddress(
+	ctx context.Context,
+	req *pb.AddSubscriberAddressRequest,
+) (*emptypb.Empty, error) {
+	err := s.svc.AddAddress(ctx, subscriber.Address{
+		SubscriberID: req.GetSubscriberId(),
+		Channel:      mapChannel(req.GetChannel()),
+		Address:      req.GetAddress(),
+	})
+	if errors.Is(err, admin.ErrInvalidChannel) || errors.Is(err, admin.ErrInvalidAddress) {
+		return nil, status.Errorf(codes.InvalidArgument, "%s: %v", operation, err)
+	}
+	if errors.Is(err, subscriber.ErrNotFound) {
+		return nil, status.Errorf(codes.NotFound, "%s: subscriber %d is not found", operation, req.GetSubscriberId())
+	}
+	if errors.Is(err, subscriber.ErrAddressExists) {
+		return nil, status.Errorf(codes.AlreadyExists, "%s: %v", operation, err)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to add address: %w", operation, err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+// RemoveSubscriberAddress method calls underlying service method, so
+// notifications aren't sent through the channel anymore. Returns
+// InvalidArgument code if channel is invalid and NotFound code if
+// subscriber doesn't have an address of the channel.
+func (s *Server) RemoveSubscriberAddress(
+	ctx context.Context,
+	req *pb.RemoveSubscriberAddressRequest,
+) (*emptypb.Empty, error) {
+	err := s.svc.RemoveAddress(ctx, req.GetSubscriberId(), mapChannel(req.GetChannel()))
+	if errors.Is(err, admin.ErrInvalidChannel) {
+		return nil, status.Errorf(codes.InvalidArgument, "%s: %v", operation, err)
+	}
+	if errors.Is(err, subscriber.ErrAddressNotFound) {
+		return nil, status.Errorf(codes.NotFound, "%s: %v", operation, err)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to remove address: %w", operation, err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+// mapFilter maps GRPC filter to the subscriber's one.
+// Missing filter matches all subscribers.
+func mapFilter(pf *pb.SubscriberFilter) subscriber.Filter {
+	f := subscriber.Filter{
+		Email:  pf.GetEmail(),
+		Status: mapStatus(pf.GetStatus()),
+	}
+
+	if pf.GetCreatedFrom() != nil {
+		f.CreatedFrom = pf.GetCreatedFrom().AsTime()
+	}
+
+	if pf.GetCreatedTo() != nil {
+		f.CreatedTo = pf.GetCreatedTo().AsTime()
+	}
+
+	return f
+}
+
+// mapStatus maps GRPC subscriber status to the subscriber's one.
+// Unspecified status is mapped to the empty one, so it's ignored.
+func mapStatus(s pb.SubscriberStatus) subscriber.Status {
+	switch s {
+	case pb.SubscriberStatus_SUBSCRIBER_STATUS_ACTIVE:
+		return subscriber.StatusActive
+	case pb.SubscriberStatus_SUBSCRIBER_STATUS_UNSUBSCRIBED:
+		return subscriber.StatusUnsubscribed
+	default:
+		return ""
+	}
+}
+
+// mapChannel maps GRPC channel to the subscriber's one.
+// Unspecified channel is mapped to the empty one, so it's rejected.
+func mapChannel(c pb.Channel) subscriber.Channel {
+	switch c {
+	case pb.Channel_CHANNEL_EMAIL:
+		return subscriber.ChannelEmail
+	case pb.Channel_CHANNEL_TELEGRAM:
+		return subscriber.ChannelTelegram
+	case pb.Channel_CHANNEL_WEBHOOK:
+		return subscriber.ChannelWebhook
+	case pb.Channel_CHANNEL_SLACK:
+		return subscriber.ChannelSlack
+	case pb.Channel_CHANNEL_DISCORD:
+		return subscriber.ChannelDiscord
+	default:
+		return ""
+	}
+}
+
+// mapPbChannel maps subscriber's channel to the GRPC one.
+// Empty channel is mapped to the email, since it's a default one.
+func mapPbChannel(c subscriber.Channel) pb.Channel {
+	switch c {
+	case "", subscriber.ChannelEmail:
+		return pb.Channel_CHANNEL_EMAIL
+	case subscriber.ChannelTelegram:
+		return pb.Channel_CHANNEL_TELEGRAM
+	case subscriber.ChannelWebhook:
+		return pb.Channel_CHANNEL_WEBHOOK
+	case subscriber.ChannelSlack:
+		return pb.Channel_CHANNEL_SLACK
+	case subscriber.ChannelDiscord:
+		return pb.Channel_CHANNEL_DISCORD
+	default:
+		return pb.Channel_CHANNEL_UNSPECIFIED
+	}
+}
+
+func mapSubscriber(s subscriber.Subscriber) *pb.Subscriber {
+	res := &pb.Subscriber{
+		Id:        s.ID,
+		Email:     s.Email,
+		Frequency: pb.Frequency_FREQUENCY_UNSPECIFIED,
+		Weekday:   int32(s.Weekday),
+		MonthDay:  int32(s.MonthDay),
+		Status:    pb.SubscriberStatus_SUBSCRIBER_STATUS_UNSPECIFIED,
+		Locale:    string(s.Locale),
+		CreatedAt: timestamppb.New(s.CreatedAt),
+		Channel:   mapPbChannel(s.Channel),
+	}
+
+	for _, a := range s.Addresses {
+		res.Addresses = append(res.Addresses, &pb.SubscriberAddress{
+			Channel:   mapPbChannel(a.Channel),
+			Address:   a.Address,
+			CreatedAt: timestamppb.New(a.CreatedAt),
+		})
+	}
+
+	switch s.Frequency {
+	case subscriber.FrequencyDaily:
+		res.Frequency = pb.Frequency_FREQUENCY_DAILY
+	case subscriber.FrequencyWeekly:
+		res.Frequency = pb.Frequency_FREQUENCY_WEEKLY
+	case subscriber.FrequencyMonthly:
+		res.Frequency = pb.Frequency_FREQUENCY_MONTHLY
+	}
+
+	switch s.Status {
+	case subscriber.StatusActive:
+		res.Status = pb.SubscriberStatus_SUBSCRIBER_STATUS_ACTIVE
+	case subscriber.StatusUnsubscribed:
+		res.Status = pb.SubscriberStatus_SUBSCRIBER_STATUS_UNSUBSCRIBED
+	}
+
+	return res
+}
@@ -0,0 +1,722 @@
+package admin
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	pb "github.com/hrvadl/converter/protos/gen/go/v1/sub"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/hrvadl/converter/sub/internal/service/admin"
+	"github.com/hrvadl/converter/sub/internal/storage/subscriber"
+	"github.com/hrvadl/converter/sub/internal/transport/grpc/server/admin/mocks"
+)
+
+func TestServerListSubscribers(t *testing.T) {
+	t.Parallel()
+	type fields struct {
+		log *slog.Logger
+		svc Service
+	}
+	type args struct {
+		ctx context.Context
+		req *pb.ListSubscribersRequest
+	}
+	from := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 5, 2, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		setup   func(t *testing.T, svc Service)
+		want    *pb.ListSubscribersResponse
+		wantErr bool
+	}{
+		{
+			name: "Should return subscribers when service succeeded",
+			fields: fields{
+				log: slog.Default(),
+				svc: mocks.NewMockService(gomock.NewController(t)),
+			},
+			args: args{
+				ctx: context.Background(),
+				req: &pb.ListSubscribersRequest{
+					Filter: &pb.SubscriberFilter{
+						Email:       "test",
+						Status:      pb.SubscriberStatus_SUBSCRIBER_STATUS_ACTIVE,
+						CreatedFrom: timestamppb.New(from),
+						CreatedTo:   timestamppb.New(to),
+					},
+					AfterId: 10,
+					Limit:   1,
+				},
+			},
+			setup: func(t *testing.T, svc Service) {
+				t.Helper()
+				s, ok := svc.(*mocks.MockService)
+				if !ok {
+					t.Fatalf("Failed to cast service to mock service")
+				}
+
+				s.EXPECT().
+					ListSubscribers(gomock.Any(), subscriber.Filter{
+						Email:       "test",
+						Status:      subscriber.StatusActive,
+						CreatedFrom: from,
+						CreatedTo:   to,
+						AfterID:     10,
+						Limit:       1,
+					}).
+					Times(1).
+					Return(subscriber.Page{
+						Subscribers: []subscriber.Subscriber{{
+							ID:        11,
+							Email:     "test@test.com",
+							Frequency: subscriber.FrequencyWeekly,
+							Weekday:   1,
+							MonthDay:  1,
+							Status:    subscriber.StatusActive,
+							CreatedAt: from,
+						}},
+						NextAfterID: 11,
+					}, nil)
+			},
+			want: &pb.ListSubscribersResponse{
+				Subscribers: []*pb.Subscriber{{
+					Id:        11,
+					Email:     "test@test.com",
+					Frequency: pb.Frequency_FREQUENCY_WEEKLY,
+					Weekday:   1,
+					MonthDay:  1,
+					Status:    pb.SubscriberStatus_SUBSCRIBER_STATUS_ACTIVE,
+					CreatedAt: timestamppb.New(from),
+					Channel:   pb.Channel_CHANNEL_EMAIL,
+				}},
+				NextAfterId: 11,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Should not filter when filter is missing",
+			fields: fields{
+				log: slog.Default(),
+				svc: mocks.NewMockService(gomock.NewController(t)),
+			},
+			args: args{
+				ctx: context.Background(),
+				req: &pb.ListSubscribersRequest{},
+			},
+			setup: func(t *testing.T, svc Service) {
+				t.Helper()
+				s, ok := svc.(*mocks.MockService)
+				if !ok {
+					t.Fatalf("Failed to cast service to mock service")
+				}
+
+				s.EXPECT().
+					ListSubscribers(gomock.Any(), subscriber.Filter{}).
+					Times(1).
+					Return(subscriber.Page{}, nil)
+			},
+			want:    &pb.ListSubscribersResponse{},
+			wantErr: false,
+		},
+		{
+			name: "Should return error when service failed",
+			fields: fields{
+				log: slog.Default(),
+				svc: mocks.NewMockService(gomock.NewController(t)),
+			},
+			args: args{
+				ctx: context.Background(),
+				req: &pb.ListSubscribersRequest{},
+			},
+			setup: func(t *testing.T, svc Service) {
+				t.Helper()
+				s, ok := svc.(*mocks.MockService)
+				if !ok {
+					t.Fatalf("Failed to cast service to mock service")
+				}
+
+				s.EXPECT().
+					ListSubscribers(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(subscriber.Page{}, errors.New("failed to list"))
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			tt.setup(t, tt.fields.svc)
+			s := &Server{
+				log: tt.fields.log,
+				svc: tt.fields.svc,
+			}
+			got, err := s.ListSubscribers(tt.args.ctx, tt.args.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Server.ListSubscribers() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !proto.Equal(got, tt.want) {
+				t.Errorf("Server.ListSubscribers() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestServerGetSubscriber(t *testing.T) {
+	t.Parallel()
+	type fields struct {
+		log *slog.Logger
+		svc Service
+	}
+	type args struct {
+		ctx context.Context
+		req *pb.GetSubscriberRequest
+	}
+	created := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name     string
+		fields   fields
+		args     args
+		setup    func(t *testing.T, svc Service)
+		want     *pb.Subscriber
+		wantCode codes.Code
+	}{
+		{
+			name: "Should return subscriber when it exists",
+			fields: fields{
+				log: slog.Default(),
+				svc: mocks.NewMockService(gomock.NewController(t)),
+			},
+			args: args{
+				ctx: context.Background(),
+				req: &pb.GetSubscriberRequest{Id: 1},
+			},
+			setup: func(t *testing.T, svc Service) {
+				t.Helper()
+				s, ok := svc.(*mocks.MockService)
+				if !ok {
+					t.Fatalf("Failed to cast service to mock service")
+				}
+
+				s.EXPECT().
+					GetSubscriber(gomock.Any(), int64(1)).
+					Times(1).
+					Return(subscriber.Subscriber{
+						ID:        1,
+						Email:     "test@test.com",
+						Frequency: subscriber.FrequencyDaily,
+						MonthDay:  1,
+						Status:    subscriber.StatusUnsubscribed,
+						CreatedAt: created,
+						Channel:   subscriber.ChannelEmail,
+						Addresses: []subscriber.Address{{
+							SubscriberID: 1,
+							Channel:      subscriber.ChannelTelegram,
+							Address:      "42",
+							CreatedAt:    created,
+						}},
+					}, nil)
+			},
+			want: &pb.Subscriber{
+				Id:        1,
+				Email:     "test@test.com",
+				Frequency: pb.Frequency_FREQUENCY_DAILY,
+				MonthDay:  1,
+				Status:    pb.SubscriberStatus_SUBSCRIBER_STATUS_UNSUBSCRIBED,
+				CreatedAt: timestamppb.New(created),
+				Channel:   pb.Channel_CHANNEL_EMAIL,
+				Addresses: []*pb.SubscriberAddress{{
+					Channel:   pb.Channel_CHANNEL_TELEGRAM,
+					Address:   "42",
+					CreatedAt: timestamppb.New(created),
+				}},
+			},
+			wantCode: codes.OK,
+		},
+		{
+			name: "Should return not found code when subscriber doesn't exist",
+			fields: fields{
+				log: slog.Default(),
+				svc: mocks.NewMockService(gomock.NewController(t)),
+			},
+			args: args{
+				ctx: context.Background(),
+				req: &pb.GetSubscriberRequest{Id: 2},
+			},
+			setup: func(t *testing.T, svc Service) {
+				t.Helper()
+				s, ok := svc.(*mocks.MockService)
+				if !ok {
+					t.Fatalf("Failed to cast service to mock service")
+				}
+
+				s.EXPECT().
+					GetSubscriber(gomock.Any(), int64(2)).
+					Times(1).
+					Return(subscriber.Subscriber{}, subscriber.ErrNotFound)
+			},
+			want:     nil,
+			wantCode: codes.NotFound,
+		},
+		{
+			name: "Should return error when service failed",
+			fields: fields{
+				log: slog.Default(),
+				svc: mocks.NewMockService(gomock.NewController(t)),
+			},
+			args: args{
+				ctx: context.Background(),
+				req: &pb.GetSubscriberRequest{Id: 3},
+			},
+			setup: func(t *testing.T, svc Service) {
+				t.Helper()
+				s, ok := svc.(*mocks.MockService)
+				if !ok {
+					t.Fatalf("Failed to cast service to mock service")
+				}
+
+				s.EXPECT().
+					GetSubscriber(gomock.Any(), int64(3)).
+					Times(1).
+					Return(subscriber.Subscriber{}, errors.New("failed to get"))
+			},
+			want:     nil,
+			wantCode: codes.Unknown,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			tt.setup(t, tt.fields.svc)
+			s := &Server{
+				log: tt.fields.log,
+				svc: tt.fields.svc,
+			}
+			got, err := s.GetSubscriber(tt.args.ctx, tt.args.req)
+			if code := status.Code(err); code != tt.wantCode {
+				t.Errorf("Server.GetSubscriber() code = %v, wantCode %v", code, tt.wantCode)
+				return
+			}
+			if !proto.Equal(got, tt.want) {
+				t.Errorf("Server.GetSubscriber() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestServerDeleteSubscriber(t *testing.T) {
+	t.Parallel()
+	type fields struct {
+		log *slog.Logger
+		svc Service
+	}
+	type args struct {
+		ctx context.Context
+		req *pb.DeleteSubscriberRequest
+	}
+	tests := []struct {
+		name     string
+		fields   fields
+		args     args
+		setup    func(t *testing.T, svc Service)
+		wantCode codes.Code
+	}{
+		{
+			name: "Should delete subscriber when it exists",
+			fields: fields{
+				log: slog.Default(),
+				svc: mocks.NewMockService(gomock.NewController(t)),
+			},
+			args: args{
+				ctx: context.Background(),
+				req: &pb.DeleteSubscriberRequest{Id: 1},
+			},
+			setup: func(t *testing.T, svc Service) {
+				t.Helper()
+				s, ok := svc.(*mocks.MockService)
+				if !ok {
+					t.Fatalf("Failed to cast service to mock service")
+				}
+
+				s.EXPECT().DeleteSubscriber(gomock.Any(), int64(1)).Times(1).Return(nil)
+			},
+			wantCode: codes.OK,
+		},
+		{
+			name: "Should return not found code when subscriber doesn't exist",
+			fields: fields{
+				log: slog.Default(),
+				svc: mocks.NewMockService(gomock.NewController(t)),
+			},
+			args: args{
+				ctx: context.Background(),
+				req: &pb.DeleteSubscriberRequest{Id: 2},
+			},
+			setup: func(t *testing.T, svc Service) {
+				t.Helper()
+				s, ok := svc.(*mocks.MockService)
+				if !ok {
+					t.Fatalf("Failed to cast service to mock service")
+				}
+
+				s.EXPECT().DeleteSubscriber(gomock.Any(), int64(2)).Times(1).Return(subscriber.ErrNotFound)
+			},
+			wantCode: codes.NotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			tt.setup(t, tt.fields.svc)
+			s := &Server{
+				log: tt.fields.log,
+				svc: tt.fields.svc,
+			}
+			_, err := s.DeleteSubscriber(tt.args.ctx, tt.args.req)
+			if code := status.Code(err); code != tt.wantCode {
+				t.Errorf("Server.DeleteSubscriber() code = %v, wantCode %v", code, tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestServerAddSubscriberAddress(t *testing.T) {
+	t.Parallel()
+	type fields struct {
+		log *slog.Logger
+		svc Service
+	}
+	type args struct {
+		ctx context.Context
+		req *pb.AddSubscriberAddressRequest
+	}
+	tests := []struct {
+		name     string
+		fields   fields
+		args     args
+		setup    func(t *testing.T, svc Service)
+		wantCode codes.Code
+	}{
+		{
+			name: "Should add address when service succeeded",
+			fields: fields{
+				log: slog.Default(),
+				svc: mocks.NewMockService(gomock.NewController(t)),
+			},
+			args: args{
+				ctx: context.Background(),
+				req: &pb.AddSubscriberAddressRequest{
+					SubscriberId: 1,
+					Channel:      pb.Channel_CHANNEL_TELEGRAM,
+					Address:      "42",
+				},
+			},
+			setup: func(t *testing.T, svc Service) {
+				t.Helper()
+				s, ok := svc.(*mocks.MockService)
+				if !ok {
+					t.Fatalf("Failed to cast service to mock service")
+				}
+
+				s.EXPECT().
+					AddAddress(gomock.Any(), subscriber.Address{
+						SubscriberID: 1,
+						Channel:      subscriber.ChannelTelegram,
+						Address:      "42",
+					}).
+					Times(1).
+					Return(nil)
+			},
+			wantCode: codes.OK,
+		},
+		{
+			name: "Should return invalid argument code when address is invalid",
+			fields: fields{
+				log: slog.Default(),
+				svc: mocks.NewMockService(gomock.NewController(t)),
+			},
+			args: args{
+				ctx: context.Background(),
+				req: &pb.AddSubscriberAddressRequest{SubscriberId: 1, Channel: pb.Channel_CHANNEL_EMAIL},
+			},
+			setup: func(t *testing.T, svc Service) {
+				t.Helper()
+				s, ok := svc.(*mocks.MockService)
+				if !ok {
+					t.Fatalf("Failed to cast service to mock service")
+				}
+
+				s.EXPECT().AddAddress(gomock.Any(), gomock.Any()).Times(1).Return(admin.ErrInvalidAddress)
+			},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name: "Should return not found code when subscriber doesn't exist",
+			fields: fields{
+				log: slog.Default(),
+				svc: mocks.NewMockService(gomock.NewController(t)),
+			},
+			args: args{
+				ctx: context.Background(),
+				req: &pb.AddSubscriberAddressRequest{SubscriberId: 2, Channel: pb.Channel_CHANNEL_TELEGRAM, Address: "42"},
+			},
+			setup: func(t *testing.T, svc Service) {
+				t.Helper()
+				s, ok := svc.(*mocks.MockService)
+				if !ok {
+					t.Fatalf("Failed to cast service to mock service")
+				}
+
+				s.EXPECT().AddAddress(gomock.Any(), gomock.Any()).Times(1).Return(subscriber.ErrNotFound)
+			},
+			wantCode: codes.NotFound,
+		},
+		{
+			name: "Should return already exists code when subscriber has address of the channel",
+			fields: fields{
+				log: slog.Default(),
+				svc: mocks.NewMockService(gomock.NewController(t)),
+			},
+			args: args{
+				ctx: context.Background(),
+				req: &pb.AddSubscriberAddressRequest{SubscriberId: 1, Channel: pb.Channel_CHANNEL_TELEGRAM, Address: "42"},
+			},
+			setup: func(t *testing.T, svc Service) {
+				t.Helper()
+				s, ok := svc.(*mocks.MockService)
+				if !ok {
+					t.Fatalf("Failed to cast service to mock service")
+				}
+
+				s.EXPECT().AddAddress(gomock.Any(), gomock.Any()).Times(1).Return(subscriber.ErrAddressExists)
+			},
+			wantCode: codes.AlreadyExists,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			tt.setup(t, tt.fields.svc)
+			s := &Server{
+				log: tt.fields.log,
+				svc: tt.fields.svc,
+			}
+			_, err := s.AddSubscriberAddress(tt.args.ctx, tt.args.req)
+			if code := status.Code(err); code != tt.wantCode {
+				t.Errorf("Server.AddSubscriberAddress() code = %v, wantCode %v", code, tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestServerRemoveSubscriberAddress(t *testing.T) {
+	t.Parallel()
+	type fields struct {
+		log *slog.Logger
+		svc Service
+	}
+	type args struct {
+		ctx context.Context
+		req *pb.RemoveSubscriberAddressRequest
+	}
+	tests := []struct {
+		name     string
+		fields   fields
+		args     args
+		setup    func(t *testing.T, svc Service)
+		wantCode codes.Code
+	}{
+		{
+			name: "Should remove address when it exists",
+			fields: fields{
+				log: slog.Default(),
+				svc: mocks.NewMockService(gomock.NewController(t)),
+			},
+			args: args{
+				ctx: context.Background(),
+				req: &pb.RemoveSubscriberAddressRequest{SubscriberId: 1, Channel: pb.Channel_CHANNEL_SLACK},
+			},
+			setup: func(t *testing.T, svc Service) {
+				t.Helper()
+				s, ok := svc.(*mocks.MockService)
+				if !ok {
+					t.Fatalf("Failed to cast service to mock service")
+				}
+
+				s.EXPECT().RemoveAddress(gomock.Any(), int64(1), subscriber.ChannelSlack).Times(1).Return(nil)
+			},
+			wantCode: codes.OK,
+		},
+		{
+			name: "Should return invalid argument code when channel is unspecified",
+			fields: fields{
+				log: slog.Default(),
+				svc: mocks.NewMockService(gomock.NewController(t)),
+			},
+			args: args{
+				ctx: context.Background(),
+				req: &pb.RemoveSubscriberAddressRequest{SubscriberId: 1},
+			},
+			setup: func(t *testing.T, svc Service) {
+				t.Helper()
+				s, ok := svc.(*mocks.MockService)
+				if !ok {
+					t.Fatalf("Failed to cast service to mock service")
+				}
+
+				s.EXPECT().
+					RemoveAddress(gomock.Any(), int64(1), subscriber.Channel("")).
+					Times(1).
+					Return(admin.ErrInvalidChannel)
+			},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name: "Should return not found code when address doesn't exist",
+			fields: fields{
+				log: slog.Default(),
+				svc: mocks.NewMockService(gomock.NewController(t)),
+			},
+			args: args{
+				ctx: context.Background(),
+				req: &pb.RemoveSubscriberAddressRequest{SubscriberId: 2, Channel: pb.Channel_CHANNEL_DISCORD},
+			},
+			setup: func(t *testing.T, svc Service) {
+				t.Helper()
+				s, ok := svc.(*mocks.MockService)
+				if !ok {
+					t.Fatalf("Failed to cast service to mock service")
+				}
+
+				s.EXPECT().
+					RemoveAddress(gomock.Any(), int64(2), subscriber.ChannelDiscord).
+					Times(1).
+					Return(subscriber.ErrAddressNotFound)
+			},
+			wantCode: codes.NotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			tt.setup(t, tt.fields.svc)
+			s := &Server{
+				log: tt.fields.log,
+				svc: tt.fields.svc,
+			}
+			_, err := s.RemoveSubscriberAddress(tt.args.ctx, tt.args.req)
+			if code := status.Code(err); code != tt.wantCode {
+				t.Errorf("Server.RemoveSubscriberAddress() code = %v, wantCode %v", code, tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestServerCountSubscribers(t *testing.T) {
+	t.Parallel()
+	type fields struct {
+		log *slog.Logger
+		svc Service
+	}
+	type args struct {
+		ctx context.Context
+		req *pb.CountSubscribersRequest
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		setup   func(t *testing.T, svc Service)
+		want    *pb.CountSubscribersResponse
+		wantErr bool
+	}{
+		{
+			name: "Should return count when service succeeded",
+			fields: fields{
+				log: slog.Default(),
+				svc: mocks.NewMockService(gomock.NewController(t)),
+			},
+			args: args{
+				ctx: context.Background(),
+				req: &pb.CountSubscribersRequest{
+					Filter: &pb.SubscriberFilter{Status: pb.SubscriberStatus_SUBSCRIBER_STATUS_UNSUBSCRIBED},
+				},
+			},
+			setup: func(t *testing.T, svc Service) {
+				t.Helper()
+				s, ok := svc.(*mocks.MockService)
+				if !ok {
+					t.Fatalf("Failed to cast service to mock service")
+				}
+
+				s.EXPECT().
+					CountSubscribers(gomock.Any(), subscriber.Filter{Status: subscriber.StatusUnsubscribed}).
+					Times(1).
+					Return(int64(7), nil)
+			},
+			want:    &pb.CountSubscribersResponse{Count: 7},
+			wantErr: false,
+		},
+		{
+			name: "Should return error when service failed",
+			fields: fields{
+				log: slog.Default(),
+				svc: mocks.NewMockService(gomock.NewController(t)),
+			},
+			args: args{
+				ctx: context.Background(),
+				req: &pb.CountSubscribersRequest{},
+			},
+			setup: func(t *testing.T, svc Service) {
+				t.Helper()
+				s, ok := svc.(*mocks.MockService)
+				if !ok {
+					t.Fatalf("Failed to cast service to mock service")
+				}
+
+				s.EXPECT().
+					CountSubscribers(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(int64(0), errors.New("failed to count"))
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			tt.setup(t, tt.fields.svc)
+			s := &Server{
+				log: tt.fields.log,
+				svc: tt.fields.svc,
+			}
+			got, err := s.CountSubscribers(tt.args.ctx, tt.args.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Server.CountSubscribers() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !proto.Equal(got, tt.want) {
+				t.Errorf("Server.CountSubscribers() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
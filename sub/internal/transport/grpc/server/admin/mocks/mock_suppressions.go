@@ -0,0 +1,54 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/hrvadl/converter/sub/internal/transport/grpc/server/admin (interfaces: Suppressions)
+//
+// Generated by this command:
+//
+//	mockgen -destination=./mocks/mock_suppressions.go -package=mocks . Suppressions
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockSuppressions is a mock of Suppressions interface.
+type MockSuppressions struct {
+	ctrl     *gomock.Controller
+	recorder *MockSuppressionsMockRecorder
+}
+
+// MockSuppressionsMockRecorder is the mock recorder for MockSuppressions.
+type MockSuppressionsMockRecorder struct {
+	mock *MockSuppressions
+}
+
+// NewMockSuppressions creates a new mock instance.
+func NewMockSuppressions(ctrl *gomock.Controller) *MockSuppressions {
+	mock := &MockSuppressions{ctrl: ctrl}
+	mock.recorder = &MockSuppressionsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSuppressions) EXPECT() *MockSuppressionsMockRecorder {
+	return m.recorder
+}
+
+// Lift mocks base method.
+func (m *MockSuppressions) Lift(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Lift", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Lift indicates an expected call of Lift.
+func (mr *MockSuppressionsMockRecorder) Lift(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Lift", reflect.TypeOf((*MockSuppressions)(nil).Lift), arg0, arg1)
+}
@@ -0,0 +1,128 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/hrvadl/converter/sub/internal/transport/grpc/server/admin (interfaces: Service)
+//
+// Generated by this command:
+//
+//	mockgen -destination=./mocks/mock_svc.go -package=mocks . Service
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	subscriber "github.com/hrvadl/converter/sub/internal/storage/subscriber"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockService is a mock of Service interface.
+type MockService struct {
+	ctrl     *gomock.Controller
+	recorder *MockServiceMockRecorder
+}
+
+// MockServiceMockRecorder is the mock recorder for MockService.
+type MockServiceMockRecorder struct {
+	mock *MockService
+}
+
+// NewMockService creates a new mock instance.
+func NewMockService(ctrl *gomock.Controller) *MockService {
+	mock := &MockService{ctrl: ctrl}
+	mock.recorder = &MockServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockService) EXPECT() *MockServiceMockRecorder {
+	return m.recorder
+}
+
+// AddAddress mocks base method.
+func (m *MockService) AddAddress(arg0 context.Context, arg1 subscriber.Address) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddAddress", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddAddress indicates an expected call of AddAddress.
+func (mr *MockServiceMockRecorder) AddAddress(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddAddress", reflect.TypeOf((*MockService)(nil).AddAddress), arg0, arg1)
+}
+
+// CountSubscribers mocks base method.
+func (m *MockService) CountSubscribers(arg0 context.Context, arg1 subscriber.Filter) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountSubscribers", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountSubscribers indicates an expected call of CountSubscribers.
+func (mr *MockServiceMockRecorder) CountSubscribers(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountSubscribers", reflect.TypeOf((*MockService)(nil).CountSubscribers), arg0, arg1)
+}
+
+// DeleteSubscriber mocks base method.
+func (m *MockService) DeleteSubscriber(arg0 context.Context, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSubscriber", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSubscriber indicates an expected call of DeleteSubscriber.
+func (mr *MockServiceMockRecorder) DeleteSubscriber(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSubscriber", reflect.TypeOf((*MockService)(nil).DeleteSubscriber), arg0, arg1)
+}
+
+// GetSubscriber mocks base method.
+func (m *MockService) GetSubscriber(arg0 context.Context, arg1 int64) (subscriber.Subscriber, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSubscriber", arg0, arg1)
+	ret0, _ := ret[0].(subscriber.Subscriber)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSubscriber indicates an expected call of GetSubscriber.
+func (mr *MockServiceMockRecorder) GetSubscriber(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubscriber", reflect.TypeOf((*MockService)(nil).GetSubscriber), arg0, arg1)
+}
+
+// ListSubscribers mocks base method.
+func (m *MockService) ListSubscribers(arg0 context.Context, arg1 subscriber.Filter) (subscriber.Page, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSubscribers", arg0, arg1)
+	ret0, _ := ret[0].(subscriber.Page)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSubscribers indicates an expected call of ListSubscribers.
+func (mr *MockServiceMockRecorder) ListSubscribers(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSubscribers", reflect.TypeOf((*MockService)(nil).ListSubscribers), arg0, arg1)
+}
+
+// RemoveAddress mocks base method.
+func (m *MockService) RemoveAddress(arg0 context.Context, arg1 int64, arg2 subscriber.Channel) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveAddress", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveAddress indicates an expected call of RemoveAddress.
+func (mr *MockServiceMockRecorder) RemoveAddress(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveAddress", reflect.TypeOf((*MockService)(nil).RemoveAddress), arg0, arg1, arg2)
+}
@@ -0,0 +1,42 @@
+package admin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	pb "github.com/hrvadl/converter/protos/gen/go/v1/sub"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/hrvadl/converter/sub/internal/storage/suppression"
+)
+
+//go:generate mockgen -destination=./mocks/mock_suppressions.go -package=mocks . Suppressions
+type Suppressions interface {
+	Lift(ctx context.Context, email string) error
+}
+
+// LiftSuppression method calls underlying suppression service method,
+// so email is mailed again. Returns InvalidArgument code if email is
+// missing and NotFound code if email isn't suppressed.
+func (s *Server) LiftSuppression(
+	ctx context.Context,
+	req *pb.LiftSuppressionRequest,
+) (*emptypb.Empty, error) {
+	if req.GetEmail() == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "%s: email is required", operation)
+	}
+
+	err := s.suppressions.Lift(ctx, req.GetEmail())
+	if errors.Is(err, suppression.ErrNotFound) {
+		return nil, status.Errorf(codes.NotFound, "%s: %s is not suppressed", operation, req.GetEmail())
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to lift suppression: %w", operation, err)
+	}
+
+	s.log.Info("Lifted suppression", "email", req.GetEmail())
+	return &emptypb.Empty{}, nil
+}
@@ -0,0 +1,135 @@
+package admin
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+
+	pb "github.com/hrvadl/converter/protos/gen/go/v1/sub"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/hrvadl/converter/sub/internal/storage/suppression"
+	"github.com/hrvadl/converter/sub/internal/transport/grpc/server/admin/mocks"
+)
+
+func TestServerLiftSuppression(t *testing.T) {
+	t.Parallel()
+	type fields struct {
+		log          *slog.Logger
+		suppressions Suppressions
+	}
+	type args struct {
+		ctx context.Context
+		req *pb.LiftSuppressionRequest
+	}
+	tests := []struct {
+		name     string
+		fields   fields
+		args     args
+		setup    func(t *testing.T, sl Suppressions)
+		wantCode codes.Code
+	}{
+		{
+			name: "Should lift suppression when email is suppressed",
+			fields: fields{
+				log:          slog.Default(),
+				suppressions: mocks.NewMockSuppressions(gomock.NewController(t)),
+			},
+			args: args{
+				ctx: context.Background(),
+				req: &pb.LiftSuppressionRequest{Email: "test@test.com"},
+			},
+			setup: func(t *testing.T, sl Suppressions) {
+				t.Helper()
+				s, ok := sl.(*mocks.MockSuppressions)
+				if !ok {
+					t.Fatalf("Failed to cast suppressions to mock")
+				}
+
+				s.EXPECT().Lift(gomock.Any(), "test@test.com").Times(1).Return(nil)
+			},
+			wantCode: codes.OK,
+		},
+		{
+			name: "Should return invalid argument code when email is missing",
+			fields: fields{
+				log:          slog.Default(),
+				suppressions: mocks.NewMockSuppressions(gomock.NewController(t)),
+			},
+			args: args{
+				ctx: context.Background(),
+				req: &pb.LiftSuppressionRequest{},
+			},
+			setup: func(t *testing.T, sl Suppressions) {
+				t.Helper()
+				s, ok := sl.(*mocks.MockSuppressions)
+				if !ok {
+					t.Fatalf("Failed to cast suppressions to mock")
+				}
+
+				s.EXPECT().Lift(gomock.Any(), gomock.Any()).Times(0)
+			},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name: "Should return not found code when email isn't suppressed",
+			fields: fields{
+				log:          slog.Default(),
+				suppressions: mocks.NewMockSuppressions(gomock.NewController(t)),
+			},
+			args: args{
+				ctx: context.Background(),
+				req: &pb.LiftSuppressionRequest{Email: "test@test.com"},
+			},
+			setup: func(t *testing.T, sl Suppressions) {
+				t.Helper()
+				s, ok := sl.(*mocks.MockSuppressions)
+				if !ok {
+					t.Fatalf("Failed to cast suppressions to mock")
+				}
+
+				s.EXPECT().Lift(gomock.Any(), "test@test.com").Times(1).Return(suppression.ErrNotFound)
+			},
+			wantCode: codes.NotFound,
+		},
+		{
+			name: "Should return error when service failed",
+			fields: fields{
+				log:          slog.Default(),
+				suppressions: mocks.NewMockSuppressions(gomock.NewController(t)),
+			},
+			args: args{
+				ctx: context.Background(),
+				req: &pb.LiftSuppressionRequest{Email: "test@test.com"},
+			},
+			setup: func(t *testing.T, sl Suppressions) {
+				t.Helper()
+				s, ok := sl.(*mocks.MockSuppressions)
+				if !ok {
+					t.Fatalf("Failed to cast suppressions to mock")
+				}
+
+				s.EXPECT().Lift(gomock.Any(), gomock.Any()).Times(1).Return(errors.New("failed to lift"))
+			},
+			wantCode: codes.Unknown,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			tt.setup(t, tt.fields.suppressions)
+			s := &Server{
+				log:          tt.fields.log,
+				suppressions: tt.fields.suppressions,
+			}
+			_, err := s.LiftSuppression(tt.args.ctx, tt.args.req)
+			if code := status.Code(err); code != tt.wantCode {
+				t.Errorf("Server.LiftSuppression() code = %v, wantCode %v", code, tt.wantCode)
+			}
+		})
+	}
+}
@@ -0,0 +1,115 @@
+package admin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	pb "github.com/hrvadl/converter/protos/gen/go/v1/sub"
+
+	"github.com/hrvadl/converter/sub/internal/service/transfer"
+	"github.com/hrvadl/converter/sub/internal/storage/subscriber"
+)
+
+//go:generate mockgen -destination=./mocks/mock_transfer.go -package=mocks . Transfer
+type Transfer interface {
+	Import(ctx context.Context, r io.Reader) (transfer.Report, error)
+	Export(ctx context.Context, f subscriber.Filter, w io.Writer) (int, error)
+}
+
+// ImportSubscribers method reads CSV chunks from the stream, passes them
+// to the underlying transfer service and sends the import report back.
+// Returns an error, in case import couldn't be completed.
+func (s *Server) ImportSubscribers(stream pb.AdminService_ImportSubscribersServer) error {
+	rep, err := s.transfer.Import(stream.Context(), &chunkReader{recv: stream.Recv})
+	if err != nil {
+		return fmt.Errorf("%s: failed to import subscribers: %w", operation, err)
+	}
+
+	s.log.Info(
+		"Imported subscribers",
+		"imported", rep.Imported,
+		"skipped", rep.Skipped,
+		"failed", rep.FailedCount,
+		"duplicates", rep.DuplicateCount,
+	)
+
+	return stream.SendAndClose(&pb.ImportSubscribersResponse{
+		Imported:       int64(rep.Imported),
+		Skipped:        int64(rep.Skipped),
+		FailedCount:    int64(rep.FailedCount),
+		DuplicateCount: int64(rep.DuplicateCount),
+		Failed:         mapRowErrors(rep.Failed),
+		Duplicates:     mapRowErrors(rep.Duplicates),
+	})
+}
+
+// ExportSubscribers method maps request to the filter and streams CSV
+// chunks written by the underlying transfer service. Returns an error,
+// in case export couldn't be completed.
+func (s *Server) ExportSubscribers(
+	req *pb.ExportSubscribersRequest,
+	stream pb.AdminService_ExportSubscribersServer,
+) error {
+	w := chunkWriter{send: func(data []byte) error {
+		return stream.Send(&pb.ExportSubscribersResponse{Data: data})
+	}}
+
+	n, err := s.transfer.Export(stream.Context(), mapFilter(req.GetFilter()), w)
+	if err != nil {
+		return fmt.Errorf("%s: failed to export subscribers: %w", operation, err)
+	}
+
+	s.log.Info("Exported subscribers", "exported", n)
+	return nil
+}
+
+// chunkReader reads the data from the received chunks,
+// so client-streamed file could be read as a whole.
+type chunkReader struct {
+	recv func() (*pb.ImportSubscribersRequest, error)
+	buf  []byte
+}
+
+func (r *chunkReader) Read(p []byte) (int, error) {
+	for len(r.buf) == 0 {
+		chunk, err := r.recv()
+		if errors.Is(err, io.EOF) {
+			return 0, io.EOF
+		}
+		if err != nil {
+			return 0, fmt.Errorf("failed to receive chunk: %w", err)
+		}
+		r.buf = chunk.GetData()
+	}
+
+	n := copy(p, r.buf)
+	r.buf = r.buf[n:]
+	return n, nil
+}
+
+// chunkWriter sends every write as a separate chunk.
+type chunkWriter struct {
+	send func(data []byte) error
+}
+
+func (w chunkWriter) Write(p []byte) (int, error) {
+	// data is copied, since writer could reuse p after the call.
+	if err := w.send(append([]byte(nil), p...)); err != nil {
+		return 0, fmt.Errorf("failed to send chunk: %w", err)
+	}
+	return len(p), nil
+}
+
+func mapRowErrors(rows []transfer.RowError) []*pb.ImportRowError {
+	res := make([]*pb.ImportRowError, 0, len(rows))
+	for _, r := range rows {
+		res = append(res, &pb.ImportRowError{
+			Line:   int64(r.Line),
+			Email:  r.Email,
+			Reason: r.Reason,
+		})
+	}
+	return res
+}
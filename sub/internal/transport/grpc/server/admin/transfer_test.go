@@ -0,0 +1,241 @@
+package admin
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+
+	pb "github.com/hrvadl/converter/protos/gen/go/v1/sub"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/hrvadl/converter/sub/internal/service/transfer"
+	"github.com/hrvadl/converter/sub/internal/storage/subscriber"
+	"github.com/hrvadl/converter/sub/internal/transport/grpc/server/admin/mocks"
+)
+
+// importStream is a fake client stream, which
+// sends given chunks and records the response.
+type importStream struct {
+	grpc.ServerStream
+	chunks [][]byte
+	res    *pb.ImportSubscribersResponse
+}
+
+func (s *importStream) Context() context.Context { return context.Background() }
+
+func (s *importStream) Recv() (*pb.ImportSubscribersRequest, error) {
+	if len(s.chunks) == 0 {
+		return nil, io.EOF
+	}
+	chunk := s.chunks[0]
+	s.chunks = s.chunks[1:]
+	return &pb.ImportSubscribersRequest{Data: chunk}, nil
+}
+
+func (s *importStream) SendAndClose(res *pb.ImportSubscribersResponse) error {
+	s.res = res
+	return nil
+}
+
+// exportStream is a fake server stream, which records sent chunks.
+type exportStream struct {
+	grpc.ServerStream
+	chunks []string
+}
+
+func (s *exportStream) Context() context.Context { return context.Background() }
+
+func (s *exportStream) Send(res *pb.ExportSubscribersResponse) error {
+	s.chunks = append(s.chunks, string(res.GetData()))
+	return nil
+}
+
+func TestServerImportSubscribers(t *testing.T) {
+	t.Parallel()
+	type fields struct {
+		log      *slog.Logger
+		transfer Transfer
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		chunks  [][]byte
+		setup   func(t *testing.T, tr Transfer)
+		want    *pb.ImportSubscribersResponse
+		wantErr bool
+	}{
+		{
+			name: "Should pass concatenated chunks to the service and send report",
+			fields: fields{
+				log:      slog.Default(),
+				transfer: mocks.NewMockTransfer(gomock.NewController(t)),
+			},
+			chunks: [][]byte{[]byte("email\nfirst@te"), {}, []byte("st.com\ninvalid\n")},
+			setup: func(t *testing.T, tr Transfer) {
+				t.Helper()
+				m, ok := tr.(*mocks.MockTransfer)
+				if !ok {
+					t.Fatal("failed to cast transfer to mock")
+				}
+				m.EXPECT().
+					Import(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ context.Context, r io.Reader) (transfer.Report, error) {
+						data, err := io.ReadAll(r)
+						if err != nil || string(data) != "email\nfirst@test.com\ninvalid\n" {
+							t.Errorf("Import() got data = %q, err = %v", data, err)
+						}
+						return transfer.Report{
+							Imported:    1,
+							FailedCount: 1,
+							Failed:      []transfer.RowError{{Line: 3, Email: "invalid", Reason: "invalid email"}},
+						}, nil
+					})
+			},
+			want: &pb.ImportSubscribersResponse{
+				Imported:    1,
+				FailedCount: 1,
+				Failed:      []*pb.ImportRowError{{Line: 3, Email: "invalid", Reason: "invalid email"}},
+				Duplicates:  []*pb.ImportRowError{},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Should return error when import failed",
+			fields: fields{
+				log:      slog.Default(),
+				transfer: mocks.NewMockTransfer(gomock.NewController(t)),
+			},
+			chunks: [][]byte{[]byte("email\n")},
+			setup: func(t *testing.T, tr Transfer) {
+				t.Helper()
+				m, ok := tr.(*mocks.MockTransfer)
+				if !ok {
+					t.Fatal("failed to cast transfer to mock")
+				}
+				m.EXPECT().
+					Import(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(transfer.Report{}, errors.New("failed to import"))
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			tt.setup(t, tt.fields.transfer)
+			s := &Server{
+				log:      tt.fields.log,
+				transfer: tt.fields.transfer,
+			}
+			stream := &importStream{chunks: tt.chunks}
+			if err := s.ImportSubscribers(stream); (err != nil) != tt.wantErr {
+				t.Errorf("Server.ImportSubscribers() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !proto.Equal(stream.res, tt.want) {
+				t.Errorf("Server.ImportSubscribers() = %v, want %v", stream.res, tt.want)
+			}
+		})
+	}
+}
+
+func TestServerExportSubscribers(t *testing.T) {
+	t.Parallel()
+	type fields struct {
+		log      *slog.Logger
+		transfer Transfer
+	}
+	tests := []struct {
+		name       string
+		fields     fields
+		req        *pb.ExportSubscribersRequest
+		setup      func(t *testing.T, tr Transfer)
+		wantChunks []string
+		wantErr    bool
+	}{
+		{
+			name: "Should stream every write as a chunk",
+			fields: fields{
+				log:      slog.Default(),
+				transfer: mocks.NewMockTransfer(gomock.NewController(t)),
+			},
+			req: &pb.ExportSubscribersRequest{
+				Filter: &pb.SubscriberFilter{Status: pb.SubscriberStatus_SUBSCRIBER_STATUS_ACTIVE},
+			},
+			setup: func(t *testing.T, tr Transfer) {
+				t.Helper()
+				m, ok := tr.(*mocks.MockTransfer)
+				if !ok {
+					t.Fatal("failed to cast transfer to mock")
+				}
+				m.EXPECT().
+					Export(gomock.Any(), subscriber.Filter{Status: subscriber.StatusActive}, gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ context.Context, _ subscriber.Filter, w io.Writer) (int, error) {
+						buf := []byte("email\n")
+						_, _ = w.Write(buf)
+						copy(buf, "first\n")
+						_, _ = w.Write(buf)
+						return 1, nil
+					})
+			},
+			wantChunks: []string{"email\n", "first\n"},
+			wantErr:    false,
+		},
+		{
+			name: "Should return error when export failed",
+			fields: fields{
+				log:      slog.Default(),
+				transfer: mocks.NewMockTransfer(gomock.NewController(t)),
+			},
+			req: &pb.ExportSubscribersRequest{},
+			setup: func(t *testing.T, tr Transfer) {
+				t.Helper()
+				m, ok := tr.(*mocks.MockTransfer)
+				if !ok {
+					t.Fatal("failed to cast transfer to mock")
+				}
+				m.EXPECT().
+					Export(gomock.Any(), subscriber.Filter{}, gomock.Any()).
+					Times(1).
+					Return(0, errors.New("failed to export"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			tt.setup(t, tt.fields.transfer)
+			s := &Server{
+				log:      tt.fields.log,
+				transfer: tt.fields.transfer,
+			}
+			stream := &exportStream{}
+			if err := s.ExportSubscribers(tt.req, stream); (err != nil) != tt.wantErr {
+				t.Errorf("Server.ExportSubscribers() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if len(stream.chunks) != len(tt.wantChunks) {
+				t.Fatalf("Server.ExportSubscribers() chunks = %q, want %q", stream.chunks, tt.wantChunks)
+			}
+			for i := range stream.chunks {
+				if stream.chunks[i] != tt.wantChunks[i] {
+					t.Errorf("Server.ExportSubscribers() chunks = %q, want %q", stream.chunks, tt.wantChunks)
+				}
+			}
+		})
+	}
+}
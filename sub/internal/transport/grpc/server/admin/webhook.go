@@ -0,0 +1,84 @@
+package admin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	pb "github.com/hrvadl/converter/protos/gen/go/v1/sub"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/hrvadl/converter/sub/internal/service/webhook"
+	"github.com/hrvadl/converter/sub/internal/storage/subscriber"
+)
+
+//go:generate mockgen -destination=./mocks/mock_webhooks.go -package=mocks . Webhooks
+type Webhooks interface {
+	Register(ctx context.Context, url, secret string, c subscriber.Channel) (int64, error)
+	Unregister(ctx context.Context, url string) error
+}
+
+// RegisterWebhook method maps format to the channel and calls underlying
+// webhook service method, so the rate is posted to the URL daily. Returns
+// InvalidArgument code if URL, format or secret is invalid and AlreadyExists
+// code if URL is already registered.
+func (s *Server) RegisterWebhook(
+	ctx context.Context,
+	req *pb.RegisterWebhookRequest,
+) (*pb.RegisterWebhookResponse, error) {
+	id, err := s.webhooks.Register(ctx, req.GetUrl(), req.GetSecret(), mapFormat(req.GetFormat()))
+	if errors.Is(err, webhook.ErrInvalidURL) ||
+		errors.Is(err, webhook.ErrInvalidChannel) ||
+		errors.Is(err, webhook.ErrInvalidSecret) {
+		return nil, status.Errorf(codes.InvalidArgument, "%s: %v", operation, err)
+	}
+	if errors.Is(err, webhook.ErrAlreadyRegistered) {
+		return nil, status.Errorf(codes.AlreadyExists, "%s: %v", operation, err)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to register webhook: %w", operation, err)
+	}
+
+	s.log.Info("Registered webhook", "id", id)
+	return &pb.RegisterWebhookResponse{Id: id}, nil
+}
+
+// UnregisterWebhook method calls underlying webhook service method,
+// so the rate isn't posted to the URL anymore. Returns InvalidArgument
+// code if URL is invalid and NotFound code if URL isn't registered.
+func (s *Server) UnregisterWebhook(
+	ctx context.Context,
+	req *pb.UnregisterWebhookRequest,
+) (*emptypb.Empty, error) {
+	err := s.webhooks.Unregister(ctx, req.GetUrl())
+	if errors.Is(err, webhook.ErrInvalidURL) {
+		return nil, status.Errorf(codes.InvalidArgument, "%s: %v", operation, err)
+	}
+	if errors.Is(err, webhook.ErrNotRegistered) {
+		return nil, status.Errorf(codes.NotFound, "%s: %v", operation, err)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to unregister webhook: %w", operation, err)
+	}
+
+	s.log.Info("Unregistered webhook")
+	return &emptypb.Empty{}, nil
+}
+
+// mapFormat maps GRPC webhook format to the channel of the subscriber.
+// Unspecified format is mapped to the signed JSON payloads, while unknown
+// one is mapped as is, so it's rejected.
+func mapFormat(f pb.WebhookFormat) subscriber.Channel {
+	switch f {
+	case pb.WebhookFormat_WEBHOOK_FORMAT_UNSPECIFIED, pb.WebhookFormat_WEBHOOK_FORMAT_JSON:
+		return subscriber.ChannelWebhook
+	case pb.WebhookFormat_WEBHOOK_FORMAT_SLACK:
+		return subscriber.ChannelSlack
+	case pb.WebhookFormat_WEBHOOK_FORMAT_DISCORD:
+		return subscriber.ChannelDiscord
+	default:
+		return subscriber.Channel(f.String())
+	}
+}
@@ -0,0 +1,179 @@
+package admin
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+
+	pb "github.com/hrvadl/converter/protos/gen/go/v1/sub"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/hrvadl/converter/sub/internal/service/webhook"
+	"github.com/hrvadl/converter/sub/internal/storage/subscriber"
+	"github.com/hrvadl/converter/sub/internal/transport/grpc/server/admin/mocks"
+)
+
+func TestServerRegisterWebhook(t *testing.T) {
+	t.Parallel()
+	req := &pb.RegisterWebhookRequest{Url: "https://example.com/hook", Secret: "0123456789abcdef"}
+	tests := []struct {
+		name     string
+		req      *pb.RegisterWebhookRequest
+		setup    func(wh *mocks.MockWebhooks)
+		want     int64
+		wantCode codes.Code
+	}{
+		{
+			name: "Should register webhook and return its ID",
+			req:  req,
+			setup: func(wh *mocks.MockWebhooks) {
+				wh.EXPECT().
+					Register(gomock.Any(), req.Url, req.Secret, subscriber.ChannelWebhook).
+					Times(1).
+					Return(int64(3), nil)
+			},
+			want:     3,
+			wantCode: codes.OK,
+		},
+		{
+			name: "Should register slack webhook",
+			req:  &pb.RegisterWebhookRequest{Url: req.Url, Format: pb.WebhookFormat_WEBHOOK_FORMAT_SLACK},
+			setup: func(wh *mocks.MockWebhooks) {
+				wh.EXPECT().Register(gomock.Any(), req.Url, "", subscriber.ChannelSlack).Times(1).Return(int64(4), nil)
+			},
+			want:     4,
+			wantCode: codes.OK,
+		},
+		{
+			name: "Should register discord webhook",
+			req:  &pb.RegisterWebhookRequest{Url: req.Url, Format: pb.WebhookFormat_WEBHOOK_FORMAT_DISCORD},
+			setup: func(wh *mocks.MockWebhooks) {
+				wh.EXPECT().Register(gomock.Any(), req.Url, "", subscriber.ChannelDiscord).Times(1).Return(int64(5), nil)
+			},
+			want:     5,
+			wantCode: codes.OK,
+		},
+		{
+			name: "Should return invalid argument code when format is unknown",
+			req:  &pb.RegisterWebhookRequest{Url: req.Url, Format: pb.WebhookFormat(42)},
+			setup: func(wh *mocks.MockWebhooks) {
+				wh.EXPECT().
+					Register(gomock.Any(), gomock.Any(), gomock.Any(), subscriber.Channel("42")).
+					Times(1).
+					Return(int64(0), webhook.ErrInvalidChannel)
+			},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name: "Should return invalid argument code when URL is invalid",
+			req:  req,
+			setup: func(wh *mocks.MockWebhooks) {
+				wh.EXPECT().Register(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(int64(0), webhook.ErrInvalidURL)
+			},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name: "Should return invalid argument code when secret is invalid",
+			req:  req,
+			setup: func(wh *mocks.MockWebhooks) {
+				wh.EXPECT().
+					Register(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(int64(0), webhook.ErrInvalidSecret)
+			},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name: "Should return already exists code when URL is registered",
+			req:  req,
+			setup: func(wh *mocks.MockWebhooks) {
+				wh.EXPECT().
+					Register(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(int64(0), webhook.ErrAlreadyRegistered)
+			},
+			wantCode: codes.AlreadyExists,
+		},
+		{
+			name: "Should return error when service failed",
+			req:  req,
+			setup: func(wh *mocks.MockWebhooks) {
+				wh.EXPECT().
+					Register(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(int64(0), errors.New("failed to register"))
+			},
+			wantCode: codes.Unknown,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			wh := mocks.NewMockWebhooks(gomock.NewController(t))
+			tt.setup(wh)
+			s := &Server{log: slog.Default(), webhooks: wh}
+			got, err := s.RegisterWebhook(context.Background(), tt.req)
+			if code := status.Code(err); code != tt.wantCode {
+				t.Fatalf("Server.RegisterWebhook() code = %v, wantCode %v", code, tt.wantCode)
+			}
+			if got.GetId() != tt.want {
+				t.Errorf("Server.RegisterWebhook() id = %v, want %v", got.GetId(), tt.want)
+			}
+		})
+	}
+}
+
+func TestServerUnregisterWebhook(t *testing.T) {
+	t.Parallel()
+	req := &pb.UnregisterWebhookRequest{Url: "https://example.com/hook"}
+	tests := []struct {
+		name     string
+		setup    func(wh *mocks.MockWebhooks)
+		wantCode codes.Code
+	}{
+		{
+			name: "Should unregister webhook",
+			setup: func(wh *mocks.MockWebhooks) {
+				wh.EXPECT().Unregister(gomock.Any(), req.Url).Times(1).Return(nil)
+			},
+			wantCode: codes.OK,
+		},
+		{
+			name: "Should return invalid argument code when URL is invalid",
+			setup: func(wh *mocks.MockWebhooks) {
+				wh.EXPECT().Unregister(gomock.Any(), gomock.Any()).Times(1).Return(webhook.ErrInvalidURL)
+			},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name: "Should return not found code when URL isn't registered",
+			setup: func(wh *mocks.MockWebhooks) {
+				wh.EXPECT().Unregister(gomock.Any(), gomock.Any()).Times(1).Return(webhook.ErrNotRegistered)
+			},
+			wantCode: codes.NotFound,
+		},
+		{
+			name: "Should return error when service failed",
+			setup: func(wh *mocks.MockWebhooks) {
+				wh.EXPECT().Unregister(gomock.Any(), gomock.Any()).Times(1).Return(errors.New("failed to unregister"))
+			},
+			wantCode: codes.Unknown,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			wh := mocks.NewMockWebhooks(gomock.NewController(t))
+			tt.setup(wh)
+			s := &Server{log: slog.Default(), webhooks: wh}
+			if _, err := s.UnregisterWebhook(context.Background(), req); status.Code(err) != tt.wantCode {
+				t.Errorf("Server.UnregisterWebhook() code = %v, wantCode %v", status.Code(err), tt.wantCode)
+			}
+		})
+	}
+}
@@ -0,0 +1,112 @@
+package delivery
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	pb "github.com/hrvadl/converter/protos/gen/go/v1/sub"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/hrvadl/converter/sub/internal/storage/delivery"
+)
+
+const operation = "delivery server"
+
+// Registers delivery handler to the given GRPC server.
+// NOTE: all parameters are required, the service will panic if
+// either of them is missing.
+func Register(srv *grpc.Server, svc Service, log *slog.Logger) {
+	pb.RegisterDeliveryServiceServer(srv, &Server{
+		log: log,
+		svc: svc,
+	})
+}
+
+//go:generate mockgen -destination=./mocks/mock_svc.go -package=mocks . Service
+type Service interface {
+	GetHistory(ctx context.Context, f delivery.Filter) (delivery.Page, error)
+}
+
+// Server represents delivery GRPC server
+// which will handle the incoming requests and delegate
+// all work to the underlying svc.
+type Server struct {
+	pb.UnimplementedDeliveryServiceServer
+	log *slog.Logger
+	svc Service
+}
+
+// GetDeliveryHistory method maps request to the filter, calls underlying
+// service method and maps deliveries to the GRPC response. Returns an error,
+// in case there was a failure.
+func (s *Server) GetDeliveryHistory(
+	ctx context.Context,
+	req *pb.GetDeliveryHistoryRequest,
+) (*pb.GetDeliveryHistoryResponse, error) {
+	f := delivery.Filter{
+		Email:        req.GetEmail(),
+		SubscriberID: req.GetSubscriberId(),
+		Status:       mapStatus(req.GetStatus()),
+		BeforeID:     req.GetBeforeId(),
+		Limit:        int(req.GetLimit()),
+	}
+
+	if req.GetFrom() != nil {
+		f.From = req.GetFrom().AsTime()
+	}
+
+	if req.GetTo() != nil {
+		f.To = req.GetTo().AsTime()
+	}
+
+	p, err := s.svc.GetHistory(ctx, f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to get delivery history: %w", operation, err)
+	}
+
+	res := &pb.GetDeliveryHistoryResponse{
+		Deliveries:   make([]*pb.Delivery, 0, len(p.Deliveries)),
+		NextBeforeId: p.NextBeforeID,
+	}
+	for i := range p.Deliveries {
+		res.Deliveries = append(res.Deliveries, mapDelivery(p.Deliveries[i]))
+	}
+
+	return res, nil
+}
+
+// mapStatus maps GRPC delivery status to the delivery's one.
+// Unspecified status is mapped to the empty one, so deliveries
+// aren't filtered by it.
+func mapStatus(s pb.DeliveryStatus) delivery.Status {
+	switch s {
+	case pb.DeliveryStatus_DELIVERY_STATUS_SENT:
+		return delivery.StatusSent
+	case pb.DeliveryStatus_DELIVERY_STATUS_FAILED:
+		return delivery.StatusFailed
+	default:
+		return ""
+	}
+}
+
+func mapDelivery(d delivery.Delivery) *pb.Delivery {
+	status := pb.DeliveryStatus_DELIVERY_STATUS_SENT
+	if d.Status == delivery.StatusFailed {
+		status = pb.DeliveryStatus_DELIVERY_STATUS_FAILED
+	}
+
+	return &pb.Delivery{
+		Id:             d.ID,
+		NotificationId: d.NotificationID,
+		SubscriberId:   d.SubscriberID,
+		Email:          d.Email,
+		ScheduledAt:    timestamppb.New(d.ScheduledAt),
+		Rate:           d.Rate,
+		MessageId:      d.MessageID,
+		Status:         status,
+		Error:          d.Error,
+		CreatedAt:      timestamppb.New(d.CreatedAt),
+	}
+}
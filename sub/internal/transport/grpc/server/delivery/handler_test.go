@@ -0,0 +1,210 @@
+package delivery
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	pb "github.com/hrvadl/converter/protos/gen/go/v1/sub"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/hrvadl/converter/sub/internal/storage/delivery"
+	"github.com/hrvadl/converter/sub/internal/transport/grpc/server/delivery/mocks"
+)
+
+func TestServerGetDeliveryHistory(t *testing.T) {
+	t.Parallel()
+	type fields struct {
+		log *slog.Logger
+		svc Service
+	}
+	type args struct {
+		ctx context.Context
+		req *pb.GetDeliveryHistoryRequest
+	}
+	from := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 5, 2, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		setup   func(t *testing.T, svc Service)
+		want    *pb.GetDeliveryHistoryResponse
+		wantErr bool
+	}{
+		{
+			name: "Should return deliveries when service succeeded",
+			fields: fields{
+				log: slog.Default(),
+				svc: mocks.NewMockService(gomock.NewController(t)),
+			},
+			args: args{
+				ctx: context.Background(),
+				req: &pb.GetDeliveryHistoryRequest{
+					Email:        "test@test.com",
+					SubscriberId: 1,
+					Status:       pb.DeliveryStatus_DELIVERY_STATUS_FAILED,
+					From:         timestamppb.New(from),
+					To:           timestamppb.New(to),
+					BeforeId:     10,
+					Limit:        1,
+				},
+			},
+			setup: func(t *testing.T, svc Service) {
+				t.Helper()
+				s, ok := svc.(*mocks.MockService)
+				if !ok {
+					t.Fatalf("Failed to cast service to mock service")
+				}
+
+				s.EXPECT().
+					GetHistory(gomock.Any(), delivery.Filter{
+						Email:        "test@test.com",
+						SubscriberID: 1,
+						Status:       delivery.StatusFailed,
+						From:         from,
+						To:           to,
+						BeforeID:     10,
+						Limit:        1,
+					}).
+					Times(1).
+					Return(delivery.Page{
+						Deliveries: []delivery.Delivery{{
+							ID:             9,
+							NotificationID: 5,
+							SubscriberID:   1,
+							Email:          "test@test.com",
+							ScheduledAt:    from,
+							Rate:           40.5,
+							Status:         delivery.StatusFailed,
+							Error:          "failed to send",
+							CreatedAt:      from,
+						}},
+						NextBeforeID: 9,
+					}, nil)
+			},
+			want: &pb.GetDeliveryHistoryResponse{
+				Deliveries: []*pb.Delivery{{
+					Id:             9,
+					NotificationId: 5,
+					SubscriberId:   1,
+					Email:          "test@test.com",
+					ScheduledAt:    timestamppb.New(from),
+					Rate:           40.5,
+					Status:         pb.DeliveryStatus_DELIVERY_STATUS_FAILED,
+					Error:          "failed to send",
+					CreatedAt:      timestamppb.New(from),
+				}},
+				NextBeforeId: 9,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Should not filter by unset fields",
+			fields: fields{
+				log: slog.Default(),
+				svc: mocks.NewMockService(gomock.NewController(t)),
+			},
+			args: args{
+				ctx: context.Background(),
+				req: &pb.GetDeliveryHistoryRequest{},
+			},
+			setup: func(t *testing.T, svc Service) {
+				t.Helper()
+				s, ok := svc.(*mocks.MockService)
+				if !ok {
+					t.Fatalf("Failed to cast service to mock service")
+				}
+
+				s.EXPECT().
+					GetHistory(gomock.Any(), delivery.Filter{}).
+					Times(1).
+					Return(delivery.Page{}, nil)
+			},
+			want:    &pb.GetDeliveryHistoryResponse{},
+			wantErr: false,
+		},
+		{
+			name: "Should return error when service failed",
+			fields: fields{
+				log: slog.Default(),
+				svc: mocks.NewMockService(gomock.NewController(t)),
+			},
+			args: args{
+				ctx: context.Background(),
+				req: &pb.GetDeliveryHistoryRequest{},
+			},
+			setup: func(t *testing.T, svc Service) {
+				t.Helper()
+				s, ok := svc.(*mocks.MockService)
+				if !ok {
+					t.Fatalf("Failed to cast service to mock service")
+				}
+
+				s.EXPECT().
+					GetHistory(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(delivery.Page{}, errors.New("failed to get history"))
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			tt.setup(t, tt.fields.svc)
+			s := &Server{
+				log: tt.fields.log,
+				svc: tt.fields.svc,
+			}
+			got, err := s.GetDeliveryHistory(tt.args.ctx, tt.args.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Server.GetDeliveryHistory() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !proto.Equal(got, tt.want) {
+				t.Errorf("Server.GetDeliveryHistory() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMapStatus(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name string
+		s    pb.DeliveryStatus
+		want delivery.Status
+	}{
+		{
+			name: "Should map sent status correctly",
+			s:    pb.DeliveryStatus_DELIVERY_STATUS_SENT,
+			want: delivery.StatusSent,
+		},
+		{
+			name: "Should map failed status correctly",
+			s:    pb.DeliveryStatus_DELIVERY_STATUS_FAILED,
+			want: delivery.StatusFailed,
+		},
+		{
+			name: "Should map unspecified status to empty one",
+			s:    pb.DeliveryStatus_DELIVERY_STATUS_UNSPECIFIED,
+			want: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := mapStatus(tt.s); got != tt.want {
+				t.Errorf("mapStatus() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
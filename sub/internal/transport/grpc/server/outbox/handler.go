@@ -0,0 +1,85 @@
+package outbox
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	pb "github.com/hrvadl/converter/protos/gen/go/v1/sub"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/hrvadl/converter/sub/internal/storage/outbox"
+)
+
+const operation = "outbox server"
+
+// Registers outbox handler to the given GRPC server.
+// NOTE: all parameters are required, the service will panic if
+// either of them is missing.
+func Register(srv *grpc.Server, svc Service, log *slog.Logger) {
+	pb.RegisterOutboxServiceServer(srv, &Server{
+		log: log,
+		svc: svc,
+	})
+}
+
+//go:generate mockgen -destination=./mocks/mock_svc.go -package=mocks . Service
+type Service interface {
+	ListDeadLetters(ctx context.Context, afterID int64, limit int) ([]outbox.Notification, error)
+	RequeueDeadLetters(ctx context.Context, ids ...int64) (int64, error)
+}
+
+// Server represents outbox GRPC server
+// which will handle the incoming requests and delegate
+// all work to the underlying svc.
+type Server struct {
+	pb.UnimplementedOutboxServiceServer
+	log *slog.Logger
+	svc Service
+}
+
+// ListDeadLetters method calls underlying service method and maps dead letters
+// to the GRPC response. Returns an error, in case there was a failure.
+func (s *Server) ListDeadLetters(
+	ctx context.Context,
+	req *pb.ListDeadLettersRequest,
+) (*pb.ListDeadLettersResponse, error) {
+	n, err := s.svc.ListDeadLetters(ctx, req.GetAfterId(), int(req.GetLimit()))
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to list dead letters: %w", operation, err)
+	}
+
+	res := &pb.ListDeadLettersResponse{DeadLetters: make([]*pb.DeadLetter, 0, len(n))}
+	for i := range n {
+		res.DeadLetters = append(res.DeadLetters, mapDeadLetter(n[i]))
+	}
+
+	return res, nil
+}
+
+// RequeueDeadLetters method calls underlying service method and returns an error,
+// in case there was a failure.
+func (s *Server) RequeueDeadLetters(
+	ctx context.Context,
+	req *pb.RequeueDeadLettersRequest,
+) (*pb.RequeueDeadLettersResponse, error) {
+	n, err := s.svc.RequeueDeadLetters(ctx, req.GetIds()...)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to requeue dead letters: %w", operation, err)
+	}
+
+	return &pb.RequeueDeadLettersResponse{Requeued: n}, nil
+}
+
+func mapDeadLetter(n outbox.Notification) *pb.DeadLetter {
+	return &pb.DeadLetter{
+		Id:        n.ID,
+		Email:     n.Email,
+		Subject:   n.Subject,
+		Attempts:  int32(n.Attempts),
+		LastError: n.LastError,
+		CreatedAt: timestamppb.New(n.CreatedAt),
+		UpdatedAt: timestamppb.New(n.UpdatedAt),
+	}
+}
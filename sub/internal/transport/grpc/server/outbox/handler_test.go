@@ -0,0 +1,216 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	pb "github.com/hrvadl/converter/protos/gen/go/v1/sub"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/hrvadl/converter/sub/internal/storage/outbox"
+	"github.com/hrvadl/converter/sub/internal/transport/grpc/server/outbox/mocks"
+)
+
+func TestServerListDeadLetters(t *testing.T) {
+	t.Parallel()
+	type fields struct {
+		log *slog.Logger
+		svc Service
+	}
+	type args struct {
+		ctx context.Context
+		req *pb.ListDeadLettersRequest
+	}
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		setup   func(t *testing.T, svc Service)
+		want    *pb.ListDeadLettersResponse
+		wantErr bool
+	}{
+		{
+			name: "Should return dead letters when service succeeded",
+			fields: fields{
+				log: slog.Default(),
+				svc: mocks.NewMockService(gomock.NewController(t)),
+			},
+			args: args{
+				ctx: context.Background(),
+				req: &pb.ListDeadLettersRequest{AfterId: 10, Limit: 20},
+			},
+			setup: func(t *testing.T, svc Service) {
+				t.Helper()
+				s, ok := svc.(*mocks.MockService)
+				if !ok {
+					t.Fatalf("Failed to cast service to mock service")
+				}
+
+				s.EXPECT().
+					ListDeadLetters(gomock.Any(), int64(10), 20).
+					Times(1).
+					Return([]outbox.Notification{{
+						ID:        11,
+						Email:     "test@test.com",
+						Subject:   "subject",
+						Attempts:  5,
+						LastError: "failed to send",
+						CreatedAt: now,
+						UpdatedAt: now,
+					}}, nil)
+			},
+			want: &pb.ListDeadLettersResponse{
+				DeadLetters: []*pb.DeadLetter{{
+					Id:        11,
+					Email:     "test@test.com",
+					Subject:   "subject",
+					Attempts:  5,
+					LastError: "failed to send",
+					CreatedAt: timestamppb.New(now),
+					UpdatedAt: timestamppb.New(now),
+				}},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Should return error when service failed",
+			fields: fields{
+				log: slog.Default(),
+				svc: mocks.NewMockService(gomock.NewController(t)),
+			},
+			args: args{
+				ctx: context.Background(),
+				req: &pb.ListDeadLettersRequest{},
+			},
+			setup: func(t *testing.T, svc Service) {
+				t.Helper()
+				s, ok := svc.(*mocks.MockService)
+				if !ok {
+					t.Fatalf("Failed to cast service to mock service")
+				}
+
+				s.EXPECT().
+					ListDeadLetters(gomock.Any(), int64(0), 0).
+					Times(1).
+					Return(nil, errors.New("failed to list"))
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			tt.setup(t, tt.fields.svc)
+			s := &Server{
+				log: tt.fields.log,
+				svc: tt.fields.svc,
+			}
+			got, err := s.ListDeadLetters(tt.args.ctx, tt.args.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Server.ListDeadLetters() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !proto.Equal(got, tt.want) {
+				t.Errorf("Server.ListDeadLetters() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestServerRequeueDeadLetters(t *testing.T) {
+	t.Parallel()
+	type fields struct {
+		log *slog.Logger
+		svc Service
+	}
+	type args struct {
+		ctx context.Context
+		req *pb.RequeueDeadLettersRequest
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		setup   func(t *testing.T, svc Service)
+		want    *pb.RequeueDeadLettersResponse
+		wantErr bool
+	}{
+		{
+			name: "Should return number of requeued dead letters when service succeeded",
+			fields: fields{
+				log: slog.Default(),
+				svc: mocks.NewMockService(gomock.NewController(t)),
+			},
+			args: args{
+				ctx: context.Background(),
+				req: &pb.RequeueDeadLettersRequest{Ids: []int64{1, 2}},
+			},
+			setup: func(t *testing.T, svc Service) {
+				t.Helper()
+				s, ok := svc.(*mocks.MockService)
+				if !ok {
+					t.Fatalf("Failed to cast service to mock service")
+				}
+
+				s.EXPECT().
+					RequeueDeadLetters(gomock.Any(), int64(1), int64(2)).
+					Times(1).
+					Return(int64(2), nil)
+			},
+			want:    &pb.RequeueDeadLettersResponse{Requeued: 2},
+			wantErr: false,
+		},
+		{
+			name: "Should return error when service failed",
+			fields: fields{
+				log: slog.Default(),
+				svc: mocks.NewMockService(gomock.NewController(t)),
+			},
+			args: args{
+				ctx: context.Background(),
+				req: &pb.RequeueDeadLettersRequest{},
+			},
+			setup: func(t *testing.T, svc Service) {
+				t.Helper()
+				s, ok := svc.(*mocks.MockService)
+				if !ok {
+					t.Fatalf("Failed to cast service to mock service")
+				}
+
+				s.EXPECT().
+					RequeueDeadLetters(gomock.Any()).
+					Times(1).
+					Return(int64(0), errors.New("failed to requeue"))
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			tt.setup(t, tt.fields.svc)
+			s := &Server{
+				log: tt.fields.log,
+				svc: tt.fields.svc,
+			}
+			got, err := s.RequeueDeadLetters(tt.args.ctx, tt.args.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Server.RequeueDeadLetters() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !proto.Equal(got, tt.want) {
+				t.Errorf("Server.RequeueDeadLetters() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
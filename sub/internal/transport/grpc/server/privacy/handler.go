@@ -0,0 +1,113 @@
+package privacy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	pb "github.com/hrvadl/converter/protos/gen/go/v1/sub"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/hrvadl/converter/sub/internal/service/privacy"
+	"github.com/hrvadl/converter/sub/internal/storage/erasure"
+)
+
+const operation = "privacy server"
+
+// Registers privacy handler to the given GRPC server.
+// NOTE: all parameters are required, the service will panic if
+// either of them is missing.
+func Register(srv *grpc.Server, svc Service, log *slog.Logger) {
+	pb.RegisterPrivacyServiceServer(srv, &Server{
+		log: log,
+		svc: svc,
+	})
+}
+
+//go:generate mockgen -destination=./mocks/mock_svc.go -package=mocks . Service
+type Service interface {
+	RequestToken(ctx context.Context, email string) error
+	Export(ctx context.Context, email, token string) ([]byte, error)
+	Erase(ctx context.Context, email, token string) (erasure.Audit, error)
+}
+
+// Server represents privacy GRPC server
+// which will handle the incoming requests and delegate
+// all work to the underlying svc.
+type Server struct {
+	pb.UnimplementedPrivacyServiceServer
+	log *slog.Logger
+	svc Service
+}
+
+// RequestPrivacyToken method calls underlying service method, which mails
+// the token to the given email. Returns an error, in case there was a failure.
+func (s *Server) RequestPrivacyToken(
+	ctx context.Context,
+	req *pb.RequestPrivacyTokenRequest,
+) (*emptypb.Empty, error) {
+	if req.GetEmail() == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "%s: email is required", operation)
+	}
+
+	if err := s.svc.RequestToken(ctx, req.GetEmail()); err != nil {
+		return nil, fmt.Errorf("%s: failed to request token: %w", operation, err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+// ExportPersonalData method calls underlying service method and returns
+// JSON document with the personal data. Returns PermissionDenied code
+// if token is invalid.
+func (s *Server) ExportPersonalData(
+	ctx context.Context,
+	req *pb.ExportPersonalDataRequest,
+) (*pb.ExportPersonalDataResponse, error) {
+	if req.GetEmail() == "" || req.GetToken() == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "%s: email and token are required", operation)
+	}
+
+	data, err := s.svc.Export(ctx, req.GetEmail(), req.GetToken())
+	if errors.Is(err, privacy.ErrInvalidToken) {
+		return nil, status.Errorf(codes.PermissionDenied, "%s: %v", operation, privacy.ErrInvalidToken)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to export personal data: %w", operation, err)
+	}
+
+	return &pb.ExportPersonalDataResponse{Data: data}, nil
+}
+
+// ErasePersonalData method calls underlying service method and maps
+// anonymised audit entry to the GRPC response. Returns PermissionDenied
+// code if token is invalid.
+func (s *Server) ErasePersonalData(
+	ctx context.Context,
+	req *pb.ErasePersonalDataRequest,
+) (*pb.ErasePersonalDataResponse, error) {
+	if req.GetEmail() == "" || req.GetToken() == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "%s: email and token are required", operation)
+	}
+
+	a, err := s.svc.Erase(ctx, req.GetEmail(), req.GetToken())
+	if errors.Is(err, privacy.ErrInvalidToken) {
+		return nil, status.Errorf(codes.PermissionDenied, "%s: %v", operation, privacy.ErrInvalidToken)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to erase personal data: %w", operation, err)
+	}
+
+	return &pb.ErasePersonalDataResponse{
+		AuditId:              a.ID,
+		SubscriberDeleted:    a.SubscriberDeleted,
+		NotificationsDeleted: a.NotificationsDeleted,
+		DeliveriesDeleted:    a.DeliveriesDeleted,
+		ErasedAt:             timestamppb.New(a.CreatedAt),
+	}, nil
+}
@@ -0,0 +1,278 @@
+package privacy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"testing"
+	"time"
+
+	pb "github.com/hrvadl/converter/protos/gen/go/v1/sub"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/hrvadl/converter/sub/internal/service/privacy"
+	"github.com/hrvadl/converter/sub/internal/storage/erasure"
+	"github.com/hrvadl/converter/sub/internal/transport/grpc/server/privacy/mocks"
+)
+
+func TestServerExportPersonalData(t *testing.T) {
+	t.Parallel()
+	type fields struct {
+		log *slog.Logger
+		svc Service
+	}
+	type args struct {
+		ctx context.Context
+		req *pb.ExportPersonalDataRequest
+	}
+	tests := []struct {
+		name     string
+		fields   fields
+		args     args
+		setup    func(t *testing.T, svc Service)
+		want     *pb.ExportPersonalDataResponse
+		wantCode codes.Code
+	}{
+		{
+			name: "Should return personal data when token is valid",
+			fields: fields{
+				log: slog.Default(),
+				svc: mocks.NewMockService(gomock.NewController(t)),
+			},
+			args: args{
+				ctx: context.Background(),
+				req: &pb.ExportPersonalDataRequest{Email: "test@test.com", Token: "token"},
+			},
+			setup: func(t *testing.T, svc Service) {
+				t.Helper()
+				s, ok := svc.(*mocks.MockService)
+				if !ok {
+					t.Fatalf("Failed to cast service to mock service")
+				}
+
+				s.EXPECT().
+					Export(gomock.Any(), "test@test.com", "token").
+					Times(1).
+					Return([]byte(`{"email":"test@test.com"}`), nil)
+			},
+			want:     &pb.ExportPersonalDataResponse{Data: []byte(`{"email":"test@test.com"}`)},
+			wantCode: codes.OK,
+		},
+		{
+			name: "Should return permission denied code when token is invalid",
+			fields: fields{
+				log: slog.Default(),
+				svc: mocks.NewMockService(gomock.NewController(t)),
+			},
+			args: args{
+				ctx: context.Background(),
+				req: &pb.ExportPersonalDataRequest{Email: "test@test.com", Token: "token"},
+			},
+			setup: func(t *testing.T, svc Service) {
+				t.Helper()
+				s, ok := svc.(*mocks.MockService)
+				if !ok {
+					t.Fatalf("Failed to cast service to mock service")
+				}
+
+				s.EXPECT().
+					Export(gomock.Any(), "test@test.com", "token").
+					Times(1).
+					Return(nil, fmt.Errorf("privacy service: %w", privacy.ErrInvalidToken))
+			},
+			want:     nil,
+			wantCode: codes.PermissionDenied,
+		},
+		{
+			name: "Should return invalid argument code when token is missing",
+			fields: fields{
+				log: slog.Default(),
+				svc: mocks.NewMockService(gomock.NewController(t)),
+			},
+			args: args{
+				ctx: context.Background(),
+				req: &pb.ExportPersonalDataRequest{Email: "test@test.com"},
+			},
+			setup:    func(t *testing.T, _ Service) { t.Helper() },
+			want:     nil,
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name: "Should return error when service failed",
+			fields: fields{
+				log: slog.Default(),
+				svc: mocks.NewMockService(gomock.NewController(t)),
+			},
+			args: args{
+				ctx: context.Background(),
+				req: &pb.ExportPersonalDataRequest{Email: "test@test.com", Token: "token"},
+			},
+			setup: func(t *testing.T, svc Service) {
+				t.Helper()
+				s, ok := svc.(*mocks.MockService)
+				if !ok {
+					t.Fatalf("Failed to cast service to mock service")
+				}
+
+				s.EXPECT().
+					Export(gomock.Any(), "test@test.com", "token").
+					Times(1).
+					Return(nil, errors.New("failed to export"))
+			},
+			want:     nil,
+			wantCode: codes.Unknown,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			tt.setup(t, tt.fields.svc)
+			s := &Server{
+				log: tt.fields.log,
+				svc: tt.fields.svc,
+			}
+			got, err := s.ExportPersonalData(tt.args.ctx, tt.args.req)
+			if code := status.Code(err); code != tt.wantCode {
+				t.Errorf("Server.ExportPersonalData() code = %v, want %v", code, tt.wantCode)
+				return
+			}
+			if !proto.Equal(got, tt.want) {
+				t.Errorf("Server.ExportPersonalData() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestServerErasePersonalData(t *testing.T) {
+	t.Parallel()
+	type fields struct {
+		log *slog.Logger
+		svc Service
+	}
+	type args struct {
+		ctx context.Context
+		req *pb.ErasePersonalDataRequest
+	}
+	erasedAt := time.Date(2024, 5, 2, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name     string
+		fields   fields
+		args     args
+		setup    func(t *testing.T, svc Service)
+		want     *pb.ErasePersonalDataResponse
+		wantCode codes.Code
+	}{
+		{
+			name: "Should return audit entry when personal data is erased",
+			fields: fields{
+				log: slog.Default(),
+				svc: mocks.NewMockService(gomock.NewController(t)),
+			},
+			args: args{
+				ctx: context.Background(),
+				req: &pb.ErasePersonalDataRequest{Email: "test@test.com", Token: "token"},
+			},
+			setup: func(t *testing.T, svc Service) {
+				t.Helper()
+				s, ok := svc.(*mocks.MockService)
+				if !ok {
+					t.Fatalf("Failed to cast service to mock service")
+				}
+
+				s.EXPECT().
+					Erase(gomock.Any(), "test@test.com", "token").
+					Times(1).
+					Return(erasure.Audit{
+						ID:                   1,
+						SubscriberDeleted:    true,
+						NotificationsDeleted: 2,
+						DeliveriesDeleted:    3,
+						CreatedAt:            erasedAt,
+					}, nil)
+			},
+			want: &pb.ErasePersonalDataResponse{
+				AuditId:              1,
+				SubscriberDeleted:    true,
+				NotificationsDeleted: 2,
+				DeliveriesDeleted:    3,
+				ErasedAt:             timestamppb.New(erasedAt),
+			},
+			wantCode: codes.OK,
+		},
+		{
+			name: "Should return permission denied code when token is invalid",
+			fields: fields{
+				log: slog.Default(),
+				svc: mocks.NewMockService(gomock.NewController(t)),
+			},
+			args: args{
+				ctx: context.Background(),
+				req: &pb.ErasePersonalDataRequest{Email: "test@test.com", Token: "token"},
+			},
+			setup: func(t *testing.T, svc Service) {
+				t.Helper()
+				s, ok := svc.(*mocks.MockService)
+				if !ok {
+					t.Fatalf("Failed to cast service to mock service")
+				}
+
+				s.EXPECT().
+					Erase(gomock.Any(), "test@test.com", "token").
+					Times(1).
+					Return(erasure.Audit{}, fmt.Errorf("privacy service: %w", privacy.ErrInvalidToken))
+			},
+			want:     nil,
+			wantCode: codes.PermissionDenied,
+		},
+		{
+			name: "Should return error when service failed",
+			fields: fields{
+				log: slog.Default(),
+				svc: mocks.NewMockService(gomock.NewController(t)),
+			},
+			args: args{
+				ctx: context.Background(),
+				req: &pb.ErasePersonalDataRequest{Email: "test@test.com", Token: "token"},
+			},
+			setup: func(t *testing.T, svc Service) {
+				t.Helper()
+				s, ok := svc.(*mocks.MockService)
+				if !ok {
+					t.Fatalf("Failed to cast service to mock service")
+				}
+
+				s.EXPECT().
+					Erase(gomock.Any(), "test@test.com", "token").
+					Times(1).
+					Return(erasure.Audit{}, errors.New("failed to erase"))
+			},
+			want:     nil,
+			wantCode: codes.Unknown,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			tt.setup(t, tt.fields.svc)
+			s := &Server{
+				log: tt.fields.log,
+				svc: tt.fields.svc,
+			}
+			got, err := s.ErasePersonalData(tt.args.ctx, tt.args.req)
+			if code := status.Code(err); code != tt.wantCode {
+				t.Errorf("Server.ErasePersonalData() code = %v, want %v", code, tt.wantCode)
+				return
+			}
+			if !proto.Equal(got, tt.want) {
+				t.Errorf("Server.ErasePersonalData() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -1,17 +1,33 @@
 package sub
 
 import (
+	"errors"
 	"fmt"
 	"log/slog"
 
 	pb "github.com/hrvadl/converter/protos/gen/go/v1/sub"
 	"golang.org/x/net/context"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/emptypb"
+
+	subsvc "github.com/hrvadl/converter/sub/internal/service/sub"
+	"github.com/hrvadl/converter/sub/internal/service/validator"
+	"github.com/hrvadl/converter/sub/internal/storage/subscriber"
 )
 
 const operation = "sub server"
 
+// errorDomain is a domain of the rejection reasons,
+// which are attached to the InvalidArgument errors.
+const errorDomain = "sub.converter"
+
+// reasonInvalidPreferences is a reason of the rejection,
+// when frequency or the day of the digest is invalid.
+const reasonInvalidPreferences = "INVALID_PREFERENCES"
+
 // Registers subscribe handler to the given GRPC server.
 // NOTE: all parameters are required, the service will panic if
 // either of them is missing.
@@ -24,7 +40,7 @@ func Register(srv *grpc.Server, svc Service, log *slog.Logger) {
 
 //go:generate mockgen -destination=./mocks/mock_svcr.go -package=mocks . Service
 type Service interface {
-	Subscribe(ctx context.Context, mail string) (int64, error)
+	Subscribe(ctx context.Context, s subscriber.Subscriber) (int64, error)
 }
 
 // Server represents subscribe GRPC server
@@ -37,10 +53,61 @@ type Server struct {
 }
 
 // Subscribe method calls underlying service method and returns an error, in case there was a
-// failure.
+// failure. Rejected subscriber is reported with InvalidArgument code and
+// ErrorInfo detail with the reason, already subscribed one is reported
+// with AlreadyExists code.
 func (s *Server) Subscribe(ctx context.Context, req *pb.SubscribeRequest) (*emptypb.Empty, error) {
-	if _, err := s.svc.Subscribe(ctx, req.Email); err != nil {
-		return nil, fmt.Errorf("%s: failed to subscribe user: %w", operation, err)
+	if _, err := s.svc.Subscribe(ctx, subscriber.Subscriber{
+		Email:     req.GetEmail(),
+		Frequency: mapFrequency(req.GetFrequency()),
+		Weekday:   int(req.GetWeekday()),
+		MonthDay:  int(req.GetMonthDay()),
+		Locale:    subscriber.Locale(req.GetLocale()),
+	}); err != nil {
+		return nil, mapError(err)
 	}
 	return nil, nil
 }
+
+// mapError maps service error to the GRPC status error.
+func mapError(err error) error {
+	var r *validator.Rejection
+	switch {
+	case errors.As(err, &r):
+		return withReason(codes.InvalidArgument, string(r.Reason), r.Msg)
+	case errors.Is(err, subsvc.ErrInvalidSubscriber):
+		return withReason(codes.InvalidArgument, reasonInvalidPreferences, err.Error())
+	case errors.Is(err, subscriber.ErrAlreadyExists):
+		return status.Errorf(codes.AlreadyExists, "%s: %v", operation, subscriber.ErrAlreadyExists)
+	default:
+		return fmt.Errorf("%s: failed to subscribe user: %w", operation, err)
+	}
+}
+
+// withReason constructs status error with the ErrorInfo detail.
+func withReason(c codes.Code, reason, msg string) error {
+	st, err := status.New(c, msg).WithDetails(&errdetails.ErrorInfo{
+		Reason: reason,
+		Domain: errorDomain,
+	})
+	if err != nil {
+		return status.Error(c, msg)
+	}
+	return st.Err()
+}
+
+// mapFrequency maps GRPC frequency to the subscriber's one.
+// Unspecified frequency is mapped to the empty one, so service
+// could fallback to the default.
+func mapFrequency(f pb.Frequency) subscriber.Frequency {
+	switch f {
+	case pb.Frequency_FREQUENCY_DAILY:
+		return subscriber.FrequencyDaily
+	case pb.Frequency_FREQUENCY_WEEKLY:
+		return subscriber.FrequencyWeekly
+	case pb.Frequency_FREQUENCY_MONTHLY:
+		return subscriber.FrequencyMonthly
+	default:
+		return ""
+	}
+}
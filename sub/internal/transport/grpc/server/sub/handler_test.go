@@ -2,6 +2,7 @@ package sub
 
 import (
 	"errors"
+	"fmt"
 	"log/slog"
 	"reflect"
 	"testing"
@@ -9,8 +10,14 @@ import (
 	pb "github.com/hrvadl/converter/protos/gen/go/v1/sub"
 	"go.uber.org/mock/gomock"
 	"golang.org/x/net/context"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/emptypb"
 
+	subsvc "github.com/hrvadl/converter/sub/internal/service/sub"
+	"github.com/hrvadl/converter/sub/internal/service/validator"
+	"github.com/hrvadl/converter/sub/internal/storage/subscriber"
 	"github.com/hrvadl/converter/sub/internal/transport/grpc/server/sub/mocks"
 )
 
@@ -49,7 +56,10 @@ func TestServerSubscribe(t *testing.T) {
 					t.Fatalf("Failed to cast service to mock service")
 				}
 
-				s.EXPECT().Subscribe(gomock.Any(), "test@test.com").Times(1).Return(int64(1), nil)
+				s.EXPECT().
+					Subscribe(gomock.Any(), subscriber.Subscriber{Email: "test@test.com"}).
+					Times(1).
+					Return(int64(1), nil)
 			},
 			want:    nil,
 			wantErr: false,
@@ -72,12 +82,45 @@ func TestServerSubscribe(t *testing.T) {
 				}
 
 				s.EXPECT().
-					Subscribe(gomock.Any(), "test@test.com").
+					Subscribe(gomock.Any(), subscriber.Subscriber{Email: "test@test.com"}).
 					Times(1).
 					Return(int64(0), errors.New("failed to subscribe"))
 			},
 			wantErr: true,
 		},
+		{
+			name: "Should map digest preferences when subscribing",
+			fields: fields{
+				log: slog.Default(),
+				svc: mocks.NewMockService(gomock.NewController(t)),
+			},
+			args: args{
+				ctx: context.Background(),
+				req: &pb.SubscribeRequest{
+					Email:     "test@test.com",
+					Frequency: pb.Frequency_FREQUENCY_MONTHLY,
+					MonthDay:  15,
+				},
+			},
+			setup: func(t *testing.T, svc Service) {
+				t.Helper()
+				s, ok := svc.(*mocks.MockService)
+				if !ok {
+					t.Fatalf("Failed to cast service to mock service")
+				}
+
+				s.EXPECT().
+					Subscribe(gomock.Any(), subscriber.Subscriber{
+						Email:     "test@test.com",
+						Frequency: subscriber.FrequencyMonthly,
+						MonthDay:  15,
+					}).
+					Times(1).
+					Return(int64(1), nil)
+			},
+			want:    nil,
+			wantErr: false,
+		},
 	}
 
 	for _, tt := range tests {
@@ -101,3 +144,98 @@ func TestServerSubscribe(t *testing.T) {
 		})
 	}
 }
+
+func TestMapFrequency(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name string
+		f    pb.Frequency
+		want subscriber.Frequency
+	}{
+		{
+			name: "Should map daily frequency",
+			f:    pb.Frequency_FREQUENCY_DAILY,
+			want: subscriber.FrequencyDaily,
+		},
+		{
+			name: "Should map weekly frequency",
+			f:    pb.Frequency_FREQUENCY_WEEKLY,
+			want: subscriber.FrequencyWeekly,
+		},
+		{
+			name: "Should map monthly frequency",
+			f:    pb.Frequency_FREQUENCY_MONTHLY,
+			want: subscriber.FrequencyMonthly,
+		},
+		{
+			name: "Should map unspecified frequency to empty one",
+			f:    pb.Frequency_FREQUENCY_UNSPECIFIED,
+			want: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := mapFrequency(tt.f); got != tt.want {
+				t.Errorf("mapFrequency() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMapError(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name       string
+		err        error
+		wantCode   codes.Code
+		wantReason string
+	}{
+		{
+			name: "Should map rejection to invalid argument with the reason",
+			err: fmt.Errorf("%w: %w", subsvc.ErrInvalidSubscriber, &validator.Rejection{
+				Reason: validator.ReasonDisposableDomain,
+				Msg:    "mailinator.com is a disposable mail provider",
+			}),
+			wantCode:   codes.InvalidArgument,
+			wantReason: string(validator.ReasonDisposableDomain),
+		},
+		{
+			name:       "Should map invalid preferences to invalid argument",
+			err:        fmt.Errorf("%w: invalid weekday", subsvc.ErrInvalidSubscriber),
+			wantCode:   codes.InvalidArgument,
+			wantReason: reasonInvalidPreferences,
+		},
+		{
+			name:     "Should map existing subscriber to already exists",
+			err:      fmt.Errorf("failed to save: %w", subscriber.ErrAlreadyExists),
+			wantCode: codes.AlreadyExists,
+		},
+		{
+			name:     "Should map other errors to unknown",
+			err:      errors.New("failed to connect"),
+			wantCode: codes.Unknown,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			st := status.Convert(mapError(tt.err))
+			if st.Code() != tt.wantCode {
+				t.Errorf("mapError() code = %v, want %v", st.Code(), tt.wantCode)
+			}
+
+			var reason string
+			for _, d := range st.Details() {
+				if info, ok := d.(*errdetails.ErrorInfo); ok {
+					reason = info.GetReason()
+				}
+			}
+			if reason != tt.wantReason {
+				t.Errorf("mapError() reason = %v, want %v", reason, tt.wantReason)
+			}
+		})
+	}
+}
@@ -13,6 +13,7 @@ import (
 	context "context"
 	reflect "reflect"
 
+	subscriber "github.com/hrvadl/converter/sub/internal/storage/subscriber"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -40,7 +41,7 @@ func (m *MockService) EXPECT() *MockServiceMockRecorder {
 }
 
 // Subscribe mocks base method.
-func (m *MockService) Subscribe(arg0 context.Context, arg1 string) (int64, error) {
+func (m *MockService) Subscribe(arg0 context.Context, arg1 subscriber.Subscriber) (int64, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "Subscribe", arg0, arg1)
 	ret0, _ := ret[0].(int64)
@@ -0,0 +1,86 @@
+package suppression
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	pb "github.com/hrvadl/converter/protos/gen/go/v1/sub"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/hrvadl/converter/sub/internal/storage/suppression"
+)
+
+const operation = "suppression server"
+
+// Registers suppression handler to the given GRPC server.
+// NOTE: all parameters are required, the service will panic if
+// either of them is missing.
+func Register(srv *grpc.Server, svc Service, log *slog.Logger) {
+	pb.RegisterSuppressionServiceServer(srv, &Server{
+		log: log,
+		svc: svc,
+	})
+}
+
+//go:generate mockgen -destination=./mocks/mock_svc.go -package=mocks . Service
+type Service interface {
+	Record(ctx context.Context, e suppression.Event) error
+}
+
+// Server represents suppression GRPC server
+// which will handle the incoming requests and delegate
+// all work to the underlying svc.
+type Server struct {
+	pb.UnimplementedSuppressionServiceServer
+	log *slog.Logger
+	svc Service
+}
+
+// RecordMailEvent method maps request to the mail event and calls
+// underlying service method. Returns InvalidArgument code if email
+// or event type is missing.
+func (s *Server) RecordMailEvent(
+	ctx context.Context,
+	req *pb.RecordMailEventRequest,
+) (*emptypb.Empty, error) {
+	if req.GetEmail() == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "%s: email is required", operation)
+	}
+
+	t, ok := mapEventType(req.GetType())
+	if !ok {
+		return nil, status.Errorf(codes.InvalidArgument, "%s: event type %s is not supported", operation, req.GetType())
+	}
+
+	err := s.svc.Record(ctx, suppression.Event{
+		EventID:     req.GetEventId(),
+		Email:       req.GetEmail(),
+		Type:        t,
+		MessageID:   req.GetMessageId(),
+		Description: req.GetDescription(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to record mail event: %w", operation, err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+// mapEventType maps GRPC event type to the suppression's one.
+// Returns false if type is unspecified or unknown.
+func mapEventType(t pb.MailEventType) (suppression.EventType, bool) {
+	switch t {
+	case pb.MailEventType_MAIL_EVENT_TYPE_HARD_BOUNCE:
+		return suppression.EventHardBounce, true
+	case pb.MailEventType_MAIL_EVENT_TYPE_SOFT_BOUNCE:
+		return suppression.EventSoftBounce, true
+	case pb.MailEventType_MAIL_EVENT_TYPE_COMPLAINT:
+		return suppression.EventComplaint, true
+	default:
+		return "", false
+	}
+}
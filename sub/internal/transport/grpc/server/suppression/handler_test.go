@@ -0,0 +1,209 @@
+package suppression
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+
+	pb "github.com/hrvadl/converter/protos/gen/go/v1/sub"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/hrvadl/converter/sub/internal/storage/suppression"
+	"github.com/hrvadl/converter/sub/internal/transport/grpc/server/suppression/mocks"
+)
+
+func TestServerRecordMailEvent(t *testing.T) {
+	t.Parallel()
+	type fields struct {
+		log *slog.Logger
+		svc Service
+	}
+	type args struct {
+		ctx context.Context
+		req *pb.RecordMailEventRequest
+	}
+	tests := []struct {
+		name     string
+		fields   fields
+		args     args
+		setup    func(t *testing.T, svc Service)
+		wantErr  bool
+		wantCode codes.Code
+	}{
+		{
+			name: "Should record mail event when service succeeded",
+			fields: fields{
+				log: slog.Default(),
+				svc: mocks.NewMockService(gomock.NewController(t)),
+			},
+			args: args{
+				ctx: context.Background(),
+				req: &pb.RecordMailEventRequest{
+					EventId:     "msg_1",
+					Email:       "test@test.com",
+					Type:        pb.MailEventType_MAIL_EVENT_TYPE_HARD_BOUNCE,
+					MessageId:   "id",
+					Description: "mailbox doesn't exist",
+				},
+			},
+			setup: func(t *testing.T, svc Service) {
+				t.Helper()
+				s, ok := svc.(*mocks.MockService)
+				if !ok {
+					t.Fatalf("Failed to cast service to mock service")
+				}
+
+				s.EXPECT().
+					Record(gomock.Any(), suppression.Event{
+						EventID:     "msg_1",
+						Email:       "test@test.com",
+						Type:        suppression.EventHardBounce,
+						MessageID:   "id",
+						Description: "mailbox doesn't exist",
+					}).
+					Times(1).
+					Return(nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "Should return invalid argument when email is missing",
+			fields: fields{
+				log: slog.Default(),
+				svc: mocks.NewMockService(gomock.NewController(t)),
+			},
+			args: args{
+				ctx: context.Background(),
+				req: &pb.RecordMailEventRequest{Type: pb.MailEventType_MAIL_EVENT_TYPE_COMPLAINT},
+			},
+			setup: func(t *testing.T, svc Service) {
+				t.Helper()
+				s, ok := svc.(*mocks.MockService)
+				if !ok {
+					t.Fatalf("Failed to cast service to mock service")
+				}
+
+				s.EXPECT().Record(gomock.Any(), gomock.Any()).Times(0)
+			},
+			wantErr:  true,
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name: "Should return invalid argument when event type is unspecified",
+			fields: fields{
+				log: slog.Default(),
+				svc: mocks.NewMockService(gomock.NewController(t)),
+			},
+			args: args{
+				ctx: context.Background(),
+				req: &pb.RecordMailEventRequest{Email: "test@test.com"},
+			},
+			setup: func(t *testing.T, svc Service) {
+				t.Helper()
+				s, ok := svc.(*mocks.MockService)
+				if !ok {
+					t.Fatalf("Failed to cast service to mock service")
+				}
+
+				s.EXPECT().Record(gomock.Any(), gomock.Any()).Times(0)
+			},
+			wantErr:  true,
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name: "Should return error when service failed",
+			fields: fields{
+				log: slog.Default(),
+				svc: mocks.NewMockService(gomock.NewController(t)),
+			},
+			args: args{
+				ctx: context.Background(),
+				req: &pb.RecordMailEventRequest{
+					Email: "test@test.com",
+					Type:  pb.MailEventType_MAIL_EVENT_TYPE_SOFT_BOUNCE,
+				},
+			},
+			setup: func(t *testing.T, svc Service) {
+				t.Helper()
+				s, ok := svc.(*mocks.MockService)
+				if !ok {
+					t.Fatalf("Failed to cast service to mock service")
+				}
+
+				s.EXPECT().
+					Record(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(errors.New("failed to record"))
+			},
+			wantErr:  true,
+			wantCode: codes.Unknown,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			tt.setup(t, tt.fields.svc)
+			s := &Server{
+				log: tt.fields.log,
+				svc: tt.fields.svc,
+			}
+			_, err := s.RecordMailEvent(tt.args.ctx, tt.args.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Server.RecordMailEvent() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr && status.Code(err) != tt.wantCode {
+				t.Errorf("Server.RecordMailEvent() code = %v, want %v", status.Code(err), tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestMapEventType(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name   string
+		t      pb.MailEventType
+		want   suppression.EventType
+		wantOk bool
+	}{
+		{
+			name:   "Should map hard bounce correctly",
+			t:      pb.MailEventType_MAIL_EVENT_TYPE_HARD_BOUNCE,
+			want:   suppression.EventHardBounce,
+			wantOk: true,
+		},
+		{
+			name:   "Should map soft bounce correctly",
+			t:      pb.MailEventType_MAIL_EVENT_TYPE_SOFT_BOUNCE,
+			want:   suppression.EventSoftBounce,
+			wantOk: true,
+		},
+		{
+			name:   "Should map complaint correctly",
+			t:      pb.MailEventType_MAIL_EVENT_TYPE_COMPLAINT,
+			want:   suppression.EventComplaint,
+			wantOk: true,
+		},
+		{
+			name:   "Should not map unspecified type",
+			t:      pb.MailEventType_MAIL_EVENT_TYPE_UNSPECIFIED,
+			want:   "",
+			wantOk: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, ok := mapEventType(tt.t)
+			if got != tt.want || ok != tt.wantOk {
+				t.Errorf("mapEventType() = %v, %v, want %v, %v", got, ok, tt.want, tt.wantOk)
+			}
+		})
+	}
+}
@@ -0,0 +1,188 @@
+package telegram
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hrvadl/converter/sub/internal/service/telegram"
+	"github.com/hrvadl/converter/sub/internal/storage/subscriber"
+)
+
+const (
+	// pollTimeout is a duration of the single long polling call.
+	pollTimeout = time.Second * 30
+	// retryDelay is a delay before the next polling
+	// call after the failed one.
+	retryDelay = time.Second * 5
+	// handleTimeout is a maximum duration of the command handling.
+	handleTimeout = time.Second * 10
+)
+
+const (
+	helpReply = "Hi! I send the USD to UAH exchange rate every day.\n\n" +
+		"/rate - current rate\n" +
+		"/subscribe - receive the rate every day\n" +
+		"/unsubscribe - stop receiving the rate"
+	subscribedReply        = "Subscribed! You'll receive the rate every day."
+	alreadySubscribedReply = "You're already subscribed."
+	unsubscribedReply      = "Unsubscribed. You won't receive the rate anymore."
+	notSubscribedReply     = "You aren't subscribed."
+	unknownCommandReply    = "Sorry, I don't know this command.\n\n" + helpReply
+	failedReply            = "Something went wrong, please try again later."
+	rateReplyFormat        = "1 USD = %.2f UAH"
+)
+
+// ukrainianLanguageCode is a language code of the Ukrainian users.
+const ukrainianLanguageCode = "uk"
+
+// NewBot constructs bot, which polls updates through the API.
+// NOTE: neither of arguments can't be nil, or bot will panic.
+func NewBot(api API, subs Subscriptions, rg RateGetter, log *slog.Logger) *Bot {
+	return &Bot{
+		api:  api,
+		subs: subs,
+		rate: rg,
+		log:  log,
+	}
+}
+
+// API is a Bot API used by the bot. It's implemented by the Client.
+type API interface {
+	GetUpdates(ctx context.Context, offset int64, timeout time.Duration) ([]Update, error)
+	Send(ctx context.Context, chatID, text string) (string, error)
+}
+
+//go:generate mockgen -destination=./mocks/mock_subscriptions.go -package=mocks . Subscriptions
+type Subscriptions interface {
+	Subscribe(ctx context.Context, chatID int64, l subscriber.Locale) error
+	Unsubscribe(ctx context.Context, chatID int64) error
+}
+
+//go:generate mockgen -destination=./mocks/mock_rategetter.go -package=mocks . RateGetter
+type RateGetter interface {
+	GetRate(ctx context.Context) (float32, error)
+}
+
+// Bot is a main structure, responsible for
+// handling commands sent to the Telegram bot.
+type Bot struct {
+	api  API
+	subs Subscriptions
+	rate RateGetter
+	log  *slog.Logger
+}
+
+// Run method long polls updates and handles commands until context
+// is cancelled. Failed polling call is retried after retryDelay, or
+// after the delay requested by the API. Bot API allows only a single
+// poller per bot, so bot should be run by a single replica.
+func (b *Bot) Run(ctx context.Context) {
+	var offset int64
+	for ctx.Err() == nil {
+		updates, err := b.api.GetUpdates(ctx, offset, pollTimeout)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+
+			delay := retryDelay
+			var apiErr *APIError
+			if errors.As(err, &apiErr) && apiErr.RetryAfter > 0 {
+				delay = apiErr.RetryAfter
+			}
+
+			b.log.Error("Failed to get updates", "err", err, "retryIn", delay)
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(delay):
+			}
+			continue
+		}
+
+		for _, u := range updates {
+			offset = u.UpdateID + 1
+			b.handle(ctx, u)
+		}
+	}
+}
+
+// handle replies to the command from the update.
+// Updates without messages are ignored.
+func (b *Bot) handle(ctx context.Context, u Update) {
+	if u.Message == nil || u.Message.Text == "" {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, handleTimeout)
+	defer cancel()
+
+	chatID := u.Message.Chat.ID
+	reply := b.reply(ctx, chatID, locale(u.Message.From), command(u.Message.Text))
+	if _, err := b.api.Send(ctx, strconv.FormatInt(chatID, 10), reply); err != nil {
+		b.log.Error("Failed to reply", "chatID", chatID, "err", err)
+	}
+}
+
+// reply executes the command and returns reply to it.
+func (b *Bot) reply(ctx context.Context, chatID int64, l subscriber.Locale, cmd string) string {
+	switch cmd {
+	case "/start", "/help":
+		return helpReply
+	case "/rate":
+		r, err := b.rate.GetRate(ctx)
+		if err != nil {
+			b.log.Error("Failed to get rate", "err", err)
+			return failedReply
+		}
+		return fmt.Sprintf(rateReplyFormat, r)
+	case "/subscribe":
+		err := b.subs.Subscribe(ctx, chatID, l)
+		switch {
+		case errors.Is(err, telegram.ErrAlreadySubscribed):
+			return alreadySubscribedReply
+		case err != nil:
+			b.log.Error("Failed to subscribe chat", "chatID", chatID, "err", err)
+			return failedReply
+		}
+		return subscribedReply
+	case "/unsubscribe":
+		err := b.subs.Unsubscribe(ctx, chatID)
+		switch {
+		case errors.Is(err, telegram.ErrNotSubscribed):
+			return notSubscribedReply
+		case err != nil:
+			b.log.Error("Failed to unsubscribe chat", "chatID", chatID, "err", err)
+			return failedReply
+		}
+		return unsubscribedReply
+	default:
+		return unknownCommandReply
+	}
+}
+
+// command returns command from the message text without its arguments
+// and the bot mention, i.e. "/rate@rate_bot now" is "/rate".
+func command(text string) string {
+	fields := strings.Fields(text)
+	if len(fields) == 0 {
+		return ""
+	}
+
+	cmd, _, _ := strings.Cut(fields[0], "@")
+	return strings.ToLower(cmd)
+}
+
+// locale returns locale of the notifications from the
+// user's language. English is used for unknown languages.
+func locale(u *User) subscriber.Locale {
+	if u != nil && strings.HasPrefix(u.LanguageCode, ukrainianLanguageCode) {
+		return subscriber.LocaleUkrainian
+	}
+	return subscriber.LocaleEnglish
+}
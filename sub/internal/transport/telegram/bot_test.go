@@ -0,0 +1,255 @@
+package telegram
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"reflect"
+	"testing"
+	"time"
+
+	"go.uber.org/mock/gomock"
+
+	"github.com/hrvadl/converter/sub/internal/service/telegram"
+	"github.com/hrvadl/converter/sub/internal/storage/subscriber"
+	"github.com/hrvadl/converter/sub/internal/transport/telegram/mocks"
+	"github.com/hrvadl/converter/sub/internal/transport/telegram/telegramtest"
+)
+
+func TestNewBot(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	api := NewClient(DefaultBaseURL, token)
+	subs := mocks.NewMockSubscriptions(ctrl)
+	rg := mocks.NewMockRateGetter(ctrl)
+
+	want := &Bot{api: api, subs: subs, rate: rg, log: slog.Default()}
+	if got := NewBot(api, subs, rg, slog.Default()); !reflect.DeepEqual(got, want) {
+		t.Errorf("NewBot() = %v, want %v", got, want)
+	}
+}
+
+func TestBotReply(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name   string
+		cmd    string
+		locale subscriber.Locale
+		setup  func(subs *mocks.MockSubscriptions, rg *mocks.MockRateGetter)
+		want   string
+	}{
+		{
+			name:  "Should reply with help to /start",
+			cmd:   "/start",
+			setup: func(_ *mocks.MockSubscriptions, _ *mocks.MockRateGetter) {},
+			want:  helpReply,
+		},
+		{
+			name: "Should reply with current rate to /rate",
+			cmd:  "/rate",
+			setup: func(_ *mocks.MockSubscriptions, rg *mocks.MockRateGetter) {
+				rg.EXPECT().GetRate(gomock.Any()).Times(1).Return(float32(41.5), nil)
+			},
+			want: "1 USD = 41.50 UAH",
+		},
+		{
+			name: "Should reply with failure when rate couldn't be got",
+			cmd:  "/rate",
+			setup: func(_ *mocks.MockSubscriptions, rg *mocks.MockRateGetter) {
+				rg.EXPECT().GetRate(gomock.Any()).Times(1).Return(float32(0), errors.New("rw is down"))
+			},
+			want: failedReply,
+		},
+		{
+			name:   "Should subscribe chat in user's locale",
+			cmd:    "/subscribe",
+			locale: subscriber.LocaleUkrainian,
+			setup: func(subs *mocks.MockSubscriptions, _ *mocks.MockRateGetter) {
+				subs.EXPECT().Subscribe(gomock.Any(), int64(42), subscriber.LocaleUkrainian).Times(1).Return(nil)
+			},
+			want: subscribedReply,
+		},
+		{
+			name:   "Should tell when chat is already subscribed",
+			cmd:    "/subscribe",
+			locale: subscriber.LocaleEnglish,
+			setup: func(subs *mocks.MockSubscriptions, _ *mocks.MockRateGetter) {
+				subs.EXPECT().
+					Subscribe(gomock.Any(), int64(42), subscriber.LocaleEnglish).
+					Times(1).
+					Return(telegram.ErrAlreadySubscribed)
+			},
+			want: alreadySubscribedReply,
+		},
+		{
+			name:   "Should reply with failure when chat couldn't be subscribed",
+			cmd:    "/subscribe",
+			locale: subscriber.LocaleEnglish,
+			setup: func(subs *mocks.MockSubscriptions, _ *mocks.MockRateGetter) {
+				subs.EXPECT().Subscribe(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(errors.New("db is down"))
+			},
+			want: failedReply,
+		},
+		{
+			name: "Should unsubscribe chat",
+			cmd:  "/unsubscribe",
+			setup: func(subs *mocks.MockSubscriptions, _ *mocks.MockRateGetter) {
+				subs.EXPECT().Unsubscribe(gomock.Any(), int64(42)).Times(1).Return(nil)
+			},
+			want: unsubscribedReply,
+		},
+		{
+			name: "Should tell when chat isn't subscribed",
+			cmd:  "/unsubscribe",
+			setup: func(subs *mocks.MockSubscriptions, _ *mocks.MockRateGetter) {
+				subs.EXPECT().Unsubscribe(gomock.Any(), int64(42)).Times(1).Return(telegram.ErrNotSubscribed)
+			},
+			want: notSubscribedReply,
+		},
+		{
+			name:  "Should reply with help to unknown command",
+			cmd:   "/weather",
+			setup: func(_ *mocks.MockSubscriptions, _ *mocks.MockRateGetter) {},
+			want:  unknownCommandReply,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			subs := mocks.NewMockSubscriptions(ctrl)
+			rg := mocks.NewMockRateGetter(ctrl)
+			tt.setup(subs, rg)
+
+			b := NewBot(&fakeAPI{}, subs, rg, slog.Default())
+			if got := b.reply(context.Background(), 42, tt.locale, tt.cmd); got != tt.want {
+				t.Errorf("Bot.reply() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBotRun(t *testing.T) {
+	t.Parallel()
+	srv := telegramtest.NewServer(t, token)
+	ctrl := gomock.NewController(t)
+	subs := mocks.NewMockSubscriptions(ctrl)
+	rg := mocks.NewMockRateGetter(ctrl)
+	subs.EXPECT().Subscribe(gomock.Any(), int64(42), subscriber.LocaleUkrainian).Times(1).Return(nil)
+	rg.EXPECT().GetRate(gomock.Any()).Times(1).Return(float32(41.5), nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		NewBot(NewClient(srv.URL(), token), subs, rg, slog.Default()).Run(ctx)
+	}()
+
+	srv.PushMessage(42, "uk-UA", "/subscribe")
+	srv.PushMessage(43, "en", "/rate@rate_bot")
+	sent := srv.WaitSent(t, 2)
+	cancel()
+
+	want := []telegramtest.Message{
+		{ChatID: "42", Text: subscribedReply},
+		{ChatID: "43", Text: "1 USD = 41.50 UAH"},
+	}
+	if !reflect.DeepEqual(sent, want) {
+		t.Errorf("Bot sent %v, want %v", sent, want)
+	}
+
+	select {
+	case <-done:
+	case <-time.After(time.Second * 5):
+		t.Fatal("Bot.Run() hasn't returned after context was cancelled")
+	}
+}
+
+func TestBotRunRetries(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithCancel(context.Background())
+	api := &fakeAPI{
+		polls: []func() ([]Update, error){
+			func() ([]Update, error) {
+				return nil, &APIError{Code: 429, Description: "Too Many Requests", RetryAfter: time.Millisecond}
+			},
+			func() ([]Update, error) {
+				return []Update{{UpdateID: 5}}, nil
+			},
+			func() ([]Update, error) {
+				cancel()
+				return nil, ctx.Err()
+			},
+		},
+	}
+
+	NewBot(api, nil, nil, slog.Default()).Run(ctx)
+
+	if want := []int64{0, 0, 6}; !reflect.DeepEqual(api.offsets, want) {
+		t.Errorf("Bot polled with offsets %v, want %v", api.offsets, want)
+	}
+}
+
+// fakeAPI replies to the polling calls in order. It's
+// used where fake Telegram server can't reproduce the case.
+type fakeAPI struct {
+	polls   []func() ([]Update, error)
+	offsets []int64
+}
+
+func (f *fakeAPI) GetUpdates(_ context.Context, offset int64, _ time.Duration) ([]Update, error) {
+	f.offsets = append(f.offsets, offset)
+	poll := f.polls[0]
+	f.polls = f.polls[1:]
+	return poll()
+}
+
+func (f *fakeAPI) Send(context.Context, string, string) (string, error) {
+	return "", nil
+}
+
+func TestCommand(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{name: "Should return plain command", text: "/rate", want: "/rate"},
+		{name: "Should strip bot mention", text: "/rate@rate_bot", want: "/rate"},
+		{name: "Should strip arguments", text: " /Subscribe now ", want: "/subscribe"},
+		{name: "Should return empty command for blank text", text: "  ", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := command(tt.text); got != tt.want {
+				t.Errorf("command() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLocale(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name string
+		u    *User
+		want subscriber.Locale
+	}{
+		{name: "Should return Ukrainian for Ukrainian users", u: &User{LanguageCode: "uk"}, want: subscriber.LocaleUkrainian},
+		{name: "Should return English for other users", u: &User{LanguageCode: "de"}, want: subscriber.LocaleEnglish},
+		{name: "Should return English when user is unknown", u: nil, want: subscriber.LocaleEnglish},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := locale(tt.u); got != tt.want {
+				t.Errorf("locale() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
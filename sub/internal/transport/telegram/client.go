@@ -0,0 +1,181 @@
+// Package telegram implements the Telegram Bot API client and the bot,
+// through which users subscribe to the rate in Telegram.
+package telegram
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultBaseURL is a base URL of the Telegram Bot API.
+const DefaultBaseURL = "https://api.telegram.org"
+
+// requestTimeout is a maximum duration of the API call.
+// Long polling calls are extended by the polling timeout.
+const requestTimeout = time.Second * 10
+
+// NewClient constructs Bot API client of the bot with the given token.
+// Base URL could point to the local server, i.e. in tests.
+func NewClient(baseURL, token string) *Client {
+	return &Client{
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+		token:   token,
+		http:    &http.Client{},
+	}
+}
+
+// Client is a thin Telegram Bot API client, which
+// supports only methods needed by the bot.
+type Client struct {
+	baseURL string
+	token   string
+	http    *http.Client
+}
+
+// APIError is returned when Bot API has rejected the call.
+// RetryAfter is set when bot has exceeded the flood limits.
+type APIError struct {
+	Code        int
+	Description string
+	RetryAfter  time.Duration
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("telegram api error %d: %s", e.Code, e.Description)
+}
+
+// Update is an incoming update. Only messages are supported.
+type Update struct {
+	UpdateID int64    `json:"update_id"`
+	Message  *Message `json:"message,omitempty"`
+}
+
+// Message is a message sent to the bot.
+type Message struct {
+	MessageID int64  `json:"message_id"`
+	Chat      Chat   `json:"chat"`
+	From      *User  `json:"from,omitempty"`
+	Text      string `json:"text,omitempty"`
+}
+
+// Chat is a chat, where message was sent.
+type Chat struct {
+	ID int64 `json:"id"`
+}
+
+// User is a sender of the message.
+type User struct {
+	ID           int64  `json:"id"`
+	LanguageCode string `json:"language_code,omitempty"`
+}
+
+type response struct {
+	OK          bool            `json:"ok"`
+	Result      json.RawMessage `json:"result"`
+	ErrorCode   int             `json:"error_code"`
+	Description string          `json:"description"`
+	Parameters  struct {
+		RetryAfter int `json:"retry_after"`
+	} `json:"parameters"`
+}
+
+type getUpdatesRequest struct {
+	Offset         int64    `json:"offset,omitempty"`
+	Timeout        int      `json:"timeout"`
+	AllowedUpdates []string `json:"allowed_updates"`
+}
+
+type sendMessageRequest struct {
+	ChatID string `json:"chat_id"`
+	Text   string `json:"text"`
+}
+
+// GetUpdates method long polls updates with ID greater or equal
+// to the offset. Call returns as soon as there's an update, or
+// when timeout has expired.
+func (c *Client) GetUpdates(ctx context.Context, offset int64, timeout time.Duration) ([]Update, error) {
+	var updates []Update
+	req := getUpdatesRequest{
+		Offset:         offset,
+		Timeout:        int(timeout.Seconds()),
+		AllowedUpdates: []string{"message"},
+	}
+	if err := c.call(ctx, "getUpdates", req, &updates, requestTimeout+timeout); err != nil {
+		return nil, err
+	}
+
+	return updates, nil
+}
+
+// Send method sends plain-text message to the chat.
+// Returns ID of the sent message.
+func (c *Client) Send(ctx context.Context, chatID, text string) (string, error) {
+	var msg Message
+	req := sendMessageRequest{ChatID: chatID, Text: text}
+	if err := c.call(ctx, "sendMessage", req, &msg, requestTimeout); err != nil {
+		return "", err
+	}
+
+	return strconv.FormatInt(msg.MessageID, 10), nil
+}
+
+// call posts JSON encoded request to the API method and decodes
+// its result. Token is a part of the URL, so it's stripped from
+// the transport errors to not leak to the logs.
+func (c *Client) call(ctx context.Context, method string, req, res any, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	body, err := json.Marshal(req)
+	if err != nil {
+		return fmt.Errorf("failed to encode %s request: %w", method, err)
+	}
+
+	r, err := http.NewRequestWithContext(
+		ctx,
+		http.MethodPost,
+		c.baseURL+"/bot"+c.token+"/"+method,
+		bytes.NewReader(body),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to build %s request: %w", method, err)
+	}
+	r.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.http.Do(r)
+	if err != nil {
+		var uerr *url.Error
+		if errors.As(err, &uerr) {
+			err = uerr.Err
+		}
+		return fmt.Errorf("failed to call %s: %w", method, err)
+	}
+	defer resp.Body.Close()
+
+	var out response
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return fmt.Errorf("failed to decode %s response: %w", method, err)
+	}
+
+	if !out.OK {
+		return &APIError{
+			Code:        out.ErrorCode,
+			Description: out.Description,
+			RetryAfter:  time.Duration(out.Parameters.RetryAfter) * time.Second,
+		}
+	}
+
+	if err := json.Unmarshal(out.Result, res); err != nil {
+		return fmt.Errorf("failed to decode %s result: %w", method, err)
+	}
+
+	return nil
+}
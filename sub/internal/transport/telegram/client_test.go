@@ -0,0 +1,116 @@
+package telegram
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hrvadl/converter/sub/internal/transport/telegram/telegramtest"
+)
+
+const token = "123:secret"
+
+func TestClientSend(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		token    string
+		chatID   string
+		blocked  bool
+		want     string
+		wantCode int
+	}{
+		{
+			name:   "Should send message and return its ID",
+			token:  token,
+			chatID: "42",
+			want:   "1",
+		},
+		{
+			name:     "Should return APIError when user has blocked the bot",
+			token:    token,
+			chatID:   "42",
+			blocked:  true,
+			wantCode: http.StatusForbidden,
+		},
+		{
+			name:     "Should return APIError when token is invalid",
+			token:    "invalid",
+			chatID:   "42",
+			wantCode: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			srv := telegramtest.NewServer(t, token)
+			if tt.blocked {
+				srv.Block(tt.chatID)
+			}
+
+			got, err := NewClient(srv.URL(), tt.token).Send(context.Background(), tt.chatID, "1 USD = 41.50 UAH")
+			if tt.wantCode != 0 {
+				var apiErr *APIError
+				if !errors.As(err, &apiErr) || apiErr.Code != tt.wantCode {
+					t.Fatalf("Client.Send() error = %v, want API error %d", err, tt.wantCode)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Client.Send() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Client.Send() = %v, want %v", got, tt.want)
+			}
+
+			sent := srv.Sent()
+			if len(sent) != 1 || sent[0] != (telegramtest.Message{ChatID: tt.chatID, Text: "1 USD = 41.50 UAH"}) {
+				t.Errorf("Server received %v, want single message to %s", sent, tt.chatID)
+			}
+		})
+	}
+}
+
+func TestClientGetUpdates(t *testing.T) {
+	t.Parallel()
+	srv := telegramtest.NewServer(t, token)
+	srv.PushMessage(42, "uk", "/start")
+	srv.PushMessage(43, "en", "/rate")
+	c := NewClient(srv.URL()+"/", token)
+
+	updates, err := c.GetUpdates(context.Background(), 0, 0)
+	if err != nil {
+		t.Fatalf("Client.GetUpdates() error = %v", err)
+	}
+	if len(updates) != 2 {
+		t.Fatalf("Client.GetUpdates() returned %d updates, want 2", len(updates))
+	}
+
+	first := updates[0]
+	if first.UpdateID != 1 || first.Message == nil || first.Message.Chat.ID != 42 ||
+		first.Message.Text != "/start" || first.Message.From == nil || first.Message.From.LanguageCode != "uk" {
+		t.Errorf("Client.GetUpdates() first update = %+v, want /start from chat 42", first)
+	}
+
+	updates, err = c.GetUpdates(context.Background(), updates[1].UpdateID+1, 0)
+	if err != nil {
+		t.Fatalf("Client.GetUpdates() error = %v", err)
+	}
+	if len(updates) != 0 {
+		t.Errorf("Client.GetUpdates() = %+v, want confirmed updates to be skipped", updates)
+	}
+}
+
+func TestClientHidesToken(t *testing.T) {
+	t.Parallel()
+	_, err := NewClient("http://127.0.0.1:1", token).Send(context.Background(), "42", "text")
+	if err == nil {
+		t.Fatal("Client.Send() error = nil, want connection error")
+	}
+	if strings.Contains(err.Error(), token) {
+		t.Errorf("Client.Send() error = %v, want token to be hidden", err)
+	}
+}
@@ -0,0 +1,69 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/hrvadl/converter/sub/internal/transport/telegram (interfaces: Subscriptions)
+//
+// Generated by this command:
+//
+//	mockgen -destination=./mocks/mock_subscriptions.go -package=mocks . Subscriptions
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	subscriber "github.com/hrvadl/converter/sub/internal/storage/subscriber"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockSubscriptions is a mock of Subscriptions interface.
+type MockSubscriptions struct {
+	ctrl     *gomock.Controller
+	recorder *MockSubscriptionsMockRecorder
+}
+
+// MockSubscriptionsMockRecorder is the mock recorder for MockSubscriptions.
+type MockSubscriptionsMockRecorder struct {
+	mock *MockSubscriptions
+}
+
+// NewMockSubscriptions creates a new mock instance.
+func NewMockSubscriptions(ctrl *gomock.Controller) *MockSubscriptions {
+	mock := &MockSubscriptions{ctrl: ctrl}
+	mock.recorder = &MockSubscriptionsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSubscriptions) EXPECT() *MockSubscriptionsMockRecorder {
+	return m.recorder
+}
+
+// Subscribe mocks base method.
+func (m *MockSubscriptions) Subscribe(arg0 context.Context, arg1 int64, arg2 subscriber.Locale) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Subscribe", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Subscribe indicates an expected call of Subscribe.
+func (mr *MockSubscriptionsMockRecorder) Subscribe(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Subscribe", reflect.TypeOf((*MockSubscriptions)(nil).Subscribe), arg0, arg1, arg2)
+}
+
+// Unsubscribe mocks base method.
+func (m *MockSubscriptions) Unsubscribe(arg0 context.Context, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Unsubscribe", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Unsubscribe indicates an expected call of Unsubscribe.
+func (mr *MockSubscriptionsMockRecorder) Unsubscribe(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unsubscribe", reflect.TypeOf((*MockSubscriptions)(nil).Unsubscribe), arg0, arg1)
+}
@@ -0,0 +1,211 @@
+// Package telegramtest provides fake Telegram Bot API server,
+// so bot and client could be tested without the real Telegram.
+package telegramtest
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// Message is a message, which was sent by the bot.
+type Message struct {
+	ChatID string
+	Text   string
+}
+
+// NewServer starts fake Bot API server of the bot with the given
+// token. Server is closed when test finishes.
+func NewServer(t testing.TB, token string) *Server {
+	t.Helper()
+	s := &Server{
+		token:   token,
+		blocked: make(map[string]bool),
+		changed: make(chan struct{}),
+	}
+	s.srv = httptest.NewServer(http.HandlerFunc(s.serve))
+	t.Cleanup(s.srv.Close)
+	return s
+}
+
+// Server is a fake Bot API server. It serves updates pushed by the
+// test to the getUpdates calls and records messages sent by the bot.
+type Server struct {
+	srv     *httptest.Server
+	token   string
+	mu      sync.Mutex
+	updates []json.RawMessage
+	sent    []Message
+	blocked map[string]bool
+	// changed is closed and replaced when
+	// update is pushed or message is sent.
+	changed chan struct{}
+}
+
+// URL returns base URL of the server.
+func (s *Server) URL() string {
+	return s.srv.URL
+}
+
+// PushMessage queues text message from the user
+// with the given language code to the chat.
+func (s *Server) PushMessage(chatID int64, languageCode, text string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	id := int64(len(s.updates) + 1)
+	u, _ := json.Marshal(map[string]any{
+		"update_id": id,
+		"message": map[string]any{
+			"message_id": id,
+			"chat":       map[string]any{"id": chatID, "type": "private"},
+			"from":       map[string]any{"id": chatID, "is_bot": false, "language_code": languageCode},
+			"date":       time.Now().Unix(),
+			"text":       text,
+		},
+	})
+	s.updates = append(s.updates, u)
+	s.notify()
+}
+
+// Block makes the chat reject messages, as if user has blocked the bot.
+func (s *Server) Block(chatID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.blocked[chatID] = true
+}
+
+// Sent returns messages sent by the bot so far.
+func (s *Server) Sent() []Message {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Message(nil), s.sent...)
+}
+
+// WaitSent waits until bot sends at least n messages
+// and returns them. Test fails, if it takes too long.
+func (s *Server) WaitSent(t testing.TB, n int) []Message {
+	t.Helper()
+	timeout := time.After(time.Second * 5)
+	for {
+		s.mu.Lock()
+		sent, changed := append([]Message(nil), s.sent...), s.changed
+		s.mu.Unlock()
+		if len(sent) >= n {
+			return sent
+		}
+
+		select {
+		case <-changed:
+		case <-timeout:
+			t.Fatalf("Bot has sent %d messages, want %d", len(sent), n)
+		}
+	}
+}
+
+func (s *Server) notify() {
+	close(s.changed)
+	s.changed = make(chan struct{})
+}
+
+func (s *Server) serve(w http.ResponseWriter, r *http.Request) {
+	method, ok := strings.CutPrefix(r.URL.Path, "/bot"+s.token+"/")
+	if !ok {
+		reply(w, http.StatusUnauthorized, false, nil, "Unauthorized")
+		return
+	}
+
+	switch method {
+	case "getUpdates":
+		s.getUpdates(w, r)
+	case "sendMessage":
+		s.sendMessage(w, r)
+	default:
+		reply(w, http.StatusNotFound, false, nil, "Not Found: method not found")
+	}
+}
+
+func (s *Server) getUpdates(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Offset  int64 `json:"offset"`
+		Timeout int   `json:"timeout"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		reply(w, http.StatusBadRequest, false, nil, "Bad Request: "+err.Error())
+		return
+	}
+
+	timeout := time.After(time.Duration(req.Timeout) * time.Second)
+	for {
+		s.mu.Lock()
+		// Update IDs start with 1 and follow
+		// the order, in which updates were pushed.
+		start := min(max(req.Offset-1, 0), int64(len(s.updates)))
+		updates, changed := append([]json.RawMessage{}, s.updates[start:]...), s.changed
+		s.mu.Unlock()
+
+		if len(updates) > 0 {
+			reply(w, http.StatusOK, true, updates, "")
+			return
+		}
+
+		select {
+		case <-changed:
+		case <-timeout:
+			reply(w, http.StatusOK, true, updates, "")
+			return
+		case <-r.Context().Done():
+			return
+		}
+	}
+}
+
+func (s *Server) sendMessage(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		ChatID string `json:"chat_id"`
+		Text   string `json:"text"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.ChatID == "" || req.Text == "" {
+		reply(w, http.StatusBadRequest, false, nil, "Bad Request: chat_id and text are required")
+		return
+	}
+
+	chatID, err := strconv.ParseInt(req.ChatID, 10, 64)
+	if err != nil {
+		reply(w, http.StatusBadRequest, false, nil, "Bad Request: chat not found")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.blocked[req.ChatID] {
+		reply(w, http.StatusForbidden, false, nil, "Forbidden: bot was blocked by the user")
+		return
+	}
+
+	s.sent = append(s.sent, Message(req))
+	s.notify()
+	reply(w, http.StatusOK, true, map[string]any{
+		"message_id": len(s.sent),
+		"chat":       map[string]any{"id": chatID},
+		"text":       req.Text,
+	}, "")
+}
+
+func reply(w http.ResponseWriter, status int, ok bool, result any, description string) {
+	body := map[string]any{"ok": ok}
+	if ok {
+		body["result"] = result
+	} else {
+		body["error_code"] = status
+		body["description"] = description
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
@@ -0,0 +1,116 @@
+// Package webhook implements the client, which posts
+// signed JSON payloads to the registered webhooks.
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+const (
+	// IDHeader identifies the payload. It stays the same
+	// across retries, so receiver could drop duplicates.
+	IDHeader = "X-Webhook-Id"
+	// TimestampHeader is a unix time, when payload was signed.
+	TimestampHeader = "X-Webhook-Timestamp"
+	// SignatureHeader is an HMAC-SHA256 signature of the payload.
+	SignatureHeader = "X-Webhook-Signature"
+	// signaturePrefix is a prefix of the signature,
+	// which names the algorithm.
+	signaturePrefix = "sha256="
+)
+
+// requestTimeout is a maximum duration of the post
+// including reading of the response.
+const requestTimeout = time.Second * 10
+
+// maxResponseSize is a number of bytes of the response
+// drained, so connection could be reused.
+const maxResponseSize = 64 << 10
+
+// StatusError is returned when webhook has
+// responded with non-2xx status.
+type StatusError struct {
+	Code int
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("webhook responded with status %d", e.Code)
+}
+
+// NewClient constructs webhook client on top of the given HTTP
+// client, i.e. the one of the test server. Redirects aren't followed,
+// so payloads are posted only to the registered URLs.
+// NOTE: http client can't be nil, or client will panic.
+func NewClient(hc *http.Client) *Client {
+	c := *hc
+	c.CheckRedirect = func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	}
+	return &Client{http: &c}
+}
+
+// Client posts payloads to the webhooks.
+type Client struct {
+	http *http.Client
+}
+
+// Post method posts JSON payload to the URL signed with the secret.
+// Signature is sent in the SignatureHeader as "sha256=" followed by the
+// hex-encoded HMAC-SHA256 of the timestamp and the payload joined with
+// the dot, so signed payload couldn't be replayed later with another
+// timestamp. Returns StatusError if webhook has responded with non-2xx
+// status. Transport errors don't contain URL, since it could carry tokens.
+func (c *Client) Post(ctx context.Context, rawURL, secret, id string, body []byte) error {
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, rawURL, bytes.NewReader(body))
+	if err != nil {
+		return errors.New("failed to build webhook request")
+	}
+
+	ts := strconv.FormatInt(time.Now().Unix(), 10)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(IDHeader, id)
+	req.Header.Set(TimestampHeader, ts)
+	req.Header.Set(SignatureHeader, Sign(secret, ts, body))
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		var uerr *url.Error
+		if errors.As(err, &uerr) {
+			err = uerr.Err
+		}
+		return fmt.Errorf("failed to post webhook: %w", err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, maxResponseSize))
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return &StatusError{Code: resp.StatusCode}
+	}
+
+	return nil
+}
+
+// Sign returns signature of the payload sent at the given unix
+// timestamp, as it's sent in the SignatureHeader. Receivers should
+// compute it the same way and compare in constant time.
+func Sign(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return signaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
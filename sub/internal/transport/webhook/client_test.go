@@ -0,0 +1,130 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+const secret = "0123456789abcdef"
+
+func TestSign(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		secret    string
+		timestamp string
+		body      string
+		want      string
+	}{
+		{
+			name:      "Should sign timestamp and payload joined with dot",
+			secret:    "secret",
+			timestamp: "1700000000",
+			body:      `{"rate":41.5}`,
+			want:      "sha256=6e1d84c8623aef8f4eb09431d99e608a1bd07deba97f9431771c57abd6d3b5a3",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := Sign(tt.secret, tt.timestamp, []byte(tt.body))
+			if got != tt.want {
+				t.Errorf("Sign() = %v, want %v", got, tt.want)
+			}
+			if other := Sign(tt.secret, tt.timestamp+"0", []byte(tt.body)); other == got {
+				t.Error("Sign() with another timestamp returned the same signature")
+			}
+		})
+	}
+}
+
+func TestClientPost(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		status   int
+		redirect bool
+		wantCode int
+	}{
+		{
+			name:   "Should post signed payload",
+			status: http.StatusNoContent,
+		},
+		{
+			name:     "Should return StatusError when webhook failed",
+			status:   http.StatusInternalServerError,
+			wantCode: http.StatusInternalServerError,
+		},
+		{
+			name:     "Should not follow redirects",
+			redirect: true,
+			wantCode: http.StatusFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			body := []byte(`{"pair":"USD/UAH","rate":41.5}`)
+			var posted int
+			srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path == "/elsewhere" {
+					t.Error("Client followed redirect")
+					return
+				}
+				if tt.redirect {
+					http.Redirect(w, r, "/elsewhere", http.StatusFound)
+					return
+				}
+
+				posted++
+				got, _ := io.ReadAll(r.Body)
+				ts := r.Header.Get(TimestampHeader)
+				if r.Method != http.MethodPost || string(got) != string(body) ||
+					r.Header.Get("Content-Type") != "application/json" ||
+					r.Header.Get(IDHeader) != "9" ||
+					r.Header.Get(SignatureHeader) != Sign(secret, ts, got) {
+					t.Errorf("Unexpected request %s %s %v", r.Method, got, r.Header)
+				}
+				w.WriteHeader(tt.status)
+			}))
+			t.Cleanup(srv.Close)
+
+			err := NewClient(srv.Client()).Post(context.Background(), srv.URL+"/hook", secret, "9", body)
+			if tt.wantCode != 0 {
+				var statusErr *StatusError
+				if !errors.As(err, &statusErr) || statusErr.Code != tt.wantCode {
+					t.Fatalf("Client.Post() error = %v, want status %d", err, tt.wantCode)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Client.Post() error = %v", err)
+			}
+			if posted != 1 {
+				t.Errorf("Client.Post() posted %d times, want 1", posted)
+			}
+		})
+	}
+}
+
+func TestClientPostStripsURL(t *testing.T) {
+	t.Parallel()
+	srv := httptest.NewTLSServer(http.NotFoundHandler())
+	srv.Close()
+
+	err := NewClient(srv.Client()).Post(context.Background(), srv.URL+"/hook?token=topsecret", secret, "9", nil)
+	if err == nil {
+		t.Fatal("Client.Post() error = nil, want error")
+	}
+	if strings.Contains(err.Error(), "topsecret") {
+		t.Errorf("Client.Post() error = %v, want error without URL", err)
+	}
+}
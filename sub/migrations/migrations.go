@@ -0,0 +1,27 @@
+// Package migrations embeds SQL migrations of the sub schema,
+// so they're applied by the sub binary itself.
+package migrations
+
+import (
+	"embed"
+	"fmt"
+	"io/fs"
+)
+
+// FS contains up and down migrations of every dialect in the directory
+// named after the dialect, i.e mysql/{version}_{title}.{up|down}.sql.
+// PostgreSQL and SQLite schemas start at the version, which MySQL schema
+// had when they were introduced, so new migrations share the version
+// across all dialects.
+//
+//go:embed mysql/*.sql postgres/*.sql sqlite/*.sql
+var FS embed.FS
+
+// For returns migrations of the given dialect.
+func For(dialect string) (fs.FS, error) {
+	if _, err := fs.Stat(FS, dialect); err != nil {
+		return nil, fmt.Errorf("no migrations for %q dialect: %w", dialect, err)
+	}
+
+	return fs.Sub(FS, dialect)
+}
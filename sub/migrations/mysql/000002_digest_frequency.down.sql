@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS rates;
+
+ALTER TABLE subscribers
+DROP COLUMN frequency,
+DROP COLUMN weekday,
+DROP COLUMN month_day;
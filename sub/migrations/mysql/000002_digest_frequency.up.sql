@@ -0,0 +1,12 @@
+ALTER TABLE subscribers
+ADD COLUMN frequency ENUM('daily', 'weekly', 'monthly') NOT NULL DEFAULT 'daily',
+ADD COLUMN weekday TINYINT NOT NULL DEFAULT 0,
+ADD COLUMN month_day TINYINT NOT NULL DEFAULT 1;
+
+CREATE TABLE rates (
+  id int PRIMARY KEY AUTO_INCREMENT,
+  rate DOUBLE NOT NULL,
+  created_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IDX_rates_created_at ON rates (created_at);
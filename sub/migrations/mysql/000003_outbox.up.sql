@@ -0,0 +1,15 @@
+CREATE TABLE outbox (
+  id int PRIMARY KEY AUTO_INCREMENT,
+  subscriber_id int NOT NULL,
+  email varchar(255) NOT NULL,
+  subject varchar(255) NOT NULL,
+  body TEXT NOT NULL,
+  status ENUM('pending', 'sent', 'dead') NOT NULL DEFAULT 'pending',
+  attempts int NOT NULL DEFAULT 0,
+  last_error varchar(1024) NOT NULL DEFAULT '',
+  next_attempt_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  created_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IDX_outbox_status_next_attempt_at ON outbox (status, next_attempt_at);
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS deliveries;
+
+ALTER TABLE outbox
+DROP COLUMN rate;
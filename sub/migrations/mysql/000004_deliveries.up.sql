@@ -0,0 +1,19 @@
+ALTER TABLE outbox
+ADD COLUMN rate DOUBLE NOT NULL DEFAULT 0;
+
+CREATE TABLE deliveries (
+  id int PRIMARY KEY AUTO_INCREMENT,
+  notification_id int NOT NULL,
+  subscriber_id int NOT NULL,
+  email varchar(255) NOT NULL,
+  scheduled_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  rate DOUBLE NOT NULL DEFAULT 0,
+  message_id varchar(255) NOT NULL DEFAULT '',
+  status ENUM('sent', 'failed') NOT NULL,
+  error varchar(1024) NOT NULL DEFAULT '',
+  created_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IDX_deliveries_email ON deliveries (email);
+CREATE INDEX IDX_deliveries_subscriber_id ON deliveries (subscriber_id);
+CREATE INDEX IDX_deliveries_created_at ON deliveries (created_at);
@@ -0,0 +1,6 @@
+DROP INDEX UQ_outbox_subscriber_id_run_date ON outbox;
+
+ALTER TABLE outbox
+DROP COLUMN run_date;
+
+DROP TABLE IF EXISTS job_runs;
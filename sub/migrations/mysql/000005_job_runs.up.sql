@@ -0,0 +1,16 @@
+CREATE TABLE job_runs (
+  name varchar(64) NOT NULL,
+  run_date DATE NOT NULL,
+  status ENUM('running', 'done') NOT NULL DEFAULT 'running',
+  owner varchar(255) NOT NULL,
+  token BIGINT NOT NULL DEFAULT 1,
+  lease_until timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  created_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (name, run_date)
+);
+
+ALTER TABLE outbox
+ADD COLUMN run_date DATE NULL;
+
+CREATE UNIQUE INDEX UQ_outbox_subscriber_id_run_date ON outbox (subscriber_id, run_date);
@@ -0,0 +1,5 @@
+DROP INDEX IDX_subscribers_created_at ON subscribers;
+DROP INDEX IDX_subscribers_status ON subscribers;
+
+ALTER TABLE subscribers
+DROP COLUMN status;
@@ -0,0 +1,5 @@
+ALTER TABLE subscribers
+ADD COLUMN status ENUM('active', 'unsubscribed') NOT NULL DEFAULT 'active';
+
+CREATE INDEX IDX_subscribers_status ON subscribers (status);
+CREATE INDEX IDX_subscribers_created_at ON subscribers (created_at);
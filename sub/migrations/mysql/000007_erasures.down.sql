@@ -0,0 +1,3 @@
+DROP INDEX IDX_outbox_email ON outbox;
+
+DROP TABLE IF EXISTS erasures;
@@ -0,0 +1,9 @@
+CREATE TABLE erasures (
+  id int PRIMARY KEY AUTO_INCREMENT,
+  subscriber_deleted BOOLEAN NOT NULL DEFAULT FALSE,
+  notifications_deleted int NOT NULL DEFAULT 0,
+  deliveries_deleted int NOT NULL DEFAULT 0,
+  created_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IDX_outbox_email ON outbox (email);
@@ -0,0 +1,8 @@
+-- Merged duplicates couldn't be restored. Email column is kept
+-- wide, so longer emails aren't truncated.
+DROP INDEX IDX_subscribers_email ON subscribers;
+
+ALTER TABLE subscribers
+DROP INDEX UC_subscriber_canonical_email,
+DROP COLUMN canonical_email,
+ADD CONSTRAINT UC_subscriber_email UNIQUE (email);
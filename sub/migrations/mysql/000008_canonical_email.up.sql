@@ -0,0 +1,47 @@
+ALTER TABLE subscribers
+MODIFY COLUMN email varchar(254) NOT NULL,
+ADD COLUMN canonical_email varchar(254) NULL;
+
+-- Existing subscribers are canonicalised by case only. Punycode and
+-- provider rules are applied to them by the service on start, which
+-- merges the rest of duplicates (see 000016_canonical_rules).
+UPDATE subscribers SET canonical_email = LOWER(TRIM(email));
+
+-- Duplicates are merged into the active subscriber, or into the
+-- oldest one if all of them are unsubscribed.
+CREATE TEMPORARY TABLE subscriber_merges AS
+SELECT
+  id AS duplicate_id,
+  FIRST_VALUE(id) OVER (
+    PARTITION BY canonical_email
+    ORDER BY status = 'active' DESC, id
+  ) AS survivor_id
+FROM subscribers;
+
+DELETE FROM subscriber_merges WHERE duplicate_id = survivor_id;
+
+-- Pending notifications of the duplicates are dropped, so the
+-- mailbox doesn't receive the same mail twice.
+DELETE o FROM outbox o
+JOIN subscriber_merges m ON o.subscriber_id = m.duplicate_id
+WHERE o.status = 'pending';
+
+UPDATE outbox o
+JOIN subscriber_merges m ON o.subscriber_id = m.duplicate_id
+SET o.subscriber_id = m.survivor_id;
+
+UPDATE deliveries d
+JOIN subscriber_merges m ON d.subscriber_id = m.duplicate_id
+SET d.subscriber_id = m.survivor_id;
+
+DELETE s FROM subscribers s
+JOIN subscriber_merges m ON s.id = m.duplicate_id;
+
+DROP TEMPORARY TABLE subscriber_merges;
+
+ALTER TABLE subscribers
+MODIFY COLUMN canonical_email varchar(254) NOT NULL,
+DROP INDEX UC_subscriber_email,
+ADD CONSTRAINT UC_subscriber_canonical_email UNIQUE (canonical_email);
+
+CREATE INDEX IDX_subscribers_email ON subscribers (email);
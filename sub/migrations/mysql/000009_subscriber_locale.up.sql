@@ -0,0 +1,2 @@
+ALTER TABLE subscribers
+ADD COLUMN locale varchar(8) NOT NULL DEFAULT 'en';
@@ -0,0 +1,3 @@
+ALTER TABLE outbox
+DROP COLUMN text,
+MODIFY COLUMN body TEXT NOT NULL;
@@ -0,0 +1,3 @@
+ALTER TABLE outbox
+MODIFY COLUMN body MEDIUMTEXT NOT NULL,
+ADD COLUMN text MEDIUMTEXT NOT NULL AFTER body;
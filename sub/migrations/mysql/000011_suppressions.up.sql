@@ -0,0 +1,19 @@
+CREATE TABLE suppression_events (
+  id int PRIMARY KEY AUTO_INCREMENT,
+  event_id varchar(255) NULL,
+  email varchar(254) NOT NULL,
+  type ENUM('hard_bounce', 'soft_bounce', 'complaint', 'lifted') NOT NULL,
+  message_id varchar(255) NOT NULL DEFAULT '',
+  description varchar(1024) NOT NULL DEFAULT '',
+  created_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX UQ_suppression_events_event_id_email ON suppression_events (event_id, email);
+CREATE INDEX IDX_suppression_events_email_type_created_at ON suppression_events (email, type, created_at);
+
+CREATE TABLE suppressions (
+  email varchar(254) PRIMARY KEY,
+  reason ENUM('hard_bounce', 'soft_bounce', 'complaint') NOT NULL,
+  description varchar(1024) NOT NULL DEFAULT '',
+  created_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
@@ -0,0 +1,5 @@
+ALTER TABLE outbox
+DROP COLUMN channel;
+
+ALTER TABLE subscribers
+DROP COLUMN channel;
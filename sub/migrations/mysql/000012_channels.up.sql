@@ -0,0 +1,5 @@
+ALTER TABLE subscribers
+ADD COLUMN channel varchar(16) NOT NULL DEFAULT 'email' AFTER id;
+
+ALTER TABLE outbox
+ADD COLUMN channel varchar(16) NOT NULL DEFAULT 'email' AFTER subscriber_id;
@@ -0,0 +1,4 @@
+DROP TABLE webhooks;
+
+ALTER TABLE rates
+DROP COLUMN provider;
@@ -0,0 +1,8 @@
+ALTER TABLE rates
+ADD COLUMN provider varchar(64) NOT NULL DEFAULT '';
+
+CREATE TABLE webhooks (
+  subscriber_id int PRIMARY KEY,
+  secret varchar(255) NOT NULL,
+  created_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
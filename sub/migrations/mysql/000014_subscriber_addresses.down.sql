@@ -0,0 +1,5 @@
+DROP INDEX UQ_outbox_subscriber_id_channel_run_date ON outbox;
+
+CREATE UNIQUE INDEX UQ_outbox_subscriber_id_run_date ON outbox (subscriber_id, run_date);
+
+DROP TABLE subscriber_addresses;
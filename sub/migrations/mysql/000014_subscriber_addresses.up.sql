@@ -0,0 +1,11 @@
+CREATE TABLE subscriber_addresses (
+  subscriber_id int NOT NULL,
+  channel varchar(16) NOT NULL,
+  address varchar(255) NOT NULL,
+  created_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (subscriber_id, channel)
+);
+
+DROP INDEX UQ_outbox_subscriber_id_run_date ON outbox;
+
+CREATE UNIQUE INDEX UQ_outbox_subscriber_id_channel_run_date ON outbox (subscriber_id, channel, run_date);
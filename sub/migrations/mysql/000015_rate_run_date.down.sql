@@ -0,0 +1,4 @@
+DROP INDEX UQ_rates_run_date ON rates;
+
+ALTER TABLE rates
+DROP COLUMN run_date;
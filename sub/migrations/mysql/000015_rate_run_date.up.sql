@@ -0,0 +1,4 @@
+ALTER TABLE rates
+ADD COLUMN run_date DATE NULL;
+
+CREATE UNIQUE INDEX UQ_rates_run_date ON rates (run_date);
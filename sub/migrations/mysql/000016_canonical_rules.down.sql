@@ -0,0 +1 @@
+DROP TABLE canonical_rules;
@@ -0,0 +1,10 @@
+-- Rules, which canonical emails of the subscribers were computed with.
+-- Empty rules mean emails weren't canonicalised by the service yet, so
+-- they're recomputed and duplicates are merged on the next start.
+CREATE TABLE canonical_rules (
+  id int PRIMARY KEY,
+  rules varchar(255) NOT NULL DEFAULT '',
+  updated_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+INSERT INTO canonical_rules (id, rules) VALUES (1, '');
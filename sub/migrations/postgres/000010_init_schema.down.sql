@@ -0,0 +1,6 @@
+DROP TABLE erasures;
+DROP TABLE job_runs;
+DROP TABLE deliveries;
+DROP TABLE outbox;
+DROP TABLE rates;
+DROP TABLE subscribers;
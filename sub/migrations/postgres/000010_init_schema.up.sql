@@ -0,0 +1,82 @@
+CREATE TABLE subscribers (
+  id serial PRIMARY KEY,
+  email varchar(254) NOT NULL,
+  canonical_email varchar(254) NOT NULL,
+  frequency varchar(16) NOT NULL DEFAULT 'daily' CHECK (frequency IN ('daily', 'weekly', 'monthly')),
+  weekday smallint NOT NULL DEFAULT 0,
+  month_day smallint NOT NULL DEFAULT 1,
+  status varchar(16) NOT NULL DEFAULT 'active' CHECK (status IN ('active', 'unsubscribed')),
+  locale varchar(8) NOT NULL DEFAULT 'en',
+  created_at timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  CONSTRAINT UC_subscriber_canonical_email UNIQUE (canonical_email)
+);
+
+CREATE INDEX IDX_subscribers_email ON subscribers (email);
+CREATE INDEX IDX_subscribers_status ON subscribers (status);
+CREATE INDEX IDX_subscribers_created_at ON subscribers (created_at);
+
+CREATE TABLE rates (
+  id serial PRIMARY KEY,
+  rate double precision NOT NULL,
+  created_at timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IDX_rates_created_at ON rates (created_at);
+
+CREATE TABLE outbox (
+  id serial PRIMARY KEY,
+  subscriber_id integer NOT NULL,
+  email varchar(255) NOT NULL,
+  subject varchar(255) NOT NULL,
+  body text NOT NULL,
+  text text NOT NULL,
+  rate double precision NOT NULL DEFAULT 0,
+  status varchar(16) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'sent', 'dead')),
+  attempts integer NOT NULL DEFAULT 0,
+  last_error varchar(1024) NOT NULL DEFAULT '',
+  run_date date NULL,
+  next_attempt_at timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  created_at timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_at timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IDX_outbox_status_next_attempt_at ON outbox (status, next_attempt_at);
+CREATE INDEX IDX_outbox_email ON outbox (email);
+CREATE UNIQUE INDEX UQ_outbox_subscriber_id_run_date ON outbox (subscriber_id, run_date);
+
+CREATE TABLE deliveries (
+  id serial PRIMARY KEY,
+  notification_id integer NOT NULL,
+  subscriber_id integer NOT NULL,
+  email varchar(255) NOT NULL,
+  scheduled_at timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  rate double precision NOT NULL DEFAULT 0,
+  message_id varchar(255) NOT NULL DEFAULT '',
+  status varchar(16) NOT NULL CHECK (status IN ('sent', 'failed')),
+  error varchar(1024) NOT NULL DEFAULT '',
+  created_at timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IDX_deliveries_email ON deliveries (email);
+CREATE INDEX IDX_deliveries_subscriber_id ON deliveries (subscriber_id);
+CREATE INDEX IDX_deliveries_created_at ON deliveries (created_at);
+
+CREATE TABLE job_runs (
+  name varchar(64) NOT NULL,
+  run_date date NOT NULL,
+  status varchar(16) NOT NULL DEFAULT 'running' CHECK (status IN ('running', 'done')),
+  owner varchar(255) NOT NULL,
+  token bigint NOT NULL DEFAULT 1,
+  lease_until timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  created_at timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_at timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (name, run_date)
+);
+
+CREATE TABLE erasures (
+  id serial PRIMARY KEY,
+  subscriber_deleted boolean NOT NULL DEFAULT FALSE,
+  notifications_deleted integer NOT NULL DEFAULT 0,
+  deliveries_deleted integer NOT NULL DEFAULT 0,
+  created_at timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
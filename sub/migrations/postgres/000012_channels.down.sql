@@ -0,0 +1,3 @@
+ALTER TABLE outbox DROP COLUMN channel;
+
+ALTER TABLE subscribers DROP COLUMN channel;
@@ -0,0 +1,7 @@
+ALTER TABLE rates ADD COLUMN provider varchar(64) NOT NULL DEFAULT '';
+
+CREATE TABLE webhooks (
+  subscriber_id integer PRIMARY KEY,
+  secret varchar(255) NOT NULL,
+  created_at timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
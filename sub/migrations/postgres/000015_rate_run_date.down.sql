@@ -0,0 +1,3 @@
+DROP INDEX UQ_rates_run_date;
+
+ALTER TABLE rates DROP COLUMN run_date;
@@ -0,0 +1,3 @@
+ALTER TABLE rates ADD COLUMN run_date date NULL;
+
+CREATE UNIQUE INDEX UQ_rates_run_date ON rates (run_date);
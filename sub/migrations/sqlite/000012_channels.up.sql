@@ -0,0 +1,3 @@
+ALTER TABLE subscribers ADD COLUMN channel varchar(16) NOT NULL DEFAULT 'email';
+
+ALTER TABLE outbox ADD COLUMN channel varchar(16) NOT NULL DEFAULT 'email';
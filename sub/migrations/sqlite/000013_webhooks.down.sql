@@ -0,0 +1,3 @@
+DROP TABLE webhooks;
+
+ALTER TABLE rates DROP COLUMN provider;